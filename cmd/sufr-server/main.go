@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/joeshaw/envdecode"
+	"github.com/kyleterry/sufr/pkg/config"
+	"github.com/kyleterry/sufr/pkg/server"
+	"github.com/kyleterry/sufr/pkg/service/sqlitestore"
+	"github.com/kyleterry/sufr/pkg/sessionkeys"
+)
+
+const defaultGRPCBindAddr = "localhost:8091"
+
+func main() {
+	cfg := &config.Config{}
+
+	config.SetBuildInfo(cfg)
+
+	if err := envdecode.Decode(cfg); err != nil {
+		if err != envdecode.ErrNoTargetFieldsAreSet {
+			log.Fatal(err)
+		}
+	}
+
+	config.SetDefaults(cfg)
+
+	flag.StringVar(&cfg.BindAddr, "bind", cfg.BindAddr, "Host and port to bind to")
+	grpcBindAddr := flag.String("grpc-bind", defaultGRPCBindAddr, `Host and port to serve the gRPC API on, "" to turn it off`)
+	flag.StringVar(&cfg.DataDir, "data-dir", cfg.DataDir, "Location to store data in")
+	archiveDir := flag.String("archive-dir", "", "Directory to archive saved pages in (default <data-dir>/archive)")
+	flag.IntVar(&cfg.FetchWorkers, "fetch-workers", cfg.FetchWorkers, "Number of url metadata fetches to run at once")
+	flag.DurationVar(&cfg.LinkCheckInterval, "link-check-interval", cfg.LinkCheckInterval, "How often to check saved urls for broken links, negative to disable")
+	flag.IntVar(&cfg.LinkCheckDeadAfter, "link-check-dead-after", cfg.LinkCheckDeadAfter, "Number of failed link checks in a row before a url is flagged dead")
+
+	flag.Parse()
+
+	if *archiveDir == "" {
+		*archiveDir = filepath.Join(cfg.DataDir, "archive")
+	}
+
+	if err := run(cfg, *grpcBindAddr, *archiveDir); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run serves the SQL backed server until it gets SIGINT or SIGTERM.
+func run(cfg *config.Config, grpcBindAddr, archiveDir string) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		<-signals
+		cancel()
+	}()
+
+	if err := os.MkdirAll(cfg.DataDir, config.DBFileMode); err != nil {
+		return err
+	}
+
+	db, err := sqlitestore.New(sqlitestore.WithPath(cfg.SQLDatabaseFile()))
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	if err := db.Migrate(ctx); err != nil {
+		return err
+	}
+
+	keys, err := sessionkeys.Load(cfg)
+	if err != nil {
+		return err
+	}
+
+	srv, err := server.New(
+		server.WithStore(db),
+		server.WithConfig(cfg),
+		server.WithSessionKeys(keys),
+		server.WithBindAddr(cfg.BindAddr),
+		server.WithGRPCBindAddr(grpcBindAddr),
+		server.WithArchiveDir(archiveDir),
+		server.WithFetchWorkers(cfg.FetchWorkers),
+		server.WithLinkCheckInterval(cfg.LinkCheckInterval),
+		server.WithLinkCheckDeadAfter(cfg.LinkCheckDeadAfter),
+	)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("listening on http://%s", cfg.BindAddr)
+
+	if grpcBindAddr != "" {
+		log.Printf("serving grpc on %s", grpcBindAddr)
+	}
+
+	return srv.Run(ctx)
+}
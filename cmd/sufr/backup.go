@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kyleterry/sufr/pkg/archive"
+	"github.com/kyleterry/sufr/pkg/config"
+	"github.com/kyleterry/sufr/pkg/service/sqlitestore"
+)
+
+// backup runs the backup command. It writes a tarball of the SQL database
+// and archived pages, which is safe to do while sufr is running.
+func backup(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("backup", flag.ExitOnError)
+	out := fs.String("o", fmt.Sprintf("sufr-backup-%s.tar.gz", time.Now().UTC().Format("2006-01-02")), `File to write to, "-" for stdout`)
+	archiveDir := fs.String("archive-dir", filepath.Join(cfg.DataDir, "archive"), `Directory of archived pages to include, "" for none`)
+
+	fs.Parse(args)
+
+	ctx := context.Background()
+
+	db, err := sqlitestore.New(sqlitestore.WithPath(cfg.SQLDatabaseFile()))
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	var archiver *archive.Archiver
+
+	if *archiveDir != "" {
+		if _, err := os.Stat(*archiveDir); err == nil {
+			archiver = archive.New(*archiveDir)
+		}
+	}
+
+	var w io.Writer = os.Stdout
+
+	if *out != "-" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+
+		defer f.Close()
+
+		w = f
+	}
+
+	if err := db.WriteBackup(ctx, w, archiver); err != nil {
+		return err
+	}
+
+	if f, ok := w.(*os.File); ok && f != os.Stdout {
+		if err := f.Close(); err != nil {
+			return err
+		}
+
+		log.Printf("wrote backup to %s", *out)
+	}
+
+	return nil
+}
+
+// restore runs the restore command. It replaces the SQL database with the
+// one in a backup made by the backup command, or with a copy of a database
+// file. sufr can keep running while it does.
+func restore(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: restore [file]\n\n")
+		fmt.Fprintf(fs.Output(), "Restores the database from a backup tarball or database file, \"-\" for stdin.\n")
+	}
+
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return errors.New("restore: a backup file is required")
+	}
+
+	ctx := context.Background()
+
+	db, err := sqlitestore.New(sqlitestore.WithPath(cfg.SQLDatabaseFile()))
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	var r io.Reader = os.Stdin
+
+	if name := fs.Arg(0); name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+
+		defer f.Close()
+
+		r = f
+	}
+
+	if err := db.RestoreBackup(ctx, r); err != nil {
+		return fmt.Errorf("restore: %w", err)
+	}
+
+	version, err := db.MigrationVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("restored %s, now at migration %s", fs.Arg(0), version)
+
+	return nil
+}
@@ -1,15 +1,20 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
+	"fmt"
 	"log"
 	"net/http"
+	"os"
 
 	"github.com/joeshaw/envdecode"
 	"github.com/kyleterry/sufr/pkg/app"
 	"github.com/kyleterry/sufr/pkg/config"
 	"github.com/kyleterry/sufr/pkg/data"
 	"github.com/kyleterry/sufr/pkg/data/migrations"
+	"github.com/kyleterry/sufr/pkg/sessionkeys"
 )
 
 func main() {
@@ -29,16 +34,72 @@ func main() {
 	flag.StringVar(&cfg.DataDir, "data-dir", cfg.DataDir, "Location to store data in")
 	flag.IntVar(&cfg.ResultsPerPage, "results-per-page", cfg.ResultsPerPage, "Results to display per page")
 	flag.BoolVar(&cfg.Debug, "debug", cfg.Debug, "Turn debugging on")
+	flag.IntVar(&cfg.FetchWorkers, "fetch-workers", cfg.FetchWorkers, "Number of url metadata fetches to run at once")
+	flag.DurationVar(&cfg.LinkCheckInterval, "link-check-interval", cfg.LinkCheckInterval, "How often to check saved urls for broken links, negative to disable")
+	flag.IntVar(&cfg.LinkCheckDeadAfter, "link-check-dead-after", cfg.LinkCheckDeadAfter, "Number of failed link checks in a row before a url is flagged dead")
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [command]\n\n", os.Args[0])
+		fmt.Fprintf(flag.CommandLine.Output(), "Commands:\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "  backup               write a backup of the database and archived pages, see backup -h\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "  disable-2fa          turn off two-factor authentication for a user, see disable-2fa -h\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "  export-warc          write saved pages to a WARC file, see export-warc -h\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "  restore              replace the database with a backup, see restore -h\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "  rotate-session-keys  replace the saved session keys, see rotate-session-keys -h\n\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "Flags:\n")
+		flag.PrintDefaults()
+	}
 
 	flag.Parse()
 
+	// commands go first, since the bolt database can't be opened while sufr
+	// is running and only export-warc needs it
+	if flag.NArg() > 0 {
+		if err := runCommand(cfg, flag.Arg(0), flag.Args()[1:]); err != nil {
+			if err == errUnknownCommand {
+				flag.Usage()
+				os.Exit(2)
+			}
+
+			log.Fatal(err)
+		}
+
+		return
+	}
+
 	data.MustInit(cfg)
 	migrations.MustMigrate(cfg)
 
-	sufrApp := app.New(cfg)
+	sufrApp := app.New(cfg, sessionkeys.MustLoad(cfg))
+
+	go sufrApp.RunFetchers(context.Background())
+
+	if cfg.LinkCheckInterval > 0 {
+		go sufrApp.RunLinkChecker(context.Background())
+	}
 
 	log.Printf("listening on http://%s", cfg.BindAddr)
 	if err := http.ListenAndServe(cfg.BindAddr, sufrApp); err != nil {
 		panic(err)
 	}
 }
+
+var errUnknownCommand = errors.New("unknown command")
+
+// runCommand runs the command name with args.
+func runCommand(cfg *config.Config, name string, args []string) error {
+	switch name {
+	case "backup":
+		return backup(cfg, args)
+	case "disable-2fa":
+		return disable2FA(cfg, args)
+	case "export-warc":
+		return exportWARC(cfg, args)
+	case "restore":
+		return restore(cfg, args)
+	case "rotate-session-keys":
+		return rotateSessionKeys(cfg, args)
+	default:
+		return errUnknownCommand
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kyleterry/sufr/pkg/api"
+	"github.com/kyleterry/sufr/pkg/config"
+	"github.com/kyleterry/sufr/pkg/data"
+	"github.com/kyleterry/sufr/pkg/service/sqlitestore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupWhileRunning(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{DataDir: t.TempDir()}
+	config.SetDefaults(cfg)
+
+	// hold both databases open like a running sufr does
+	boltDB, err := data.New(cfg.DatabaseFile())
+	require.NoError(t, err)
+
+	defer boltDB.Close()
+
+	db, err := sqlitestore.New(sqlitestore.WithPath(cfg.SQLDatabaseFile()))
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	require.NoError(t, db.Migrate(ctx))
+	require.NoError(t, db.Users().Create(ctx, &api.User{Email: "kyle@example.com", PasswordHash: []byte("hash")}))
+
+	out := filepath.Join(t.TempDir(), "backup.tar.gz")
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- runCommand(cfg, "backup", []string{"-o", out, "-archive-dir", ""})
+	}()
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("backup waited on the running sufr")
+	}
+
+	restored, err := sqlitestore.New(sqlitestore.WithPath(filepath.Join(t.TempDir(), "restored.db")))
+	require.NoError(t, err)
+
+	defer restored.Close()
+
+	f, err := os.Open(out)
+	require.NoError(t, err)
+
+	defer f.Close()
+
+	require.NoError(t, restored.RestoreBackup(ctx, f))
+
+	_, err = restored.Users().GetByEmail(ctx, "kyle@example.com")
+	require.NoError(t, err)
+}
+
+func TestExportWARCWhileRunning(t *testing.T) {
+	cfg := &config.Config{DataDir: t.TempDir()}
+	config.SetDefaults(cfg)
+
+	boltDB, err := data.New(cfg.DatabaseFile())
+	require.NoError(t, err)
+
+	defer boltDB.Close()
+
+	err = runCommand(cfg, "export-warc", []string{"-o", filepath.Join(t.TempDir(), "out.warc")})
+	require.True(t, errors.Is(err, data.ErrDatabaseInUse), "got %v", err)
+}
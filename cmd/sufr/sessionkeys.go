@@ -0,0 +1,28 @@
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/kyleterry/sufr/pkg/config"
+	"github.com/kyleterry/sufr/pkg/sessionkeys"
+)
+
+// rotateSessionKeys runs the rotate-session-keys command. It replaces the
+// session keys saved in the data dir, keeping the old ones around so people
+// stay logged in.
+func rotateSessionKeys(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("rotate-session-keys", flag.ExitOnError)
+	keep := fs.Int("keep", sessionkeys.DefaultKeep, "Number of previous keys to keep accepting, 0 logs everyone out")
+
+	fs.Parse(args)
+
+	keys, err := sessionkeys.Rotate(cfg, *keep)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("rotated session keys in %s, %d previous keys kept; restart sufr to use them", cfg.SessionKeysFile(), len(keys.Previous))
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/kyleterry/sufr/pkg/config"
+	"github.com/kyleterry/sufr/pkg/service/sqlitestore"
+	"github.com/kyleterry/sufr/pkg/store"
+)
+
+// disable2FA runs the disable-2fa command. It turns off two-factor
+// authentication for someone who lost their authenticator and their
+// recovery codes so they can log in with their password again.
+func disable2FA(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("disable-2fa", flag.ExitOnError)
+	email := fs.String("email", "", "Email address of the user to turn two-factor authentication off for")
+
+	fs.Parse(args)
+
+	if *email == "" {
+		return errors.New("disable-2fa: -email is required")
+	}
+
+	ctx := context.Background()
+
+	db, err := sqlitestore.New(sqlitestore.WithPath(cfg.SQLDatabaseFile()))
+	if err != nil {
+		return err
+	}
+
+	if err := db.Migrate(ctx); err != nil {
+		return err
+	}
+
+	user, err := db.Users().GetByEmail(ctx, *email)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return fmt.Errorf("disable-2fa: no user with email %s", *email)
+		}
+
+		return err
+	}
+
+	if !user.TotpEnabled {
+		log.Printf("two-factor authentication is already off for %s", user.Email)
+
+		return nil
+	}
+
+	if err := db.Users().DisableTOTP(ctx, user); err != nil {
+		return err
+	}
+
+	log.Printf("turned off two-factor authentication for %s", user.Email)
+
+	return nil
+}
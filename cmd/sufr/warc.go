@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/kyleterry/sufr/pkg/config"
+	"github.com/kyleterry/sufr/pkg/data"
+	"github.com/kyleterry/sufr/pkg/data/migrations"
+	"github.com/kyleterry/sufr/pkg/warc"
+)
+
+// exportWARC runs the export-warc command. It fetches the urls matching a
+// search, or every url, and writes them to a WARC file.
+func exportWARC(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("export-warc", flag.ExitOnError)
+	query := fs.String("q", "", `Only export urls matching a search like "tag:reading"`)
+	out := fs.String("o", fmt.Sprintf("sufr-%s%s", time.Now().UTC().Format("2006-01-02"), warc.FileExt), `File to write to, "-" for stdout`)
+
+	fs.Parse(args)
+
+	if err := data.Init(cfg); err != nil {
+		return err
+	}
+
+	migrations.MustMigrate(cfg)
+
+	urls, err := data.SearchURLs(*query, true)
+	if err != nil {
+		return err
+	}
+
+	targets := make([]string, len(urls))
+	for i, url := range urls {
+		targets[i] = url.URL
+	}
+
+	var w io.Writer = os.Stdout
+
+	if *out != "-" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+
+		defer f.Close()
+
+		w = f
+	}
+
+	summary, err := warc.New().Export(context.Background(), w, targets)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("captured %d urls, %d could not be fetched", summary.Captured, summary.Failed)
+
+	if f, ok := w.(*os.File); ok && f != os.Stdout {
+		return f.Close()
+	}
+
+	return nil
+}
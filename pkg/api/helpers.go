@@ -2,12 +2,25 @@ package api
 
 import (
 	"database/sql/driver"
+	"fmt"
+	"html/template"
+	"strings"
 	"time"
 
 	"github.com/gogo/protobuf/jsonpb"
+	"github.com/russross/blackfriday"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// SnippetMatchStart and SnippetMatchEnd surround the matching terms in
+// UserURL.Snippet. They are control characters so they can't collide with
+// anything a user saved and the UI can safely escape the snippet before
+// replacing them with markup.
+const (
+	SnippetMatchStart = "\x02"
+	SnippetMatchEnd   = "\x03"
+)
+
 func (ts *Timestamp) Scan(value interface{}) error {
 	t, ok := value.(time.Time)
 
@@ -45,6 +58,111 @@ func (ts *Timestamp) SetFromGoTime(t time.Time) {
 	ts.Nanos = int32(t.Nanosecond())
 }
 
+// notesHTMLFlags are blackfriday's common flags plus the ones that keep HTML
+// and javascript links in notes out of the page, since notes can be shown to
+// people other than their author.
+const notesHTMLFlags = blackfriday.HTML_USE_XHTML |
+	blackfriday.HTML_USE_SMARTYPANTS |
+	blackfriday.HTML_SMARTYPANTS_FRACTIONS |
+	blackfriday.HTML_SMARTYPANTS_DASHES |
+	blackfriday.HTML_SMARTYPANTS_LATEX_DASHES |
+	blackfriday.HTML_SKIP_HTML |
+	blackfriday.HTML_SKIP_STYLE |
+	blackfriday.HTML_SAFELINK |
+	blackfriday.HTML_NOFOLLOW_LINKS
+
+const notesExtensions = blackfriday.EXTENSION_NO_INTRA_EMPHASIS |
+	blackfriday.EXTENSION_TABLES |
+	blackfriday.EXTENSION_FENCED_CODE |
+	blackfriday.EXTENSION_AUTOLINK |
+	blackfriday.EXTENSION_STRIKETHROUGH |
+	blackfriday.EXTENSION_SPACE_HEADERS |
+	blackfriday.EXTENSION_HEADER_IDS |
+	blackfriday.EXTENSION_BACKSLASH_LINE_BREAK |
+	blackfriday.EXTENSION_DEFINITION_LISTS
+
+// NotesHTML renders the markdown notes of a UserURL. HTML in the notes is
+// left out.
+func (uu *UserURL) NotesHTML() template.HTML {
+	renderer := blackfriday.HtmlRenderer(notesHTMLFlags, "", "")
+	out := blackfriday.MarkdownOptions([]byte(uu.Notes), renderer, blackfriday.Options{Extensions: notesExtensions})
+
+	return template.HTML(string(out))
+}
+
+// visibilityNames are how visibilities are stored and written in forms and
+// API requests.
+var visibilityNames = map[Visibility]string{
+	Visibility_VISIBILITY_PRIVATE:  "private",
+	Visibility_VISIBILITY_UNLISTED: "unlisted",
+	Visibility_VISIBILITY_PUBLIC:   "public",
+}
+
+// Visibilities are the visibilities a url can have, most private first.
+var Visibilities = []Visibility{
+	Visibility_VISIBILITY_PRIVATE,
+	Visibility_VISIBILITY_UNLISTED,
+	Visibility_VISIBILITY_PUBLIC,
+}
+
+// Name returns the short name of v, like "public".
+func (v Visibility) Name() string {
+	if name, ok := visibilityNames[v]; ok {
+		return name
+	}
+
+	return visibilityNames[Visibility_VISIBILITY_PRIVATE]
+}
+
+// ParseVisibility returns the visibility named s. Both short names like
+// "public" and enum names like "VISIBILITY_PUBLIC" are accepted.
+func ParseVisibility(s string) (Visibility, error) {
+	name := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(s)), "visibility_")
+
+	for v, n := range visibilityNames {
+		if n == name {
+			return v, nil
+		}
+	}
+
+	return Visibility_VISIBILITY_PRIVATE, fmt.Errorf("unknown visibility %q", s)
+}
+
+func (v *Visibility) Scan(value interface{}) error {
+	var name string
+
+	switch value := value.(type) {
+	case string:
+		name = value
+	case []byte:
+		name = string(value)
+	case nil:
+		*v = Visibility_VISIBILITY_PRIVATE
+
+		return nil
+	default:
+		return fmt.Errorf("can't scan %T into a Visibility", value)
+	}
+
+	parsed, err := ParseVisibility(name)
+	if err != nil {
+		return err
+	}
+
+	*v = parsed
+
+	return nil
+}
+
+func (v Visibility) Value() (driver.Value, error) {
+	return v.Name(), nil
+}
+
+// Expired reports whether the share has stopped working.
+func (s *Share) Expired() bool {
+	return s.ExpiresAt != nil && !s.ExpiresAt.AsTime().After(time.Now())
+}
+
 func (t *TagList) Scan(value interface{}) error {
 	v, ok := value.(string)
 
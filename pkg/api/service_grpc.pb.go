@@ -0,0 +1,476 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package api
+
+import (
+	context "context"
+
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion7
+
+// SufrServiceClient is the client API for SufrService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SufrServiceClient interface {
+	// ListUserURLs streams the user's urls, newest first.
+	ListUserURLs(ctx context.Context, in *ListUserURLsRequest, opts ...grpc.CallOption) (SufrService_ListUserURLsClient, error)
+	// SearchUserURLs streams the user's urls matching a search query, best
+	// match first.
+	SearchUserURLs(ctx context.Context, in *SearchUserURLsRequest, opts ...grpc.CallOption) (SufrService_SearchUserURLsClient, error)
+	GetUserURL(ctx context.Context, in *GetUserURLRequest, opts ...grpc.CallOption) (*UserURL, error)
+	CreateUserURL(ctx context.Context, in *CreateUserURLRequest, opts ...grpc.CallOption) (*UserURL, error)
+	UpdateUserURL(ctx context.Context, in *UpdateUserURLRequest, opts ...grpc.CallOption) (*UserURL, error)
+	DeleteUserURL(ctx context.Context, in *DeleteUserURLRequest, opts ...grpc.CallOption) (*DeleteUserURLResponse, error)
+	// ListTags streams every tag used on at least one of the user's urls.
+	ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (SufrService_ListTagsClient, error)
+	GetPinnedCategories(ctx context.Context, in *GetPinnedCategoriesRequest, opts ...grpc.CallOption) (*CategoryList, error)
+	UpdatePinnedCategories(ctx context.Context, in *UpdatePinnedCategoriesRequest, opts ...grpc.CallOption) (*CategoryList, error)
+}
+
+type sufrServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSufrServiceClient(cc grpc.ClientConnInterface) SufrServiceClient {
+	return &sufrServiceClient{cc}
+}
+
+func (c *sufrServiceClient) ListUserURLs(ctx context.Context, in *ListUserURLsRequest, opts ...grpc.CallOption) (SufrService_ListUserURLsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SufrService_serviceDesc.Streams[0], "/protobuf.sufr.api.SufrService/ListUserURLs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sufrServiceListUserURLsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SufrService_ListUserURLsClient interface {
+	Recv() (*UserURL, error)
+	grpc.ClientStream
+}
+
+type sufrServiceListUserURLsClient struct {
+	grpc.ClientStream
+}
+
+func (x *sufrServiceListUserURLsClient) Recv() (*UserURL, error) {
+	m := new(UserURL)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *sufrServiceClient) SearchUserURLs(ctx context.Context, in *SearchUserURLsRequest, opts ...grpc.CallOption) (SufrService_SearchUserURLsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SufrService_serviceDesc.Streams[1], "/protobuf.sufr.api.SufrService/SearchUserURLs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sufrServiceSearchUserURLsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SufrService_SearchUserURLsClient interface {
+	Recv() (*UserURL, error)
+	grpc.ClientStream
+}
+
+type sufrServiceSearchUserURLsClient struct {
+	grpc.ClientStream
+}
+
+func (x *sufrServiceSearchUserURLsClient) Recv() (*UserURL, error) {
+	m := new(UserURL)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *sufrServiceClient) GetUserURL(ctx context.Context, in *GetUserURLRequest, opts ...grpc.CallOption) (*UserURL, error) {
+	out := new(UserURL)
+	err := c.cc.Invoke(ctx, "/protobuf.sufr.api.SufrService/GetUserURL", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sufrServiceClient) CreateUserURL(ctx context.Context, in *CreateUserURLRequest, opts ...grpc.CallOption) (*UserURL, error) {
+	out := new(UserURL)
+	err := c.cc.Invoke(ctx, "/protobuf.sufr.api.SufrService/CreateUserURL", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sufrServiceClient) UpdateUserURL(ctx context.Context, in *UpdateUserURLRequest, opts ...grpc.CallOption) (*UserURL, error) {
+	out := new(UserURL)
+	err := c.cc.Invoke(ctx, "/protobuf.sufr.api.SufrService/UpdateUserURL", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sufrServiceClient) DeleteUserURL(ctx context.Context, in *DeleteUserURLRequest, opts ...grpc.CallOption) (*DeleteUserURLResponse, error) {
+	out := new(DeleteUserURLResponse)
+	err := c.cc.Invoke(ctx, "/protobuf.sufr.api.SufrService/DeleteUserURL", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sufrServiceClient) ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (SufrService_ListTagsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SufrService_serviceDesc.Streams[2], "/protobuf.sufr.api.SufrService/ListTags", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sufrServiceListTagsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SufrService_ListTagsClient interface {
+	Recv() (*Tag, error)
+	grpc.ClientStream
+}
+
+type sufrServiceListTagsClient struct {
+	grpc.ClientStream
+}
+
+func (x *sufrServiceListTagsClient) Recv() (*Tag, error) {
+	m := new(Tag)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *sufrServiceClient) GetPinnedCategories(ctx context.Context, in *GetPinnedCategoriesRequest, opts ...grpc.CallOption) (*CategoryList, error) {
+	out := new(CategoryList)
+	err := c.cc.Invoke(ctx, "/protobuf.sufr.api.SufrService/GetPinnedCategories", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sufrServiceClient) UpdatePinnedCategories(ctx context.Context, in *UpdatePinnedCategoriesRequest, opts ...grpc.CallOption) (*CategoryList, error) {
+	out := new(CategoryList)
+	err := c.cc.Invoke(ctx, "/protobuf.sufr.api.SufrService/UpdatePinnedCategories", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SufrServiceServer is the server API for SufrService service.
+// All implementations must embed UnimplementedSufrServiceServer
+// for forward compatibility
+type SufrServiceServer interface {
+	// ListUserURLs streams the user's urls, newest first.
+	ListUserURLs(*ListUserURLsRequest, SufrService_ListUserURLsServer) error
+	// SearchUserURLs streams the user's urls matching a search query, best
+	// match first.
+	SearchUserURLs(*SearchUserURLsRequest, SufrService_SearchUserURLsServer) error
+	GetUserURL(context.Context, *GetUserURLRequest) (*UserURL, error)
+	CreateUserURL(context.Context, *CreateUserURLRequest) (*UserURL, error)
+	UpdateUserURL(context.Context, *UpdateUserURLRequest) (*UserURL, error)
+	DeleteUserURL(context.Context, *DeleteUserURLRequest) (*DeleteUserURLResponse, error)
+	// ListTags streams every tag used on at least one of the user's urls.
+	ListTags(*ListTagsRequest, SufrService_ListTagsServer) error
+	GetPinnedCategories(context.Context, *GetPinnedCategoriesRequest) (*CategoryList, error)
+	UpdatePinnedCategories(context.Context, *UpdatePinnedCategoriesRequest) (*CategoryList, error)
+	mustEmbedUnimplementedSufrServiceServer()
+}
+
+// UnimplementedSufrServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedSufrServiceServer struct {
+}
+
+func (UnimplementedSufrServiceServer) ListUserURLs(*ListUserURLsRequest, SufrService_ListUserURLsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListUserURLs not implemented")
+}
+func (UnimplementedSufrServiceServer) SearchUserURLs(*SearchUserURLsRequest, SufrService_SearchUserURLsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SearchUserURLs not implemented")
+}
+func (UnimplementedSufrServiceServer) GetUserURL(context.Context, *GetUserURLRequest) (*UserURL, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserURL not implemented")
+}
+func (UnimplementedSufrServiceServer) CreateUserURL(context.Context, *CreateUserURLRequest) (*UserURL, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUserURL not implemented")
+}
+func (UnimplementedSufrServiceServer) UpdateUserURL(context.Context, *UpdateUserURLRequest) (*UserURL, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUserURL not implemented")
+}
+func (UnimplementedSufrServiceServer) DeleteUserURL(context.Context, *DeleteUserURLRequest) (*DeleteUserURLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserURL not implemented")
+}
+func (UnimplementedSufrServiceServer) ListTags(*ListTagsRequest, SufrService_ListTagsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListTags not implemented")
+}
+func (UnimplementedSufrServiceServer) GetPinnedCategories(context.Context, *GetPinnedCategoriesRequest) (*CategoryList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPinnedCategories not implemented")
+}
+func (UnimplementedSufrServiceServer) UpdatePinnedCategories(context.Context, *UpdatePinnedCategoriesRequest) (*CategoryList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePinnedCategories not implemented")
+}
+func (UnimplementedSufrServiceServer) mustEmbedUnimplementedSufrServiceServer() {}
+
+// UnsafeSufrServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SufrServiceServer will
+// result in compilation errors.
+type UnsafeSufrServiceServer interface {
+	mustEmbedUnimplementedSufrServiceServer()
+}
+
+func RegisterSufrServiceServer(s grpc.ServiceRegistrar, srv SufrServiceServer) {
+	s.RegisterService(&_SufrService_serviceDesc, srv)
+}
+
+func _SufrService_ListUserURLs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListUserURLsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SufrServiceServer).ListUserURLs(m, &sufrServiceListUserURLsServer{stream})
+}
+
+type SufrService_ListUserURLsServer interface {
+	Send(*UserURL) error
+	grpc.ServerStream
+}
+
+type sufrServiceListUserURLsServer struct {
+	grpc.ServerStream
+}
+
+func (x *sufrServiceListUserURLsServer) Send(m *UserURL) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SufrService_SearchUserURLs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SearchUserURLsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SufrServiceServer).SearchUserURLs(m, &sufrServiceSearchUserURLsServer{stream})
+}
+
+type SufrService_SearchUserURLsServer interface {
+	Send(*UserURL) error
+	grpc.ServerStream
+}
+
+type sufrServiceSearchUserURLsServer struct {
+	grpc.ServerStream
+}
+
+func (x *sufrServiceSearchUserURLsServer) Send(m *UserURL) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SufrService_GetUserURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SufrServiceServer).GetUserURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protobuf.sufr.api.SufrService/GetUserURL",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SufrServiceServer).GetUserURL(ctx, req.(*GetUserURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SufrService_CreateUserURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUserURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SufrServiceServer).CreateUserURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protobuf.sufr.api.SufrService/CreateUserURL",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SufrServiceServer).CreateUserURL(ctx, req.(*CreateUserURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SufrService_UpdateUserURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SufrServiceServer).UpdateUserURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protobuf.sufr.api.SufrService/UpdateUserURL",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SufrServiceServer).UpdateUserURL(ctx, req.(*UpdateUserURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SufrService_DeleteUserURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SufrServiceServer).DeleteUserURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protobuf.sufr.api.SufrService/DeleteUserURL",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SufrServiceServer).DeleteUserURL(ctx, req.(*DeleteUserURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SufrService_ListTags_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListTagsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SufrServiceServer).ListTags(m, &sufrServiceListTagsServer{stream})
+}
+
+type SufrService_ListTagsServer interface {
+	Send(*Tag) error
+	grpc.ServerStream
+}
+
+type sufrServiceListTagsServer struct {
+	grpc.ServerStream
+}
+
+func (x *sufrServiceListTagsServer) Send(m *Tag) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SufrService_GetPinnedCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPinnedCategoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SufrServiceServer).GetPinnedCategories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protobuf.sufr.api.SufrService/GetPinnedCategories",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SufrServiceServer).GetPinnedCategories(ctx, req.(*GetPinnedCategoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SufrService_UpdatePinnedCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePinnedCategoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SufrServiceServer).UpdatePinnedCategories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protobuf.sufr.api.SufrService/UpdatePinnedCategories",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SufrServiceServer).UpdatePinnedCategories(ctx, req.(*UpdatePinnedCategoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _SufrService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "protobuf.sufr.api.SufrService",
+	HandlerType: (*SufrServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetUserURL",
+			Handler:    _SufrService_GetUserURL_Handler,
+		},
+		{
+			MethodName: "CreateUserURL",
+			Handler:    _SufrService_CreateUserURL_Handler,
+		},
+		{
+			MethodName: "UpdateUserURL",
+			Handler:    _SufrService_UpdateUserURL_Handler,
+		},
+		{
+			MethodName: "DeleteUserURL",
+			Handler:    _SufrService_DeleteUserURL_Handler,
+		},
+		{
+			MethodName: "GetPinnedCategories",
+			Handler:    _SufrService_GetPinnedCategories_Handler,
+		},
+		{
+			MethodName: "UpdatePinnedCategories",
+			Handler:    _SufrService_UpdatePinnedCategories_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListUserURLs",
+			Handler:       _SufrService_ListUserURLs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SearchUserURLs",
+			Handler:       _SufrService_SearchUserURLs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListTags",
+			Handler:       _SufrService_ListTags_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pkg/api/service.proto",
+}
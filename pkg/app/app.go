@@ -6,7 +6,7 @@ import (
 	"log"
 	"net/http"
 	"net/url"
-	"regexp"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -14,14 +14,22 @@ import (
 	"github.com/gorilla/mux"
 	gorsess "github.com/gorilla/sessions"
 	"github.com/justinas/alice"
+	"github.com/kyleterry/sufr/pkg/archive"
 	"github.com/kyleterry/sufr/pkg/config"
+	"github.com/kyleterry/sufr/pkg/csrf"
 	"github.com/kyleterry/sufr/pkg/data"
+	"github.com/kyleterry/sufr/pkg/embed"
+	"github.com/kyleterry/sufr/pkg/fetchqueue"
+	"github.com/kyleterry/sufr/pkg/linkcheck"
+	"github.com/kyleterry/sufr/pkg/sessionkeys"
+	"github.com/kyleterry/sufr/pkg/warc"
 	"github.com/pkg/errors"
 )
 
 var (
 	router = mux.NewRouter()
-	store  = gorsess.NewCookieStore([]byte("I gotta glock in my rari")) // TODO(kt): generate secret key instead of using Fetty Wap lyrics
+	// store is set up by New with the instance's session keys
+	store *gorsess.CookieStore
 )
 
 // Context key types so we don't clobber the global context store
@@ -64,47 +72,83 @@ type Sufr struct {
 	cfg      *config.Config
 	db       *data.SufrDB
 	sessions *gorsess.CookieStore
+	fetches  *fetchqueue.Pool
+	checks   *linkcheck.Checker
+	archives *archive.Archiver
+	warcs    *warc.Capturer
 }
 
-// New created a new pointer to Sufr
-func New(cfg *config.Config) *Sufr {
+// New created a new pointer to Sufr. Sessions are signed and encrypted with
+// keys.
+func New(cfg *config.Config, keys *sessionkeys.Keys) *Sufr {
 	// app := &Sufr{
 	// 	db:           opts.DB,
 	// 	sessionStore: opts.SessionStore,
 	// }
 
+	loadTemplates()
+
+	archives := archive.New(filepath.Join(cfg.DataDir, "archive"))
+
+	store = gorsess.NewCookieStore(keys.KeyPairs()...)
+
 	app := &Sufr{
-		cfg: cfg,
+		cfg:      cfg,
+		sessions: store,
+		archives: archives,
+		warcs:    warc.New(),
+		fetches: fetchqueue.New(
+			data.FetchJobQueue{},
+			data.FetchMetadataHandler(data.HTTPMetadataFetcher{}, archives),
+			fetchqueue.WithWorkers(cfg.FetchWorkers),
+		),
+		checks: linkcheck.New(
+			data.LinkCheckStore{},
+			linkcheck.WithInterval(cfg.LinkCheckInterval),
+			linkcheck.WithDeadAfter(cfg.LinkCheckDeadAfter),
+		),
 	}
 
+	// Requests made with an API token don't need a CSRF token. Browsers
+	// only send the session cookie on their own, never a token.
+	protect := csrf.New(store, csrf.WithSkip(func(r *http.Request) bool {
+		return requestAPIToken(r) != nil
+	}))
+
 	// Wrapped middleware
-	all := alice.New(SetSettingsHandler, SetLoggedInHandler, LoggingHandler, SetPinnedTagsHandler)
-	auth := alice.New(AuthHandler)
-	auth = auth.Extend(all)
-	apiAuth := alice.New(LoggedInOrAPITokenAuthHandler)
-	apiAuth = apiAuth.Extend(all)
+	forms := alice.New(LoggingHandler, protect.Handler)
+	all := alice.New(protect.Handler, SetSettingsHandler, SetLoggedInHandler, LoggingHandler, SetPinnedTagsHandler)
+	public := alice.New(APITokenScopeHandler(data.ScopeRead)).Extend(all)
+	auth := alice.New(LoggedInOrAPITokenAuthHandler(data.ScopeWrite)).Extend(all)
+	adminAuth := alice.New(LoggedInOrAPITokenAuthHandler(data.ScopeAdmin)).Extend(all)
+	readAuth := alice.New(LoggedInOrAPITokenAuthHandler(data.ScopeRead)).Extend(all)
+	backupAuth := alice.New(LoggedInOrAPITokenAuthHandler(data.ScopeBackup)).Extend(all)
 
 	const idPattern = "{id:(?i)[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}}"
 
 	// This route is used to initially configure the instance
-	router.Handle("/config", LoggingHandler(errorHandler(app.registrationHandler))).
+	router.Handle("/config", forms.Then(errorHandler(app.registrationHandler))).
 		Methods("POST", "GET").
 		Name("config")
 
-	router.Handle("/login", errorHandler(app.loginHandler)).
+	router.Handle("/login", forms.Then(errorHandler(app.loginHandler))).
 		Methods("POST", "GET").
 		Name("login")
 
-	router.Handle("/logout", errorHandler(app.logoutHandler)).
-		Methods("POST", "GET").
+	router.Handle("/logout", forms.Then(errorHandler(app.logoutHandler))).
+		Methods("POST").
 		Name("logout")
 
-	router.Handle("/", all.Then(errorHandler(app.urlIndexHandler))).
+	router.Handle("/", public.Then(errorHandler(app.urlIndexHandler))).
 		Name("url-index")
 
+	router.Handle("/broken", public.Then(errorHandler(app.urlBrokenHandler))).
+		Methods("GET").
+		Name("url-broken")
+
 	urlrouter := router.PathPrefix("/url").Subrouter()
 
-	urlrouter.Handle("/favorites", all.Then(errorHandler(app.urlFavoritesHandler))).
+	urlrouter.Handle("/favorites", public.Then(errorHandler(app.urlFavoritesHandler))).
 		Methods("GET").
 		Name("url-favorites")
 
@@ -116,10 +160,18 @@ func New(cfg *config.Config) *Sufr {
 		Methods("POST").
 		Name("url-submit")
 
-	urlrouter.Handle("/"+idPattern, all.Then(errorHandler(app.urlViewHandler))).
+	urlrouter.Handle("/"+idPattern, public.Then(errorHandler(app.urlViewHandler))).
 		Methods("GET").
 		Name("url-view")
 
+	urlrouter.Handle("/"+idPattern+"/archive", public.Then(errorHandler(app.urlArchiveHandler))).
+		Methods("GET").
+		Name("url-archive")
+
+	urlrouter.Handle("/"+idPattern+"/archive/text", public.Then(errorHandler(app.urlArchiveTextHandler))).
+		Methods("GET").
+		Name("url-archive-text")
+
 	urlrouter.Handle("/"+idPattern+"/edit", auth.Then(errorHandler(app.urlEditHandler))).
 		Methods("GET").
 		Name("url-edit")
@@ -128,8 +180,8 @@ func New(cfg *config.Config) *Sufr {
 		Methods("POST").
 		Name("url-save")
 
-	// this should use the DELETE method
 	urlrouter.Handle("/"+idPattern+"/delete", auth.Then(errorHandler(app.urlDeleteHandler))).
+		Methods("POST", "DELETE").
 		Name("url-delete")
 
 	urlrouter.Handle("/"+idPattern+"/toggle-fav", auth.Then(errorHandler(app.urlToggleFavoriteHandler))).
@@ -138,31 +190,35 @@ func New(cfg *config.Config) *Sufr {
 
 	tagrouter := router.PathPrefix("/tag").Subrouter()
 
-	tagrouter.Handle("/"+idPattern, all.Then(errorHandler(app.tagViewHandler))).
+	tagrouter.Handle("/"+idPattern, public.Then(errorHandler(app.tagViewHandler))).
 		Methods("GET").
 		Name("tag-view")
 
-	router.Handle("/settings", auth.Then(errorHandler(app.settingsHandler))).
+	router.Handle("/settings", adminAuth.Then(errorHandler(app.settingsHandler))).
 		Methods("POST", "GET").
 		Name("settings")
 
 	tokenRouter := router.PathPrefix("/api-token").Subrouter()
 
-	tokenRouter.Handle("/roll", all.Then(errorHandler(app.apiTokenRollHandler))).
-		Methods("GET").
-		Name("api-token-roll")
-	tokenRouter.Handle("/delete", all.Then(errorHandler(app.apiTokenDeleteHandler))).
-		Methods("GET").
+	tokenRouter.Handle("/create", adminAuth.Then(errorHandler(app.apiTokenCreateHandler))).
+		Methods("POST").
+		Name("api-token-create")
+	tokenRouter.Handle("/"+idPattern+"/delete", adminAuth.Then(errorHandler(app.apiTokenDeleteHandler))).
+		Methods("POST").
 		Name("api-token-delete")
 
-	router.Handle("/search", all.Then(errorHandler(app.searchHandler))).
+	router.Handle("/search", public.Then(errorHandler(app.searchHandler))).
 		Methods("GET").
 		Name("search")
 
-	router.Handle("/database-backup", apiAuth.Then(errorHandler(data.BackupHandler))).
+	router.Handle("/database-backup", backupAuth.Then(errorHandler(data.BackupHandler(archives)))).
 		Methods("GET").
 		Name("database-backup")
 
+	router.Handle("/warc-export", readAuth.Then(errorHandler(app.warcExportHandler))).
+		Methods("GET").
+		Name("warc-export")
+
 	router.Handle("/healthz", errorHandler(app.healthzHandler)).Methods("GET").Name("healthz")
 
 	router.PathPrefix("/static").Handler(LoggingHandler(staticHandler))
@@ -175,6 +231,18 @@ func New(cfg *config.Config) *Sufr {
 	return app
 }
 
+// RunFetchers fetches the metadata of new urls in the background until ctx
+// is done.
+func (s *Sufr) RunFetchers(ctx context.Context) error {
+	return s.fetches.Run(ctx)
+}
+
+// RunLinkChecker checks saved urls for broken links in the background until
+// ctx is done.
+func (s *Sufr) RunLinkChecker(ctx context.Context) error {
+	return s.checks.Run(ctx)
+}
+
 func (s Sufr) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// 1. Get session data
 	// 2. Make flashes structure
@@ -199,6 +267,21 @@ func (s Sufr) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	templateData["RequestURI"] = r.RequestURI
 	ctx = context.WithValue(ctx, templateDataKey, templateData)
 
+	// API tokens are checked before anything else so a bad one is turned
+	// away and a good one gets past the private instance check. The routes
+	// check the token has the scope they need.
+	token, err := authenticateAPIToken(r)
+	if err != nil {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="sufr"`)
+		http.Error(w, "401 Unauthorized", http.StatusUnauthorized)
+
+		return
+	}
+
+	if token != nil {
+		ctx = context.WithValue(ctx, apiTokenKey, token)
+	}
+
 	r = r.WithContext(ctx)
 
 	session.Save(r, w)
@@ -215,7 +298,7 @@ func (s Sufr) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Is it a private only instance?
-	if r.RequestURI != "/login" && instancePrivate() && !loggedIn(r) && !strings.HasPrefix(r.RequestURI, "/static") {
+	if r.RequestURI != "/login" && instancePrivate() && !loggedIn(r) && token == nil && !strings.HasPrefix(r.RequestURI, "/static") {
 		http.Redirect(w, r, reverse("login"), http.StatusSeeOther)
 		return
 	}
@@ -308,41 +391,48 @@ func loggedIn(r *http.Request) bool {
 	return true
 }
 
-func isYoutube(url string) bool {
-	return strings.Contains(url, "youtube.com/watch")
-}
+// authenticateAPIToken returns the bearer API token r was made with, if any.
+// Other kinds of authorization, like basic auth a proxy in front of sufr
+// asked for, are left alone.
+func authenticateAPIToken(r *http.Request) (*data.APIToken, error) {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") {
+		return nil, nil
+	}
 
-func youtubevid(video string) string {
-	u, _ := url.Parse(video)
-	return u.Query()["v"][0]
+	token := strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+	if token == "" {
+		return nil, nil
+	}
+
+	return data.AuthenticateAPIToken(token)
 }
 
-func isImgur(url string) bool {
-	// TODO add blacklist for other types of imgur links
-	return strings.Contains(url, "imgur.com")
+// requestAPIToken returns the API token r was authenticated with, or nil if
+// there wasn't one.
+func requestAPIToken(r *http.Request) *data.APIToken {
+	token, _ := r.Context().Value(apiTokenKey).(*data.APIToken)
+
+	return token
 }
 
-// Will match things like FyCch, FyCch.jpg and so on so we can embed raw image links too
-// Looks for an id of 3 to 8 chars long.
-// TODO: Look at the Imgur API to confirm this :^)
-var imgurRE = regexp.MustCompile(`^(?P<id>([a-zA-Z0-9]{3,8}))\.?[a-zA-z]*$`)
+// embeds embeds the links that can be from the link alone. There's nowhere
+// to cache oEmbed answers in this store.
+var embeds = embed.New()
 
-func imgurgal(gal string) string {
-	u, _ := url.Parse(gal)
+// embedFor returns the embed of rawurl if settings, the instance settings
+// passed to templates, want it shown.
+func embedFor(settings map[string]interface{}, rawurl string) *embed.Embed {
+	photos, _ := settings["EmbedPhotos"].(bool)
+	videos, _ := settings["EmbedVideos"].(bool)
+	opts := embed.Options{Photos: photos, Videos: videos}
 
-	parts := strings.Split(u.Path[1:], "/")
-	if len(parts) > 1 {
-		if parts[0] == "gallery" {
-			parts[0] = "a"
-		}
-	} else {
-		match := imgurRE.FindStringSubmatch(parts[0])
-		if len(match) > 1 {
-			parts[0] = match[1]
-		}
+	e, err := embeds.Lookup(context.Background(), "", rawurl)
+	if err != nil || e == nil || !opts.Allows(e.Type) {
+		return nil
 	}
 
-	return strings.Join(parts, "/")
+	return e
 }
 
 func newcontext(values ...interface{}) (map[string]interface{}, error) {
@@ -4,16 +4,22 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"net/http"
+	neturl "net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/asaskevich/govalidator"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/gorilla/schema"
 	"github.com/gorilla/sessions"
+	"github.com/kyleterry/sufr/pkg/archive"
 	"github.com/kyleterry/sufr/pkg/data"
 	"github.com/kyleterry/sufr/pkg/ui"
+	"github.com/kyleterry/sufr/pkg/warc"
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -92,6 +98,26 @@ func (a *Sufr) urlFavoritesHandler(w http.ResponseWriter, r *http.Request) error
 	return renderTemplate(w, r.WithContext(ctx), "url-index")
 }
 
+// urlBrokenHandler lists the urls the link checker flagged dead.
+func (a *Sufr) urlBrokenHandler(w http.ResponseWriter, r *http.Request) error {
+	paginator, err := data.NewURLPaginator(page(r), a.perPage(r), 3, data.NewSearchURLGetter("is:dead", loggedIn(r)))
+	if err != nil {
+		return errors.Wrap(err, "failed to get paginator")
+	}
+
+	ctx := r.Context()
+
+	templateData := ctx.Value(templateDataKey).(map[string]interface{})
+	templateData["Count"] = len(paginator.URLs)
+	templateData["URLs"] = paginator.URLs
+	templateData["Paginator"] = paginator
+	templateData["Title"] = "Broken links"
+
+	ctx = context.WithValue(ctx, templateDataKey, templateData)
+
+	return renderTemplate(w, r.WithContext(ctx), "url-index")
+}
+
 func (a *Sufr) urlNewHandler(w http.ResponseWriter, r *http.Request) error {
 	ctx := r.Context()
 	templateData := ctx.Value(templateDataKey).(map[string]interface{})
@@ -121,7 +147,7 @@ func (a *Sufr) urlSubmitHandler(w http.ResponseWriter, r *http.Request) error {
 
 	// TODO: make and check for a validation error
 	// session.FlashValidationError(err)
-	url, err := data.CreateURL(createURLOptions, data.HTTPMetadataFetcher{})
+	url, err := data.CreateURL(createURLOptions)
 	if err != nil {
 		if errors.Cause(err) == data.ErrDuplicateKey {
 			session.AddFlash("URL already exists", "danger")
@@ -134,6 +160,8 @@ func (a *Sufr) urlSubmitHandler(w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
+	a.fetches.Notify()
+
 	if err := a.updatePageIndexes(a.perPage(r)); err != nil {
 		return err
 	}
@@ -174,6 +202,87 @@ func (a *Sufr) urlViewHandler(w http.ResponseWriter, r *http.Request) error {
 	return renderTemplate(w, r.WithContext(ctx), "url-view")
 }
 
+// urlArchiveHandler serves the archived snapshot of a url's page.
+func (a *Sufr) urlArchiveHandler(w http.ResponseWriter, r *http.Request) error {
+	url, ok, err := a.archivedURL(w, r)
+	if !ok {
+		return err
+	}
+
+	f, err := a.archives.Open(url.ID.String(), archive.PageFile)
+	if err != nil {
+		if err == archive.ErrNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			return renderTemplate(w, r, "404")
+		}
+
+		return errors.Wrap(err, "failed to open archived page")
+	}
+	defer f.Close()
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Security-Policy", archive.ContentSecurityPolicy)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+
+	http.ServeContent(w, r, archive.PageFile, url.ArchivedAt, f)
+	return nil
+}
+
+// urlArchiveTextHandler shows the readable text of the archived snapshot of a
+// url's page.
+func (a *Sufr) urlArchiveTextHandler(w http.ResponseWriter, r *http.Request) error {
+	url, ok, err := a.archivedURL(w, r)
+	if !ok {
+		return err
+	}
+
+	text, err := a.archives.ReadText(url.ID.String())
+	if err != nil {
+		if err == archive.ErrNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			return renderTemplate(w, r, "404")
+		}
+
+		return errors.Wrap(err, "failed to read archived text")
+	}
+
+	ctx := r.Context()
+	templateData := ctx.Value(templateDataKey).(map[string]interface{})
+	templateData["Title"] = url.Title
+	templateData["OriginalURL"] = url.URL
+	templateData["PageURL"] = reverse("url-archive", "id", url.ID)
+	templateData["ArchivedAt"] = url.ArchivedAt
+	templateData["Paragraphs"] = archive.Paragraphs(text)
+
+	ctx = context.WithValue(ctx, templateDataKey, templateData)
+
+	return renderTemplate(w, r.WithContext(ctx), "url-archive")
+}
+
+// archivedURL gets the url in the route if it has been archived and the
+// user may see it. Otherwise it writes a 404 page and ok is false.
+func (a *Sufr) archivedURL(w http.ResponseWriter, r *http.Request) (url *data.URL, ok bool, err error) {
+	vars := mux.Vars(r)
+
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		return nil, false, err
+	}
+
+	url, err = data.GetURL(id)
+	if err != nil && errors.Cause(err) != data.ErrNotFound {
+		return nil, false, errors.Wrap(err, "failed to get url")
+	}
+
+	if err != nil || url.ArchivedAt.IsZero() || (!loggedIn(r) && url.Private) {
+		w.WriteHeader(http.StatusNotFound)
+		return nil, false, renderTemplate(w, r, "404")
+	}
+
+	return url, true, nil
+}
+
 func (a *Sufr) urlToggleFavoriteHandler(w http.ResponseWriter, r *http.Request) error {
 	vars := mux.Vars(r)
 
@@ -198,6 +307,19 @@ func (a *Sufr) urlToggleFavoriteHandler(w http.ResponseWriter, r *http.Request)
 		return errors.Wrap(err, "failed to toggle favorite flag")
 	}
 
+	// the favorite button is a plain form when scripts don't handle it, so
+	// send the browser back to the page it was on
+	if r.Header.Get("X-Requested-With") != "XMLHttpRequest" {
+		back := reverse("url-view", "id", url.ID)
+		if ref, err := neturl.Parse(r.Referer()); err == nil && ref.Host == r.Host {
+			back = ref.RequestURI()
+		}
+
+		http.Redirect(w, r, back, http.StatusSeeOther)
+
+		return nil
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 
 	response, err := json.Marshal(struct {
@@ -270,6 +392,8 @@ func (a *Sufr) urlSaveHandler(w http.ResponseWriter, r *http.Request) error {
 		return errors.Wrap(err, "failed to get url")
 	}
 
+	a.fetches.Notify()
+
 	if err := a.updatePageIndexes(a.perPage(r)); err != nil {
 		return err
 	}
@@ -300,6 +424,10 @@ func (a *Sufr) urlDeleteHandler(w http.ResponseWriter, r *http.Request) error {
 		return errors.Wrap(err, "failed to delete url")
 	}
 
+	if err := a.archives.Remove(url.ID.String()); err != nil {
+		return errors.Wrap(err, "failed to delete archived page")
+	}
+
 	if err := a.updatePageIndexes(a.perPage(r)); err != nil {
 		return err
 	}
@@ -554,12 +682,7 @@ func (a *Sufr) settingsHandler(w http.ResponseWriter, r *http.Request) error {
 	}
 
 	if r.Method == "GET" {
-		ctx := r.Context()
-		templateData := ctx.Value(templateDataKey).(map[string]interface{})
-		templateData["Title"] = "Settings"
-		templateData["SettingsObject"] = settings
-		ctx = context.WithValue(ctx, templateDataKey, templateData)
-		return renderTemplate(w, r.WithContext(ctx), "settings")
+		return renderSettings(w, r, settings, nil)
 	}
 
 	if err := r.ParseForm(); err != nil {
@@ -600,8 +723,30 @@ func (a *Sufr) settingsHandler(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
-func (a Sufr) apiTokenRollHandler(w http.ResponseWriter, r *http.Request) error {
-	if err := data.DeleteAPITokens(); err != nil {
+// renderSettings renders the settings page. newToken is shown when a token
+// was just created since it's the only time it can be.
+func renderSettings(w http.ResponseWriter, r *http.Request, settings *data.Settings, newToken *data.APIToken) error {
+	tokens, err := data.GetAPITokens()
+	if err != nil {
+		return errors.Wrap(err, "failed to get api tokens")
+	}
+
+	ctx := r.Context()
+	templateData := ctx.Value(templateDataKey).(map[string]interface{})
+	templateData["Title"] = "Settings"
+	templateData["SettingsObject"] = settings
+	templateData["APITokens"] = tokens
+	templateData["APIScopes"] = data.APIScopes
+	templateData["NewAPIToken"] = newToken
+	ctx = context.WithValue(ctx, templateDataKey, templateData)
+
+	return renderTemplate(w, r.WithContext(ctx), "settings")
+}
+
+// apiTokenCreateHandler creates a named API token with the scopes checked
+// and an optional expiry in days.
+func (a Sufr) apiTokenCreateHandler(w http.ResponseWriter, r *http.Request) error {
+	if err := r.ParseForm(); err != nil {
 		return err
 	}
 
@@ -610,32 +755,117 @@ func (a Sufr) apiTokenRollHandler(w http.ResponseWriter, r *http.Request) error
 		return errors.Wrap(err, "failed to get session store")
 	}
 
-	if _, err := data.CreateAPIToken(); err != nil {
-		session.AddFlash("There was an error creating a new API token", "danger")
+	fail := func(msg string) error {
+		session.AddFlash(msg, "danger")
 		session.Save(r, w)
 		http.Redirect(w, r, reverse("settings"), http.StatusSeeOther)
 
 		return nil
 	}
 
-	session.AddFlash("Successfully rolled API token", "success")
-	session.Save(r, w)
+	name := strings.TrimSpace(r.PostFormValue("name"))
+	if name == "" {
+		return fail("API tokens need a name")
+	}
 
-	http.Redirect(w, r, reverse("settings"), http.StatusSeeOther)
+	scopes := []data.APIScope{}
 
-	return nil
+	for _, s := range r.PostForm["scope"] {
+		scope, err := data.ParseAPIScope(s)
+		if err != nil {
+			return fail(err.Error())
+		}
+
+		scopes = append(scopes, scope)
+	}
+
+	if len(scopes) == 0 {
+		return fail("API tokens need at least one scope")
+	}
+
+	var expiresAt *time.Time
+
+	if v := r.PostFormValue("expires"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days <= 0 {
+			return fail("The expiry must be a number of days")
+		}
+
+		t := time.Now().AddDate(0, 0, days)
+		expiresAt = &t
+	}
+
+	token, err := data.CreateAPIToken(name, scopes, expiresAt)
+	if err != nil {
+		return fail("There was an error creating a new API token")
+	}
+
+	settings, err := data.GetSettings()
+	if err != nil {
+		return err
+	}
+
+	return renderSettings(w, r, settings, token)
 }
 
+// apiTokenDeleteHandler revokes a single API token.
 func (a Sufr) apiTokenDeleteHandler(w http.ResponseWriter, r *http.Request) error {
-	if err := data.DeleteAPITokens(); err != nil {
+	vars := mux.Vars(r)
+
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
 		return err
 	}
 
+	session, err := store.Get(r, "flashes")
+	if err != nil {
+		return errors.Wrap(err, "failed to get session store")
+	}
+
+	if err := data.DeleteAPIToken(id); err != nil {
+		if err != data.ErrNotFound {
+			return err
+		}
+
+		session.AddFlash("That API token doesn't exist", "danger")
+	} else {
+		session.AddFlash("Revoked API token", "success")
+	}
+
+	session.Save(r, w)
+
 	http.Redirect(w, r, reverse("settings"), http.StatusSeeOther)
 
 	return nil
 }
 
+// warcExportHandler downloads the urls matching the search in q, or every
+// url, as a WARC file.
+func (a Sufr) warcExportHandler(w http.ResponseWriter, r *http.Request) error {
+	urls, err := data.SearchURLs(r.URL.Query().Get("q"), true)
+	if err != nil {
+		return errors.Wrap(err, "failed to get urls")
+	}
+
+	targets := make([]string, len(urls))
+	for i, url := range urls {
+		targets[i] = url.URL
+	}
+
+	filename := fmt.Sprintf("sufr-%s%s", time.Now().UTC().Format("2006-01-02"), warc.FileExt)
+
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	// the download has started by the time anything can go wrong so all
+	// that's left to do is log it
+	if _, err := a.warcs.Export(r.Context(), w, targets); err != nil {
+		log.Printf("WARC export failed: %s\n", err)
+	}
+
+	return nil
+}
+
 func (a Sufr) healthzHandler(w http.ResponseWriter, r *http.Request) error {
 	w.WriteHeader(http.StatusOK)
 
@@ -649,7 +879,7 @@ func (a Sufr) searchHandler(w http.ResponseWriter, r *http.Request) error {
 		return nil
 	}
 
-	paginator, err := data.NewURLPaginator(page(r), a.perPage(r), 3, data.NewSearchURLGetter(query))
+	paginator, err := data.NewURLPaginator(page(r), a.perPage(r), 3, data.NewSearchURLGetter(query, loggedIn(r)))
 	if err != nil {
 		return errors.Wrap(err, "failed to get paginator")
 	}
@@ -661,6 +891,7 @@ func (a Sufr) searchHandler(w http.ResponseWriter, r *http.Request) error {
 	templateData["URLs"] = paginator.URLs
 	templateData["Paginator"] = paginator
 	templateData["Query"] = query
+	templateData["Title"] = fmt.Sprintf("Search: %s", query)
 
 	ctx = context.WithValue(ctx, templateDataKey, templateData)
 
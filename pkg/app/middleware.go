@@ -5,7 +5,6 @@ import (
 	"net/http"
 	"os"
 
-	"github.com/google/uuid"
 	"github.com/gorilla/handlers"
 	"github.com/kyleterry/sufr/pkg/config"
 	"github.com/kyleterry/sufr/pkg/data"
@@ -26,24 +25,42 @@ func AuthHandler(h http.Handler) http.Handler {
 	})
 }
 
-func LoggedInOrAPITokenAuthHandler(h http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if _, pass, ok := r.BasicAuth(); ok {
-			if apiToken, _ := data.GetAPIToken(); apiToken != nil {
-				if parsedToken, err := uuid.Parse(pass); err == nil {
-					if parsedToken == apiToken.Token {
-						h.ServeHTTP(w, r)
-
-						return
-					}
+// LoggedInOrAPITokenAuthHandler lets requests through that are logged in or
+// were made with an API token that has scope.
+func LoggedInOrAPITokenAuthHandler(scope data.APIScope) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token := requestAPIToken(r); token != nil {
+				if !token.Allows(scope) {
+					http.Error(w, data.ErrAPITokenScope.Error(), http.StatusForbidden)
+
+					return
 				}
+
+				h.ServeHTTP(w, r)
+
+				return
 			}
-		}
 
-		h = AuthHandler(h)
+			AuthHandler(h).ServeHTTP(w, r)
+		})
+	}
+}
 
-		h.ServeHTTP(w, r)
-	})
+// APITokenScopeHandler turns away requests made with an API token that
+// doesn't have scope. Requests without a token are let through.
+func APITokenScopeHandler(scope data.APIScope) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token := requestAPIToken(r); token != nil && !token.Allows(scope) {
+				http.Error(w, data.ErrAPITokenScope.Error(), http.StatusForbidden)
+
+				return
+			}
+
+			h.ServeHTTP(w, r)
+		})
+	}
 }
 
 func SetLoggedInHandler(h http.Handler) http.Handler {
@@ -70,11 +87,6 @@ func SetSettingsHandler(h http.Handler) http.Handler {
 			panic(err)
 		}
 
-		apiToken, _ := data.GetAPIToken()
-		if apiToken == nil {
-			apiToken = &data.APIToken{}
-		}
-
 		sm := make(map[string]interface{})
 		sm["EmbedPhotos"] = settings.EmbedPhotos
 		sm["EmbedVideos"] = settings.EmbedVideos
@@ -84,7 +96,6 @@ func SetSettingsHandler(h http.Handler) http.Handler {
 		sm["BuildGitHash"] = config.BuildGitHash
 		// TODO pull this from config struct
 		sm["DataDir"] = config.DataDir
-		sm["APIToken"] = apiToken.Token
 
 		ctx := context.WithValue(r.Context(), settingsKey, sm)
 		h.ServeHTTP(w, r.WithContext(ctx))
@@ -5,6 +5,7 @@ import (
 	"log"
 	"net/http"
 
+	"github.com/kyleterry/sufr/pkg/csrf"
 	"github.com/kyleterry/sufr/pkg/data"
 	"github.com/kyleterry/sufr/pkg/ui"
 	"github.com/oxtoacart/bpool"
@@ -13,23 +14,27 @@ import (
 
 var bufpool = bpool.NewBufferPool(64)
 
-var templateMap = map[string]*template.Template{
-	"url-index":    mustCreateTemplate("templates/base.html", "templates/url-index.html"),
-	"url-new":      mustCreateTemplate("templates/base.html", "templates/url-new.html"),
-	"url-view":     mustCreateTemplate("templates/base.html", "templates/url-view.html"),
-	"url-edit":     mustCreateTemplate("templates/base.html", "templates/url-edit.html"),
-	"settings":     mustCreateTemplate("templates/base.html", "templates/settings.html"),
-	"registration": mustCreateTemplate("templates/base.html", "templates/register.html"),
-	"login":        mustCreateTemplate("templates/base.html", "templates/login.html"),
-	"404":          mustCreateTemplate("templates/base.html", "templates/404.html"),
+// templateMap is filled in by New rather than when the package loads, so the
+// commands that share the sufr binary run without parsing templates.
+var templateMap map[string]*template.Template
+
+func loadTemplates() {
+	templateMap = map[string]*template.Template{
+		"url-index":    mustCreateTemplate("templates/base.html", "templates/url-index.html"),
+		"url-new":      mustCreateTemplate("templates/base.html", "templates/url-new.html"),
+		"url-view":     mustCreateTemplate("templates/base.html", "templates/url-view.html"),
+		"url-edit":     mustCreateTemplate("templates/base.html", "templates/url-edit.html"),
+		"url-archive":  mustCreateTemplate("templates/base.html", "templates/url-archive.html"),
+		"settings":     mustCreateTemplate("templates/base.html", "templates/settings.html"),
+		"registration": mustCreateTemplate("templates/base.html", "templates/register.html"),
+		"login":        mustCreateTemplate("templates/base.html", "templates/login.html"),
+		"404":          mustCreateTemplate("templates/base.html", "templates/404.html"),
+	}
 }
 
 var templateFuncs = template.FuncMap{
 	"reverse":    reverse,
-	"isyoutube":  isYoutube,
-	"youtubevid": youtubevid,
-	"isimgur":    isImgur,
-	"imgurgal":   imgurgal,
+	"embed":      embedFor,
 	"newcontext": newcontext,
 	"updatePage": updatePage,
 }
@@ -63,6 +68,8 @@ func renderTemplate(w http.ResponseWriter, r *http.Request, name string) error {
 		templateData["User"] = user
 	}
 
+	templateData["CSRFToken"] = csrf.Token(r)
+
 	pinnedTags, ok := ctx.Value(pinnedTagsKey).(*data.PinnedTags)
 	if ok {
 		templateData["PinnedTags"] = pinnedTags
@@ -0,0 +1,400 @@
+// Package archive keeps snapshots of saved pages on disk so they can still be
+// read after the site goes away. A snapshot is the page with its stylesheets
+// and images inlined, so it renders without touching the network, and a
+// readable text version of its main content.
+package archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/kyleterry/sufr/pkg/config"
+	"golang.org/x/net/html/charset"
+)
+
+const (
+	// PageFile is the page with its assets inlined.
+	PageFile = "page.html"
+	// TextFile is the readable text of the page. Paragraphs are separated by
+	// a blank line.
+	TextFile = "text.txt"
+
+	// ContentSecurityPolicy should be sent with archived pages. It only lets
+	// them use the styles and images inlined into them, in case something
+	// slipped past the archiver.
+	ContentSecurityPolicy = "default-src 'none'; img-src data:; style-src 'unsafe-inline' data:; " +
+		"font-src data:; sandbox allow-popups allow-popups-to-escape-sandbox"
+
+	DefaultTimeout       = time.Minute
+	DefaultMaxPageBytes  = 10 << 20
+	DefaultMaxAssetBytes = 20 << 20
+)
+
+var (
+	// ErrNotHTML is returned by Archive for pages that aren't html. Only html
+	// pages are archived.
+	ErrNotHTML = errors.New("page is not html")
+	// ErrNotFound is returned by Open when there is no snapshot.
+	ErrNotFound = errors.New("snapshot not found")
+)
+
+// validID matches the ids snapshots can be saved under. It keeps ids from
+// escaping the archive directory.
+var validID = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+
+// Snapshot is an archived page.
+type Snapshot struct {
+	Title     string
+	HTML      []byte
+	Text      string
+	CreatedAt time.Time
+}
+
+type archiverOptions struct {
+	timeout       time.Duration
+	maxPageBytes  int64
+	maxAssetBytes int64
+	transport     http.RoundTripper
+}
+
+type archiverOptionFunc struct {
+	f func(*archiverOptions)
+}
+
+func (a *archiverOptionFunc) apply(opts *archiverOptions) {
+	a.f(opts)
+}
+
+type ArchiverOption interface {
+	apply(*archiverOptions)
+}
+
+// WithTimeout sets how long archiving a page may take, assets included.
+func WithTimeout(d time.Duration) ArchiverOption {
+	return &archiverOptionFunc{
+		f: func(opts *archiverOptions) {
+			opts.timeout = d
+		},
+	}
+}
+
+// WithMaxPageBytes sets how much of a page is read. Anything past it is cut
+// off.
+func WithMaxPageBytes(n int64) ArchiverOption {
+	return &archiverOptionFunc{
+		f: func(opts *archiverOptions) {
+			opts.maxPageBytes = n
+		},
+	}
+}
+
+// WithMaxAssetBytes sets how many bytes of stylesheets and images are inlined
+// into a page. Assets past it are left out of the snapshot.
+func WithMaxAssetBytes(n int64) ArchiverOption {
+	return &archiverOptionFunc{
+		f: func(opts *archiverOptions) {
+			opts.maxAssetBytes = n
+		},
+	}
+}
+
+// WithTransport sets the http.RoundTripper used to make requests.
+func WithTransport(rt http.RoundTripper) ArchiverOption {
+	return &archiverOptionFunc{
+		f: func(opts *archiverOptions) {
+			opts.transport = rt
+		},
+	}
+}
+
+// Archiver takes snapshots of pages and keeps them in a directory, one
+// sub directory per snapshot.
+type Archiver struct {
+	dir    string
+	client *http.Client
+	opts   archiverOptions
+}
+
+// Dir returns the directory snapshots are kept in.
+func (a *Archiver) Dir() string {
+	return a.dir
+}
+
+// Archive takes a snapshot of the page at rawurl and saves it under id,
+// replacing any snapshot already there.
+func (a *Archiver) Archive(ctx context.Context, id, rawurl string) (*Snapshot, error) {
+	if !validID.MatchString(id) {
+		return nil, fmt.Errorf("invalid snapshot id %q", id)
+	}
+
+	snap, err := a.Snapshot(ctx, rawurl)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.save(id, snap); err != nil {
+		return nil, fmt.Errorf("failed to save snapshot: %w", err)
+	}
+
+	return snap, nil
+}
+
+// Snapshot takes a snapshot of the page at rawurl without saving it.
+func (a *Archiver) Snapshot(ctx context.Context, rawurl string) (*Snapshot, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.opts.timeout)
+	defer cancel()
+
+	res, err := a.get(ctx, rawurl)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return nil, fmt.Errorf("unexpected status %d", res.StatusCode)
+	}
+
+	contentType := res.Header.Get("Content-Type")
+
+	if mt, _, err := mime.ParseMediaType(contentType); err == nil && mt != "text/html" && mt != "application/xhtml+xml" {
+		return nil, ErrNotHTML
+	}
+
+	// everything is utf-8 from here on
+	body, err := charset.NewReader(io.LimitReader(res.Body, a.opts.maxPageBytes), contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode page: %w", err)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse page: %w", err)
+	}
+
+	base := res.Request.URL
+	if href, ok := doc.Find("base[href]").First().Attr("href"); ok {
+		if u, err := base.Parse(href); err == nil {
+			base = u
+		}
+	}
+
+	snap := &Snapshot{
+		Title:     collapseSpace(doc.Find("title").First().Text()),
+		Text:      extractText(doc),
+		CreatedAt: time.Now().UTC(),
+	}
+
+	in := &inliner{archiver: a, budget: a.opts.maxAssetBytes, cache: map[string]string{}}
+	in.inline(ctx, doc, base)
+
+	page, err := doc.Html()
+	if err != nil {
+		return nil, fmt.Errorf("failed to render snapshot: %w", err)
+	}
+
+	snap.HTML = []byte(page)
+
+	return snap, nil
+}
+
+func (a *Archiver) get(ctx context.Context, rawurl string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawurl, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", config.SUFRUserAgent)
+
+	return a.client.Do(req)
+}
+
+// save writes the files of snap to the directory of id. Each file is written
+// to a temporary file first and renamed into place so a failed save never
+// leaves a half written snapshot behind.
+func (a *Archiver) save(id string, snap *Snapshot) error {
+	dir := filepath.Join(a.dir, id)
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	files := map[string][]byte{
+		PageFile: snap.HTML,
+		TextFile: []byte(snap.Text),
+	}
+
+	for name, b := range files {
+		if err := writeFile(filepath.Join(dir, name), b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeFile(path string, b []byte) error {
+	f, err := ioutil.TempFile(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
+
+// Open opens the file name, PageFile or TextFile, of the snapshot saved under
+// id. It returns ErrNotFound if there is no such snapshot.
+func (a *Archiver) Open(id, name string) (*os.File, error) {
+	if !validID.MatchString(id) || (name != PageFile && name != TextFile) {
+		return nil, ErrNotFound
+	}
+
+	f, err := os.Open(filepath.Join(a.dir, id, name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// ReadText returns the readable text of the snapshot saved under id.
+func (a *Archiver) ReadText(id string) (string, error) {
+	f, err := a.Open(id, TextFile)
+	if err != nil {
+		return "", err
+	}
+
+	defer f.Close()
+
+	b, err := ioutil.ReadAll(f)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// Remove deletes the snapshot saved under id, if there is one.
+func (a *Archiver) Remove(id string) error {
+	if !validID.MatchString(id) {
+		return nil
+	}
+
+	return os.RemoveAll(filepath.Join(a.dir, id))
+}
+
+// AddToTar writes every snapshot file to tw with prefix prepended to its path
+// relative to the archive directory.
+func (a *Archiver) AddToTar(tw *tar.Writer, prefix string) error {
+	err := filepath.Walk(a.dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			// nothing has been archived yet
+			if os.IsNotExist(err) && path == a.dir {
+				return nil
+			}
+
+			return err
+		}
+
+		if !info.Mode().IsRegular() || filepath.Base(path)[0] == '.' {
+			return nil
+		}
+
+		rel, err := filepath.Rel(a.dir, path)
+		if err != nil {
+			return err
+		}
+
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+
+		hdr.Name = filepath.ToSlash(filepath.Join(prefix, rel))
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+
+		defer f.Close()
+
+		_, err = io.Copy(tw, f)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add snapshots to backup: %w", err)
+	}
+
+	return nil
+}
+
+// New returns an Archiver that keeps snapshots in dir.
+func New(dir string, opts ...ArchiverOption) *Archiver {
+	ao := archiverOptions{
+		timeout:       DefaultTimeout,
+		maxPageBytes:  DefaultMaxPageBytes,
+		maxAssetBytes: DefaultMaxAssetBytes,
+		transport:     http.DefaultTransport,
+	}
+
+	for _, opt := range opts {
+		opt.apply(&ao)
+	}
+
+	return &Archiver{
+		dir:    dir,
+		client: &http.Client{Transport: ao.transport},
+		opts:   ao,
+	}
+}
+
+// readLimited reads r until it ends or more than max bytes were read, in
+// which case ok is false.
+func readLimited(r io.Reader, max int64) (b []byte, ok bool, err error) {
+	var buf bytes.Buffer
+
+	n, err := io.Copy(&buf, io.LimitReader(r, max+1))
+	if err != nil {
+		return nil, false, err
+	}
+
+	if n > max {
+		return nil, false, nil
+	}
+
+	return buf.Bytes(), true, nil
+}
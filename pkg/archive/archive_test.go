@@ -0,0 +1,174 @@
+package archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testPage = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="iso-8859-1">
+<title>  A   caf` + "\xe9" + ` story </title>
+<link rel="stylesheet" href="/style.css">
+<script src="/app.js"></script>
+</head>
+<body onload="track()">
+<nav><ul><li><a href="/">Home</a></li><li><a href="/about">About</a></li></ul></nav>
+<div id="content" class="article">
+<h1>The caf` + "\xe9" + ` story</h1>
+<p>This is the first paragraph of the story, long enough to count, with a comma.</p>
+<p>And a second paragraph, which <a href="/more">links somewhere</a>, also counts.</p>
+<img src="/pixel.gif" srcset="/pixel-2x.gif 2x">
+<a href="javascript:alert(1)">click</a>
+</div>
+<div class="comments"><p>This is a comment that is long enough to count, but isn't content.</p></div>
+<footer><p>Copyright someone, some year, all rights reserved.</p></footer>
+</body>
+</html>`
+
+var testGIF = []byte("GIF89a\x01\x00\x01\x00\x80\x00\x00\xff\xff\xff\x00\x00\x00,\x00\x00\x00\x00\x01\x00\x01\x00\x00\x02\x02D\x01\x00;")
+
+func newTestServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=iso-8859-1")
+		io.WriteString(w, testPage)
+	})
+	mux.HandleFunc("/style.css", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/css")
+		io.WriteString(w, `body { background: url('/pixel.gif'); }`)
+	})
+	mux.HandleFunc("/pixel.gif", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(testGIF)
+	})
+	mux.HandleFunc("/app.js", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("scripts should not be fetched")
+	})
+	mux.HandleFunc("/file.pdf", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		io.WriteString(w, "%PDF-1.4")
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestSnapshot(t *testing.T) {
+	srv := newTestServer(t)
+	a := New(t.TempDir())
+
+	snap, err := a.Snapshot(context.Background(), srv.URL+"/page")
+	require.NoError(t, err)
+
+	require.Equal(t, "A café story", snap.Title)
+
+	page := string(snap.HTML)
+	require.Contains(t, page, `<meta charset="utf-8"/>`)
+	require.NotContains(t, page, "iso-8859-1")
+	require.NotContains(t, page, "<script")
+	require.NotContains(t, page, "onload")
+	require.NotContains(t, page, "javascript:")
+	require.NotContains(t, page, "srcset")
+	require.NotContains(t, page, "/style.css")
+	require.Contains(t, page, `<style>body { background: url("data:image/gif;base64,`)
+	require.Contains(t, page, `<img src="data:image/gif;base64,`)
+	require.Contains(t, page, `href="`+srv.URL+`/more"`)
+	require.Contains(t, page, "The café story")
+
+	require.Equal(t, []string{
+		"The café story",
+		"This is the first paragraph of the story, long enough to count, with a comma.",
+		"And a second paragraph, which links somewhere, also counts.",
+		"click",
+	}, Paragraphs(snap.Text))
+
+	t.Run("pages that aren't html are not archived", func(t *testing.T) {
+		_, err := a.Snapshot(context.Background(), srv.URL+"/file.pdf")
+		require.Equal(t, ErrNotHTML, err)
+	})
+
+	t.Run("assets past the budget are left out", func(t *testing.T) {
+		a := New(t.TempDir(), WithMaxAssetBytes(10))
+
+		snap, err := a.Snapshot(context.Background(), srv.URL+"/page")
+		require.NoError(t, err)
+		require.NotContains(t, string(snap.HTML), "data:image/gif")
+		require.NotContains(t, string(snap.HTML), "<style>")
+	})
+}
+
+func TestArchiver(t *testing.T) {
+	srv := newTestServer(t)
+	a := New(t.TempDir())
+
+	_, err := a.Open("a1", PageFile)
+	require.Equal(t, ErrNotFound, err)
+
+	_, err = a.Archive(context.Background(), "../a1", srv.URL+"/page")
+	require.Error(t, err)
+
+	snap, err := a.Archive(context.Background(), "a1", srv.URL+"/page")
+	require.NoError(t, err)
+
+	f, err := a.Open("a1", PageFile)
+	require.NoError(t, err)
+
+	b, err := ioutil.ReadAll(f)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	require.Equal(t, snap.HTML, b)
+
+	text, err := a.ReadText("a1")
+	require.NoError(t, err)
+	require.Equal(t, snap.Text, text)
+
+	_, err = a.Open("a1", "../../etc/passwd")
+	require.Equal(t, ErrNotFound, err)
+
+	var buf bytes.Buffer
+
+	tw := tar.NewWriter(&buf)
+	require.NoError(t, a.AddToTar(tw, "archive"))
+	require.NoError(t, tw.Close())
+
+	names := []string{}
+	tr := tar.NewReader(&buf)
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+
+		require.NoError(t, err)
+		names = append(names, hdr.Name)
+	}
+
+	sort.Strings(names)
+	require.Equal(t, []string{"archive/a1/page.html", "archive/a1/text.txt"}, names)
+
+	require.NoError(t, a.Remove("a1"))
+
+	_, err = a.ReadText("a1")
+	require.Equal(t, ErrNotFound, err)
+
+	t.Run("a missing archive directory adds nothing to backups", func(t *testing.T) {
+		a := New(strings.Join([]string{t.TempDir(), "missing"}, "/"))
+
+		tw := tar.NewWriter(ioutil.Discard)
+		require.NoError(t, a.AddToTar(tw, "archive"))
+	})
+}
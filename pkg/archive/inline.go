@@ -0,0 +1,262 @@
+package archive
+
+import (
+	"context"
+	"encoding/base64"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// removedElements can run code or load things from the network when a
+// snapshot is viewed, so they are dropped.
+const removedElements = "script, noscript, iframe, frame, frameset, object, embed, applet, base, " +
+	"link[rel=preload], link[rel=prefetch], link[rel=modulepreload], link[rel=manifest], picture source"
+
+// cssURL matches url() references in stylesheets.
+var cssURL = regexp.MustCompile(`url\(\s*['"]?([^'")\s]+)['"]?\s*\)`)
+
+// cssImport matches @import rules that don't use url().
+var cssImport = regexp.MustCompile(`@import\s+['"]([^'"]+)['"]`)
+
+// inliner replaces the stylesheets and images of a page with data uris. The
+// bytes it is allowed to inline are capped by budget.
+type inliner struct {
+	archiver *Archiver
+	budget   int64
+	// cache maps asset urls to their data uris so an image used several
+	// times is only fetched once.
+	cache map[string]string
+}
+
+func (in *inliner) inline(ctx context.Context, doc *goquery.Document, base *url.URL) {
+	doc.Find(removedElements).Remove()
+
+	doc.Find("meta[http-equiv]").Each(func(_ int, s *goquery.Selection) {
+		switch strings.ToLower(s.AttrOr("http-equiv", "")) {
+		case "refresh", "content-security-policy", "content-type":
+			s.Remove()
+		}
+	})
+
+	// the page is re-encoded as utf-8 when it is parsed
+	doc.Find("meta[charset]").Remove()
+	doc.Find("head").PrependHtml(`<meta charset="utf-8">`)
+
+	doc.Find("*").Each(func(_ int, s *goquery.Selection) {
+		stripEventHandlers(s.Nodes[0])
+	})
+
+	// done before stylesheets are turned into style elements so they are
+	// only inlined once
+	doc.Find("style").Each(func(_ int, s *goquery.Selection) {
+		setText(s.Nodes[0], in.inlineCSS(ctx, s.Text(), base))
+	})
+
+	doc.Find("link[href]").Each(func(_ int, s *goquery.Selection) {
+		href := resolve(base, s.AttrOr("href", ""))
+		rel := strings.ToLower(s.AttrOr("rel", ""))
+
+		switch {
+		case strings.Contains(rel, "stylesheet"):
+			css, ok := in.fetchText(ctx, href)
+			if !ok {
+				s.Remove()
+
+				return
+			}
+
+			u, _ := url.Parse(href)
+			style := &html.Node{Type: html.ElementNode, DataAtom: atom.Style, Data: "style"}
+			setText(style, in.inlineCSS(ctx, css, u))
+
+			if media := s.AttrOr("media", ""); media != "" {
+				style.Attr = append(style.Attr, html.Attribute{Key: "media", Val: media})
+			}
+
+			s.ReplaceWithNodes(style)
+		case strings.Contains(rel, "icon"):
+			if uri, ok := in.dataURI(ctx, href); ok {
+				s.SetAttr("href", uri)
+			} else {
+				s.Remove()
+			}
+		default:
+			s.SetAttr("href", href)
+		}
+	})
+
+	doc.Find("img").Each(func(_ int, s *goquery.Selection) {
+		src := s.AttrOr("src", "")
+
+		// lazy loaded images keep the real source elsewhere
+		for _, attr := range []string{"data-src", "data-lazy-src", "data-original"} {
+			if v := s.AttrOr(attr, ""); v != "" {
+				src = v
+
+				break
+			}
+		}
+
+		s.RemoveAttr("srcset")
+		s.RemoveAttr("sizes")
+		s.RemoveAttr("loading")
+
+		if src == "" || strings.HasPrefix(src, "data:") {
+			return
+		}
+
+		src = resolve(base, src)
+
+		if uri, ok := in.dataURI(ctx, src); ok {
+			s.SetAttr("src", uri)
+		} else {
+			s.SetAttr("src", src)
+		}
+	})
+
+	doc.Find("a[href]").Each(func(_ int, s *goquery.Selection) {
+		href := s.AttrOr("href", "")
+
+		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(href)), "javascript:") {
+			s.RemoveAttr("href")
+
+			return
+		}
+
+		// in page anchors still work in the snapshot
+		if !strings.HasPrefix(href, "#") {
+			s.SetAttr("href", resolve(base, href))
+		}
+	})
+
+	doc.Find("form[action]").Each(func(_ int, s *goquery.Selection) {
+		s.SetAttr("action", resolve(base, s.AttrOr("action", "")))
+	})
+}
+
+// inlineCSS replaces the urls in css, resolved against base, with data uris.
+// Imported stylesheets are inlined as well.
+func (in *inliner) inlineCSS(ctx context.Context, css string, base *url.URL) string {
+	css = cssImport.ReplaceAllString(css, `@import url("$1")`)
+
+	return cssURL.ReplaceAllStringFunc(css, func(m string) string {
+		ref := cssURL.FindStringSubmatch(m)[1]
+		if strings.HasPrefix(ref, "data:") || strings.HasPrefix(ref, "#") {
+			return m
+		}
+
+		abs := resolve(base, ref)
+
+		uri, ok := in.dataURI(ctx, abs)
+		if !ok {
+			return `url("` + abs + `")`
+		}
+
+		return `url("` + uri + `")`
+	})
+}
+
+func (in *inliner) fetchText(ctx context.Context, rawurl string) (string, bool) {
+	b, _, ok := in.fetch(ctx, rawurl)
+
+	return string(b), ok
+}
+
+func (in *inliner) dataURI(ctx context.Context, rawurl string) (string, bool) {
+	if uri, ok := in.cache[rawurl]; ok {
+		return uri, uri != ""
+	}
+
+	b, mt, ok := in.fetch(ctx, rawurl)
+	if !ok {
+		in.cache[rawurl] = ""
+
+		return "", false
+	}
+
+	uri := "data:" + mt + ";base64," + base64.StdEncoding.EncodeToString(b)
+	in.cache[rawurl] = uri
+
+	return uri, true
+}
+
+// fetch gets an asset as long as it fits in what is left of the budget.
+func (in *inliner) fetch(ctx context.Context, rawurl string) ([]byte, string, bool) {
+	if in.budget <= 0 || ctx.Err() != nil {
+		return nil, "", false
+	}
+
+	u, err := url.Parse(rawurl)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, "", false
+	}
+
+	res, err := in.archiver.get(ctx, rawurl)
+	if err != nil {
+		return nil, "", false
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return nil, "", false
+	}
+
+	b, ok, err := readLimited(res.Body, in.budget)
+	if err != nil || !ok {
+		return nil, "", false
+	}
+
+	in.budget -= int64(len(b))
+
+	mt, _, err := mime.ParseMediaType(res.Header.Get("Content-Type"))
+	if err != nil || mt == "" {
+		mt, _, _ = mime.ParseMediaType(http.DetectContentType(b))
+	}
+
+	return b, mt, true
+}
+
+// setText replaces the children of n with text. Selection.SetText can't be
+// used for style elements since it parses its argument as html.
+func setText(n *html.Node, text string) {
+	for c := n.FirstChild; c != nil; c = n.FirstChild {
+		n.RemoveChild(c)
+	}
+
+	n.AppendChild(&html.Node{Type: html.TextNode, Data: text})
+}
+
+// stripEventHandlers removes the on* attributes of n.
+func stripEventHandlers(n *html.Node) {
+	attrs := n.Attr[:0]
+
+	for _, attr := range n.Attr {
+		if !strings.HasPrefix(strings.ToLower(attr.Key), "on") {
+			attrs = append(attrs, attr)
+		}
+	}
+
+	n.Attr = attrs
+}
+
+// resolve makes ref absolute. ref is returned as is if it can't be parsed.
+func resolve(base *url.URL, ref string) string {
+	u, err := url.Parse(strings.TrimSpace(ref))
+	if err != nil {
+		return ref
+	}
+
+	if base == nil {
+		return u.String()
+	}
+
+	return base.ResolveReference(u).String()
+}
@@ -0,0 +1,240 @@
+package archive
+
+import (
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+const (
+	// minParagraphLen is the shortest paragraph that counts towards the
+	// score of the element holding it.
+	minParagraphLen = 25
+	// paragraphSeparator separates paragraphs in the readable text.
+	paragraphSeparator = "\n\n"
+)
+
+var (
+	// skippedTags never hold the main content of a page.
+	skippedTags = map[string]bool{
+		"script": true, "style": true, "noscript": true, "template": true,
+		"nav": true, "header": true, "footer": true, "aside": true,
+		"form": true, "button": true, "select": true, "textarea": true,
+		"iframe": true, "svg": true, "canvas": true,
+	}
+
+	// blockTags are written out as paragraphs of their own.
+	blockTags = map[string]bool{
+		"p": true, "pre": true, "blockquote": true, "li": true,
+		"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+		"dt": true, "dd": true, "figcaption": true, "td": true, "th": true,
+	}
+
+	// unlikelyContent matches the class and id of page furniture like
+	// comment sections, menus and share buttons.
+	unlikelyContent = regexp.MustCompile(`(?i)comment|sidebar|footer|masthead|menu|nav|share|social|sponsor|advert|promo|related|popup|cookie|banner|subscribe|breadcrumb`)
+
+	// likelyContent matches the class and id of elements that hold the
+	// content of a page. It wins over unlikelyContent.
+	likelyContent = regexp.MustCompile(`(?i)article|body|content|entry|main|post|story|text`)
+)
+
+// extractText returns the readable text of the main content of doc, in the
+// spirit of readability. Paragraphs are scored by their length and number of
+// commas and the scores added to the elements holding them. The text of the
+// best scoring element is what's returned.
+func extractText(doc *goquery.Document) string {
+	body := doc.Find("body").First()
+	if body.Length() == 0 {
+		return ""
+	}
+
+	scores := map[*html.Node]float64{}
+
+	body.Find("p, pre, blockquote, td").Each(func(_ int, s *goquery.Selection) {
+		n := s.Nodes[0]
+
+		if skipped(n) {
+			return
+		}
+
+		text := collapseSpace(s.Text())
+		if len(text) < minParagraphLen {
+			return
+		}
+
+		score := 1 + float64(strings.Count(text, ",")) + math.Min(float64(len(text))/100, 3)
+
+		if parent := n.Parent; parent != nil {
+			scores[parent] += score
+
+			if grandparent := parent.Parent; grandparent != nil {
+				scores[grandparent] += score / 2
+			}
+		}
+	})
+
+	best := body.Nodes[0]
+	bestScore := 0.0
+
+	for n, score := range scores {
+		if likelyContent.MatchString(attr(n, "class") + " " + attr(n, "id")) {
+			score *= 1.25
+		}
+
+		if score > bestScore {
+			best, bestScore = n, score
+		}
+	}
+
+	blocks := []string{}
+	collectBlocks(best, &blocks)
+
+	return strings.Join(blocks, paragraphSeparator)
+}
+
+// collectBlocks appends the text of the block elements under n to blocks.
+// Loose text between blocks becomes a paragraph of its own.
+func collectBlocks(n *html.Node, blocks *[]string) {
+	var loose strings.Builder
+
+	flush := func() {
+		if text := collapseSpace(loose.String()); text != "" {
+			*blocks = append(*blocks, text)
+		}
+
+		loose.Reset()
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		switch c.Type {
+		case html.TextNode:
+			loose.WriteString(c.Data)
+		case html.ElementNode:
+			if skipped(c) {
+				continue
+			}
+
+			switch {
+			case c.Data == "br":
+				loose.WriteString(" ")
+			case c.Data == "pre":
+				flush()
+
+				if text := strings.Trim(nodeText(c), "\n"); strings.TrimSpace(text) != "" {
+					*blocks = append(*blocks, text)
+				}
+			case blockTags[c.Data]:
+				flush()
+
+				if text := collapseSpace(nodeText(c)); text != "" {
+					*blocks = append(*blocks, text)
+				}
+			case isInline(c):
+				loose.WriteString(nodeText(c))
+			default:
+				flush()
+				collectBlocks(c, blocks)
+			}
+		}
+	}
+
+	flush()
+}
+
+// skipped reports whether n is page furniture rather than content.
+func skipped(n *html.Node) bool {
+	for ; n != nil; n = n.Parent {
+		if n.Type != html.ElementNode {
+			continue
+		}
+
+		if n.Data == "body" || n.Data == "html" {
+			return false
+		}
+
+		if skippedTags[n.Data] {
+			return true
+		}
+
+		if n.Data == "article" || n.Data == "main" {
+			continue
+		}
+
+		names := attr(n, "class") + " " + attr(n, "id")
+		if unlikelyContent.MatchString(names) && !likelyContent.MatchString(names) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isInline(n *html.Node) bool {
+	switch n.Data {
+	case "a", "abbr", "b", "cite", "code", "em", "i", "kbd", "mark", "q", "s",
+		"samp", "small", "span", "strong", "sub", "sup", "time", "u", "var":
+		return true
+	}
+
+	return false
+}
+
+// nodeText returns the text under n, leaving out skipped elements.
+func nodeText(n *html.Node) string {
+	var b strings.Builder
+
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			switch c.Type {
+			case html.TextNode:
+				b.WriteString(c.Data)
+			case html.ElementNode:
+				if skippedTags[c.Data] {
+					continue
+				}
+
+				if c.Data == "br" {
+					b.WriteString("\n")
+				}
+
+				walk(c)
+			}
+		}
+	}
+
+	walk(n)
+
+	return b.String()
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+
+	return ""
+}
+
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// Paragraphs splits the readable text of a snapshot into its paragraphs.
+func Paragraphs(text string) []string {
+	paragraphs := []string{}
+
+	for _, p := range strings.Split(text, paragraphSeparator) {
+		if strings.TrimSpace(p) != "" {
+			paragraphs = append(paragraphs, p)
+		}
+	}
+
+	return paragraphs
+}
@@ -5,6 +5,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"time"
 )
 
 const (
@@ -36,7 +37,12 @@ const (
 	DefaultBindAddr        = "localhost:8090"
 	DefaultDatabaseName    = "sufr.db"
 	DefaultSQLDatabaseName = "sufr-sql.db"
+	DefaultSessionKeysName = "session-keys.json"
 	DefaultResultsPerPage  = 40
+	DefaultFetchWorkers    = 4
+
+	DefaultLinkCheckInterval  = 24 * time.Hour
+	DefaultLinkCheckDeadAfter = 3
 )
 
 type BuildInfo struct {
@@ -71,6 +77,18 @@ func SetDefaults(cfg *Config) {
 	if cfg.DatabaseFilename == "" {
 		cfg.DatabaseFilename = DefaultDatabaseName
 	}
+
+	if cfg.FetchWorkers == 0 {
+		cfg.FetchWorkers = DefaultFetchWorkers
+	}
+
+	if cfg.LinkCheckInterval == 0 {
+		cfg.LinkCheckInterval = DefaultLinkCheckInterval
+	}
+
+	if cfg.LinkCheckDeadAfter == 0 {
+		cfg.LinkCheckDeadAfter = DefaultLinkCheckDeadAfter
+	}
 }
 
 type Config struct {
@@ -81,6 +99,46 @@ type Config struct {
 	DatabaseFilename string   `env:"SUFR_DATABASE_FILENAME"`
 	Debug            bool     `env:"SUFR_DEBUG"`
 	DatabaseURL      *url.URL `env:"SUFR_DATABASE_URL"`
+	FetchWorkers     int      `env:"SUFR_FETCH_WORKERS"`
+
+	// LinkCheckInterval is how often saved urls are checked to see if they
+	// still work. A negative interval disables link checking.
+	LinkCheckInterval  time.Duration `env:"SUFR_LINK_CHECK_INTERVAL"`
+	LinkCheckDeadAfter int           `env:"SUFR_LINK_CHECK_DEAD_AFTER"`
+
+	// SessionAuthKey and SessionEncryptionKey are the hex encoded keys
+	// session cookies are signed and encrypted with. When they aren't set,
+	// keys are generated on the first run and kept in the data dir.
+	SessionAuthKey       string `env:"SUFR_SESSION_AUTH_KEY"`
+	SessionEncryptionKey string `env:"SUFR_SESSION_ENCRYPTION_KEY"`
+	// SessionPreviousKeys are keys that have been rotated out, as hex
+	// encoded auth:encryption pairs. Sessions made with them still work
+	// until they're removed.
+	SessionPreviousKeys []string `env:"SUFR_SESSION_PREVIOUS_KEYS"`
+
+	// PublicURL is the URL people reach sufr at, like
+	// "https://sufr.example.com". Passkeys and single sign-on redirects use
+	// its host instead of the one each request was sent to.
+	PublicURL string `env:"SUFR_PUBLIC_URL"`
+
+	// OIDCIssuer is the URL of an OpenID Connect provider to log in with.
+	// Single sign-on is off when it isn't set.
+	OIDCIssuer       string `env:"SUFR_OIDC_ISSUER"`
+	OIDCClientID     string `env:"SUFR_OIDC_CLIENT_ID"`
+	OIDCClientSecret string `env:"SUFR_OIDC_CLIENT_SECRET"`
+	// OIDCAllowedDomains are the email domains that can log in with the
+	// provider. Accounts are made the first time someone from one of them
+	// logs in. When there are none, only people who already have an account
+	// can log in with the provider.
+	OIDCAllowedDomains []string `env:"SUFR_OIDC_ALLOWED_DOMAINS"`
+
+	// ProxyAuthTrustedCIDRs are the addresses of an auth proxy in front of
+	// sufr, like oauth2-proxy or Authelia. When they're set, requests are
+	// logged in as the user whose email address the proxy puts in
+	// ProxyAuthHeader, X-Forwarded-Email by default, instead of with session
+	// cookies. Requests from anywhere else aren't logged in.
+	ProxyAuthTrustedCIDRs []string `env:"SUFR_PROXY_AUTH_TRUSTED_CIDRS"`
+	ProxyAuthHeader       string   `env:"SUFR_PROXY_AUTH_HEADER"`
 
 	// build time information
 	Build BuildInfo
@@ -93,3 +151,7 @@ func (c Config) DatabaseFile() string {
 func (c Config) SQLDatabaseFile() string {
 	return filepath.Join(c.DataDir, DefaultSQLDatabaseName)
 }
+
+func (c Config) SessionKeysFile() string {
+	return filepath.Join(c.DataDir, DefaultSessionKeysName)
+}
@@ -0,0 +1,215 @@
+// Package csrf protects forms and endpoints from cross-site request forgery.
+// Every session gets a random token kept in its own session cookie. Requests
+// that can change something have to send the token back, either in a form
+// field or a header, which a page on another site has no way of reading.
+package csrf
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/sessions"
+)
+
+const (
+	// FieldName is the form field the token is sent in.
+	FieldName = "csrf_token"
+	// HeaderName is the header the token is sent in by scripts.
+	HeaderName = "X-CSRF-Token"
+	// DefaultMaxFormBytes is how much of a form is read looking for the
+	// token. It's also the limit for everything the handler reads after.
+	DefaultMaxFormBytes = 32 << 20
+
+	sessionName = "csrf"
+	tokenKey    = "token"
+	tokenBytes  = 32
+)
+
+// ErrBadToken is returned when a request is missing the token or has the
+// wrong one.
+var ErrBadToken = errors.New("csrf token is missing or wrong")
+
+type tokenContextKey struct{}
+
+// safeMethods can't change anything and aren't checked.
+var safeMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodTrace:   true,
+}
+
+type protectorOptions struct {
+	skip         func(*http.Request) bool
+	failure      http.Handler
+	maxFormBytes int64
+}
+
+type protectorOptionFunc struct {
+	f func(*protectorOptions)
+}
+
+func (p *protectorOptionFunc) apply(opts *protectorOptions) {
+	p.f(opts)
+}
+
+type ProtectorOption interface {
+	apply(*protectorOptions)
+}
+
+// WithSkip sets a function that decides which requests aren't checked, like
+// ones authenticated with a header browsers never add on their own.
+func WithSkip(f func(*http.Request) bool) ProtectorOption {
+	return &protectorOptionFunc{
+		f: func(opts *protectorOptions) {
+			opts.skip = f
+		},
+	}
+}
+
+// WithFailureHandler sets the handler requests with a bad token are sent to.
+// By default they get a plain 403.
+func WithFailureHandler(h http.Handler) ProtectorOption {
+	return &protectorOptionFunc{
+		f: func(opts *protectorOptions) {
+			opts.failure = h
+		},
+	}
+}
+
+// WithMaxFormBytes sets how big a request body can be.
+func WithMaxFormBytes(n int64) ProtectorOption {
+	return &protectorOptionFunc{
+		f: func(opts *protectorOptions) {
+			opts.maxFormBytes = n
+		},
+	}
+}
+
+// Protector hands out tokens and checks them.
+type Protector struct {
+	sessions sessions.Store
+	opts     protectorOptions
+}
+
+// New returns a Protector that keeps tokens in sessionStore.
+func New(sessionStore sessions.Store, opts ...ProtectorOption) *Protector {
+	po := protectorOptions{
+		failure:      http.HandlerFunc(failure),
+		maxFormBytes: DefaultMaxFormBytes,
+	}
+
+	for _, opt := range opts {
+		opt.apply(&po)
+	}
+
+	return &Protector{sessions: sessionStore, opts: po}
+}
+
+// Handler makes sure the session has a token, puts it in the request context
+// for Token and turns away unsafe requests without it.
+func (p *Protector) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if p.opts.skip != nil && p.opts.skip(r) {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		token, err := p.sessionToken(w, r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		r = r.WithContext(context.WithValue(r.Context(), tokenContextKey{}, token))
+
+		if !safeMethods[r.Method] {
+			r.Body = http.MaxBytesReader(w, r.Body, p.opts.maxFormBytes)
+
+			if !Valid(token, requestToken(r)) {
+				p.opts.failure.ServeHTTP(w, r)
+
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// sessionToken returns the token of the session on r, making one if there
+// isn't one yet.
+func (p *Protector) sessionToken(w http.ResponseWriter, r *http.Request) (string, error) {
+	// a cookie that can't be decoded, like one made with keys that have
+	// since been rotated out, is replaced with a new session
+	session, err := p.sessions.Get(r, sessionName)
+	if session == nil {
+		return "", err
+	}
+
+	if token, ok := session.Values[tokenKey].(string); ok && token != "" {
+		return token, nil
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return "", err
+	}
+
+	session.Values[tokenKey] = token
+	session.Options.HttpOnly = true
+	session.Options.SameSite = http.SameSiteLaxMode
+
+	if err := session.Save(r, w); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// Token returns the token for forms rendered while handling r. It's empty if
+// r didn't go through a Protector.
+func Token(r *http.Request) string {
+	token, _ := r.Context().Value(tokenContextKey{}).(string)
+
+	return token
+}
+
+// Valid returns true if got is the token want. The comparison takes the same
+// time however much of got is right.
+func Valid(want, got string) bool {
+	if want == "" || got == "" {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(want), []byte(got)) == 1
+}
+
+func requestToken(r *http.Request) string {
+	if token := r.Header.Get(HeaderName); token != "" {
+		return token
+	}
+
+	// a form that's too big can't be parsed, which leaves the token empty
+	return r.PostFormValue(FieldName)
+}
+
+func newToken() (string, error) {
+	b := make([]byte, tokenBytes)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func failure(w http.ResponseWriter, r *http.Request) {
+	http.Error(w, "403 Forbidden: "+ErrBadToken.Error(), http.StatusForbidden)
+}
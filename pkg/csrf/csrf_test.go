@@ -0,0 +1,169 @@
+package csrf
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/sessions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHandler(opts ...ProtectorOption) http.Handler {
+	p := New(sessions.NewCookieStore([]byte(strings.Repeat("k", 32))), opts...)
+
+	h := p.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(Token(r)))
+	}))
+
+	return h
+}
+
+// getToken makes a GET request and returns the token and the cookies that
+// go with it.
+func getToken(t *testing.T, h http.Handler) (string, []*http.Cookie) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NotEmpty(t, rec.Body.String())
+
+	return rec.Body.String(), rec.Result().Cookies()
+}
+
+func withCookies(r *http.Request, cookies []*http.Cookie) *http.Request {
+	for _, c := range cookies {
+		r.AddCookie(c)
+	}
+
+	return r
+}
+
+func TestHandler(t *testing.T) {
+	h := newTestHandler()
+	token, cookies := getToken(t, h)
+
+	t.Run("keeps the token for the session", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, withCookies(httptest.NewRequest(http.MethodGet, "/", nil), cookies))
+
+		assert.Equal(t, token, rec.Body.String())
+		assert.Empty(t, rec.Result().Cookies(), "the cookie is only set once")
+	})
+
+	t.Run("new sessions get new tokens", func(t *testing.T) {
+		other, _ := getToken(t, h)
+		assert.NotEqual(t, token, other)
+	})
+
+	for name, req := range map[string]func() *http.Request{
+		"form field": func() *http.Request {
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(url.Values{FieldName: {token}}.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			return r
+		},
+		"header": func() *http.Request {
+			r := httptest.NewRequest(http.MethodDelete, "/", nil)
+			r.Header.Set(HeaderName, token)
+
+			return r
+		},
+		"multipart form": func() *http.Request {
+			var body bytes.Buffer
+
+			mw := multipart.NewWriter(&body)
+			mw.WriteField(FieldName, token)
+			mw.Close()
+
+			r := httptest.NewRequest(http.MethodPost, "/", &body)
+			r.Header.Set("Content-Type", mw.FormDataContentType())
+
+			return r
+		},
+	} {
+		t.Run("accepts the token in a "+name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, withCookies(req(), cookies))
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+		})
+	}
+
+	t.Run("rejects a missing token", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, withCookies(httptest.NewRequest(http.MethodPost, "/", nil), cookies))
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("rejects another session's token", func(t *testing.T) {
+		other, _ := getToken(t, h)
+
+		r := httptest.NewRequest(http.MethodPost, "/", nil)
+		r.Header.Set(HeaderName, other)
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, withCookies(r, cookies))
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("rejects a token without a session", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/", nil)
+		r.Header.Set(HeaderName, token)
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, r)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
+
+func TestHandlerOptions(t *testing.T) {
+	failed := false
+
+	h := newTestHandler(
+		WithSkip(func(r *http.Request) bool {
+			return r.Header.Get("Authorization") != ""
+		}),
+		WithFailureHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			failed = true
+			w.WriteHeader(http.StatusTeapot)
+		})),
+		WithMaxFormBytes(64),
+	)
+
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	r.Header.Set("Authorization", "Bearer abc")
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, r)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Body.String(), "skipped requests don't get a token")
+
+	token, cookies := getToken(t, h)
+
+	form := url.Values{FieldName: {token}, "padding": {strings.Repeat("x", 64)}}
+	r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, withCookies(r, cookies))
+	assert.Equal(t, http.StatusTeapot, rec.Code)
+	assert.True(t, failed)
+}
+
+func TestValid(t *testing.T) {
+	assert.True(t, Valid("abc", "abc"))
+	assert.False(t, Valid("abc", "abd"))
+	assert.False(t, Valid("abc", ""))
+	assert.False(t, Valid("", ""))
+}
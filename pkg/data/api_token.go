@@ -1,7 +1,12 @@
 package data
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"encoding/json"
+	"sort"
 	"time"
 
 	"github.com/boltdb/bolt"
@@ -9,14 +14,89 @@ import (
 	"github.com/pkg/errors"
 )
 
+// APIScope is something an API token is allowed to do.
+type APIScope string
+
+const (
+	// ScopeRead allows viewing urls and tags, even on private instances.
+	ScopeRead APIScope = "read"
+	// ScopeWrite allows adding, changing and deleting urls.
+	ScopeWrite APIScope = "write"
+	// ScopeBackup allows downloading database backups.
+	ScopeBackup APIScope = "backup"
+	// ScopeAdmin allows everything, settings included.
+	ScopeAdmin APIScope = "admin"
+)
+
+// APIScopes are all of the scopes in the order they're shown in.
+var APIScopes = []APIScope{ScopeRead, ScopeWrite, ScopeBackup, ScopeAdmin}
+
+const apiTokenBytes = 32
+
+// lastUsedResolution is how far behind LastUsedAt can be. Recording every
+// use would make each request with a token wait its turn to write.
+const lastUsedResolution = time.Minute
+
+var (
+	ErrAPITokenExpired = errors.New("api token has expired")
+	ErrAPITokenScope   = errors.New("api token is missing the required scope")
+)
+
+// APIToken is a named token for accessing SUFR without logging in. Only a
+// hash of the token is stored so it can't be read back out of the database.
 type APIToken struct {
-	ID        uuid.UUID `json:"id"`
-	Token     uuid.UUID `json:"token"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Hash       []byte     `json:"hash"`
+	Scopes     []APIScope `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+
+	// Token is the token itself. It's only set on the APIToken returned by
+	// CreateAPIToken and never stored.
+	Token string `json:"-"`
+}
+
+// Allows returns true if t has scope. Admin tokens have every scope.
+func (t *APIToken) Allows(scope APIScope) bool {
+	for _, s := range t.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Expired returns true if t expired before now.
+func (t *APIToken) Expired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
+}
+
+// HashAPIToken returns the hash of token that is stored in place of it.
+// Tokens are long and random so a fast hash is all that's needed.
+func HashAPIToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+
+	return sum[:]
 }
 
-func CreateAPIToken() (*APIToken, error) {
+// ParseAPIScope returns the scope named s.
+func ParseAPIScope(s string) (APIScope, error) {
+	for _, scope := range APIScopes {
+		if string(scope) == s {
+			return scope, nil
+		}
+	}
+
+	return "", errors.Errorf("no such api scope %q", s)
+}
+
+// CreateAPIToken creates a token with a name and scopes. A nil expiresAt
+// never expires. The returned APIToken is the only one with Token set.
+func CreateAPIToken(name string, scopes []APIScope, expiresAt *time.Time) (*APIToken, error) {
 	tx, err := db.bolt.Begin(true)
 	if err != nil {
 		return nil, err
@@ -24,7 +104,7 @@ func CreateAPIToken() (*APIToken, error) {
 
 	defer tx.Rollback()
 
-	token, err := createAPIToken(tx)
+	token, err := createAPIToken(name, scopes, expiresAt, tx)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create api token")
 	}
@@ -36,59 +116,76 @@ func CreateAPIToken() (*APIToken, error) {
 	return token, nil
 }
 
-func createAPIToken(tx *bolt.Tx) (*APIToken, error) {
-	token := uuid.New()
+func createAPIToken(name string, scopes []APIScope, expiresAt *time.Time, tx *bolt.Tx) (*APIToken, error) {
+	if len(scopes) == 0 {
+		return nil, errors.New("api tokens need at least one scope")
+	}
+
+	b := make([]byte, apiTokenBytes)
+
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+
+	token := hex.EncodeToString(b)
 	now := time.Now()
 
 	at := &APIToken{
 		ID:        uuid.New(),
-		Token:     token,
+		Name:      name,
+		Hash:      HashAPIToken(token),
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
 
+	if err := putAPIToken(at, tx); err != nil {
+		return nil, err
+	}
+
+	at.Token = token
+
+	return at, nil
+}
+
+func putAPIToken(at *APIToken, tx *bolt.Tx) error {
 	bucket := tx.Bucket(buckets[apiTokenKey])
 
 	b, err := json.Marshal(at)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to serialize api token")
+		return errors.Wrap(err, "failed to serialize api token")
 	}
 
 	id, _ := at.ID.MarshalText()
 
 	if err := bucket.Put(id, b); err != nil {
-		return nil, errors.Wrap(err, "boltdb put failed")
+		return errors.Wrap(err, "boltdb put failed")
 	}
 
-	return at, nil
+	return nil
 }
 
-func GetAPIToken() (*APIToken, error) {
-	var token *APIToken
+// GetAPITokens returns every token, oldest first.
+func GetAPITokens() ([]*APIToken, error) {
+	var tokens []*APIToken
 
 	err := db.bolt.View(func(tx *bolt.Tx) error {
 		var err error
 
-		token, err = getAPIToken(tx)
-		if err != nil {
-			return err
-		}
-
-		if token == nil {
-			return ErrNotFound
-		}
+		tokens, err = getAPITokens(tx)
 
-		return nil
+		return err
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return token, nil
+	return tokens, nil
 }
 
-func getAPIToken(tx *bolt.Tx) (*APIToken, error) {
-	var token *APIToken
+func getAPITokens(tx *bolt.Tx) ([]*APIToken, error) {
+	tokens := []*APIToken{}
 
 	bucket := tx.Bucket(buckets[apiTokenKey])
 
@@ -98,7 +195,7 @@ func getAPIToken(tx *bolt.Tx) (*APIToken, error) {
 			return err
 		}
 
-		token = &t
+		tokens = append(tokens, &t)
 
 		return nil
 	})
@@ -106,13 +203,114 @@ func getAPIToken(tx *bolt.Tx) (*APIToken, error) {
 		return nil, err
 	}
 
-	if token == nil {
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].CreatedAt.Before(tokens[j].CreatedAt)
+	})
+
+	return tokens, nil
+}
+
+// AuthenticateAPIToken returns the APIToken for token and records that it
+// was used, at most once every lastUsedResolution. ErrNotFound is returned for unknown tokens and ErrAPITokenExpired
+// for expired ones.
+func AuthenticateAPIToken(token string) (*APIToken, error) {
+	now := time.Now()
+
+	var at *APIToken
+
+	err := db.bolt.View(func(tx *bolt.Tx) error {
+		var err error
+
+		at, err = authenticateAPIToken(token, now, tx)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if at.LastUsedAt != nil && now.Sub(*at.LastUsedAt) < lastUsedResolution {
+		return at, nil
+	}
+
+	at.LastUsedAt = &now
+
+	err = db.bolt.Update(func(tx *bolt.Tx) error {
+		return touchAPIToken(at.ID, now, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return at, nil
+}
+
+func authenticateAPIToken(token string, now time.Time, tx *bolt.Tx) (*APIToken, error) {
+	tokens, err := getAPITokens(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := HashAPIToken(token)
+
+	var found *APIToken
+
+	// every token is compared so the time taken doesn't say which matched
+	for _, at := range tokens {
+		if subtle.ConstantTimeCompare(at.Hash, hash) == 1 {
+			found = at
+		}
+	}
+
+	if found == nil {
 		return nil, ErrNotFound
 	}
 
-	return token, nil
+	if found.Expired(now) {
+		return nil, ErrAPITokenExpired
+	}
+
+	return found, nil
+}
+
+// touchAPIToken records that the token with id was used at now. Tokens
+// revoked since they were looked up are left deleted.
+func touchAPIToken(id uuid.UUID, now time.Time, tx *bolt.Tx) error {
+	bucket := tx.Bucket(buckets[apiTokenKey])
+
+	key, _ := id.MarshalText()
+
+	v := bucket.Get(key)
+	if v == nil {
+		return nil
+	}
+
+	var at APIToken
+	if err := json.Unmarshal(v, &at); err != nil {
+		return errors.Wrap(err, "failed to deserialize api token")
+	}
+
+	at.LastUsedAt = &now
+
+	return putAPIToken(&at, tx)
+}
+
+// DeleteAPIToken revokes the token with id.
+func DeleteAPIToken(id uuid.UUID) error {
+	return db.bolt.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(buckets[apiTokenKey])
+
+		key, _ := id.MarshalText()
+
+		if bucket.Get(key) == nil {
+			return ErrNotFound
+		}
+
+		return bucket.Delete(key)
+	})
 }
 
+// DeleteAPITokens revokes every token.
 func DeleteAPITokens() error {
 	tx, err := db.bolt.Begin(true)
 	if err != nil {
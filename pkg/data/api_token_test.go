@@ -2,40 +2,132 @@ package data
 
 import (
 	"testing"
+	"time"
 
+	"github.com/boltdb/bolt"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-func TestAPITokenCreateAndGet(t *testing.T) {
-	token, err := CreateAPIToken()
+func TestAPITokenCreateAndAuthenticate(t *testing.T) {
+	require.NoError(t, DeleteAPITokens())
 
-	assert.NoError(t, err)
+	token, err := CreateAPIToken("cron", []APIScope{ScopeBackup}, nil)
+
+	require.NoError(t, err)
 	assert.NotEmpty(t, token.Token)
 	assert.NotNil(t, token.ID)
+	assert.Equal(t, HashAPIToken(token.Token), token.Hash)
+
+	t.Run("only the hash is stored", func(t *testing.T) {
+		tokens, err := GetAPITokens()
+		require.NoError(t, err)
+		require.Len(t, tokens, 1)
+
+		assert.Equal(t, "cron", tokens[0].Name)
+		assert.Empty(t, tokens[0].Token)
+		assert.Equal(t, token.Hash, tokens[0].Hash)
+		assert.Nil(t, tokens[0].LastUsedAt)
+	})
+
+	t.Run("authenticates and records use", func(t *testing.T) {
+		at, err := AuthenticateAPIToken(token.Token)
+		require.NoError(t, err)
+		assert.Equal(t, token.ID, at.ID)
+		assert.True(t, at.Allows(ScopeBackup))
+		assert.False(t, at.Allows(ScopeRead))
+
+		tokens, err := GetAPITokens()
+		require.NoError(t, err)
+		require.NotNil(t, tokens[0].LastUsedAt)
+	})
+
+	t.Run("records use at most once a minute", func(t *testing.T) {
+		recent := time.Now().Add(-30 * time.Second).Round(0)
+
+		err := db.bolt.Update(func(tx *bolt.Tx) error {
+			return touchAPIToken(token.ID, recent, tx)
+		})
+		require.NoError(t, err)
+
+		_, err = AuthenticateAPIToken(token.Token)
+		require.NoError(t, err)
 
-	tkuuid := token.Token
+		tokens, err := GetAPITokens()
+		require.NoError(t, err)
+		assert.True(t, recent.Equal(*tokens[0].LastUsedAt))
 
-	{
-		token, err := GetAPIToken()
+		stale := time.Now().Add(-2 * time.Minute)
+
+		err = db.bolt.Update(func(tx *bolt.Tx) error {
+			return touchAPIToken(token.ID, stale, tx)
+		})
+		require.NoError(t, err)
+
+		_, err = AuthenticateAPIToken(token.Token)
+		require.NoError(t, err)
+
+		tokens, err = GetAPITokens()
+		require.NoError(t, err)
+		assert.True(t, tokens[0].LastUsedAt.After(stale.Add(time.Minute)))
+	})
+
+	t.Run("rejects unknown tokens", func(t *testing.T) {
+		_, err := AuthenticateAPIToken("nope")
+		assert.Equal(t, ErrNotFound, err)
+	})
+
+	t.Run("needs a scope", func(t *testing.T) {
+		_, err := CreateAPIToken("nothing", nil, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestAPITokenExpiry(t *testing.T) {
+	expires := time.Now().Add(time.Hour)
+
+	token, err := CreateAPIToken("soon", []APIScope{ScopeAdmin}, &expires)
+	require.NoError(t, err)
+	assert.True(t, token.Allows(ScopeWrite))
+
+	err = db.bolt.Update(func(tx *bolt.Tx) error {
+		_, err := authenticateAPIToken(token.Token, expires.Add(-time.Second), tx)
 		assert.NoError(t, err)
 
-		assert.Equal(t, token.Token, tkuuid)
-	}
+		_, err = authenticateAPIToken(token.Token, expires, tx)
+		assert.Equal(t, ErrAPITokenExpired, err)
+
+		return nil
+	})
+	require.NoError(t, err)
 }
 
-func TestDeleteAPITokens(t *testing.T) {
-	token, err := CreateAPIToken()
+func TestDeleteAPIToken(t *testing.T) {
+	keep, err := CreateAPIToken("keep", []APIScope{ScopeRead}, nil)
+	require.NoError(t, err)
+
+	revoke, err := CreateAPIToken("revoke", []APIScope{ScopeRead}, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, DeleteAPIToken(revoke.ID))
+	assert.Equal(t, ErrNotFound, DeleteAPIToken(revoke.ID))
+
+	_, err = AuthenticateAPIToken(revoke.Token)
+	assert.Equal(t, ErrNotFound, err)
 
+	_, err = AuthenticateAPIToken(keep.Token)
 	assert.NoError(t, err)
+}
+
+func TestDeleteAPITokens(t *testing.T) {
+	token, err := CreateAPIToken("all", []APIScope{ScopeRead}, nil)
+
+	require.NoError(t, err)
 	assert.NotEmpty(t, token.Token)
-	assert.NotNil(t, token.ID)
 
 	assert.NoError(t, DeleteAPITokens())
 
-	{
-		token, err := GetAPIToken()
-		assert.Error(t, err)
-		assert.Equal(t, ErrNotFound, err)
-		assert.Nil(t, token)
-	}
+	tokens, err := GetAPITokens()
+	assert.NoError(t, err)
+	assert.Empty(t, tokens)
 }
@@ -1,6 +1,8 @@
 package data
 
 import (
+	"archive/tar"
+	"compress/gzip"
 	"encoding/json"
 	"log"
 	"net/http"
@@ -10,6 +12,7 @@ import (
 	"time"
 
 	"github.com/boltdb/bolt"
+	"github.com/kyleterry/sufr/pkg/archive"
 	"github.com/kyleterry/sufr/pkg/config"
 	"github.com/pkg/errors"
 )
@@ -19,6 +22,7 @@ var (
 	once sync.Once
 
 	ErrDatabaseAlreadyOpen = errors.New("database is already open")
+	ErrDatabaseInUse       = errors.New("database is in use by another sufr")
 	ErrNotFound            = errors.New("object not found")
 	ErrDuplicateKey        = errors.New("duplicate key")
 )
@@ -32,6 +36,8 @@ const (
 	allURLsIndex
 	favoriteURLsIndex
 	apiTokenKey
+	fetchJobKey
+	urlCheckKey
 )
 
 var (
@@ -42,6 +48,8 @@ var (
 		allURLsIndex:      []byte("_all_urls_page_index"),
 		favoriteURLsIndex: []byte("_favorite_urls_page_index"),
 		apiTokenKey:       []byte("_api_tokens"),
+		fetchJobKey:       []byte("_fetch_jobs"),
+		urlCheckKey:       []byte("_url_checks"),
 	}
 )
 
@@ -53,15 +61,29 @@ type SufrDB struct {
 	sync.Mutex
 }
 
+// openTimeout is how long Open waits for another process to let go of the
+// database before giving up with ErrDatabaseInUse.
+const openTimeout = 5 * time.Second
+
 func MustInit(cfg *config.Config) {
+	if err := Init(cfg); err != nil {
+		panic(err)
+	}
+}
+
+// Init opens the database in cfg's data dir for the rest of the package to
+// use. It returns ErrDatabaseInUse if another sufr has it open.
+func Init(cfg *config.Config) error {
 	var err error
 
 	once.Do(func() {
 		db, err = New(cfg.DatabaseFile())
 		if err != nil {
-			panic(errors.Wrap(err, "failed to open database"))
+			err = errors.Wrap(err, "failed to open database")
 		}
 	})
+
+	return err
 }
 
 // DBWithLock runs func fn with the global db object. Locked so nothing else can use
@@ -113,7 +135,11 @@ func (s *SufrDB) Open() error {
 		return ErrDatabaseAlreadyOpen
 	}
 
-	if s.bolt, err = bolt.Open(s.path, 0600, nil); err != nil {
+	if s.bolt, err = bolt.Open(s.path, 0600, &bolt.Options{Timeout: openTimeout}); err != nil {
+		if err == bolt.ErrTimeout {
+			return ErrDatabaseInUse
+		}
+
 		return err
 	}
 
@@ -157,16 +183,70 @@ func (s *SufrDB) Statsdumper() {
 	}
 }
 
-func BackupHandler(w http.ResponseWriter, req *http.Request) error {
-	err := db.bolt.View(func(tx *bolt.Tx) error {
-		w.Header().Set("Content-Type", "application/octet-stream")
-		w.Header().Set("Content-Disposition", `attachment; filename="sufr.db"`)
-		w.Header().Set("Content-Length", strconv.Itoa(int(tx.Size())))
-		_, err := tx.WriteTo(w)
-		return err
-	})
-	if err != nil {
-		return err
+// BackupHandler returns a handler that writes a copy of the database file.
+// With archive=true in the query it writes a gzipped tarball instead, holding
+// the database and, if archiver isn't nil, every archived page snapshot under
+// archive/.
+func BackupHandler(archiver *archive.Archiver) func(http.ResponseWriter, *http.Request) error {
+	return func(w http.ResponseWriter, req *http.Request) error {
+		withArchive := false
+
+		if v := req.URL.Query().Get("archive"); v != "" {
+			var err error
+
+			withArchive, err = strconv.ParseBool(v)
+			if err != nil {
+				http.Error(w, "archive must be a boolean", http.StatusBadRequest)
+
+				return nil
+			}
+		}
+
+		if !withArchive {
+			return db.bolt.View(func(tx *bolt.Tx) error {
+				w.Header().Set("Content-Type", "application/octet-stream")
+				w.Header().Set("Content-Disposition", `attachment; filename="sufr.db"`)
+				w.Header().Set("Content-Length", strconv.Itoa(int(tx.Size())))
+				_, err := tx.WriteTo(w)
+				return err
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/gzip")
+		w.Header().Set("Content-Disposition", `attachment; filename="sufr-backup.tar.gz"`)
+
+		gw := gzip.NewWriter(w)
+		tw := tar.NewWriter(gw)
+
+		err := db.bolt.View(func(tx *bolt.Tx) error {
+			hdr := &tar.Header{
+				Name:    config.DefaultDatabaseName,
+				Mode:    0600,
+				Size:    tx.Size(),
+				ModTime: time.Now().UTC(),
+			}
+
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+
+			_, err := tx.WriteTo(tw)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		if archiver != nil {
+			if err := archiver.AddToTar(tw, "archive"); err != nil {
+				return err
+			}
+		}
+
+		if err := tw.Close(); err != nil {
+			return err
+		}
+
+		return gw.Close()
 	}
-	return nil
 }
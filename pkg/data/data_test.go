@@ -1,11 +1,18 @@
 package data
 
 import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
 	"io/ioutil"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
 
 	"github.com/boltdb/bolt"
+	"github.com/kyleterry/sufr/pkg/archive"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -100,3 +107,43 @@ func TestRunWithBucketForType(t *testing.T) {
 
 	assert.NoError(t, err)
 }
+
+func TestBackupHandler(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "a1"), 0700))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "a1", archive.PageFile), []byte("<html></html>"), 0600))
+
+	w := httptest.NewRecorder()
+	assert.NoError(t, BackupHandler(archive.New(dir))(w, httptest.NewRequest("GET", "/database-backup", nil)))
+	assert.Equal(t, "application/octet-stream", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="sufr.db"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, w.Header().Get("Content-Length"), strconv.Itoa(w.Body.Len()))
+
+	w = httptest.NewRecorder()
+	assert.NoError(t, BackupHandler(archive.New(dir))(w, httptest.NewRequest("GET", "/database-backup?archive=true", nil)))
+	assert.Equal(t, "application/gzip", w.Header().Get("Content-Type"))
+
+	gr, err := gzip.NewReader(w.Body)
+	assert.NoError(t, err)
+
+	files := map[string]int64{}
+	tr := tar.NewReader(gr)
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+
+		assert.NoError(t, err)
+
+		n, err := io.Copy(ioutil.Discard, tr)
+		assert.NoError(t, err)
+
+		files[hdr.Name] = n
+	}
+
+	assert.Len(t, files, 2)
+	assert.NotZero(t, files["sufr.db"])
+	assert.Equal(t, int64(len("<html></html>")), files["archive/a1/page.html"])
+}
@@ -0,0 +1,201 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/google/uuid"
+	"github.com/kyleterry/sufr/pkg/archive"
+	"github.com/kyleterry/sufr/pkg/fetchqueue"
+	"github.com/pkg/errors"
+)
+
+// FetchJobQueue keeps metadata fetch jobs in bolt. It implements
+// fetchqueue.Store.
+type FetchJobQueue struct{}
+
+func (FetchJobQueue) Claim(ctx context.Context, now time.Time, lease time.Duration) (*fetchqueue.Job, error) {
+	var job *fetchqueue.Job
+
+	err := db.bolt.Update(func(tx *bolt.Tx) error {
+		var err error
+
+		job, err = claimFetchJob(now, lease, tx)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (FetchJobQueue) Complete(ctx context.Context, job *fetchqueue.Job) error {
+	err := db.bolt.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(buckets[fetchJobKey]).Delete([]byte(job.ID))
+	})
+	if err != nil {
+		return errors.Wrap(err, "transaction failed")
+	}
+
+	return nil
+}
+
+func (FetchJobQueue) Retry(ctx context.Context, job *fetchqueue.Job) error {
+	err := db.bolt.Update(func(tx *bolt.Tx) error {
+		return putFetchJob(job, tx)
+	})
+	if err != nil {
+		return errors.Wrap(err, "transaction failed")
+	}
+
+	return nil
+}
+
+// enqueueFetchJob adds a job to fetch the metadata of url.
+func enqueueFetchJob(url *URL, tx *bolt.Tx) error {
+	now := time.Now().UTC()
+	id, _ := uuid.New().MarshalText()
+	urlID, _ := url.ID.MarshalText()
+
+	job := &fetchqueue.Job{
+		ID:        string(id),
+		URLID:     string(urlID),
+		URL:       url.URL,
+		RunAt:     now,
+		CreatedAt: now,
+	}
+
+	return putFetchJob(job, tx)
+}
+
+func claimFetchJob(now time.Time, lease time.Duration, tx *bolt.Tx) (*fetchqueue.Job, error) {
+	var next *fetchqueue.Job
+
+	err := tx.Bucket(buckets[fetchJobKey]).ForEach(func(_, v []byte) error {
+		var job fetchqueue.Job
+		if err := json.Unmarshal(v, &job); err != nil {
+			return errors.Wrap(err, "failed to decode object")
+		}
+
+		if !job.RunAt.After(now) && (next == nil || job.RunAt.Before(next.RunAt)) {
+			next = &job
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if next == nil {
+		return nil, fetchqueue.ErrNoJobs
+	}
+
+	next.RunAt = now.Add(lease)
+
+	if err := putFetchJob(next, tx); err != nil {
+		return nil, err
+	}
+
+	return next, nil
+}
+
+func putFetchJob(job *fetchqueue.Job, tx *bolt.Tx) error {
+	b, err := json.Marshal(job)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize fetch job")
+	}
+
+	if err := tx.Bucket(buckets[fetchJobKey]).Put([]byte(job.ID), b); err != nil {
+		return errors.Wrap(err, "boltdb put failed")
+	}
+
+	return nil
+}
+
+// FetchMetadataHandler returns a fetchqueue.Handler that fetches the page
+// of a job's url with fetcher and saves what it finds on the URL. If archiver
+// isn't nil a snapshot of the page is archived as well.
+func FetchMetadataHandler(fetcher URLMetadataFetcher, archiver *archive.Archiver) fetchqueue.Handler {
+	return func(ctx context.Context, job *fetchqueue.Job) error {
+		id, err := uuid.Parse(job.URLID)
+		if err != nil {
+			return errors.Wrap(err, "invalid url id")
+		}
+
+		pm, err := fetcher.FetchMetadata(ctx, job.URL)
+		if err != nil {
+			return errors.Wrap(err, "failed to fetch page")
+		}
+
+		var archivedAt time.Time
+
+		if archiver != nil {
+			snap, err := archiver.Archive(ctx, job.URLID, job.URL)
+			if err != nil && err != archive.ErrNotHTML {
+				return errors.Wrap(err, "failed to archive page")
+			}
+
+			if snap != nil {
+				archivedAt = snap.CreatedAt
+			}
+		}
+
+		err = db.bolt.Update(func(tx *bolt.Tx) error {
+			return updateURLMetadata(id, pm, archivedAt, tx)
+		})
+		if err != nil {
+			// the url was deleted before we got to it
+			if errors.Cause(err) == ErrNotFound {
+				return nil
+			}
+
+			return errors.Wrap(err, "transaction failed")
+		}
+
+		return nil
+	}
+}
+
+func updateURLMetadata(id uuid.UUID, pm PageMeta, archivedAt time.Time, tx *bolt.Tx) error {
+	url, err := getURL(id, tx)
+	if err != nil {
+		return err
+	}
+
+	// don't clobber a title set by hand while the job was waiting
+	if url.Title == DefaultURLTitle && pm.Title != "" {
+		url.Title = pm.Title
+	}
+
+	url.StatusCode = pm.Status
+	url.ContentType = pm.ContentType
+	url.Description = pm.Description
+	url.ImageURL = pm.ImageURL
+	url.SiteName = pm.SiteName
+	url.Author = pm.Author
+	url.PublishedAt = pm.PublishedAt
+	url.CanonicalURL = pm.CanonicalURL
+	url.Language = pm.Language
+	url.FaviconURL = pm.FaviconURL
+
+	if !archivedAt.IsZero() {
+		url.ArchivedAt = archivedAt
+	}
+
+	b, err := json.Marshal(url)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize url")
+	}
+
+	key, _ := url.ID.MarshalText()
+	if err := tx.Bucket(buckets[urlKey]).Put(key, b); err != nil {
+		return errors.Wrap(err, "boltdb put failed")
+	}
+
+	return nil
+}
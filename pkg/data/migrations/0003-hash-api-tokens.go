@@ -0,0 +1,76 @@
+package migrations
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/google/uuid"
+	"github.com/kyleterry/sufr/pkg/config"
+	"github.com/kyleterry/sufr/pkg/data"
+	"github.com/pkg/errors"
+)
+
+var apiTokenBucket = []byte("_api_tokens")
+
+type oldAPITokenModel struct {
+	ID        uuid.UUID `json:"id"`
+	Token     string    `json:"token"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// HashAPITokens replaces the plaintext api token with a hashed one. The old
+// token could only be used for backups so that's the scope it keeps.
+type HashAPITokens struct{}
+
+func (HashAPITokens) Description() string {
+	return "hashing api tokens"
+}
+
+func (HashAPITokens) Migrate(cfg *config.Config, tx *bolt.Tx) error {
+	bucket := tx.Bucket(apiTokenBucket)
+	if bucket == nil {
+		return nil
+	}
+
+	tokens := map[string]*data.APIToken{}
+
+	err := bucket.ForEach(func(k, v []byte) error {
+		var old oldAPITokenModel
+		if err := json.Unmarshal(v, &old); err != nil {
+			return err
+		}
+
+		if old.Token == "" {
+			return nil
+		}
+
+		tokens[string(k)] = &data.APIToken{
+			ID:        old.ID,
+			Name:      "backups",
+			Hash:      data.HashAPIToken(old.Token),
+			Scopes:    []data.APIScope{data.ScopeBackup},
+			CreatedAt: old.CreatedAt,
+			UpdatedAt: old.UpdatedAt,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to read api tokens")
+	}
+
+	for k, token := range tokens {
+		b, err := json.Marshal(token)
+		if err != nil {
+			return errors.Wrap(err, "failed to serialize api token")
+		}
+
+		if err := bucket.Put([]byte(k), b); err != nil {
+			return errors.Wrap(err, "boltdb put failed")
+		}
+	}
+
+	return nil
+}
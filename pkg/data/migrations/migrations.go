@@ -33,6 +33,7 @@ type Migration interface {
 var Migrations = []Migration{
 	MigrateOldDatabase{},
 	CreateInitialPageIndexes{},
+	HashAPITokens{},
 }
 
 func run(cfg *config.Config, tx *bolt.Tx) error {
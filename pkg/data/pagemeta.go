@@ -0,0 +1,154 @@
+package data
+
+import (
+	stdurl "net/url"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// publishedLayouts are the date formats found in article:published_time and
+// friends, most common first.
+var publishedLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	time.RFC1123Z,
+	time.RFC1123,
+}
+
+// parsePageMeta fills pm with everything it can find in the head of doc.
+// OpenGraph and Twitter card properties are preferred over plain meta tags
+// because sites tend to keep them accurate for link previews. Relative links
+// are resolved against base, the url the page was fetched from.
+func parsePageMeta(pm *PageMeta, doc *goquery.Document, base *stdurl.URL) {
+	meta := pageMetaTags(doc)
+
+	pm.Title = first(
+		strings.TrimSpace(doc.Find("title").First().Text()),
+		meta["og:title"],
+		meta["twitter:title"],
+	)
+	pm.Description = first(meta["og:description"], meta["twitter:description"], meta["description"])
+	pm.SiteName = first(meta["og:site_name"], meta["application-name"], meta["twitter:site"])
+	pm.Author = first(meta["author"], meta["article:author"], meta["twitter:creator"])
+	pm.Language = first(
+		strings.TrimSpace(doc.Find("html").AttrOr("lang", "")),
+		meta["content-language"],
+		meta["og:locale"],
+	)
+	pm.ImageURL = resolveURL(base, first(
+		meta["og:image:secure_url"],
+		meta["og:image"],
+		meta["twitter:image"],
+		meta["twitter:image:src"],
+	))
+	pm.CanonicalURL = resolveURL(base, first(
+		linkHref(doc, "canonical"),
+		meta["og:url"],
+	))
+	pm.FaviconURL = resolveURL(base, first(
+		linkHref(doc, "icon"),
+		linkHref(doc, "shortcut icon"),
+		linkHref(doc, "apple-touch-icon"),
+		"/favicon.ico",
+	))
+	pm.OEmbedURL = resolveURL(base, strings.TrimSpace(
+		doc.Find(`link[rel~="alternate"][type="application/json+oembed"][href], link[rel~="alternate"][type="text/json+oembed"][href]`).
+			First().AttrOr("href", ""),
+	))
+
+	published := first(
+		meta["article:published_time"],
+		meta["og:published_time"],
+		meta["datepublished"],
+		meta["date"],
+		doc.Find("time[datetime]").First().AttrOr("datetime", ""),
+	)
+
+	for _, layout := range publishedLayouts {
+		if t, err := time.Parse(layout, published); err == nil {
+			pm.PublishedAt = t.UTC()
+
+			break
+		}
+	}
+}
+
+// pageMetaTags returns the content of the meta tags in doc keyed by their
+// lowercased name, property, itemprop or http-equiv. The first tag wins when
+// a key is repeated.
+func pageMetaTags(doc *goquery.Document) map[string]string {
+	tags := map[string]string{}
+
+	doc.Find("meta[content]").Each(func(_ int, s *goquery.Selection) {
+		content := strings.TrimSpace(s.AttrOr("content", ""))
+		if content == "" {
+			return
+		}
+
+		for _, attr := range []string{"property", "name", "itemprop", "http-equiv"} {
+			key := strings.ToLower(strings.TrimSpace(s.AttrOr(attr, "")))
+			if key == "" {
+				continue
+			}
+
+			if _, ok := tags[key]; !ok {
+				tags[key] = content
+			}
+		}
+	})
+
+	return tags
+}
+
+// linkHref returns the href of the first link in doc with rel.
+func linkHref(doc *goquery.Document, rel string) string {
+	var href string
+
+	doc.Find("link[href]").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		if strings.EqualFold(strings.Join(strings.Fields(s.AttrOr("rel", "")), " "), rel) {
+			href = strings.TrimSpace(s.AttrOr("href", ""))
+		}
+
+		return href == ""
+	})
+
+	return href
+}
+
+// resolveURL makes ref absolute. Anything that isn't an http or https url
+// once resolved, like a data uri, is dropped.
+func resolveURL(base *stdurl.URL, ref string) string {
+	if ref == "" {
+		return ""
+	}
+
+	u, err := stdurl.Parse(ref)
+	if err != nil {
+		return ""
+	}
+
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return ""
+	}
+
+	return u.String()
+}
+
+func first(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
@@ -1,10 +1,246 @@
 package data
 
+import (
+	stdurl "net/url"
+	"strings"
+	"unicode"
+)
+
+// query is a parsed search string. It supports the following syntax:
+//
+//	tag:go           url must be tagged with "go"
+//	site:github.com  url must be hosted on github.com or one of its subdomains
+//	is:fav           url must be a favorite
+//	is:private       url must be private
+//	is:dead          url must be flagged dead by the link checker
+//	"some phrase"    title, url or notes must contain the phrase
+//	words            title, url or notes must contain every word
+//
+// Any of the above can be prefixed with a "-" to exclude matches instead.
 type query struct {
-	tags    []string
-	domains []string
+	tags            []string
+	excludedTags    []string
+	domains         []string
+	excludedDomains []string
+	terms           []string
+	excludedTerms   []string
+	is              map[string]bool
+}
+
+// isAliases maps the accepted is: values to the flag they check
+var isAliases = map[string]string{
+	"fav":      "fav",
+	"favorite": "fav",
+	"private":  "private",
+	"public":   "public",
+	"dead":     "dead",
+	"broken":   "dead",
 }
 
 func NewQuery(q string) *query {
-	return &query{}
+	query := &query{
+		is: make(map[string]bool),
+	}
+
+	for _, tok := range tokenizeQuery(q) {
+		query.add(tok)
+	}
+
+	return query
+}
+
+func (q *query) add(tok string) {
+	negate := false
+
+	if len(tok) > 1 && tok[0] == '-' {
+		negate = true
+		tok = tok[1:]
+	}
+
+	key, value := "", tok
+
+	if i := strings.Index(tok, ":"); i > 0 && !strings.HasPrefix(tok, `"`) {
+		key, value = strings.ToLower(tok[:i]), tok[i+1:]
+	}
+
+	value = strings.ToLower(strings.Trim(value, `"`))
+	if value == "" {
+		return
+	}
+
+	switch key {
+	case "tag":
+		if negate {
+			q.excludedTags = append(q.excludedTags, value)
+		} else {
+			q.tags = append(q.tags, value)
+		}
+	case "site":
+		value = strings.TrimPrefix(value, "www.")
+
+		if negate {
+			q.excludedDomains = append(q.excludedDomains, value)
+		} else {
+			q.domains = append(q.domains, value)
+		}
+	case "is":
+		flag, ok := isAliases[value]
+		if !ok {
+			return
+		}
+
+		q.is[flag] = !negate
+	default:
+		// unknown keys are treated as plain text so urls like
+		// "https://example.com" can still be searched for.
+		value = strings.ToLower(strings.Trim(tok, `"`))
+
+		if negate {
+			q.excludedTerms = append(q.excludedTerms, value)
+		} else {
+			q.terms = append(q.terms, value)
+		}
+	}
+}
+
+// IsEmpty returns true if the query has nothing to filter on.
+func (q *query) IsEmpty() bool {
+	return len(q.tags) == 0 &&
+		len(q.excludedTags) == 0 &&
+		len(q.domains) == 0 &&
+		len(q.excludedDomains) == 0 &&
+		len(q.terms) == 0 &&
+		len(q.excludedTerms) == 0 &&
+		len(q.is) == 0
+}
+
+// matches returns true if url satisfies every part of the query. url.Tags
+// must be filled before calling this.
+func (q *query) matches(url *URL) bool {
+	if q.IsEmpty() {
+		return false
+	}
+
+	tags := make(map[string]bool, len(url.Tags))
+	for _, tag := range url.Tags {
+		tags[strings.ToLower(tag.Name)] = true
+	}
+
+	for _, tag := range q.tags {
+		if !tags[tag] {
+			return false
+		}
+	}
+
+	for _, tag := range q.excludedTags {
+		if tags[tag] {
+			return false
+		}
+	}
+
+	host := urlHost(url.URL)
+
+	if len(q.domains) > 0 {
+		var found bool
+
+		for _, domain := range q.domains {
+			if hostMatches(host, domain) {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	for _, domain := range q.excludedDomains {
+		if hostMatches(host, domain) {
+			return false
+		}
+	}
+
+	for flag, want := range q.is {
+		var have bool
+
+		switch flag {
+		case "fav":
+			have = url.Favorite
+		case "private":
+			have = url.Private
+		case "public":
+			have = url.IsPublic()
+		case "dead":
+			have = url.Dead
+		}
+
+		if have != want {
+			return false
+		}
+	}
+
+	text := strings.ToLower(strings.Join([]string{url.Title, url.URL, url.Notes}, "\n"))
+
+	for _, term := range q.terms {
+		if !strings.Contains(text, term) {
+			return false
+		}
+	}
+
+	for _, term := range q.excludedTerms {
+		if strings.Contains(text, term) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// tokenizeQuery splits q on whitespace, keeping double quoted sections
+// together. A quote can start anywhere in a token, so tag:"foo" and -"foo bar"
+// are both a single token.
+func tokenizeQuery(q string) []string {
+	var (
+		tokens []string
+		buf    strings.Builder
+		quoted bool
+	)
+
+	flush := func() {
+		if buf.Len() > 0 {
+			tokens = append(tokens, buf.String())
+			buf.Reset()
+		}
+	}
+
+	for _, r := range q {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			buf.WriteRune(r)
+		case unicode.IsSpace(r) && !quoted:
+			flush()
+		default:
+			buf.WriteRune(r)
+		}
+	}
+
+	flush()
+
+	return tokens
+}
+
+func urlHost(rawurl string) string {
+	u, err := stdurl.Parse(rawurl)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
+
+func hostMatches(host, domain string) bool {
+	return host == domain || strings.HasSuffix(host, "."+domain)
 }
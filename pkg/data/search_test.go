@@ -0,0 +1,118 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/boltdb/bolt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewQuery(t *testing.T) {
+	q := NewQuery(`tag:go -tag:Old site:www.github.com is:fav -is:private "hello world" Compiler https://example.com`)
+
+	assert.Equal(t, []string{"go"}, q.tags)
+	assert.Equal(t, []string{"old"}, q.excludedTags)
+	assert.Equal(t, []string{"github.com"}, q.domains)
+	assert.Equal(t, map[string]bool{"fav": true, "private": false}, q.is)
+	assert.Equal(t, []string{"hello world", "compiler", "https://example.com"}, q.terms)
+	assert.False(t, q.IsEmpty())
+
+	assert.True(t, NewQuery("   ").IsEmpty())
+	assert.True(t, NewQuery("is:unknown").IsEmpty())
+}
+
+func TestQueryMatches(t *testing.T) {
+	url := &URL{
+		URL:      "https://gist.github.com/kyleterry/123",
+		Title:    "Writing a compiler in Go",
+		Notes:    "Read this on the train",
+		Favorite: true,
+		Tags:     []*Tag{{Name: "go"}, {Name: "compilers"}},
+	}
+
+	var cases = []struct {
+		query   string
+		matches bool
+	}{
+		{"tag:go", true},
+		{"tag:go tag:compilers", true},
+		{"tag:rust", false},
+		{"-tag:go", false},
+		{"-tag:rust", true},
+		{"site:github.com", true},
+		{"site:gitlab.com", false},
+		{"site:gitlab.com site:github.com", true},
+		{"-site:github.com", false},
+		{"is:fav", true},
+		{"-is:fav", false},
+		{"is:private", false},
+		{"is:public", true},
+		{"is:dead", false},
+		{"-is:broken", true},
+		{"compiler", true},
+		{"COMPILER train", true},
+		{`"on the train"`, true},
+		{`"on a train"`, false},
+		{"-train", false},
+		{"kyleterry/123", true},
+		{"tag:go is:fav site:github.com compiler", true},
+		{"", false},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.matches, NewQuery(c.query).matches(url), c.query)
+	}
+}
+
+func TestSearchURLGetter(t *testing.T) {
+	public, err := createAndFetchURL(CreateURLOptions{
+		URL:  "https://search-test.example.com/public",
+		Tags: "searchtest",
+	}, mockMetadataFetcher{title: "Public search result"})
+	assert.NoError(t, err)
+
+	private, err := createAndFetchURL(CreateURLOptions{
+		URL:  "https://search-test.example.com/private",
+		Tags: "searchtest",
+	}, mockMetadataFetcher{title: "Private search result"})
+	assert.NoError(t, err)
+
+	_, err = UpdateURL(UpdateURLOptions{
+		ID:      private.ID,
+		Title:   private.Title,
+		Private: true,
+		Tags:    "searchtest",
+	})
+	assert.NoError(t, err)
+
+	err = db.bolt.View(func(tx *bolt.Tx) error {
+		urls, err := NewSearchURLGetter("tag:searchtest", true).GetURLs(tx)
+		assert.NoError(t, err)
+		assert.Len(t, urls, 2)
+
+		urls, err = NewSearchURLGetter("tag:searchtest", false).GetURLs(tx)
+		assert.NoError(t, err)
+		assert.Len(t, urls, 1)
+		assert.Equal(t, public.ID, urls[0].ID)
+
+		urls, err = NewSearchURLGetter("site:search-test.example.com is:private", true).GetURLs(tx)
+		assert.NoError(t, err)
+		assert.Len(t, urls, 1)
+		assert.Equal(t, private.ID, urls[0].ID)
+
+		return nil
+	})
+	assert.NoError(t, err)
+
+	urls, err := SearchURLs("tag:searchtest", false)
+	assert.NoError(t, err)
+	assert.Len(t, urls, 1)
+	assert.Equal(t, public.ID, urls[0].ID)
+
+	all, err := GetURLs()
+	assert.NoError(t, err)
+
+	urls, err = SearchURLs("", true)
+	assert.NoError(t, err)
+	assert.Len(t, urls, len(all))
+}
@@ -1,8 +1,10 @@
 package data
 
 import (
+	"context"
 	"encoding/json"
 	"html/template"
+	"mime"
 	"net/http"
 	stdurl "net/url"
 	"sort"
@@ -19,11 +21,15 @@ import (
 )
 
 const (
+	// DefaultURLTitle is the title of a url until its metadata is fetched,
+	// or when the page doesn't have one.
 	DefaultURLTitle = "No title (edit to change)"
+
+	fetchTimeout = 30 * time.Second
 )
 
 type URLMetadataFetcher interface {
-	FetchMetadata(string) (PageMeta, error)
+	FetchMetadata(context.Context, string) (PageMeta, error)
 }
 
 // CreateURLOptions is passed into CreateURL from the http handler to initiate a url creation
@@ -43,8 +49,19 @@ type UpdateURLOptions struct {
 }
 
 type PageMeta struct {
-	Title  string
-	Status int
+	Title        string
+	Status       int
+	ContentType  string
+	Description  string
+	ImageURL     string
+	SiteName     string
+	Author       string
+	PublishedAt  time.Time
+	CanonicalURL string
+	Language     string
+	FaviconURL   string
+	// OEmbedURL is the oEmbed endpoint the page advertised for itself.
+	OEmbedURL string
 }
 
 type URLsByDateDesc []*URL
@@ -55,17 +72,30 @@ func (u URLsByDateDesc) Less(i, j int) bool { return u[i].CreatedAt.After(u[j].C
 
 // URL is the model for a url object
 type URL struct {
-	ID         uuid.UUID   `json:"id"`
-	URL        string      `json:"url"`
-	Title      string      `json:"title"`
-	Notes      string      `json:"notes"`
-	StatusCode int         `json:"status_code"`
-	Private    bool        `json:"private"`
-	Favorite   bool        `json:"favorite"`
-	Tags       []*Tag      `json:"-"`
-	TagIDs     []uuid.UUID `json:"tag_ids"`
-	CreatedAt  time.Time   `json:"created_at"`
-	UpdatedAt  time.Time   `json:"updated_at"`
+	ID           uuid.UUID   `json:"id"`
+	URL          string      `json:"url"`
+	Title        string      `json:"title"`
+	Notes        string      `json:"notes"`
+	StatusCode   int         `json:"status_code"`
+	ContentType  string      `json:"content_type"`
+	Description  string      `json:"description"`
+	ImageURL     string      `json:"image_url"`
+	SiteName     string      `json:"site_name"`
+	Author       string      `json:"author"`
+	PublishedAt  time.Time   `json:"published_at"`
+	CanonicalURL string      `json:"canonical_url"`
+	Language     string      `json:"language"`
+	FaviconURL   string      `json:"favicon_url"`
+	Failures     int         `json:"failures"`
+	Dead         bool        `json:"dead"`
+	CheckedAt    time.Time   `json:"checked_at"`
+	ArchivedAt   time.Time   `json:"archived_at"`
+	Private      bool        `json:"private"`
+	Favorite     bool        `json:"favorite"`
+	Tags         []*Tag      `json:"-"`
+	TagIDs       []uuid.UUID `json:"tag_ids"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
 }
 
 // helpers
@@ -141,7 +171,10 @@ func (u *URL) fillTags(tx *bolt.Tx) error {
 	return nil
 }
 
-func CreateURL(opts CreateURLOptions, fetcher URLMetadataFetcher) (*URL, error) {
+// CreateURL saves a new url with a placeholder title and queues a job to
+// fetch its metadata. Run a fetchqueue.Pool with FetchJobQueue and
+// FetchMetadataHandler to work the queue.
+func CreateURL(opts CreateURLOptions) (*URL, error) {
 	tx, err := db.bolt.Begin(true)
 	if err != nil {
 		return nil, err
@@ -149,7 +182,7 @@ func CreateURL(opts CreateURLOptions, fetcher URLMetadataFetcher) (*URL, error)
 
 	defer tx.Rollback()
 
-	url, err := createURL(opts, fetcher, tx)
+	url, err := createURL(opts, tx)
 
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create url")
@@ -162,7 +195,7 @@ func CreateURL(opts CreateURLOptions, fetcher URLMetadataFetcher) (*URL, error)
 	return url, nil
 }
 
-func createURL(opts CreateURLOptions, fetcher URLMetadataFetcher, tx *bolt.Tx) (*URL, error) {
+func createURL(opts CreateURLOptions, tx *bolt.Tx) (*URL, error) {
 	if _, err := stdurl.Parse(opts.URL); err != nil {
 		return nil, errors.Wrap(err, "failed to parse url")
 	}
@@ -171,25 +204,15 @@ func createURL(opts CreateURLOptions, fetcher URLMetadataFetcher, tx *bolt.Tx) (
 		return nil, ErrDuplicateKey
 	}
 
-	pm, err := fetcher.FetchMetadata(opts.URL)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to fetch page")
-	}
-
-	if pm.Title == "" {
-		pm.Title = DefaultURLTitle
-	}
-
 	now := time.Now()
 
 	url := &URL{
-		ID:         uuid.New(),
-		URL:        opts.URL,
-		Title:      pm.Title,
-		StatusCode: pm.Status,
-		CreatedAt:  now,
-		UpdatedAt:  now,
-		TagIDs:     []uuid.UUID{},
+		ID:        uuid.New(),
+		URL:       opts.URL,
+		Title:     DefaultURLTitle,
+		CreatedAt: now,
+		UpdatedAt: now,
+		TagIDs:    []uuid.UUID{},
 	}
 
 	tagNames := parseTags(opts.Tags)
@@ -220,6 +243,10 @@ func createURL(opts CreateURLOptions, fetcher URLMetadataFetcher, tx *bolt.Tx) (
 		return nil, errors.Wrap(err, "boltdb put failed")
 	}
 
+	if err := enqueueFetchJob(url, tx); err != nil {
+		return nil, errors.Wrap(err, "failed to queue metadata fetch")
+	}
+
 	return url, nil
 }
 
@@ -347,6 +374,10 @@ func deleteURL(url *URL, tx *bolt.Tx) error {
 		return err
 	}
 
+	if err := tx.Bucket(buckets[urlCheckKey]).Delete(id); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -371,6 +402,40 @@ func GetURLs() ([]*URL, error) {
 	return urls, nil
 }
 
+// SearchURLs returns the urls matching query, in the language described on
+// query, sorted by URL.CreatedAt desc. An empty query matches every url.
+func SearchURLs(query string, includePrivate bool) ([]*URL, error) {
+	var urls []*URL
+
+	err := db.bolt.View(func(tx *bolt.Tx) error {
+		var err error
+
+		if !NewQuery(query).IsEmpty() {
+			urls, err = NewSearchURLGetter(query, includePrivate).GetURLs(tx)
+
+			return err
+		}
+
+		all, err := getURLs(tx)
+		if err != nil {
+			return err
+		}
+
+		for _, url := range all {
+			if includePrivate || !url.Private {
+				urls = append(urls, url)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "transaction failed")
+	}
+
+	return urls, nil
+}
+
 func getURLs(tx *bolt.Tx) ([]*URL, error) {
 	var urls []*URL
 	bucket := tx.Bucket(buckets[urlKey])
@@ -536,20 +601,31 @@ func parseTags(tagsstring string) []string {
 	})
 }
 
+// NewPageRequest returns a GET request for the page at url set up the way
+// HTTPMetadataFetcher sends them.
+func NewPageRequest(ctx context.Context, url string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", config.SUFRUserAgent)
+
+	return req, nil
+}
+
 type HTTPMetadataFetcher struct{}
 
-// Returns the page title or an error. If there is an error, the url is returned as well.
-func (HTTPMetadataFetcher) FetchMetadata(url string) (PageMeta, error) {
+// Returns the metadata of the page at url or an error.
+func (HTTPMetadataFetcher) FetchMetadata(ctx context.Context, url string) (PageMeta, error) {
 	var pm PageMeta
 
-	client := &http.Client{}
-	req, err := http.NewRequest("GET", url, nil)
+	client := &http.Client{Timeout: fetchTimeout}
+	req, err := NewPageRequest(ctx, url)
 	if err != nil {
 		return pm, err
 	}
 
-	req.Header.Set("User-Agent", config.SUFRUserAgent)
-
 	res, err := client.Do(req)
 	if err != nil {
 		return pm, err
@@ -559,12 +635,22 @@ func (HTTPMetadataFetcher) FetchMetadata(url string) (PageMeta, error) {
 
 	pm.Status = res.StatusCode
 
+	if mt, _, err := mime.ParseMediaType(res.Header.Get("Content-Type")); err == nil {
+		pm.ContentType = mt
+	}
+
+	// only html pages have a title to look for
+	if pm.ContentType != "" && pm.ContentType != "text/html" && pm.ContentType != "application/xhtml+xml" {
+		return pm, nil
+	}
+
 	doc, err := goquery.NewDocumentFromReader(res.Body)
 
 	if err != nil {
 		return pm, err
 	}
 
-	pm.Title = doc.Find("title").Text()
+	parsePageMeta(&pm, doc, res.Request.URL)
+
 	return pm, nil
 }
@@ -0,0 +1,167 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/google/uuid"
+	"github.com/kyleterry/sufr/pkg/linkcheck"
+	"github.com/pkg/errors"
+)
+
+// urlCheckHistory is how many checks are kept for each url.
+const urlCheckHistory = 30
+
+// LinkCheckStore keeps the link check history of urls in bolt. It implements
+// linkcheck.Store.
+type LinkCheckStore struct{}
+
+func (LinkCheckStore) Due(ctx context.Context, before time.Time, limit int) ([]*linkcheck.Link, error) {
+	var urls []*URL
+
+	err := db.bolt.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(buckets[urlKey]).ForEach(func(_, v []byte) error {
+			url := URL{}
+			if err := json.Unmarshal(v, &url); err != nil {
+				return errors.Wrap(err, "failed to decode object")
+			}
+
+			if url.CheckedAt.IsZero() || !url.CheckedAt.After(before) {
+				urls = append(urls, &url)
+			}
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "transaction failed")
+	}
+
+	// never checked urls have a zero CheckedAt so they sort first
+	sort.SliceStable(urls, func(i, j int) bool {
+		return urls[i].CheckedAt.Before(urls[j].CheckedAt)
+	})
+
+	if len(urls) > limit {
+		urls = urls[:limit]
+	}
+
+	links := make([]*linkcheck.Link, len(urls))
+
+	for i, url := range urls {
+		id, _ := url.ID.MarshalText()
+		links[i] = &linkcheck.Link{ID: string(id), URL: url.URL}
+	}
+
+	return links, nil
+}
+
+func (LinkCheckStore) Record(ctx context.Context, result *linkcheck.Result, deadAfter int) error {
+	id, err := uuid.Parse(result.URLID)
+	if err != nil {
+		return errors.Wrap(err, "invalid url id")
+	}
+
+	err = db.bolt.Update(func(tx *bolt.Tx) error {
+		return recordURLCheck(id, result, deadAfter, tx)
+	})
+	if err != nil {
+		// the url was deleted while it was being checked
+		if errors.Cause(err) == ErrNotFound {
+			return nil
+		}
+
+		return errors.Wrap(err, "transaction failed")
+	}
+
+	return nil
+}
+
+func recordURLCheck(id uuid.UUID, result *linkcheck.Result, deadAfter int, tx *bolt.Tx) error {
+	url, err := getURL(id, tx)
+	if err != nil {
+		return err
+	}
+
+	if result.OK {
+		url.Failures = 0
+	} else {
+		url.Failures++
+	}
+
+	url.StatusCode = result.StatusCode
+	url.Dead = url.Failures >= deadAfter
+	url.CheckedAt = result.CheckedAt
+
+	b, err := json.Marshal(url)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize url")
+	}
+
+	key, _ := id.MarshalText()
+	if err := tx.Bucket(buckets[urlKey]).Put(key, b); err != nil {
+		return errors.Wrap(err, "boltdb put failed")
+	}
+
+	checks, err := getURLChecks(id, tx)
+	if err != nil {
+		return err
+	}
+
+	result.ID = uuid.New().String()
+	checks = append([]*linkcheck.Result{result}, checks...)
+
+	if len(checks) > urlCheckHistory {
+		checks = checks[:urlCheckHistory]
+	}
+
+	b, err = json.Marshal(checks)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize url checks")
+	}
+
+	if err := tx.Bucket(buckets[urlCheckKey]).Put(key, b); err != nil {
+		return errors.Wrap(err, "boltdb put failed")
+	}
+
+	return nil
+}
+
+// GetURLChecks returns the latest link checks of the url with id, newest
+// first.
+func GetURLChecks(id uuid.UUID) ([]*linkcheck.Result, error) {
+	var checks []*linkcheck.Result
+
+	err := db.bolt.View(func(tx *bolt.Tx) error {
+		var err error
+
+		checks, err = getURLChecks(id, tx)
+
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "transaction failed")
+	}
+
+	return checks, nil
+}
+
+func getURLChecks(id uuid.UUID, tx *bolt.Tx) ([]*linkcheck.Result, error) {
+	checks := []*linkcheck.Result{}
+
+	key, _ := id.MarshalText()
+
+	raw := tx.Bucket(buckets[urlCheckKey]).Get(key)
+	if len(raw) == 0 {
+		return checks, nil
+	}
+
+	if err := json.Unmarshal(raw, &checks); err != nil {
+		return nil, errors.Wrap(err, "failed to decode object")
+	}
+
+	return checks, nil
+}
@@ -0,0 +1,95 @@
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyleterry/sufr/pkg/linkcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkCheckStore(t *testing.T) {
+	ctx := context.Background()
+	s := LinkCheckStore{}
+
+	url, err := CreateURL(CreateURLOptions{URL: "https://linkcheck-test.example.com/gone"})
+	assert.NoError(t, err)
+
+	id, _ := url.ID.MarshalText()
+	now := time.Now().UTC()
+
+	record := func(ok bool, at time.Time) {
+		res := &linkcheck.Result{URLID: string(id), OK: ok, StatusCode: 200, CheckedAt: at}
+		if !ok {
+			res.StatusCode = 404
+		}
+
+		assert.NoError(t, s.Record(ctx, res, 2))
+	}
+
+	due := func(before time.Time) bool {
+		links, err := s.Due(ctx, before, 1000)
+		assert.NoError(t, err)
+
+		for _, link := range links {
+			if link.ID == string(id) {
+				assert.Equal(t, url.URL, link.URL)
+
+				return true
+			}
+		}
+
+		return false
+	}
+
+	assert.True(t, due(now))
+
+	record(false, now.Add(-time.Hour))
+
+	assert.False(t, due(now.Add(-2*time.Hour)))
+	assert.True(t, due(now))
+
+	url, err = GetURL(url.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 404, url.StatusCode)
+	assert.Equal(t, 1, url.Failures)
+	assert.False(t, url.Dead)
+
+	record(false, now.Add(-time.Minute))
+
+	url, err = GetURL(url.ID)
+	assert.NoError(t, err)
+	assert.True(t, url.Dead)
+	assert.True(t, NewQuery("is:dead").matches(url))
+
+	record(true, now)
+
+	url, err = GetURL(url.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, url.Failures)
+	assert.False(t, url.Dead)
+
+	checks, err := GetURLChecks(url.ID)
+	assert.NoError(t, err)
+	assert.Len(t, checks, 3)
+	assert.True(t, checks[0].OK)
+	assert.Equal(t, 404, checks[1].StatusCode)
+
+	for i := 0; i < urlCheckHistory; i++ {
+		record(true, now)
+	}
+
+	checks, err = GetURLChecks(url.ID)
+	assert.NoError(t, err)
+	assert.Len(t, checks, urlCheckHistory)
+
+	assert.NoError(t, DeleteURL(url))
+
+	checks, err = GetURLChecks(url.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, checks)
+
+	// checks of deleted urls are dropped
+	record(false, now)
+}
@@ -15,14 +15,36 @@ func (FavURLGetter) GetURLs(tx *bolt.Tx) ([]*URL, error) {
 }
 
 type SearchURLGetter struct {
-	query string
+	query          string
+	includePrivate bool
 }
 
-func NewSearchURLGetter(query string) *SearchURLGetter {
-	return &SearchURLGetter{query}
+// NewSearchURLGetter returns a urlGetter that filters URLs using the query
+// language described on query. Private URLs are only included when
+// includePrivate is true.
+func NewSearchURLGetter(query string, includePrivate bool) *SearchURLGetter {
+	return &SearchURLGetter{query, includePrivate}
 }
 
 func (s SearchURLGetter) GetURLs(tx *bolt.Tx) ([]*URL, error) {
-	// return getResultingURLsFromSearch(s.query)
-	return nil, nil
+	urls, err := getURLs(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	q := NewQuery(s.query)
+
+	results := []*URL{}
+
+	for _, url := range urls {
+		if url.Private && !s.includePrivate {
+			continue
+		}
+
+		if q.matches(url) {
+			results = append(results, url)
+		}
+	}
+
+	return results, nil
 }
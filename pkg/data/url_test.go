@@ -1,17 +1,29 @@
 package data
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/kyleterry/sufr/pkg/archive"
+	"github.com/kyleterry/sufr/pkg/fetchqueue"
 	"github.com/stretchr/testify/assert"
 )
 
 type mockMetadataFetcher struct {
 	title      string
 	statusCode int
+	err        error
 }
 
-func (m mockMetadataFetcher) FetchMetadata(_ string) (PageMeta, error) {
+func (m mockMetadataFetcher) FetchMetadata(_ context.Context, _ string) (PageMeta, error) {
+	if m.err != nil {
+		return PageMeta{}, m.err
+	}
+
 	if m.title == "" {
 		m.title = "no title"
 	}
@@ -20,7 +32,48 @@ func (m mockMetadataFetcher) FetchMetadata(_ string) (PageMeta, error) {
 		m.statusCode = 200
 	}
 
-	return PageMeta{m.title, m.statusCode}, nil
+	return PageMeta{Title: m.title, Status: m.statusCode, ContentType: "text/html"}, nil
+}
+
+// workFetchJobs works every queued fetch job with fetcher the way a
+// fetchqueue.Pool would.
+func workFetchJobs(fetcher URLMetadataFetcher) error {
+	ctx := context.Background()
+	q := FetchJobQueue{}
+	handler := FetchMetadataHandler(fetcher, nil)
+
+	for {
+		job, err := q.Claim(ctx, time.Now().UTC(), time.Minute)
+		if errors.Is(err, fetchqueue.ErrNoJobs) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := handler(ctx, job); err != nil {
+			return err
+		}
+
+		if err := q.Complete(ctx, job); err != nil {
+			return err
+		}
+	}
+}
+
+// createAndFetchURL creates a url and fetches its metadata with fetcher.
+func createAndFetchURL(opts CreateURLOptions, fetcher URLMetadataFetcher) (*URL, error) {
+	url, err := CreateURL(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := workFetchJobs(fetcher); err != nil {
+		return nil, err
+	}
+
+	return GetURL(url.ID)
 }
 
 func TestCreateURL(t *testing.T) {
@@ -29,12 +82,101 @@ func TestCreateURL(t *testing.T) {
 		Tags: "search engine",
 	}
 
-	url, err := CreateURL(opts, mockMetadataFetcher{title: "Google"})
+	url, err := CreateURL(opts)
 
 	assert.NoError(t, err)
 	assert.Equal(t, url.URL, "https://google.com")
+	assert.Equal(t, url.Title, DefaultURLTitle)
+	assert.Equal(t, url.StatusCode, 0)
+
+	assert.NoError(t, workFetchJobs(mockMetadataFetcher{title: "Google"}))
+
+	url, err = GetURL(url.ID)
+
+	assert.NoError(t, err)
 	assert.Equal(t, url.Title, "Google")
 	assert.Equal(t, url.StatusCode, 200)
+	assert.Equal(t, url.ContentType, "text/html")
+}
+
+func TestFetchMetadataHandler(t *testing.T) {
+	ctx := context.Background()
+	q := FetchJobQueue{}
+
+	t.Run("keeps a title set while the job was queued", func(t *testing.T) {
+		url, err := CreateURL(CreateURLOptions{URL: "https://fetch-test.example.com/edited"})
+		assert.NoError(t, err)
+
+		_, err = UpdateURL(UpdateURLOptions{ID: url.ID, Title: "My title"})
+		assert.NoError(t, err)
+
+		assert.NoError(t, workFetchJobs(mockMetadataFetcher{title: "Page title"}))
+
+		url, err = GetURL(url.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "My title", url.Title)
+		assert.Equal(t, 200, url.StatusCode)
+	})
+
+	t.Run("failed fetches leave the job queued", func(t *testing.T) {
+		url, err := CreateURL(CreateURLOptions{URL: "https://fetch-test.example.com/down"})
+		assert.NoError(t, err)
+
+		job, err := q.Claim(ctx, time.Now().UTC(), time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, url.URL, job.URL)
+
+		// claimed jobs are held for the lease
+		_, err = q.Claim(ctx, time.Now().UTC(), time.Minute)
+		assert.True(t, errors.Is(err, fetchqueue.ErrNoJobs))
+
+		assert.Error(t, FetchMetadataHandler(mockMetadataFetcher{err: errors.New("timeout")}, nil)(ctx, job))
+
+		job.Attempts++
+		job.RunAt = time.Now().UTC()
+		assert.NoError(t, q.Retry(ctx, job))
+
+		retried, err := q.Claim(ctx, time.Now().UTC(), time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, job.ID, retried.ID)
+		assert.Equal(t, 1, retried.Attempts)
+		assert.NoError(t, q.Complete(ctx, retried))
+	})
+
+	t.Run("deleted urls complete the job", func(t *testing.T) {
+		url, err := CreateURL(CreateURLOptions{URL: "https://fetch-test.example.com/deleted"})
+		assert.NoError(t, err)
+		assert.NoError(t, DeleteURL(url))
+		assert.NoError(t, workFetchJobs(mockMetadataFetcher{}))
+	})
+
+	t.Run("archives a snapshot of the page", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte(`<html><body><p>Some text that is long enough to be archived.</p></body></html>`))
+		}))
+		defer srv.Close()
+
+		archiver := archive.New(t.TempDir())
+
+		url, err := CreateURL(CreateURLOptions{URL: srv.URL + "/archived"})
+		assert.NoError(t, err)
+
+		job, err := q.Claim(ctx, time.Now().UTC(), time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, url.URL, job.URL)
+
+		assert.NoError(t, FetchMetadataHandler(mockMetadataFetcher{}, archiver)(ctx, job))
+		assert.NoError(t, q.Complete(ctx, job))
+
+		url, err = GetURL(url.ID)
+		assert.NoError(t, err)
+		assert.False(t, url.ArchivedAt.IsZero())
+
+		text, err := archiver.ReadText(job.URLID)
+		assert.NoError(t, err)
+		assert.Equal(t, "Some text that is long enough to be archived.", text)
+	})
 }
 
 func TestGetURL(t *testing.T) {
@@ -43,7 +185,7 @@ func TestGetURL(t *testing.T) {
 		Tags: "search engine",
 	}
 
-	url, err := CreateURL(opts, mockMetadataFetcher{title: "Yahoo!"})
+	url, err := createAndFetchURL(opts, mockMetadataFetcher{title: "Yahoo!"})
 
 	assert.NoError(t, err)
 
@@ -66,3 +208,68 @@ func TestParseTags(t *testing.T) {
 		assert.Equal(t, parseTags(c.tagStr), c.tags)
 	}
 }
+
+const testPage = `<!doctype html>
+<html lang="en-US">
+<head>
+  <title> A page </title>
+  <meta name="description" content="Plain description">
+  <meta property="og:description" content="OpenGraph description">
+  <meta property="og:image" content="/images/preview.png">
+  <meta property="og:site_name" content="Example Site">
+  <meta name="author" content="Jane Doe">
+  <meta property="article:published_time" content="2020-11-20T09:30:00-08:00">
+  <link rel="canonical" href="https://example.com/a-page">
+  <link rel="shortcut icon" href="/static/favicon.png">
+  <link rel="alternate" type="application/json+oembed" href="/oembed?url=https%3A%2F%2Fexample.com%2Fa-page&amp;format=json">
+</head>
+<body></body>
+</html>`
+
+func TestHTTPMetadataFetcher(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/page":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte(testPage))
+		case "/bare":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte(`<html><head><meta property="og:title" content="OG title"></head></html>`))
+		case "/image.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte{0x89, 'P', 'N', 'G'})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+
+	pm, err := HTTPMetadataFetcher{}.FetchMetadata(ctx, srv.URL+"/page")
+	assert.NoError(t, err)
+	assert.Equal(t, 200, pm.Status)
+	assert.Equal(t, "text/html", pm.ContentType)
+	assert.Equal(t, "A page", pm.Title)
+	assert.Equal(t, "OpenGraph description", pm.Description)
+	assert.Equal(t, srv.URL+"/images/preview.png", pm.ImageURL)
+	assert.Equal(t, "Example Site", pm.SiteName)
+	assert.Equal(t, "Jane Doe", pm.Author)
+	assert.Equal(t, time.Date(2020, 11, 20, 17, 30, 0, 0, time.UTC), pm.PublishedAt)
+	assert.Equal(t, "https://example.com/a-page", pm.CanonicalURL)
+	assert.Equal(t, "en-US", pm.Language)
+	assert.Equal(t, srv.URL+"/static/favicon.png", pm.FaviconURL)
+	assert.Equal(t, srv.URL+"/oembed?url=https%3A%2F%2Fexample.com%2Fa-page&format=json", pm.OEmbedURL)
+
+	pm, err = HTTPMetadataFetcher{}.FetchMetadata(ctx, srv.URL+"/bare")
+	assert.NoError(t, err)
+	assert.Equal(t, "OG title", pm.Title)
+	assert.Equal(t, srv.URL+"/favicon.ico", pm.FaviconURL)
+	assert.Empty(t, pm.OEmbedURL)
+	assert.True(t, pm.PublishedAt.IsZero())
+
+	pm, err = HTTPMetadataFetcher{}.FetchMetadata(ctx, srv.URL+"/image.png")
+	assert.NoError(t, err)
+	assert.Equal(t, "image/png", pm.ContentType)
+	assert.Empty(t, pm.Title)
+}
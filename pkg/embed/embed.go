@@ -0,0 +1,268 @@
+// Package embed turns links to videos, photos and posts into HTML that shows
+// them in place. Links are matched against a list of providers, which either
+// know how to embed them from the link alone or ask the site's oEmbed
+// endpoint. Pages that advertise an oEmbed endpoint of their own are embedded
+// too. Whatever comes back from a site is sanitized, and oEmbed answers are
+// kept in a Cache so showing them doesn't mean asking again.
+package embed
+
+import (
+	"context"
+	"errors"
+	"html/template"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	DefaultTimeout = 10 * time.Second
+)
+
+// ErrNotCached is returned by a Cache that has nothing for a url.
+var ErrNotCached = errors.New("embed: not cached")
+
+// Type is what an embed shows, named like oEmbed's types.
+type Type string
+
+const (
+	TypePhoto Type = "photo"
+	TypeVideo Type = "video"
+	// TypeRich embeds are everything else, like posts, gists and audio
+	// players.
+	TypeRich Type = "rich"
+)
+
+// Embed is a link along with the HTML that shows it in a page.
+type Embed struct {
+	// URL is the link that's embedded.
+	URL          string `json:"url"`
+	Type         Type   `json:"type"`
+	Title        string `json:"title"`
+	ProviderName string `json:"provider_name"`
+	// HTML is sanitized before an Embed is returned by a Registry.
+	HTML         string    `json:"html"`
+	Width        int       `json:"width"`
+	Height       int       `json:"height"`
+	ThumbnailURL string    `json:"thumbnail_url"`
+	FetchedAt    time.Time `json:"fetched_at"`
+}
+
+// SafeHTML returns the HTML of e for use in templates.
+func (e *Embed) SafeHTML() template.HTML {
+	return template.HTML(e.HTML)
+}
+
+// Options are the kinds of embeds someone wants to see.
+type Options struct {
+	Photos bool
+	Videos bool
+}
+
+// Allows reports whether embeds of type t should be shown. Rich embeds
+// come along with videos since they're players and posts rather than
+// pictures.
+func (o Options) Allows(t Type) bool {
+	switch t {
+	case TypePhoto:
+		return o.Photos
+	case TypeVideo, TypeRich:
+		return o.Videos
+	}
+
+	return false
+}
+
+// Cache keeps the embeds found for saved urls.
+type Cache interface {
+	// Get returns the embed cached for the url with urlID, or ErrNotCached.
+	Get(ctx context.Context, urlID string) (*Embed, error)
+	// Put caches e for the url with urlID, replacing what was there.
+	Put(ctx context.Context, urlID string, e *Embed) error
+}
+
+type registryOptions struct {
+	providers []*Provider
+	cache     Cache
+	timeout   time.Duration
+	transport http.RoundTripper
+}
+
+type registryOptionFunc struct {
+	f func(*registryOptions)
+}
+
+func (r *registryOptionFunc) apply(opts *registryOptions) {
+	r.f(opts)
+}
+
+type RegistryOption interface {
+	apply(*registryOptions)
+}
+
+// WithProviders sets the providers links are matched against, replacing
+// the built-in ones.
+func WithProviders(providers ...*Provider) RegistryOption {
+	return &registryOptionFunc{
+		f: func(opts *registryOptions) {
+			opts.providers = providers
+		},
+	}
+}
+
+// WithCache sets where oEmbed answers are kept. Without one only the
+// providers that need nothing but the link can be embedded.
+func WithCache(c Cache) RegistryOption {
+	return &registryOptionFunc{
+		f: func(opts *registryOptions) {
+			opts.cache = c
+		},
+	}
+}
+
+// WithTimeout sets how long asking an oEmbed endpoint may take.
+func WithTimeout(d time.Duration) RegistryOption {
+	return &registryOptionFunc{
+		f: func(opts *registryOptions) {
+			opts.timeout = d
+		},
+	}
+}
+
+// WithTransport sets the http.RoundTripper used to ask oEmbed endpoints.
+func WithTransport(rt http.RoundTripper) RegistryOption {
+	return &registryOptionFunc{
+		f: func(opts *registryOptions) {
+			opts.transport = rt
+		},
+	}
+}
+
+// Registry finds the embeds of links.
+type Registry struct {
+	opts   registryOptions
+	client *http.Client
+}
+
+// Lookup returns the embed of the url with urlID and rawurl without going
+// over the network: either a provider makes it from the link or it was
+// cached by Resolve. It returns nil if there's none.
+func (r *Registry) Lookup(ctx context.Context, urlID, rawurl string) (*Embed, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, nil
+	}
+
+	if p, id := r.match(u); p != nil && p.HTML != nil {
+		if html := p.HTML(u, id); html != "" {
+			return newEmbed(rawurl, p.Type, p.Name, html), nil
+		}
+	}
+
+	if r.opts.cache == nil {
+		return nil, nil
+	}
+
+	e, err := r.opts.cache.Get(ctx, urlID)
+	if err != nil {
+		if errors.Is(err, ErrNotCached) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// Resolve finds the embed of the url with urlID and rawurl, asking oEmbed
+// endpoints if it has to, and caches it. discovered is the oEmbed endpoint
+// the page of the url advertised, if any, and is used when no provider
+// matches. It returns nil if the url can't be embedded.
+func (r *Registry) Resolve(ctx context.Context, urlID, rawurl, discovered string) (*Embed, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, nil
+	}
+
+	var endpoint string
+
+	if p, id := r.match(u); p != nil {
+		if p.HTML != nil {
+			if html := p.HTML(u, id); html != "" {
+				return newEmbed(rawurl, p.Type, p.Name, html), nil
+			}
+		}
+
+		if p.Endpoint == "" {
+			return nil, nil
+		}
+
+		endpoint, err = endpointURL(p.Endpoint, rawurl)
+		if err != nil {
+			return nil, err
+		}
+	} else if discovered != "" {
+		endpoint = discovered
+	} else {
+		return nil, nil
+	}
+
+	e, err := r.fetchOEmbed(ctx, endpoint, rawurl)
+	if err != nil || e == nil {
+		return nil, err
+	}
+
+	if r.opts.cache != nil {
+		if err := r.opts.cache.Put(ctx, urlID, e); err != nil {
+			return nil, err
+		}
+	}
+
+	return e, nil
+}
+
+// match returns the first provider that embeds u, along with the id of what
+// u links to.
+func (r *Registry) match(u *url.URL) (*Provider, string) {
+	for _, p := range r.opts.providers {
+		if id := p.match(u); id != "" {
+			return p, id
+		}
+	}
+
+	return nil, ""
+}
+
+// newEmbed returns the embed of a link made by a provider without asking
+// anyone.
+func newEmbed(rawurl string, t Type, provider, html string) *Embed {
+	return &Embed{
+		URL:          rawurl,
+		Type:         t,
+		ProviderName: provider,
+		HTML:         Sanitize(html),
+		FetchedAt:    time.Now().UTC(),
+	}
+}
+
+// New returns a Registry with the built-in providers.
+func New(opts ...RegistryOption) *Registry {
+	ro := registryOptions{
+		providers: Providers(),
+		timeout:   DefaultTimeout,
+		transport: http.DefaultTransport,
+	}
+
+	for _, opt := range opts {
+		opt.apply(&ro)
+	}
+
+	return &Registry{
+		opts: ro,
+		client: &http.Client{
+			Transport: ro.transport,
+			Timeout:   ro.timeout,
+		},
+	}
+}
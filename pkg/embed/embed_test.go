@@ -0,0 +1,295 @@
+package embed
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type memCache struct {
+	mu     sync.Mutex
+	embeds map[string]*Embed
+}
+
+func (c *memCache) Get(ctx context.Context, urlID string) (*Embed, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.embeds[urlID]
+	if !ok {
+		return nil, ErrNotCached
+	}
+
+	return e, nil
+}
+
+func (c *memCache) Put(ctx context.Context, urlID string, e *Embed) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.embeds[urlID] = e
+
+	return nil
+}
+
+func TestProviders(t *testing.T) {
+	tests := []struct {
+		link     string
+		provider string
+		id       string
+	}{
+		{"https://www.youtube.com/watch?v=dQw4w9WgXcQ", "YouTube", "dQw4w9WgXcQ"},
+		{"https://m.youtube.com/watch?feature=share&v=dQw4w9WgXcQ&t=42", "YouTube", "dQw4w9WgXcQ"},
+		{"https://youtu.be/dQw4w9WgXcQ", "YouTube", "dQw4w9WgXcQ"},
+		{"https://www.youtube.com/shorts/dQw4w9WgXcQ", "YouTube", "dQw4w9WgXcQ"},
+		{"https://www.youtube.com/watch?list=PL123", "", ""},
+		{"https://www.youtube.com/watch", "", ""},
+		{"https://www.youtube.com/channel/UC123", "", ""},
+		{"https://vimeo.com/76979871", "Vimeo", "76979871"},
+		{"https://vimeo.com/channels/staffpicks/76979871", "Vimeo", "76979871"},
+		{"https://player.vimeo.com/video/76979871", "Vimeo", "76979871"},
+		{"https://vimeo.com/about", "", ""},
+		{"https://tube.example.org/videos/watch/9c9de5e8-0a1e-484a-b099-e80766180a6d", "PeerTube", "9c9de5e8-0a1e-484a-b099-e80766180a6d"},
+		{"https://tube.example.org/w/kkGMgK9ZtnKfYAgnEtQxbv", "PeerTube", "kkGMgK9ZtnKfYAgnEtQxbv"},
+		{"http://tube.example.org/w/kkGMgK9ZtnKfYAgnEtQxbv", "", ""},
+		{"https://en.wikipedia.org/w/index.php", "", ""},
+		{"https://soundcloud.com/artist/a-track", "SoundCloud", "artist/a-track"},
+		{"https://soundcloud.com/discover", "", ""},
+		{"https://i.imgur.com/FyCch.png", "Imgur", "FyCch.png"},
+		{"https://imgur.com/FyCch", "Imgur", "FyCch.jpg"},
+		{"https://i.imgur.com/FyCch.gifv", "Imgur", "FyCch.gif"},
+		{"https://imgur.com/gallery/FyCch", "Imgur", "gallery/FyCch"},
+		{"https://imgur.com/a/FyCch", "Imgur", "a/FyCch"},
+		{"https://imgur.com/upload", "", ""},
+		{"https://gist.github.com/someone/4d8d9e5f8f1b2c3a", "GitHub Gist", "someone/4d8d9e5f8f1b2c3a"},
+		{"https://gist.github.com/someone", "", ""},
+		{"https://twitter.com/someone/status/1331000000000000000", "Twitter", "someone/status/1331000000000000000"},
+		{"https://x.com/someone/status/1331000000000000000", "Twitter", "someone/status/1331000000000000000"},
+		{"https://twitter.com/someone", "", ""},
+		{"https://example.com/", "", ""},
+		{"ftp://youtu.be/dQw4w9WgXcQ", "", ""},
+	}
+
+	r := New()
+
+	for _, tt := range tests {
+		t.Run(tt.link, func(t *testing.T) {
+			u, err := url.Parse(tt.link)
+			require.NoError(t, err)
+
+			p, id := r.match(u)
+			if tt.provider == "" {
+				require.Nil(t, p)
+
+				return
+			}
+
+			require.NotNil(t, p)
+			require.Equal(t, tt.provider, p.Name)
+			require.Equal(t, tt.id, id)
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	ctx := context.Background()
+	cache := &memCache{embeds: map[string]*Embed{}}
+	r := New(WithCache(cache))
+
+	t.Run("makes embeds from links", func(t *testing.T) {
+		e, err := r.Lookup(ctx, "u1", "https://youtu.be/dQw4w9WgXcQ?t=90")
+		require.NoError(t, err)
+		require.NotNil(t, e)
+		require.Equal(t, TypeVideo, e.Type)
+		require.Equal(t, "YouTube", e.ProviderName)
+		require.Contains(t, e.HTML, `src="https://www.youtube.com/embed/dQw4w9WgXcQ?start=90"`)
+		require.Contains(t, e.HTML, `sandbox="`)
+
+		e, err = r.Lookup(ctx, "u2", "https://i.imgur.com/FyCch.png")
+		require.NoError(t, err)
+		require.Equal(t, TypePhoto, e.Type)
+		require.Contains(t, e.HTML, `<img src="https://i.imgur.com/FyCch.png"`)
+	})
+
+	t.Run("uses the cache for everything else", func(t *testing.T) {
+		e, err := r.Lookup(ctx, "u3", "https://soundcloud.com/artist/a-track")
+		require.NoError(t, err)
+		require.Nil(t, e)
+
+		cached := &Embed{URL: "https://soundcloud.com/artist/a-track", Type: TypeRich, HTML: "<p>cached</p>"}
+		require.NoError(t, cache.Put(ctx, "u3", cached))
+
+		e, err = r.Lookup(ctx, "u3", "https://soundcloud.com/artist/a-track")
+		require.NoError(t, err)
+		require.Equal(t, cached, e)
+	})
+
+	t.Run("doesn't panic on watch pages without a video", func(t *testing.T) {
+		e, err := r.Lookup(ctx, "u4", "https://www.youtube.com/watch?list=PL123")
+		require.NoError(t, err)
+		require.Nil(t, e)
+	})
+}
+
+func TestResolve(t *testing.T) {
+	ctx := context.Background()
+
+	var asked url.Values
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		asked = r.URL.Query()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/oembed":
+			w.Write([]byte(`{
+				"type": "rich",
+				"version": "1.0",
+				"title": "A track",
+				"provider_name": "Sounds",
+				"width": "100%",
+				"height": 166,
+				"html": "<iframe src=\"https://w.sounds.example/player\" onload=\"steal()\"></iframe><script src=\"https://evil.example/x.js\"></script>"
+			}`))
+		case "/photo":
+			w.Write([]byte(`{"type": "photo", "title": "A <cat>", "url": "https://img.example/cat.jpg", "width": 640, "height": 480}`))
+		case "/link":
+			w.Write([]byte(`{"type": "link", "title": "Just a link"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	sounds := &Provider{
+		Name:     "Sounds",
+		Type:     TypeRich,
+		Hosts:    []string{"sounds.example"},
+		Match:    submatch(soundcloudRE),
+		Endpoint: srv.URL + "/oembed?maxheight=166",
+	}
+
+	cache := &memCache{embeds: map[string]*Embed{}}
+	r := New(WithCache(cache), WithProviders(sounds))
+
+	t.Run("asks the endpoint of providers", func(t *testing.T) {
+		e, err := r.Resolve(ctx, "u1", "https://www.sounds.example/artist/a-track", "")
+		require.NoError(t, err)
+		require.NotNil(t, e)
+		require.Equal(t, "https://www.sounds.example/artist/a-track", asked.Get("url"))
+		require.Equal(t, "json", asked.Get("format"))
+		require.Equal(t, "166", asked.Get("maxheight"))
+
+		require.Equal(t, TypeRich, e.Type)
+		require.Equal(t, "A track", e.Title)
+		require.Equal(t, 0, e.Width)
+		require.Equal(t, 166, e.Height)
+		require.Contains(t, e.HTML, `src="https://w.sounds.example/player"`)
+		require.NotContains(t, e.HTML, "onload")
+		require.NotContains(t, e.HTML, "<script")
+
+		cached, err := cache.Get(ctx, "u1")
+		require.NoError(t, err)
+		require.Equal(t, e, cached)
+
+		looked, err := r.Lookup(ctx, "u1", "https://www.sounds.example/artist/a-track")
+		require.NoError(t, err)
+		require.Equal(t, e, looked)
+	})
+
+	t.Run("asks discovered endpoints", func(t *testing.T) {
+		e, err := r.Resolve(ctx, "u2", "https://cats.example/1", srv.URL+"/photo?url=https%3A%2F%2Fcats.example%2F1")
+		require.NoError(t, err)
+		require.NotNil(t, e)
+		require.Equal(t, TypePhoto, e.Type)
+		require.Equal(t, `<img src="https://img.example/cat.jpg" alt="A &lt;cat&gt;" loading="lazy" referrerpolicy="no-referrer"/>`, e.HTML)
+	})
+
+	t.Run("skips answers with nothing to show", func(t *testing.T) {
+		e, err := r.Resolve(ctx, "u3", "https://links.example/1", srv.URL+"/link")
+		require.NoError(t, err)
+		require.Nil(t, e)
+
+		e, err = r.Resolve(ctx, "u3", "https://links.example/1", srv.URL+"/missing")
+		require.NoError(t, err)
+		require.Nil(t, e)
+
+		e, err = r.Resolve(ctx, "u3", "https://links.example/1", "")
+		require.NoError(t, err)
+		require.Nil(t, e)
+
+		_, err = cache.Get(ctx, "u3")
+		require.True(t, errors.Is(err, ErrNotCached))
+	})
+
+	t.Run("refuses endpoints that aren't http", func(t *testing.T) {
+		_, err := r.Resolve(ctx, "u4", "https://links.example/1", "file:///etc/passwd")
+		require.Error(t, err)
+	})
+}
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		out  string
+	}{
+		{
+			name: "keeps formatting and links",
+			in:   `<blockquote class="tweet"><p>hi <a href="https://t.co/x" onclick="x()">there</a></p>&mdash; someone</blockquote>`,
+			out:  `<blockquote><p>hi <a href="https://t.co/x" rel="nofollow noopener noreferrer" target="_blank">there</a></p>— someone</blockquote>`,
+		},
+		{
+			name: "removes scripts and styles with what's in them",
+			in:   `<p>a</p><script>alert(1)</script><style>p{}</style><noscript><img src="https://x/y"></noscript>`,
+			out:  `<p>a</p>`,
+		},
+		{
+			name: "unwraps unknown elements",
+			in:   `<section><h1>title</h1><marquee>text</marquee></section>`,
+			out:  `titletext`,
+		},
+		{
+			name: "drops unsafe urls",
+			in:   `<a href="javascript:alert(1)">x</a><img src="data:image/png;base64,AA" alt=""><img src="/relative.png">`,
+			out:  `<a rel="nofollow noopener noreferrer" target="_blank">x</a>`,
+		},
+		{
+			name: "only frames https pages",
+			in:   `<iframe src="http://player.example/1"></iframe><iframe src="//player.example/2" width="100%" height="big" allow="autoplay; camera; fullscreen 'self'" allowfullscreen></iframe>`,
+			out:  `<iframe src="https://player.example/2" width="100%" allow="autoplay; fullscreen" allowfullscreen="" loading="lazy" referrerpolicy="no-referrer" sandbox="` + iframeSandbox + `"></iframe>`,
+		},
+		{
+			name: "removes comments",
+			in:   `<!-- hi --><p>x</p>`,
+			out:  `<p>x</p>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.out, Sanitize(tt.in))
+		})
+	}
+}
+
+func TestOptionsAllows(t *testing.T) {
+	photos := Options{Photos: true}
+	require.True(t, photos.Allows(TypePhoto))
+	require.False(t, photos.Allows(TypeVideo))
+	require.False(t, photos.Allows(TypeRich))
+
+	videos := Options{Videos: true}
+	require.False(t, videos.Allows(TypePhoto))
+	require.True(t, videos.Allows(TypeVideo))
+	require.True(t, videos.Allows(TypeRich))
+
+	require.False(t, Options{Photos: true, Videos: true}.Allows(Type("link")))
+}
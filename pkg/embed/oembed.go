@@ -0,0 +1,134 @@
+package embed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kyleterry/sufr/pkg/config"
+)
+
+// maxResponseBytes is how much of an oEmbed answer is read.
+const maxResponseBytes = 1 << 20
+
+// oembedResponse is the part of an oEmbed answer that's used.
+// https://oembed.com/#section2.3
+type oembedResponse struct {
+	Type         string    `json:"type"`
+	Title        string    `json:"title"`
+	ProviderName string    `json:"provider_name"`
+	HTML         string    `json:"html"`
+	URL          string    `json:"url"`
+	Width        dimension `json:"width"`
+	Height       dimension `json:"height"`
+	ThumbnailURL string    `json:"thumbnail_url"`
+}
+
+// dimension is a width or height in pixels. Providers send them as numbers,
+// strings or null, so anything that isn't a whole number is 0.
+type dimension int
+
+func (d *dimension) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), `"`)
+
+	if f, err := strconv.ParseFloat(s, 64); err == nil && f > 0 {
+		*d = dimension(f)
+	}
+
+	return nil
+}
+
+// endpointURL returns the url that asks endpoint about link, keeping the
+// query parameters endpoint already has.
+func endpointURL(endpoint, link string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid oembed endpoint %q: %w", endpoint, err)
+	}
+
+	q := u.Query()
+	q.Set("url", link)
+	q.Set("format", "json")
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// fetchOEmbed asks endpoint for the embed of link. It returns nil if the
+// endpoint has no embed for it.
+func (r *Registry) fetchOEmbed(ctx context.Context, endpoint, link string) (*Embed, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("invalid oembed endpoint %q", endpoint)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", config.SUFRUserAgent)
+	req.Header.Set("Accept", "application/json")
+
+	res, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to ask oembed endpoint: %w", err)
+	}
+
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotImplemented:
+		// the link is missing, private or can't be embedded
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("oembed endpoint answered %s", res.Status)
+	}
+
+	var or oembedResponse
+
+	if err := json.NewDecoder(io.LimitReader(res.Body, maxResponseBytes)).Decode(&or); err != nil {
+		return nil, fmt.Errorf("failed to decode oembed answer: %w", err)
+	}
+
+	e := &Embed{
+		URL:          link,
+		Type:         Type(or.Type),
+		Title:        or.Title,
+		ProviderName: or.ProviderName,
+		Width:        int(or.Width),
+		Height:       int(or.Height),
+		ThumbnailURL: safeURL(or.ThumbnailURL, false),
+		FetchedAt:    time.Now().UTC(),
+	}
+
+	switch e.Type {
+	case TypePhoto:
+		src := safeURL(or.URL, false)
+		if src == "" {
+			return nil, nil
+		}
+
+		e.HTML = fmt.Sprintf(`<img src="%s" alt="%s">`, html.EscapeString(src), html.EscapeString(or.Title))
+	case TypeVideo, TypeRich:
+		e.HTML = or.HTML
+	default:
+		// link answers have nothing to show
+		return nil, nil
+	}
+
+	e.HTML = Sanitize(e.HTML)
+	if e.HTML == "" {
+		return nil, nil
+	}
+
+	return e, nil
+}
@@ -0,0 +1,257 @@
+package embed
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Provider embeds the links of one site.
+type Provider struct {
+	Name string
+	// Type is what the embeds made by HTML show.
+	Type Type
+	// Hosts are where the provider's links are. A leading www. is ignored
+	// on links. Providers without hosts are tried on links to any host.
+	Hosts []string
+	// Match returns the id of what u links to, or "" if the provider doesn't
+	// embed u.
+	Match func(u *url.URL) string
+	// HTML makes the embed of u, which links to id, from the link alone.
+	// When HTML is nil or returns "", Endpoint is asked instead.
+	HTML func(u *url.URL, id string) string
+	// Endpoint is the provider's oEmbed endpoint. Query parameters on it
+	// are sent along with the link.
+	Endpoint string
+}
+
+func (p *Provider) match(u *url.URL) string {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return ""
+	}
+
+	if len(p.Hosts) > 0 {
+		host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+		found := false
+
+		for _, h := range p.Hosts {
+			if host == h {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return ""
+		}
+	}
+
+	return p.Match(u)
+}
+
+// Providers returns the built-in providers.
+func Providers() []*Provider {
+	return []*Provider{
+		{
+			Name:  "YouTube",
+			Type:  TypeVideo,
+			Hosts: []string{"youtube.com", "m.youtube.com", "music.youtube.com", "youtube-nocookie.com", "youtu.be"},
+			Match: youtubeID,
+			HTML: func(u *url.URL, id string) string {
+				src := "https://www.youtube.com/embed/" + id
+
+				if start := youtubeStart(u); start > 0 {
+					src += "?start=" + strconv.Itoa(start)
+				}
+
+				return iframe(src, "560", "315")
+			},
+		},
+		{
+			Name:  "Vimeo",
+			Type:  TypeVideo,
+			Hosts: []string{"vimeo.com", "player.vimeo.com"},
+			Match: submatch(vimeoRE),
+			HTML: func(u *url.URL, id string) string {
+				return iframe("https://player.vimeo.com/video/"+id, "640", "360")
+			},
+		},
+		{
+			// PeerTube instances can be anywhere, so any https link that
+			// looks like one of their videos is tried.
+			Name:  "PeerTube",
+			Type:  TypeVideo,
+			Match: peertubeID,
+			HTML: func(u *url.URL, id string) string {
+				return iframe("https://"+u.Host+"/videos/embed/"+id, "560", "315")
+			},
+		},
+		{
+			Name:     "SoundCloud",
+			Type:     TypeRich,
+			Hosts:    []string{"soundcloud.com", "m.soundcloud.com"},
+			Match:    submatch(soundcloudRE),
+			Endpoint: "https://soundcloud.com/oembed?maxheight=166",
+		},
+		{
+			// albums and galleries only have an oEmbed answer, single
+			// images are linked to directly.
+			Name:  "Imgur",
+			Type:  TypePhoto,
+			Hosts: []string{"imgur.com", "i.imgur.com", "m.imgur.com"},
+			Match: imgurID,
+			HTML: func(u *url.URL, id string) string {
+				if strings.Contains(id, "/") {
+					return ""
+				}
+
+				return fmt.Sprintf(`<img src="https://i.imgur.com/%s" alt="">`, html.EscapeString(id))
+			},
+			Endpoint: "https://api.imgur.com/oembed.json",
+		},
+		{
+			// gists are embedded with a script, so their plain html page
+			// is framed instead.
+			Name:  "GitHub Gist",
+			Type:  TypeRich,
+			Hosts: []string{"gist.github.com"},
+			Match: submatch(gistRE),
+			HTML: func(u *url.URL, id string) string {
+				return iframe("https://gist.github.com/"+id+".pibb", "100%", "300")
+			},
+		},
+		{
+			// the script that turns tweets into cards is sanitized away, so
+			// they're shown as the quote it starts from.
+			Name:     "Twitter",
+			Type:     TypeRich,
+			Hosts:    []string{"twitter.com", "mobile.twitter.com", "x.com"},
+			Match:    submatch(tweetRE),
+			Endpoint: "https://publish.twitter.com/oembed?omit_script=true&dnt=true",
+		},
+	}
+}
+
+var (
+	youtubeIDRE  = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+	vimeoRE      = regexp.MustCompile(`^/(?:video/|channels/[^/]+/|groups/[^/]+/videos/)?(\d+)/?$`)
+	peertubeRE   = regexp.MustCompile(`^/(?:videos/watch|videos/embed|w)/([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}|[1-9A-HJ-NP-Za-km-z]{22})/?$`)
+	soundcloudRE = regexp.MustCompile(`^/([^/]+/[^/]+(?:/[^/]+)?)/?$`)
+	gistRE       = regexp.MustCompile(`^/([^/]+/[0-9a-f]+)/?$`)
+	tweetRE      = regexp.MustCompile(`^/([^/]+/status(?:es)?/\d+)/?$`)
+	// imgur ids are a handful of letters and digits. Requiring a digit or a
+	// capital keeps pages like /upload from being taken for images.
+	imgurRE      = regexp.MustCompile(`^/(?:(a|gallery)/)?([a-zA-Z0-9]{5,8})(\.[a-z0-9]+)?/?$`)
+	imgurMixedRE = regexp.MustCompile(`[A-Z0-9]`)
+)
+
+// submatch returns a Match func that matches the path of links with re,
+// whose first group is the id.
+func submatch(re *regexp.Regexp) func(u *url.URL) string {
+	return func(u *url.URL) string {
+		m := re.FindStringSubmatch(u.EscapedPath())
+		if m == nil {
+			return ""
+		}
+
+		return m[1]
+	}
+}
+
+// youtubeID returns the id of the video u links to, from watch pages,
+// shorts, youtu.be links and the like.
+func youtubeID(u *url.URL) string {
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	var id string
+
+	switch {
+	case strings.EqualFold(u.Hostname(), "youtu.be"):
+		id = parts[0]
+	case len(parts) == 1 && parts[0] == "watch":
+		id = u.Query().Get("v")
+	case len(parts) == 2 && (parts[0] == "shorts" || parts[0] == "embed" || parts[0] == "live" || parts[0] == "v"):
+		id = parts[1]
+	}
+
+	if !youtubeIDRE.MatchString(id) {
+		return ""
+	}
+
+	return id
+}
+
+// youtubeStart returns the second a YouTube link starts playing at, or 0.
+// Only plain seconds are understood, like t=90 or t=90s.
+func youtubeStart(u *url.URL) int {
+	q := u.Query()
+
+	t := q.Get("t")
+	if t == "" {
+		t = q.Get("start")
+	}
+
+	n, err := strconv.Atoi(strings.TrimSuffix(t, "s"))
+	if err != nil || n < 0 {
+		return 0
+	}
+
+	return n
+}
+
+func peertubeID(u *url.URL) string {
+	if u.Scheme != "https" {
+		return ""
+	}
+
+	m := peertubeRE.FindStringSubmatch(u.Path)
+	if m == nil {
+		return ""
+	}
+
+	return m[1]
+}
+
+// imgurID returns the image u links to with its extension, like FyCch.jpg,
+// or the album or gallery with its kind, like a/FyCch.
+func imgurID(u *url.URL) string {
+	m := imgurRE.FindStringSubmatch(u.Path)
+	if m == nil || !imgurMixedRE.MatchString(m[2]) {
+		return ""
+	}
+
+	kind, id, ext := m[1], m[2], m[3]
+
+	if kind != "" {
+		return kind + "/" + id
+	}
+
+	switch ext {
+	case "", ".jpg", ".jpeg", ".png", ".gif", ".webp":
+	case ".gifv", ".mp4":
+		// animations have a gif of themselves
+		ext = ".gif"
+	default:
+		return ""
+	}
+
+	if ext == "" {
+		// imgur answers with the image whatever extension is asked for
+		ext = ".jpg"
+	}
+
+	return id + ext
+}
+
+// iframe returns an iframe of src. width and height are attribute values,
+// like 560 or 100%.
+func iframe(src, width, height string) string {
+	return fmt.Sprintf(
+		`<iframe src="%s" width="%s" height="%s" frameborder="0" allow="autoplay; encrypted-media; fullscreen; picture-in-picture" allowfullscreen></iframe>`,
+		html.EscapeString(src), width, height,
+	)
+}
@@ -0,0 +1,260 @@
+package embed
+
+import (
+	"bytes"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// allowedAttrs are the elements kept by Sanitize along with the attributes
+// they keep. Elements that aren't here are replaced by what's in them.
+var allowedAttrs = map[atom.Atom][]string{
+	atom.A:          {"href", "title"},
+	atom.B:          nil,
+	atom.Blockquote: {"cite"},
+	atom.Br:         nil,
+	atom.Code:       nil,
+	atom.Div:        nil,
+	atom.Em:         nil,
+	atom.Figcaption: nil,
+	atom.Figure:     nil,
+	atom.I:          nil,
+	atom.Iframe:     {"src", "width", "height", "title", "allow", "allowfullscreen", "frameborder"},
+	atom.Img:        {"src", "alt", "width", "height", "title"},
+	atom.Li:         nil,
+	atom.Ol:         nil,
+	atom.P:          nil,
+	atom.Pre:        nil,
+	atom.Small:      nil,
+	atom.Span:       nil,
+	atom.Strong:     nil,
+	atom.Ul:         nil,
+}
+
+// droppedElements are removed along with everything in them.
+var droppedElements = map[atom.Atom]bool{
+	atom.Applet:   true,
+	atom.Base:     true,
+	atom.Button:   true,
+	atom.Embed:    true,
+	atom.Form:     true,
+	atom.Frame:    true,
+	atom.Frameset: true,
+	atom.Head:     true,
+	atom.Input:    true,
+	atom.Link:     true,
+	atom.Math:     true,
+	atom.Meta:     true,
+	atom.Noscript: true,
+	atom.Object:   true,
+	atom.Script:   true,
+	atom.Select:   true,
+	atom.Style:    true,
+	atom.Svg:      true,
+	atom.Template: true,
+	atom.Textarea: true,
+	atom.Title:    true,
+}
+
+// allowedFeatures are the iframe permissions players are given.
+var allowedFeatures = map[string]bool{
+	"autoplay":           true,
+	"clipboard-write":    true,
+	"encrypted-media":    true,
+	"fullscreen":         true,
+	"picture-in-picture": true,
+}
+
+var sizeRE = regexp.MustCompile(`^\d{1,4}%?$`)
+
+// iframeSandbox lets players run and open links, but keeps them from
+// navigating the page they're on.
+const iframeSandbox = "allow-scripts allow-same-origin allow-popups allow-popups-to-escape-sandbox allow-presentation"
+
+// Sanitize returns the parts of the HTML fragment s that are safe to show in
+// a page: text, simple formatting, links, images and iframes of https pages.
+// Scripts, styles, forms, event handlers and everything else are removed.
+// Frames are sandboxed and everything is loaded lazily without a referrer.
+func Sanitize(s string) string {
+	ctx := &html.Node{Type: html.ElementNode, Data: "div", DataAtom: atom.Div}
+
+	nodes, err := html.ParseFragment(strings.NewReader(s), ctx)
+	if err != nil {
+		return ""
+	}
+
+	for _, n := range nodes {
+		ctx.AppendChild(n)
+	}
+
+	sanitizeChildren(ctx)
+
+	var b bytes.Buffer
+
+	for n := ctx.FirstChild; n != nil; n = n.NextSibling {
+		if err := html.Render(&b, n); err != nil {
+			return ""
+		}
+	}
+
+	return strings.TrimSpace(b.String())
+}
+
+func sanitizeChildren(parent *html.Node) {
+	for n := parent.FirstChild; n != nil; {
+		next := n.NextSibling
+
+		switch n.Type {
+		case html.TextNode:
+		case html.ElementNode:
+			attrs, ok := allowedAttrs[n.DataAtom]
+
+			switch {
+			case droppedElements[n.DataAtom]:
+				parent.RemoveChild(n)
+			case !ok || !sanitizeElement(n, attrs):
+				// keep what's inside where the element was
+				sanitizeChildren(n)
+
+				for c := n.FirstChild; c != nil; c = n.FirstChild {
+					n.RemoveChild(c)
+					parent.InsertBefore(c, n)
+				}
+
+				parent.RemoveChild(n)
+			default:
+				sanitizeChildren(n)
+			}
+		default:
+			parent.RemoveChild(n)
+		}
+
+		n = next
+	}
+}
+
+// sanitizeElement keeps the attributes of n that are allowed and safe. It
+// returns false if n can't be kept at all, like an image without a source.
+func sanitizeElement(n *html.Node, allowed []string) bool {
+	var attrs []html.Attribute
+
+	for _, a := range n.Attr {
+		if a.Namespace != "" || !contains(allowed, a.Key) {
+			continue
+		}
+
+		switch a.Key {
+		case "href", "cite":
+			a.Val = safeURL(a.Val, false)
+		case "src":
+			// frames of plain http pages would be blocked as mixed content
+			a.Val = safeURL(a.Val, n.DataAtom == atom.Iframe)
+		case "width", "height":
+			if !sizeRE.MatchString(a.Val) {
+				a.Val = ""
+			}
+		case "allow":
+			a.Val = allowFeatures(a.Val)
+		}
+
+		// empty alts mark images as decoration
+		if a.Val == "" && a.Key != "allowfullscreen" && a.Key != "alt" {
+			continue
+		}
+
+		attrs = append(attrs, a)
+	}
+
+	n.Attr = attrs
+
+	switch n.DataAtom {
+	case atom.A:
+		n.Attr = append(n.Attr,
+			html.Attribute{Key: "rel", Val: "nofollow noopener noreferrer"},
+			html.Attribute{Key: "target", Val: "_blank"},
+		)
+	case atom.Img, atom.Iframe:
+		if attr(n, "src") == "" {
+			return false
+		}
+
+		n.Attr = append(n.Attr,
+			html.Attribute{Key: "loading", Val: "lazy"},
+			html.Attribute{Key: "referrerpolicy", Val: "no-referrer"},
+		)
+
+		if n.DataAtom == atom.Iframe {
+			n.Attr = append(n.Attr, html.Attribute{Key: "sandbox", Val: iframeSandbox})
+		}
+	}
+
+	return true
+}
+
+// safeURL returns rawurl if it's an absolute http or https url, or only
+// https if httpsOnly is set, and "" otherwise. Protocol relative urls are
+// taken to be https.
+func safeURL(rawurl string, httpsOnly bool) string {
+	rawurl = strings.TrimSpace(rawurl)
+	if strings.HasPrefix(rawurl, "//") {
+		rawurl = "https:" + rawurl
+	}
+
+	u, err := url.Parse(rawurl)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+
+	switch u.Scheme {
+	case "https":
+	case "http":
+		if httpsOnly {
+			return ""
+		}
+	default:
+		return ""
+	}
+
+	return u.String()
+}
+
+// allowFeatures returns the permissions policy of an iframe with only the
+// allowed features left.
+func allowFeatures(policy string) string {
+	var kept []string
+
+	for _, f := range strings.Split(policy, ";") {
+		f = strings.TrimSpace(f)
+
+		// features can name the origins they're allowed for after a space
+		if name := strings.Fields(f); len(name) > 0 && allowedFeatures[strings.ToLower(name[0])] {
+			kept = append(kept, strings.ToLower(name[0]))
+		}
+	}
+
+	return strings.Join(kept, "; ")
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+
+	return ""
+}
+
+func contains(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
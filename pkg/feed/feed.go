@@ -0,0 +1,279 @@
+// Package feed writes Atom and RSS 2.0 feeds.
+package feed
+
+import (
+	"encoding/xml"
+	"html"
+	"io"
+	"time"
+)
+
+// Generator is the name feeds say they were made by.
+const Generator = "SUFR"
+
+// Feed is a list of entries along with what it's about.
+type Feed struct {
+	// ID is the permanent URL of the feed itself.
+	ID    string
+	Title string
+	// Link is the URL of the page the feed is a copy of.
+	Link string
+	// Description is a sentence about the feed. RSS requires one, so Title
+	// is used when it's empty.
+	Description string
+	// AuthorName and AuthorURI are who the entries are by.
+	AuthorName string
+	AuthorURI  string
+	// Updated is when the feed last changed. Entries that changed later
+	// move it up.
+	Updated time.Time
+	Entries []*Entry
+}
+
+// Entry is a single item in a Feed.
+type Entry struct {
+	// ID is a URL that stays the same for the life of the entry.
+	ID    string
+	Title string
+	// Link is the URL the entry is about.
+	Link string
+	// Summary is plain text and Content is HTML.
+	Summary    string
+	Content    string
+	Categories []string
+	Published  time.Time
+	Updated    time.Time
+}
+
+// LastUpdated returns when f or any of its entries last changed.
+func (f *Feed) LastUpdated() time.Time {
+	t := f.Updated
+
+	for _, e := range f.Entries {
+		if e.Updated.After(t) {
+			t = e.Updated
+		}
+
+		if e.Published.After(t) {
+			t = e.Published
+		}
+	}
+
+	return t
+}
+
+type atomFeed struct {
+	XMLName   xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Subtitle  string      `xml:"subtitle,omitempty"`
+	Updated   string      `xml:"updated"`
+	Links     []atomLink  `xml:"link"`
+	Author    *atomAuthor `xml:"author,omitempty"`
+	Generator string      `xml:"generator"`
+	Entries   []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri,omitempty"`
+}
+
+type atomText struct {
+	Type string `xml:"type,attr,omitempty"`
+	Body string `xml:",chardata"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Link       atomLink       `xml:"link"`
+	Published  string         `xml:"published,omitempty"`
+	Updated    string         `xml:"updated"`
+	Categories []atomCategory `xml:"category"`
+	Summary    *atomText      `xml:"summary,omitempty"`
+	Content    *atomText      `xml:"content,omitempty"`
+}
+
+// WriteAtom writes f to w as an Atom feed.
+func WriteAtom(w io.Writer, f *Feed) error {
+	af := atomFeed{
+		ID:        f.ID,
+		Title:     f.Title,
+		Subtitle:  f.Description,
+		Updated:   atomTime(f.LastUpdated()),
+		Generator: Generator,
+		Links: []atomLink{
+			{Rel: "self", Type: "application/atom+xml", Href: f.ID},
+		},
+	}
+
+	if f.Link != "" {
+		af.Links = append(af.Links, atomLink{Rel: "alternate", Type: "text/html", Href: f.Link})
+	}
+
+	// atom needs an author on the feed or on every entry
+	af.Author = &atomAuthor{Name: f.AuthorName, URI: f.AuthorURI}
+	if af.Author.Name == "" {
+		af.Author.Name = f.Title
+	}
+
+	for _, e := range f.Entries {
+		ae := atomEntry{
+			ID:      e.ID,
+			Title:   e.Title,
+			Link:    atomLink{Rel: "alternate", Href: e.Link},
+			Updated: atomTime(e.Updated),
+		}
+
+		if !e.Published.IsZero() {
+			ae.Published = atomTime(e.Published)
+		}
+
+		if e.Updated.IsZero() {
+			ae.Updated = atomTime(e.Published)
+		}
+
+		for _, c := range e.Categories {
+			ae.Categories = append(ae.Categories, atomCategory{Term: c})
+		}
+
+		if e.Summary != "" {
+			ae.Summary = &atomText{Type: "text", Body: e.Summary}
+		}
+
+		if e.Content != "" {
+			ae.Content = &atomText{Type: "html", Body: e.Content}
+		}
+
+		af.Entries = append(af.Entries, ae)
+	}
+
+	return writeXML(w, af)
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Atom    string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Generator     string    `xml:"generator"`
+	Self          rssSelf   `xml:"atom:link"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssSelf struct {
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+	Href string `xml:"href,attr"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        rssGUID  `xml:"guid"`
+	PubDate     string   `xml:"pubDate,omitempty"`
+	Categories  []string `xml:"category"`
+	Description string   `xml:"description,omitempty"`
+}
+
+// WriteRSS writes f to w as an RSS 2.0 feed. RSS items don't have a time
+// they were updated, so only the feed's is kept.
+func WriteRSS(w io.Writer, f *Feed) error {
+	rf := rssFeed{
+		Version: "2.0",
+		Atom:    "http://www.w3.org/2005/Atom",
+		Channel: rssChannel{
+			Title:       f.Title,
+			Link:        f.Link,
+			Description: f.Description,
+			Generator:   Generator,
+			Self:        rssSelf{Rel: "self", Type: "application/rss+xml", Href: f.ID},
+		},
+	}
+
+	if rf.Channel.Link == "" {
+		rf.Channel.Link = f.ID
+	}
+
+	if rf.Channel.Description == "" {
+		rf.Channel.Description = f.Title
+	}
+
+	if t := f.LastUpdated(); !t.IsZero() {
+		rf.Channel.LastBuildDate = rssTime(t)
+	}
+
+	for _, e := range f.Entries {
+		item := rssItem{
+			Title:      e.Title,
+			Link:       e.Link,
+			GUID:       rssGUID{Value: e.ID},
+			Categories: e.Categories,
+		}
+
+		if !e.Published.IsZero() {
+			item.PubDate = rssTime(e.Published)
+		}
+
+		// description is html in rss, so plain text summaries are escaped
+		// once more than content
+		switch {
+		case e.Content != "":
+			item.Description = e.Content
+		case e.Summary != "":
+			item.Description = html.EscapeString(e.Summary)
+		}
+
+		rf.Channel.Items = append(rf.Channel.Items, item)
+	}
+
+	return writeXML(w, rf)
+}
+
+func writeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+
+	return err
+}
+
+func atomTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func rssTime(t time.Time) string {
+	return t.UTC().Format(time.RFC1123Z)
+}
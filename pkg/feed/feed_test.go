@@ -0,0 +1,158 @@
+package feed
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	published = time.Date(2020, 9, 13, 12, 26, 40, 0, time.UTC)
+	updated   = published.Add(time.Hour)
+)
+
+func testFeed() *Feed {
+	return &Feed{
+		ID:         "https://sufr.example.com/feeds/u1/timeline.atom",
+		Title:      "Bookmarks",
+		Link:       "https://sufr.example.com/u/u1",
+		AuthorName: "u1",
+		Updated:    published.Add(-time.Hour),
+		Entries: []*Entry{
+			{
+				ID:         "https://sufr.example.com/url/1",
+				Title:      "Go & friends",
+				Link:       "https://golang.org/?a=1&b=2",
+				Summary:    "a <language>",
+				Content:    "<p><strong>read</strong> the spec</p>",
+				Categories: []string{"go", "languages"},
+				Published:  published,
+				Updated:    updated,
+			},
+			{
+				ID:        "https://sufr.example.com/url/2",
+				Title:     "Example",
+				Link:      "https://example.com/",
+				Summary:   "just <text>",
+				Published: published,
+			},
+		},
+	}
+}
+
+func TestLastUpdated(t *testing.T) {
+	f := testFeed()
+	require.Equal(t, updated, f.LastUpdated())
+
+	f.Entries = nil
+	require.Equal(t, published.Add(-time.Hour), f.LastUpdated())
+}
+
+func TestWriteAtom(t *testing.T) {
+	b := &bytes.Buffer{}
+	require.NoError(t, WriteAtom(b, testFeed()))
+
+	var feed struct {
+		XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+		ID      string   `xml:"id"`
+		Updated string   `xml:"updated"`
+		Links   []struct {
+			Rel  string `xml:"rel,attr"`
+			Href string `xml:"href,attr"`
+		} `xml:"link"`
+		Author struct {
+			Name string `xml:"name"`
+		} `xml:"author"`
+		Entries []struct {
+			ID         string `xml:"id"`
+			Title      string `xml:"title"`
+			Published  string `xml:"published"`
+			Updated    string `xml:"updated"`
+			Categories []struct {
+				Term string `xml:"term,attr"`
+			} `xml:"category"`
+			Link struct {
+				Href string `xml:"href,attr"`
+			} `xml:"link"`
+			Summary string `xml:"summary"`
+			Content struct {
+				Type string `xml:"type,attr"`
+				Body string `xml:",chardata"`
+			} `xml:"content"`
+		} `xml:"entry"`
+	}
+
+	require.NoError(t, xml.Unmarshal(b.Bytes(), &feed))
+	require.Equal(t, "https://sufr.example.com/feeds/u1/timeline.atom", feed.ID)
+	require.Equal(t, "2020-09-13T13:26:40Z", feed.Updated)
+	require.Len(t, feed.Links, 2)
+	require.Equal(t, "self", feed.Links[0].Rel)
+	require.Equal(t, "alternate", feed.Links[1].Rel)
+	require.Equal(t, "https://sufr.example.com/u/u1", feed.Links[1].Href)
+	require.Equal(t, "u1", feed.Author.Name)
+	require.Len(t, feed.Entries, 2)
+
+	e := feed.Entries[0]
+	require.Equal(t, "Go & friends", e.Title)
+	require.Equal(t, "https://golang.org/?a=1&b=2", e.Link.Href)
+	require.Equal(t, "2020-09-13T12:26:40Z", e.Published)
+	require.Equal(t, "2020-09-13T13:26:40Z", e.Updated)
+	require.Len(t, e.Categories, 2)
+	require.Equal(t, "languages", e.Categories[1].Term)
+	require.Equal(t, "a <language>", e.Summary)
+	require.Equal(t, "html", e.Content.Type)
+	require.Equal(t, "<p><strong>read</strong> the spec</p>", e.Content.Body)
+
+	// entries that were never updated were last changed when they were made
+	require.Equal(t, "2020-09-13T12:26:40Z", feed.Entries[1].Updated)
+}
+
+func TestWriteRSS(t *testing.T) {
+	b := &bytes.Buffer{}
+	require.NoError(t, WriteRSS(b, testFeed()))
+
+	var feed struct {
+		XMLName xml.Name `xml:"rss"`
+		Version string   `xml:"version,attr"`
+		Channel struct {
+			Title string `xml:"title"`
+			// first so the atom link doesn't land in Link
+			Self struct {
+				Href string `xml:"href,attr"`
+			} `xml:"http://www.w3.org/2005/Atom link"`
+			Link          string `xml:"link"`
+			Description   string `xml:"description"`
+			LastBuildDate string `xml:"lastBuildDate"`
+			Items         []struct {
+				Title       string   `xml:"title"`
+				Link        string   `xml:"link"`
+				GUID        string   `xml:"guid"`
+				PubDate     string   `xml:"pubDate"`
+				Categories  []string `xml:"category"`
+				Description string   `xml:"description"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+
+	require.NoError(t, xml.Unmarshal(b.Bytes(), &feed))
+	require.Equal(t, "2.0", feed.Version)
+	require.Equal(t, "https://sufr.example.com/u/u1", feed.Channel.Link)
+	require.Equal(t, "https://sufr.example.com/feeds/u1/timeline.atom", feed.Channel.Self.Href)
+	// rss requires a description, so the title stands in for a missing one
+	require.Equal(t, "Bookmarks", feed.Channel.Description)
+	require.Equal(t, "Sun, 13 Sep 2020 13:26:40 +0000", feed.Channel.LastBuildDate)
+	require.Len(t, feed.Channel.Items, 2)
+
+	item := feed.Channel.Items[0]
+	require.Equal(t, "https://golang.org/?a=1&b=2", item.Link)
+	require.Equal(t, "https://sufr.example.com/url/1", item.GUID)
+	require.Equal(t, "Sun, 13 Sep 2020 12:26:40 +0000", item.PubDate)
+	require.Equal(t, []string{"go", "languages"}, item.Categories)
+	require.Equal(t, "<p><strong>read</strong> the spec</p>", item.Description)
+
+	// descriptions are html, so plain text summaries are escaped for it
+	require.Equal(t, "just &lt;text&gt;", feed.Channel.Items[1].Description)
+}
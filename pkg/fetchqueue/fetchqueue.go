@@ -0,0 +1,275 @@
+// Package fetchqueue runs url metadata fetches in the background. Jobs are
+// kept in a Store so they survive restarts and are worked by a bounded pool
+// of workers that retry failed fetches with exponential backoff.
+package fetchqueue
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultWorkers      = 4
+	DefaultMaxAttempts  = 5
+	DefaultMinBackoff   = 30 * time.Second
+	DefaultMaxBackoff   = 6 * time.Hour
+	DefaultPollInterval = 5 * time.Second
+	DefaultTimeout      = 30 * time.Second
+)
+
+// ErrNoJobs is returned by Store.Claim when no job is due.
+var ErrNoJobs = errors.New("no jobs due")
+
+// Job is a pending metadata fetch for a url.
+type Job struct {
+	ID    string `json:"id"`
+	URLID string `json:"url_id"`
+	URL   string `json:"url"`
+	// Attempts is the number of times the job has failed.
+	Attempts  int       `json:"attempts"`
+	RunAt     time.Time `json:"run_at"`
+	LastError string    `json:"last_error"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Store persists jobs.
+type Store interface {
+	// Claim returns the job that has been due the longest at now and moves
+	// its RunAt forward by lease so no other worker claims it in the
+	// meantime. If the process dies while working a job it is picked up
+	// again once the lease runs out. Claim returns ErrNoJobs when nothing
+	// is due.
+	Claim(ctx context.Context, now time.Time, lease time.Duration) (*Job, error)
+	// Complete removes job from the store.
+	Complete(ctx context.Context, job *Job) error
+	// Retry saves the Attempts, LastError and RunAt of job.
+	Retry(ctx context.Context, job *Job) error
+}
+
+// Handler does the work of a job. A returned error schedules a retry.
+type Handler func(ctx context.Context, job *Job) error
+
+type poolOptions struct {
+	workers      int
+	maxAttempts  int
+	minBackoff   time.Duration
+	maxBackoff   time.Duration
+	pollInterval time.Duration
+	timeout      time.Duration
+}
+
+type poolOptionFunc struct {
+	f func(*poolOptions)
+}
+
+func (p *poolOptionFunc) apply(opts *poolOptions) {
+	p.f(opts)
+}
+
+type PoolOption interface {
+	apply(*poolOptions)
+}
+
+// WithWorkers sets how many jobs are worked at once.
+func WithWorkers(n int) PoolOption {
+	return &poolOptionFunc{
+		f: func(opts *poolOptions) {
+			opts.workers = n
+		},
+	}
+}
+
+// WithMaxAttempts sets how many times a job is tried before it is dropped.
+func WithMaxAttempts(n int) PoolOption {
+	return &poolOptionFunc{
+		f: func(opts *poolOptions) {
+			opts.maxAttempts = n
+		},
+	}
+}
+
+// WithBackoff sets the delay before the first retry and the longest delay
+// between retries. The delay doubles after each failure.
+func WithBackoff(min, max time.Duration) PoolOption {
+	return &poolOptionFunc{
+		f: func(opts *poolOptions) {
+			opts.minBackoff = min
+			opts.maxBackoff = max
+		},
+	}
+}
+
+// WithPollInterval sets how often idle workers look for due jobs.
+func WithPollInterval(d time.Duration) PoolOption {
+	return &poolOptionFunc{
+		f: func(opts *poolOptions) {
+			opts.pollInterval = d
+		},
+	}
+}
+
+// WithTimeout sets how long a job may run. It is also the lease a job is
+// claimed for.
+func WithTimeout(d time.Duration) PoolOption {
+	return &poolOptionFunc{
+		f: func(opts *poolOptions) {
+			opts.timeout = d
+		},
+	}
+}
+
+// Pool works the jobs in a Store.
+type Pool struct {
+	store   Store
+	handler Handler
+	opts    poolOptions
+	wake    chan struct{}
+}
+
+// Notify wakes an idle worker. Call it after enqueueing a job so it doesn't
+// wait for the next poll.
+func (p *Pool) Notify() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run works jobs until ctx is done. It waits for running jobs to finish
+// before returning.
+func (p *Pool) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+
+	for i := 0; i < p.opts.workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			p.work(ctx)
+		}()
+	}
+
+	wg.Wait()
+
+	return ctx.Err()
+}
+
+func (p *Pool) work(ctx context.Context) {
+	ticker := time.NewTicker(p.opts.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		// keep working while there are jobs due
+		for p.next(ctx) {
+			if ctx.Err() != nil {
+				return
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// next claims and works one job. It returns false when there was nothing to
+// do.
+func (p *Pool) next(ctx context.Context) bool {
+	job, err := p.store.Claim(ctx, time.Now().UTC(), p.opts.timeout)
+	if err != nil {
+		if !errors.Is(err, ErrNoJobs) && ctx.Err() == nil {
+			log.Printf("fetchqueue: failed to claim job: %s", err)
+		}
+
+		return false
+	}
+
+	jobCtx, cancel := context.WithTimeout(ctx, p.opts.timeout)
+	err = p.handler(jobCtx, job)
+	cancel()
+
+	if err == nil {
+		if err := p.store.Complete(ctx, job); err != nil {
+			log.Printf("fetchqueue: failed to complete job %s: %s", job.ID, err)
+		}
+
+		return true
+	}
+
+	// the job didn't fail, we are shutting down. It runs again once its
+	// lease is up.
+	if ctx.Err() != nil {
+		return false
+	}
+
+	job.Attempts++
+	job.LastError = err.Error()
+
+	if job.Attempts >= p.opts.maxAttempts {
+		log.Printf("fetchqueue: giving up on %s after %d attempts: %s", job.URL, job.Attempts, err)
+
+		if err := p.store.Complete(ctx, job); err != nil {
+			log.Printf("fetchqueue: failed to complete job %s: %s", job.ID, err)
+		}
+
+		return true
+	}
+
+	job.RunAt = time.Now().UTC().Add(p.backoff(job.Attempts))
+
+	if err := p.store.Retry(ctx, job); err != nil {
+		log.Printf("fetchqueue: failed to reschedule job %s: %s", job.ID, err)
+	}
+
+	return true
+}
+
+// backoff returns the delay before retrying a job that has failed attempts
+// times.
+func (p *Pool) backoff(attempts int) time.Duration {
+	d := p.opts.minBackoff
+
+	for i := 1; i < attempts; i++ {
+		d *= 2
+
+		if d >= p.opts.maxBackoff {
+			return p.opts.maxBackoff
+		}
+	}
+
+	return d
+}
+
+// New returns a Pool that works the jobs in store with handler.
+func New(store Store, handler Handler, opts ...PoolOption) *Pool {
+	po := poolOptions{
+		workers:      DefaultWorkers,
+		maxAttempts:  DefaultMaxAttempts,
+		minBackoff:   DefaultMinBackoff,
+		maxBackoff:   DefaultMaxBackoff,
+		pollInterval: DefaultPollInterval,
+		timeout:      DefaultTimeout,
+	}
+
+	for _, opt := range opts {
+		opt.apply(&po)
+	}
+
+	if po.workers < 1 {
+		po.workers = 1
+	}
+
+	return &Pool{
+		store:   store,
+		handler: handler,
+		opts:    po,
+		wake:    make(chan struct{}, 1),
+	}
+}
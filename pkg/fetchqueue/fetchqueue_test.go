@@ -0,0 +1,155 @@
+package fetchqueue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type memoryStore struct {
+	sync.Mutex
+	jobs      map[string]*Job
+	completed []string
+}
+
+func (s *memoryStore) Claim(ctx context.Context, now time.Time, lease time.Duration) (*Job, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	var next *Job
+
+	for _, job := range s.jobs {
+		if !job.RunAt.After(now) && (next == nil || job.RunAt.Before(next.RunAt)) {
+			next = job
+		}
+	}
+
+	if next == nil {
+		return nil, ErrNoJobs
+	}
+
+	next.RunAt = now.Add(lease)
+	claimed := *next
+
+	return &claimed, nil
+}
+
+func (s *memoryStore) Complete(ctx context.Context, job *Job) error {
+	s.Lock()
+	defer s.Unlock()
+
+	delete(s.jobs, job.ID)
+	s.completed = append(s.completed, job.ID)
+
+	return nil
+}
+
+func (s *memoryStore) Retry(ctx context.Context, job *Job) error {
+	s.Lock()
+	defer s.Unlock()
+
+	saved := *job
+	s.jobs[job.ID] = &saved
+
+	return nil
+}
+
+func (s *memoryStore) get(id string) *Job {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.jobs[id]
+}
+
+func (s *memoryStore) done() int {
+	s.Lock()
+	defer s.Unlock()
+
+	return len(s.completed)
+}
+
+func TestPool(t *testing.T) {
+	t.Run("works every due job", func(t *testing.T) {
+		s := &memoryStore{jobs: map[string]*Job{
+			"1": {ID: "1", URL: "https://example.com/1"},
+			"2": {ID: "2", URL: "https://example.com/2"},
+			"3": {ID: "3", URL: "https://example.com/3", RunAt: time.Now().Add(time.Hour)},
+		}}
+
+		var mu sync.Mutex
+		seen := map[string]bool{}
+
+		p := New(s, func(ctx context.Context, job *Job) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			seen[job.URL] = true
+
+			return nil
+		}, WithWorkers(2), WithPollInterval(10*time.Millisecond))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+
+		go func() {
+			p.Run(ctx)
+			close(done)
+		}()
+
+		require.Eventually(t, func() bool { return s.done() == 2 }, time.Second, 10*time.Millisecond)
+
+		cancel()
+		<-done
+
+		require.True(t, seen["https://example.com/1"])
+		require.True(t, seen["https://example.com/2"])
+		require.NotNil(t, s.get("3"))
+	})
+
+	t.Run("retries with backoff and gives up", func(t *testing.T) {
+		s := &memoryStore{jobs: map[string]*Job{
+			"1": {ID: "1", URL: "https://example.com/1"},
+		}}
+
+		errFetch := errors.New("connection refused")
+
+		p := New(s, func(ctx context.Context, job *Job) error {
+			return errFetch
+		}, WithMaxAttempts(3), WithBackoff(time.Hour, 2*time.Hour))
+
+		ctx := context.Background()
+
+		require.True(t, p.next(ctx))
+
+		job := s.get("1")
+		require.Equal(t, 1, job.Attempts)
+		require.Equal(t, errFetch.Error(), job.LastError)
+		require.WithinDuration(t, time.Now().Add(time.Hour), job.RunAt, time.Minute)
+
+		// not due yet
+		require.False(t, p.next(ctx))
+
+		job.RunAt = time.Time{}
+		require.True(t, p.next(ctx))
+		require.Equal(t, 2, s.get("1").Attempts)
+
+		s.get("1").RunAt = time.Time{}
+		require.True(t, p.next(ctx))
+		require.Nil(t, s.get("1"))
+		require.Equal(t, 1, s.done())
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	p := New(nil, nil, WithBackoff(time.Second, 10*time.Second))
+
+	require.Equal(t, time.Second, p.backoff(1))
+	require.Equal(t, 2*time.Second, p.backoff(2))
+	require.Equal(t, 8*time.Second, p.backoff(4))
+	require.Equal(t, 10*time.Second, p.backoff(5))
+	require.Equal(t, 10*time.Second, p.backoff(50))
+}
@@ -0,0 +1,417 @@
+// Package linkcheck periodically re-requests saved urls to find the ones that
+// stopped working. Every check is recorded in a Store and a link is flagged
+// dead once enough checks in a row have failed.
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/kyleterry/sufr/pkg/config"
+)
+
+const (
+	DefaultInterval     = 24 * time.Hour
+	DefaultDeadAfter    = 3
+	DefaultWorkers      = 8
+	DefaultPerHost      = 2
+	DefaultTimeout      = 30 * time.Second
+	DefaultPollInterval = time.Minute
+
+	// batchSize is how many due links are loaded from the store at a time.
+	batchSize = 100
+	// maxRedirects is how many redirects are followed before giving up and
+	// treating the last response as the answer.
+	maxRedirects = 10
+	// maxBodyBytes is how much of a GET response is read so the connection
+	// can be reused.
+	maxBodyBytes = 64 << 10
+)
+
+// Link is a url waiting to be checked.
+type Link struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+// Result is the outcome of checking a Link.
+type Result struct {
+	ID    string `json:"id"`
+	URLID string `json:"url_id"`
+	// OK is set when the link answered with a status below 400.
+	OK         bool `json:"ok"`
+	StatusCode int  `json:"status_code"`
+	// FinalURL is where redirects ended up. It is empty when there were
+	// none.
+	FinalURL       string `json:"final_url"`
+	Redirects      int    `json:"redirects"`
+	ResponseTimeMS int64  `json:"response_time_ms"`
+	// Error is set when no response came back at all.
+	Error     string    `json:"error"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Store persists the outcome of checks.
+type Store interface {
+	// Due returns up to limit links last checked at or before before.
+	// Links that were never checked come first, then the ones checked the
+	// longest ago.
+	Due(ctx context.Context, before time.Time, limit int) ([]*Link, error)
+	// Record saves result and updates the health of its link. The link is
+	// dead once deadAfter checks in a row have failed and alive again after
+	// the next one that doesn't.
+	Record(ctx context.Context, result *Result, deadAfter int) error
+}
+
+type checkerOptions struct {
+	interval     time.Duration
+	deadAfter    int
+	workers      int
+	perHost      int
+	timeout      time.Duration
+	pollInterval time.Duration
+	transport    http.RoundTripper
+}
+
+type checkerOptionFunc struct {
+	f func(*checkerOptions)
+}
+
+func (c *checkerOptionFunc) apply(opts *checkerOptions) {
+	c.f(opts)
+}
+
+type CheckerOption interface {
+	apply(*checkerOptions)
+}
+
+// WithInterval sets how long to wait before checking a link again.
+func WithInterval(d time.Duration) CheckerOption {
+	return &checkerOptionFunc{
+		f: func(opts *checkerOptions) {
+			opts.interval = d
+		},
+	}
+}
+
+// WithDeadAfter sets how many checks in a row have to fail before a link is
+// flagged dead.
+func WithDeadAfter(n int) CheckerOption {
+	return &checkerOptionFunc{
+		f: func(opts *checkerOptions) {
+			opts.deadAfter = n
+		},
+	}
+}
+
+// WithWorkers sets how many links are checked at once.
+func WithWorkers(n int) CheckerOption {
+	return &checkerOptionFunc{
+		f: func(opts *checkerOptions) {
+			opts.workers = n
+		},
+	}
+}
+
+// WithPerHost sets how many links on the same host are checked at once.
+func WithPerHost(n int) CheckerOption {
+	return &checkerOptionFunc{
+		f: func(opts *checkerOptions) {
+			opts.perHost = n
+		},
+	}
+}
+
+// WithTimeout sets how long a single check may take, redirects included.
+func WithTimeout(d time.Duration) CheckerOption {
+	return &checkerOptionFunc{
+		f: func(opts *checkerOptions) {
+			opts.timeout = d
+		},
+	}
+}
+
+// WithPollInterval sets how often the store is asked for due links once
+// everything is checked.
+func WithPollInterval(d time.Duration) CheckerOption {
+	return &checkerOptionFunc{
+		f: func(opts *checkerOptions) {
+			opts.pollInterval = d
+		},
+	}
+}
+
+// WithTransport sets the http.RoundTripper used to make requests.
+func WithTransport(rt http.RoundTripper) CheckerOption {
+	return &checkerOptionFunc{
+		f: func(opts *checkerOptions) {
+			opts.transport = rt
+		},
+	}
+}
+
+// Checker checks the links in a Store.
+type Checker struct {
+	store Store
+	opts  checkerOptions
+	hosts *hostLimiter
+}
+
+// Run checks links as they become due until ctx is done. It waits for
+// running checks to finish before returning.
+func (c *Checker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.opts.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		// keep going while there are links due
+		for {
+			n, err := c.checkDue(ctx)
+			if err != nil && ctx.Err() == nil {
+				log.Printf("linkcheck: failed to get due links: %s", err)
+			}
+
+			if n == 0 || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkDue checks one batch of due links and returns how many there were.
+// The whole batch is finished before returning so a link is never checked
+// twice at once.
+func (c *Checker) checkDue(ctx context.Context) (int, error) {
+	links, err := c.store.Due(ctx, time.Now().UTC().Add(-c.opts.interval), batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	queue := make(chan *Link)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < c.opts.workers && i < len(links); i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for link := range queue {
+				c.checkLink(ctx, link)
+			}
+		}()
+	}
+
+	for _, link := range links {
+		select {
+		case queue <- link:
+		case <-ctx.Done():
+		}
+	}
+
+	close(queue)
+	wg.Wait()
+
+	return len(links), nil
+}
+
+func (c *Checker) checkLink(ctx context.Context, link *Link) {
+	host := link.URL
+	if u, err := url.Parse(link.URL); err == nil {
+		host = u.Host
+	}
+
+	release, err := c.hosts.acquire(ctx, host)
+	if err != nil {
+		return
+	}
+
+	res := c.Check(ctx, link.URL)
+
+	release()
+
+	// the check didn't fail, we are shutting down
+	if ctx.Err() != nil {
+		return
+	}
+
+	res.URLID = link.ID
+
+	if err := c.store.Record(ctx, res, c.opts.deadAfter); err != nil {
+		log.Printf("linkcheck: failed to record check of %s: %s", link.URL, err)
+	}
+}
+
+// Check requests rawurl and reports how it went. A HEAD request is tried
+// first and a GET request made when that fails, since plenty of servers
+// don't answer HEAD properly.
+func (c *Checker) Check(ctx context.Context, rawurl string) *Result {
+	res := c.request(ctx, http.MethodHead, rawurl)
+	if !res.OK && ctx.Err() == nil {
+		res = c.request(ctx, http.MethodGet, rawurl)
+	}
+
+	return res
+}
+
+func (c *Checker) request(ctx context.Context, method, rawurl string) *Result {
+	res := &Result{CheckedAt: time.Now().UTC()}
+
+	ctx, cancel := context.WithTimeout(ctx, c.opts.timeout)
+	defer cancel()
+
+	client := &http.Client{
+		Transport: c.opts.transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > maxRedirects {
+				return http.ErrUseLastResponse
+			}
+
+			res.Redirects = len(via)
+
+			return nil
+		},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, rawurl, nil)
+	if err != nil {
+		res.Error = err.Error()
+
+		return res
+	}
+
+	req.Header.Set("User-Agent", config.SUFRUserAgent)
+
+	start := time.Now()
+
+	resp, err := client.Do(req)
+
+	res.ResponseTimeMS = time.Since(start).Milliseconds()
+
+	if err != nil {
+		var uerr *url.Error
+		if errors.As(err, &uerr) {
+			err = uerr.Err
+		}
+
+		res.Error = err.Error()
+
+		return res
+	}
+
+	defer resp.Body.Close()
+
+	io.Copy(ioutil.Discard, io.LimitReader(resp.Body, maxBodyBytes))
+
+	res.StatusCode = resp.StatusCode
+	res.OK = resp.StatusCode < http.StatusBadRequest
+
+	if res.Redirects > 0 {
+		res.FinalURL = resp.Request.URL.String()
+	}
+
+	return res
+}
+
+// New returns a Checker that checks the links in store.
+func New(store Store, opts ...CheckerOption) *Checker {
+	co := checkerOptions{
+		interval:     DefaultInterval,
+		deadAfter:    DefaultDeadAfter,
+		workers:      DefaultWorkers,
+		perHost:      DefaultPerHost,
+		timeout:      DefaultTimeout,
+		pollInterval: DefaultPollInterval,
+		transport:    http.DefaultTransport,
+	}
+
+	for _, opt := range opts {
+		opt.apply(&co)
+	}
+
+	if co.workers < 1 {
+		co.workers = 1
+	}
+
+	if co.perHost < 1 {
+		co.perHost = 1
+	}
+
+	if co.deadAfter < 1 {
+		co.deadAfter = 1
+	}
+
+	return &Checker{
+		store: store,
+		opts:  co,
+		hosts: &hostLimiter{limit: co.perHost, slots: map[string]*hostSlots{}},
+	}
+}
+
+// hostLimiter caps the number of requests in flight to each host.
+type hostLimiter struct {
+	mu    sync.Mutex
+	limit int
+	slots map[string]*hostSlots
+}
+
+type hostSlots struct {
+	sem chan struct{}
+	// users is the number of callers holding or waiting for a slot. The
+	// host is forgotten when it drops to 0.
+	users int
+}
+
+// acquire waits for a free slot for host. release must be called once the
+// request is done.
+func (l *hostLimiter) acquire(ctx context.Context, host string) (release func(), err error) {
+	l.mu.Lock()
+
+	hs, ok := l.slots[host]
+	if !ok {
+		hs = &hostSlots{sem: make(chan struct{}, l.limit)}
+		l.slots[host] = hs
+	}
+
+	hs.users++
+
+	l.mu.Unlock()
+
+	done := func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		hs.users--
+
+		if hs.users == 0 {
+			delete(l.slots, host)
+		}
+	}
+
+	select {
+	case hs.sem <- struct{}{}:
+	case <-ctx.Done():
+		done()
+
+		return nil, ctx.Err()
+	}
+
+	return func() {
+		<-hs.sem
+		done()
+	}, nil
+}
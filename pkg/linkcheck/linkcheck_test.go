@@ -0,0 +1,197 @@
+package linkcheck
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type memoryStore struct {
+	sync.Mutex
+	links    []*Link
+	results  map[string][]*Result
+	failures map[string]int
+	dead     map[string]bool
+}
+
+func newMemoryStore(links ...*Link) *memoryStore {
+	return &memoryStore{
+		links:    links,
+		results:  map[string][]*Result{},
+		failures: map[string]int{},
+		dead:     map[string]bool{},
+	}
+}
+
+func (s *memoryStore) Due(ctx context.Context, before time.Time, limit int) ([]*Link, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	due := []*Link{}
+
+	for _, link := range s.links {
+		results := s.results[link.ID]
+		if len(results) > 0 && results[len(results)-1].CheckedAt.After(before) {
+			continue
+		}
+
+		if len(due) < limit {
+			due = append(due, link)
+		}
+	}
+
+	return due, nil
+}
+
+func (s *memoryStore) Record(ctx context.Context, result *Result, deadAfter int) error {
+	s.Lock()
+	defer s.Unlock()
+
+	s.results[result.URLID] = append(s.results[result.URLID], result)
+
+	if result.OK {
+		s.failures[result.URLID] = 0
+	} else {
+		s.failures[result.URLID]++
+	}
+
+	s.dead[result.URLID] = s.failures[result.URLID] >= deadAfter
+
+	return nil
+}
+
+func TestCheck(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("/gone", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "gone", http.StatusGone)
+	})
+	mux.HandleFunc("/no-head", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+
+			return
+		}
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/moved-again", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/moved-again", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ok", http.StatusFound)
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	c := New(nil)
+	ctx := context.Background()
+
+	t.Run("ok", func(t *testing.T) {
+		res := c.Check(ctx, ts.URL+"/ok")
+		require.True(t, res.OK)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.Empty(t, res.FinalURL)
+		require.Empty(t, res.Error)
+		require.NotZero(t, res.CheckedAt)
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		res := c.Check(ctx, ts.URL+"/gone")
+		require.False(t, res.OK)
+		require.Equal(t, http.StatusGone, res.StatusCode)
+	})
+
+	t.Run("falls back to GET", func(t *testing.T) {
+		res := c.Check(ctx, ts.URL+"/no-head")
+		require.True(t, res.OK)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+	})
+
+	t.Run("follows redirects", func(t *testing.T) {
+		res := c.Check(ctx, ts.URL+"/moved")
+		require.True(t, res.OK)
+		require.Equal(t, 2, res.Redirects)
+		require.Equal(t, ts.URL+"/ok", res.FinalURL)
+	})
+
+	t.Run("stops following redirect loops", func(t *testing.T) {
+		res := c.Check(ctx, ts.URL+"/loop")
+		require.True(t, res.OK)
+		require.Equal(t, http.StatusFound, res.StatusCode)
+		require.Equal(t, maxRedirects, res.Redirects)
+	})
+
+	t.Run("no response", func(t *testing.T) {
+		closed := httptest.NewServer(mux)
+		closed.Close()
+
+		res := c.Check(ctx, closed.URL+"/ok")
+		require.False(t, res.OK)
+		require.Zero(t, res.StatusCode)
+		require.NotEmpty(t, res.Error)
+	})
+}
+
+func TestCheckerRun(t *testing.T) {
+	var inFlight, maxInFlight int32
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+
+		if r.URL.Path == "/dead" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	s := newMemoryStore(
+		&Link{ID: "1", URL: ts.URL + "/1"},
+		&Link{ID: "2", URL: ts.URL + "/2"},
+		&Link{ID: "3", URL: ts.URL + "/3"},
+		&Link{ID: "4", URL: ts.URL + "/4"},
+		&Link{ID: "dead", URL: ts.URL + "/dead"},
+	)
+
+	c := New(s, WithWorkers(4), WithPerHost(2), WithDeadAfter(2), WithInterval(time.Hour))
+	ctx := context.Background()
+
+	n, err := c.checkDue(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 5, n)
+	require.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(2))
+
+	// nothing is due again until the interval is up
+	n, err = c.checkDue(ctx)
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	require.Len(t, s.results["1"], 1)
+	require.True(t, s.results["1"][0].OK)
+	require.False(t, s.dead["dead"])
+
+	c.opts.interval = 0
+
+	_, err = c.checkDue(ctx)
+	require.NoError(t, err)
+	require.True(t, s.dead["dead"])
+	require.False(t, s.dead["1"])
+}
@@ -0,0 +1,234 @@
+// Package netscape reads and writes the Netscape bookmark file format that
+// every browser uses to import and export bookmarks.
+package netscape
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	nethtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Bookmark is a single link from a bookmark file.
+type Bookmark struct {
+	URL   string
+	Title string
+	Notes string
+	Tags  []string
+	// Folders is the path of folders the bookmark was found in, outermost
+	// first.
+	Folders      []string
+	AddDate      time.Time
+	LastModified time.Time
+	// Private and Public are set when the bookmark is marked PRIVATE="1" or
+	// PRIVATE="0". Browsers leave the attribute out, so neither is set for
+	// most bookmarks.
+	Private bool
+	Public  bool
+}
+
+// Parse reads every bookmark from a Netscape bookmark file. The format is
+// loosely specified and rarely valid HTML, so Parse only looks at the tags
+// that matter and ignores everything else.
+func Parse(r io.Reader) ([]*Bookmark, error) {
+	var (
+		bookmarks []*Bookmark
+		folders   []string
+		// pending is the name of the folder whose DL comes next
+		pending string
+		last    *Bookmark
+		// notes collects the text of a DD, which is never closed in
+		// bookmark files so it ends at the next tag other than BR.
+		notes *strings.Builder
+	)
+
+	endNotes := func() {
+		if notes != nil && last != nil {
+			last.Notes = strings.TrimSpace(notes.String())
+		}
+
+		notes = nil
+	}
+
+	z := nethtml.NewTokenizer(r)
+
+	for {
+		tt := z.Next()
+
+		switch tt {
+		case nethtml.ErrorToken:
+			endNotes()
+
+			if z.Err() == io.EOF {
+				return bookmarks, nil
+			}
+
+			return nil, z.Err()
+		case nethtml.TextToken:
+			if notes != nil {
+				notes.Write(z.Text())
+			}
+		case nethtml.StartTagToken, nethtml.SelfClosingTagToken:
+			tok := z.Token()
+
+			if notes != nil && tok.DataAtom == atom.Br {
+				notes.WriteString("\n")
+
+				continue
+			}
+
+			endNotes()
+
+			switch tok.DataAtom {
+			case atom.H3:
+				pending = strings.TrimSpace(readText(z, atom.H3))
+			case atom.Dl:
+				folders = append(folders, pending)
+				pending = ""
+			case atom.A:
+				b := &Bookmark{
+					Folders: folderPath(folders),
+				}
+
+				for _, attr := range tok.Attr {
+					switch strings.ToLower(attr.Key) {
+					case "href":
+						b.URL = strings.TrimSpace(attr.Val)
+					case "add_date":
+						b.AddDate = parseUnix(attr.Val)
+					case "last_modified":
+						b.LastModified = parseUnix(attr.Val)
+					case "private":
+						b.Private = attr.Val == "1"
+						b.Public = attr.Val == "0"
+					case "tags":
+						b.Tags = splitTags(attr.Val)
+					}
+				}
+
+				b.Title = strings.TrimSpace(readText(z, atom.A))
+
+				if b.URL != "" {
+					bookmarks = append(bookmarks, b)
+					last = b
+				}
+			case atom.Dd:
+				notes = &strings.Builder{}
+			}
+		case nethtml.EndTagToken:
+			endNotes()
+
+			tok := z.Token()
+
+			switch tok.DataAtom {
+			case atom.Dl:
+				if len(folders) > 0 {
+					folders = folders[:len(folders)-1]
+				}
+
+				last = nil
+			case atom.Dt:
+				last = nil
+			}
+		}
+	}
+}
+
+// readText returns the text up to the closing tag a.
+func readText(z *nethtml.Tokenizer, a atom.Atom) string {
+	var sb strings.Builder
+
+	for {
+		switch z.Next() {
+		case nethtml.ErrorToken:
+			return sb.String()
+		case nethtml.TextToken:
+			sb.Write(z.Text())
+		case nethtml.EndTagToken:
+			if z.Token().DataAtom == a {
+				return sb.String()
+			}
+		}
+	}
+}
+
+func folderPath(folders []string) []string {
+	path := []string{}
+
+	for _, f := range folders {
+		if f != "" {
+			path = append(path, f)
+		}
+	}
+
+	return path
+}
+
+func parseUnix(s string) time.Time {
+	secs, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil || secs <= 0 {
+		return time.Time{}
+	}
+
+	// some browsers write microseconds
+	if secs > 1e12 {
+		secs /= 1e6
+	}
+
+	return time.Unix(secs, 0).UTC()
+}
+
+func splitTags(s string) []string {
+	tags := []string{}
+
+	for _, tag := range strings.Split(s, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+var fileTemplate = template.Must(template.New("bookmarks").Funcs(template.FuncMap{
+	"escape": html.EscapeString,
+	"unix": func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+
+		return strconv.FormatInt(t.Unix(), 10)
+	},
+	"join": strings.Join,
+}).Parse(`<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<!-- This is an automatically generated file.
+     It will be read and overwritten.
+     DO NOT EDIT! -->
+<META HTTP-EQUIV="Content-Type" CONTENT="text/html; charset=UTF-8">
+<TITLE>Bookmarks</TITLE>
+<H1>Bookmarks</H1>
+<DL><p>
+{{- range . }}
+    <DT><A HREF="{{ escape .URL }}"{{ with unix .AddDate }} ADD_DATE="{{ . }}"{{ end }}{{ with unix .LastModified }} LAST_MODIFIED="{{ . }}"{{ end }} PRIVATE="{{ if .Private }}1{{ else }}0{{ end }}"{{ with .Tags }} TAGS="{{ escape (join . ",") }}"{{ end }}>{{ escape .Title }}</A>
+{{- with .Notes }}
+    <DD>{{ escape . }}
+{{- end }}
+{{- end }}
+</DL><p>
+`))
+
+// Write writes bookmarks as a flat Netscape bookmark file. Folders are not
+// written; tags carry that information instead.
+func Write(w io.Writer, bookmarks []*Bookmark) error {
+	if err := fileTemplate.Execute(w, bookmarks); err != nil {
+		return fmt.Errorf("failed to write bookmarks: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package netscape
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testFile = `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<META HTTP-EQUIV="Content-Type" CONTENT="text/html; charset=UTF-8">
+<TITLE>Bookmarks</TITLE>
+<H1>Bookmarks</H1>
+<DL><p>
+    <DT><H3 ADD_DATE="1600000000">Programming</H3>
+    <DL><p>
+        <DT><A HREF="https://golang.org/" ADD_DATE="1600000001" TAGS="go, languages">The Go &amp; Programming Language</A>
+        <DD>Read the spec<br>twice
+        <DT><H3>Rust</H3>
+        <DL><p>
+            <DT><A HREF="https://www.rust-lang.org/" ADD_DATE="1600000002000000" PRIVATE="1">Rust</A>
+        </DL><p>
+    </DL><p>
+    <DT><A HREF="https://example.com/" LAST_MODIFIED="1600000003" PRIVATE="0">Example</A>
+    <DT><A HREF="">No href</A>
+</DL><p>
+`
+
+func TestParse(t *testing.T) {
+	bookmarks, err := Parse(strings.NewReader(testFile))
+	require.NoError(t, err)
+	require.Len(t, bookmarks, 3)
+
+	golang := bookmarks[0]
+	require.Equal(t, "https://golang.org/", golang.URL)
+	require.Equal(t, "The Go & Programming Language", golang.Title)
+	require.Equal(t, "Read the spec\ntwice", golang.Notes)
+	require.Equal(t, []string{"go", "languages"}, golang.Tags)
+	require.Equal(t, []string{"Programming"}, golang.Folders)
+	require.Equal(t, time.Unix(1600000001, 0).UTC(), golang.AddDate)
+	require.False(t, golang.Private)
+	require.False(t, golang.Public)
+
+	rust := bookmarks[1]
+	require.Equal(t, []string{"Programming", "Rust"}, rust.Folders)
+	require.Equal(t, time.Unix(1600000002, 0).UTC(), rust.AddDate)
+	require.True(t, rust.Private)
+	require.False(t, rust.Public)
+	require.Empty(t, rust.Notes)
+
+	example := bookmarks[2]
+	require.Empty(t, example.Folders)
+	require.True(t, example.AddDate.IsZero())
+	require.Equal(t, time.Unix(1600000003, 0).UTC(), example.LastModified)
+	require.False(t, example.Private)
+	require.True(t, example.Public)
+}
+
+func TestWriteRoundTrip(t *testing.T) {
+	bookmarks := []*Bookmark{
+		{
+			URL:     "https://example.com/?a=1&b=2",
+			Title:   `<script>"quoted"</script>`,
+			Notes:   "some notes",
+			Tags:    []string{"one", "two"},
+			AddDate: time.Unix(1600000000, 0).UTC(),
+			Private: true,
+		},
+		{
+			URL:   "https://example.org/",
+			Title: "Example",
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, Write(buf, bookmarks))
+	require.NotContains(t, buf.String(), "<script>")
+
+	parsed, err := Parse(buf)
+	require.NoError(t, err)
+	require.Len(t, parsed, 2)
+
+	for i, b := range bookmarks {
+		require.Equal(t, b.URL, parsed[i].URL)
+		require.Equal(t, b.Title, parsed[i].Title)
+		require.Equal(t, b.Notes, parsed[i].Notes)
+		require.Equal(t, b.AddDate, parsed[i].AddDate)
+		require.Equal(t, b.Private, parsed[i].Private)
+		require.Equal(t, !b.Private, parsed[i].Public)
+		require.Empty(t, parsed[i].Folders)
+	}
+
+	require.Equal(t, bookmarks[0].Tags, parsed[0].Tags)
+	require.Empty(t, parsed[1].Tags)
+}
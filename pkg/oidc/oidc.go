@@ -0,0 +1,387 @@
+// Package oidc logs people in with an OpenID Connect provider, like a
+// company's identity provider. It's a relying party for the authorization
+// code flow with PKCE.
+//
+// The provider's endpoints come from its discovery document and ID tokens
+// are checked against the keys it publishes there. Only ID tokens are used;
+// the access token the provider hands out is thrown away.
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kyleterry/sufr/pkg/config"
+)
+
+const (
+	// Timeout is how long people are given to log in at the provider.
+	Timeout = 10 * time.Minute
+
+	discoveryPath    = "/.well-known/openid-configuration"
+	requestTimeout   = 10 * time.Second
+	maxResponseBytes = 1 << 20
+	secretBytes      = 32
+)
+
+var (
+	// ErrInvalidToken is returned for ID tokens that are malformed, aren't
+	// signed by the provider or weren't made for this login.
+	ErrInvalidToken = errors.New("invalid oidc id token")
+	// ErrStateMismatch is returned when the state the provider sent back
+	// isn't the one the login was started with.
+	ErrStateMismatch = errors.New("oidc state doesn't match")
+)
+
+// DefaultScopes are asked for besides openid when Config doesn't list any.
+var DefaultScopes = []string{"email", "profile"}
+
+// Config is the provider to log in with and what sufr is registered there as.
+type Config struct {
+	// Issuer is the provider's URL, like "https://accounts.example.com".
+	// Its discovery document is under it.
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// Scopes are asked for besides openid.
+	Scopes []string
+	// AllowedDomains are the email domains that can log in. When there are
+	// none, anyone with an account can log in but no accounts are made.
+	AllowedDomains []string
+	// HTTPClient talks to the provider. http.DefaultClient is used when it's
+	// nil.
+	HTTPClient *http.Client
+}
+
+// FromConfig returns the SUFR_OIDC_* settings of cfg, or nil when no issuer
+// is set.
+func FromConfig(cfg config.Config) *Config {
+	if cfg.OIDCIssuer == "" {
+		return nil
+	}
+
+	return &Config{
+		Issuer:         cfg.OIDCIssuer,
+		ClientID:       cfg.OIDCClientID,
+		ClientSecret:   cfg.OIDCClientSecret,
+		AllowedDomains: cfg.OIDCAllowedDomains,
+	}
+}
+
+// AllowedEmail reports whether email is in one of the allowed domains.
+// Subdomains have to be listed on their own.
+func (c *Config) AllowedEmail(email string) bool {
+	i := strings.LastIndexByte(email, '@')
+	if i < 1 || i == len(email)-1 {
+		return false
+	}
+
+	domain := email[i+1:]
+
+	for _, d := range c.AllowedDomains {
+		if strings.EqualFold(domain, strings.TrimPrefix(strings.TrimSpace(d), "@")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TrustedEmail returns the email address in claims and whether it can be
+// used to find or make an account. The provider has to have verified it,
+// except in the allowed domains: providers that only hand out addresses they
+// manage often leave email_verified out, and those domains are trusted to be
+// theirs. Addresses the provider says are unverified are never trusted.
+func (c *Config) TrustedEmail(claims *Claims) (string, bool) {
+	if email, ok := claims.VerifiedEmail(); ok {
+		return email, true
+	}
+
+	if claims.EmailVerified == nil && c.AllowedEmail(claims.Email) {
+		return claims.Email, true
+	}
+
+	return "", false
+}
+
+// CreatesUsers reports whether accounts are made for people logging in for
+// the first time.
+func (c *Config) CreatesUsers() bool {
+	return len(c.AllowedDomains) > 0
+}
+
+// metadata is the part of the discovery document that's used.
+type metadata struct {
+	Issuer                   string   `json:"issuer"`
+	AuthorizationEndpoint    string   `json:"authorization_endpoint"`
+	TokenEndpoint            string   `json:"token_endpoint"`
+	JWKSURI                  string   `json:"jwks_uri"`
+	TokenEndpointAuthMethods []string `json:"token_endpoint_auth_methods_supported"`
+}
+
+// Provider is an OpenID Connect provider. Its discovery document is fetched
+// the first time it's needed and kept once it's been fetched.
+type Provider struct {
+	cfg    Config
+	client *http.Client
+	keys   *keySet
+	now    func() time.Time
+
+	mu   sync.Mutex
+	meta *metadata
+}
+
+// New returns the provider cfg describes.
+func New(cfg Config) *Provider {
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = DefaultScopes
+	}
+
+	client := cfg.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	p := &Provider{
+		cfg:    cfg,
+		client: client,
+		now:    time.Now,
+	}
+
+	p.keys = &keySet{get: p.getJSON}
+
+	return p
+}
+
+// Config returns what the provider was made with.
+func (p *Provider) Config() *Config {
+	return &p.cfg
+}
+
+func (p *Provider) metadata(ctx context.Context) (*metadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.meta != nil {
+		return p.meta, nil
+	}
+
+	issuer := strings.TrimSuffix(p.cfg.Issuer, "/")
+
+	var meta metadata
+
+	if err := p.getJSON(ctx, issuer+discoveryPath, &meta); err != nil {
+		return nil, fmt.Errorf("failed to discover oidc provider: %w", err)
+	}
+
+	if strings.TrimSuffix(meta.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("oidc provider says it's %q, not %q", meta.Issuer, p.cfg.Issuer)
+	}
+
+	if meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == "" {
+		return nil, errors.New("oidc discovery document is missing endpoints")
+	}
+
+	p.meta = &meta
+	p.keys.uri = meta.JWKSURI
+
+	return p.meta, nil
+}
+
+// AuthRequest is a login in progress. It's kept until the provider sends
+// the person back so their answer can be checked against it.
+type AuthRequest struct {
+	State    string
+	Nonce    string
+	Verifier string
+}
+
+// NewAuthRequest starts a login with a random state, nonce and PKCE code
+// verifier.
+func NewAuthRequest() (*AuthRequest, error) {
+	var secrets [3]string
+
+	for i := range secrets {
+		b := make([]byte, secretBytes)
+
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+
+		secrets[i] = base64.RawURLEncoding.EncodeToString(b)
+	}
+
+	return &AuthRequest{State: secrets[0], Nonce: secrets[1], Verifier: secrets[2]}, nil
+}
+
+// Challenge returns the S256 PKCE code challenge for the verifier.
+func (req *AuthRequest) Challenge() string {
+	sum := sha256.Sum256([]byte(req.Verifier))
+
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// CheckState returns ErrStateMismatch unless state is the one req was made
+// with.
+func (req *AuthRequest) CheckState(state string) error {
+	if state == "" || subtle.ConstantTimeCompare([]byte(state), []byte(req.State)) != 1 {
+		return ErrStateMismatch
+	}
+
+	return nil
+}
+
+// AuthCodeURL returns where to send someone to log in at the provider. They
+// come back to redirectURL with a code for Exchange.
+func (p *Provider) AuthCodeURL(ctx context.Context, redirectURL string, req *AuthRequest) (string, error) {
+	meta, err := p.metadata(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(meta.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid oidc authorization endpoint: %w", err)
+	}
+
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", p.cfg.ClientID)
+	q.Set("redirect_uri", redirectURL)
+	q.Set("scope", strings.Join(append([]string{"openid"}, p.cfg.Scopes...), " "))
+	q.Set("state", req.State)
+	q.Set("nonce", req.Nonce)
+	q.Set("code_challenge", req.Challenge())
+	q.Set("code_challenge_method", "S256")
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Exchange trades the code the provider sent back for an ID token and
+// returns its claims once it's checked.
+func (p *Provider) Exchange(ctx context.Context, redirectURL, code string, req *AuthRequest) (*Claims, error) {
+	meta, err := p.metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURL},
+		"code_verifier": {req.Verifier},
+	}
+
+	basic := p.cfg.ClientSecret != "" && supportsBasicAuth(meta.TokenEndpointAuthMethods)
+	if !basic {
+		form.Set("client_id", p.cfg.ClientID)
+
+		if p.cfg.ClientSecret != "" {
+			form.Set("client_secret", p.cfg.ClientSecret)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	hr, err := http.NewRequestWithContext(ctx, http.MethodPost, meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	hr.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	hr.Header.Set("Accept", "application/json")
+
+	if basic {
+		// the client id and secret are form encoded first, RFC 6749 2.3.1
+		hr.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+
+	resp, err := p.client.Do(hr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange oidc code: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	var tr tokenResponse
+
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&tr); err != nil {
+		return nil, fmt.Errorf("failed to exchange oidc code: %s", resp.Status)
+	}
+
+	if tr.Error != "" {
+		return nil, fmt.Errorf("failed to exchange oidc code: %s: %s", tr.Error, tr.ErrorDescription)
+	}
+
+	if resp.StatusCode != http.StatusOK || tr.IDToken == "" {
+		return nil, fmt.Errorf("failed to exchange oidc code: no id token in %s response", resp.Status)
+	}
+
+	return p.VerifyIDToken(ctx, tr.IDToken, req.Nonce)
+}
+
+// supportsBasicAuth reports whether the token endpoint takes the client
+// secret in an Authorization header. It's the default when the provider
+// doesn't say.
+func supportsBasicAuth(methods []string) bool {
+	if len(methods) == 0 {
+		return true
+	}
+
+	for _, m := range methods {
+		if m == "client_secret_basic" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getJSON decodes the JSON document at u into v.
+func (p *Provider) getJSON(ctx context.Context, u string, v interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(ioutil.Discard, io.LimitReader(resp.Body, maxResponseBytes))
+
+		return fmt.Errorf("%s returned %s", u, resp.Status)
+	}
+
+	return json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(v)
+}
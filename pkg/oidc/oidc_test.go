@@ -0,0 +1,603 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyleterry/sufr/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testClientID     = "sufr"
+	testClientSecret = "s3cr3t+/="
+	testRedirectURL  = "https://sufr.example.com/login/oidc/callback"
+)
+
+// testKey is a signing key of the stand-in provider.
+type testKey struct {
+	kid string
+	alg string
+	key crypto.Signer
+}
+
+func newRSAKey(t *testing.T, kid string) *testKey {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	return &testKey{kid: kid, alg: "RS256", key: key}
+}
+
+func newECKey(t *testing.T, kid string) *testKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	return &testKey{kid: kid, alg: "ES256", key: key}
+}
+
+func (k *testKey) jwk() map[string]string {
+	b64 := func(i *big.Int) string { return base64.RawURLEncoding.EncodeToString(i.Bytes()) }
+
+	switch pub := k.key.Public().(type) {
+	case *rsa.PublicKey:
+		return map[string]string{"kty": "RSA", "kid": k.kid, "use": "sig", "alg": k.alg, "n": b64(pub.N), "e": b64(big.NewInt(int64(pub.E)))}
+	case *ecdsa.PublicKey:
+		return map[string]string{"kty": "EC", "kid": k.kid, "crv": "P-256", "x": b64(pub.X), "y": b64(pub.Y)}
+	}
+
+	return nil
+}
+
+// sign makes a JWT of claims with the key, like a provider would.
+func (k *testKey) sign(t *testing.T, header map[string]interface{}, claims map[string]interface{}) string {
+	t.Helper()
+
+	if header == nil {
+		header = map[string]interface{}{"alg": k.alg, "kid": k.kid, "typ": "JWT"}
+	}
+
+	enc := func(v interface{}) string {
+		b, err := json.Marshal(v)
+		require.NoError(t, err)
+
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+
+	signed := enc(header) + "." + enc(claims)
+	sum := sha256.Sum256([]byte(signed))
+
+	var sig []byte
+
+	switch key := k.key.(type) {
+	case *rsa.PrivateKey:
+		var err error
+		sig, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, sum[:])
+		require.NoError(t, err)
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, key, sum[:])
+		require.NoError(t, err)
+
+		sig = make([]byte, 64)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:])
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+// grant is an authorization code the stand-in provider handed out.
+type grant struct {
+	challenge   string
+	redirectURL string
+	nonce       string
+	email       string
+}
+
+// testProvider is a stand-in OpenID Connect provider. Logging in at its
+// authorization endpoint is skipped; authorize hands out codes directly.
+type testProvider struct {
+	t      *testing.T
+	server *httptest.Server
+
+	mu         sync.Mutex
+	keys       []*testKey
+	signer     *testKey
+	grants     map[string]*grant
+	keyFetches int
+}
+
+func newTestProvider(t *testing.T) *testProvider {
+	t.Helper()
+
+	key := newRSAKey(t, "key-1")
+	tp := &testProvider{
+		t:      t,
+		keys:   []*testKey{key},
+		signer: key,
+		grants: map[string]*grant{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(discoveryPath, tp.handleDiscovery)
+	mux.HandleFunc("/keys", tp.handleKeys)
+	mux.HandleFunc("/token", tp.handleToken)
+
+	tp.server = httptest.NewServer(mux)
+	t.Cleanup(tp.server.Close)
+
+	return tp
+}
+
+func (tp *testProvider) issuer() string {
+	return tp.server.URL
+}
+
+func (tp *testProvider) config() Config {
+	return Config{
+		Issuer:         tp.issuer(),
+		ClientID:       testClientID,
+		ClientSecret:   testClientSecret,
+		AllowedDomains: []string{"example.com"},
+	}
+}
+
+func (tp *testProvider) handleDiscovery(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"issuer":                                tp.issuer(),
+		"authorization_endpoint":                tp.issuer() + "/authorize",
+		"token_endpoint":                        tp.issuer() + "/token",
+		"jwks_uri":                              tp.issuer() + "/keys",
+		"token_endpoint_auth_methods_supported": []string{"client_secret_basic", "client_secret_post"},
+	})
+}
+
+func (tp *testProvider) handleKeys(w http.ResponseWriter, r *http.Request) {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+
+	tp.keyFetches++
+
+	keys := make([]map[string]string, len(tp.keys))
+	for i, k := range tp.keys {
+		keys[i] = k.jwk()
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{"keys": keys})
+}
+
+// authorize does what the provider's authorization endpoint does once
+// someone with email logs in and returns the code it sends back.
+func (tp *testProvider) authorize(authURL, email string) (code, state string) {
+	tp.t.Helper()
+
+	u, err := url.Parse(authURL)
+	require.NoError(tp.t, err)
+	require.Equal(tp.t, tp.issuer()+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+
+	q := u.Query()
+	require.Equal(tp.t, "code", q.Get("response_type"))
+	require.Equal(tp.t, testClientID, q.Get("client_id"))
+	require.Equal(tp.t, "openid email profile", q.Get("scope"))
+	require.Equal(tp.t, "S256", q.Get("code_challenge_method"))
+
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+
+	code = "code-" + q.Get("state")[:8]
+	tp.grants[code] = &grant{
+		challenge:   q.Get("code_challenge"),
+		redirectURL: q.Get("redirect_uri"),
+		nonce:       q.Get("nonce"),
+		email:       email,
+	}
+
+	return code, q.Get("state")
+}
+
+func (tp *testProvider) handleToken(w http.ResponseWriter, r *http.Request) {
+	tokenError := func(code string) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": code})
+	}
+
+	id, secret, ok := r.BasicAuth()
+	if id, _ = url.QueryUnescape(id); ok {
+		secret, _ = url.QueryUnescape(secret)
+	} else {
+		id, secret = r.PostFormValue("client_id"), r.PostFormValue("client_secret")
+	}
+
+	if id != testClientID || secret != testClientSecret {
+		tokenError("invalid_client")
+
+		return
+	}
+
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+
+	g, ok := tp.grants[r.PostFormValue("code")]
+	delete(tp.grants, r.PostFormValue("code"))
+
+	sum := sha256.Sum256([]byte(r.PostFormValue("code_verifier")))
+
+	if !ok || r.PostFormValue("grant_type") != "authorization_code" ||
+		r.PostFormValue("redirect_uri") != g.redirectURL ||
+		base64.RawURLEncoding.EncodeToString(sum[:]) != g.challenge {
+		tokenError("invalid_grant")
+
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{
+		"access_token": "unused",
+		"token_type":   "Bearer",
+		"id_token":     tp.signer.sign(tp.t, nil, tp.idClaims(g.email, g.nonce)),
+	})
+}
+
+func (tp *testProvider) idClaims(email, nonce string) map[string]interface{} {
+	now := time.Now()
+
+	return map[string]interface{}{
+		"iss":            tp.issuer(),
+		"sub":            "user-" + email,
+		"aud":            testClientID,
+		"exp":            now.Add(time.Hour).Unix(),
+		"iat":            now.Unix(),
+		"nonce":          nonce,
+		"email":          email,
+		"email_verified": true,
+		"name":           "Test User",
+	}
+}
+
+// login goes through the whole flow as email and returns the claims.
+func login(t *testing.T, tp *testProvider, p *Provider, email string) (*Claims, error) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	req, err := NewAuthRequest()
+	require.NoError(t, err)
+
+	authURL, err := p.AuthCodeURL(ctx, testRedirectURL, req)
+	require.NoError(t, err)
+
+	code, state := tp.authorize(authURL, email)
+	require.NoError(t, req.CheckState(state))
+
+	return p.Exchange(ctx, testRedirectURL, code, req)
+}
+
+func TestLogin(t *testing.T) {
+	tp := newTestProvider(t)
+	p := New(tp.config())
+
+	claims, err := login(t, tp, p, "kyle@example.com")
+	require.NoError(t, err)
+	require.Equal(t, tp.server.URL, claims.Issuer)
+	require.Equal(t, "user-kyle@example.com", claims.Subject)
+	require.Equal(t, "Test User", claims.Name)
+
+	email, ok := claims.VerifiedEmail()
+	require.True(t, ok)
+	require.Equal(t, "kyle@example.com", email)
+
+	// keys are kept between logins
+	_, err = login(t, tp, p, "kyle@example.com")
+	require.NoError(t, err)
+	require.Equal(t, 1, tp.keyFetches)
+}
+
+func TestLoginClientSecretPost(t *testing.T) {
+	tp := newTestProvider(t)
+	p := New(tp.config())
+
+	meta, err := p.metadata(context.Background())
+	require.NoError(t, err)
+
+	meta.TokenEndpointAuthMethods = []string{"client_secret_post"}
+
+	_, err = login(t, tp, p, "kyle@example.com")
+	require.NoError(t, err)
+}
+
+func TestExchangeRejects(t *testing.T) {
+	tp := newTestProvider(t)
+	p := New(tp.config())
+	ctx := context.Background()
+
+	req, err := NewAuthRequest()
+	require.NoError(t, err)
+
+	authURL, err := p.AuthCodeURL(ctx, testRedirectURL, req)
+	require.NoError(t, err)
+
+	code, state := tp.authorize(authURL, "kyle@example.com")
+
+	other, err := NewAuthRequest()
+	require.NoError(t, err)
+	require.True(t, errors.Is(other.CheckState(state), ErrStateMismatch))
+	require.True(t, errors.Is(req.CheckState(""), ErrStateMismatch))
+
+	// the code verifier has to match the challenge
+	_, err = p.Exchange(ctx, testRedirectURL, code, other)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid_grant")
+
+	// and codes only work once
+	_, err = p.Exchange(ctx, testRedirectURL, code, req)
+	require.Error(t, err)
+
+	wrongSecret := tp.config()
+	wrongSecret.ClientSecret = "nope"
+
+	_, err = login(t, tp, New(wrongSecret), "kyle@example.com")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid_client")
+}
+
+func TestVerifyIDToken(t *testing.T) {
+	tp := newTestProvider(t)
+	p := New(tp.config())
+	ctx := context.Background()
+	nonce := "the-nonce"
+
+	claims, err := p.VerifyIDToken(ctx, tp.signer.sign(t, nil, tp.idClaims("kyle@example.com", nonce)), nonce)
+	require.NoError(t, err)
+	require.Equal(t, "kyle@example.com", claims.Email)
+
+	unknownKey := newRSAKey(t, "key-1")
+
+	tests := []struct {
+		name   string
+		header map[string]interface{}
+		change func(map[string]interface{})
+		key    *testKey
+		nonce  string
+	}{
+		{name: "wrong nonce", nonce: "other"},
+		{name: "no nonce", change: func(c map[string]interface{}) { delete(c, "nonce") }},
+		{name: "wrong issuer", change: func(c map[string]interface{}) { c["iss"] = "https://evil.example.com" }},
+		{name: "wrong audience", change: func(c map[string]interface{}) { c["aud"] = "someone-else" }},
+		{name: "other authorized party", change: func(c map[string]interface{}) {
+			c["aud"] = []string{testClientID, "someone-else"}
+			c["azp"] = "someone-else"
+		}},
+		{name: "expired", change: func(c map[string]interface{}) { c["exp"] = time.Now().Add(-time.Hour).Unix() }},
+		{name: "no expiry", change: func(c map[string]interface{}) { delete(c, "exp") }},
+		{name: "issued in the future", change: func(c map[string]interface{}) { c["iat"] = time.Now().Add(time.Hour).Unix() }},
+		{name: "no subject", change: func(c map[string]interface{}) { delete(c, "sub") }},
+		{name: "unsigned", header: map[string]interface{}{"alg": "none"}},
+		{name: "hmac", header: map[string]interface{}{"alg": "HS256", "kid": "key-1"}},
+		{name: "critical header", header: map[string]interface{}{"alg": "RS256", "kid": "key-1", "crit": []string{"exp"}}},
+		{name: "wrong key", key: unknownKey},
+		{name: "wrong key type", key: newECKey(t, "key-1")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tp.idClaims("kyle@example.com", nonce)
+			if tt.change != nil {
+				tt.change(c)
+			}
+
+			key := tp.signer
+			if tt.key != nil {
+				key = tt.key
+			}
+
+			want := nonce
+			if tt.nonce != "" {
+				want = tt.nonce
+			}
+
+			_, err := p.VerifyIDToken(ctx, key.sign(t, tt.header, c), want)
+			require.True(t, errors.Is(err, ErrInvalidToken), "got %v", err)
+		})
+	}
+
+	_, err = p.VerifyIDToken(ctx, "not.a-jwt", nonce)
+	require.True(t, errors.Is(err, ErrInvalidToken))
+}
+
+func TestVerifyIDTokenClaims(t *testing.T) {
+	tp := newTestProvider(t)
+	p := New(tp.config())
+	ctx := context.Background()
+
+	c := tp.idClaims("kyle@example.com", "n")
+	c["aud"] = []string{testClientID, "other"}
+	c["azp"] = testClientID
+	c["email_verified"] = "false"
+
+	claims, err := p.VerifyIDToken(ctx, tp.signer.sign(t, nil, c), "n")
+	require.NoError(t, err)
+
+	_, ok := claims.VerifiedEmail()
+	require.False(t, ok)
+
+	delete(c, "email_verified")
+
+	claims, err = p.VerifyIDToken(ctx, tp.signer.sign(t, nil, c), "n")
+	require.NoError(t, err)
+	require.Nil(t, claims.EmailVerified)
+
+	_, ok = claims.VerifiedEmail()
+	require.False(t, ok)
+
+	c["email_verified"] = true
+
+	claims, err = p.VerifyIDToken(ctx, tp.signer.sign(t, nil, c), "n")
+	require.NoError(t, err)
+
+	email, ok := claims.VerifiedEmail()
+	require.True(t, ok)
+	require.Equal(t, "kyle@example.com", email)
+}
+
+func TestTrustedEmail(t *testing.T) {
+	yes, no := true, false
+
+	tests := []struct {
+		name     string
+		cfg      *Config
+		claims   *Claims
+		expected string
+	}{
+		{
+			name:     "verified",
+			cfg:      &Config{},
+			claims:   &Claims{Email: "kyle@example.com", EmailVerified: &yes},
+			expected: "kyle@example.com",
+		},
+		{
+			name:   "missing claim without allowed domains",
+			cfg:    &Config{},
+			claims: &Claims{Email: "kyle@example.com"},
+		},
+		{
+			name:   "missing claim outside the allowed domains",
+			cfg:    &Config{AllowedDomains: []string{"example.org"}},
+			claims: &Claims{Email: "kyle@example.com"},
+		},
+		{
+			name:     "missing claim in an allowed domain",
+			cfg:      &Config{AllowedDomains: []string{"example.com"}},
+			claims:   &Claims{Email: "kyle@example.com"},
+			expected: "kyle@example.com",
+		},
+		{
+			name:   "unverified in an allowed domain",
+			cfg:    &Config{AllowedDomains: []string{"example.com"}},
+			claims: &Claims{Email: "kyle@example.com", EmailVerified: &no},
+		},
+		{
+			name:   "no email",
+			cfg:    &Config{AllowedDomains: []string{"example.com"}},
+			claims: &Claims{EmailVerified: &yes},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			email, ok := tt.cfg.TrustedEmail(tt.claims)
+			require.Equal(t, tt.expected != "", ok)
+			require.Equal(t, tt.expected, email)
+		})
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	tp := newTestProvider(t)
+	p := New(tp.config())
+
+	now := time.Now()
+	p.now = func() time.Time { return now }
+
+	_, err := login(t, tp, p, "kyle@example.com")
+	require.NoError(t, err)
+
+	rotated := newECKey(t, "key-2")
+
+	tp.mu.Lock()
+	tp.keys = append(tp.keys, rotated)
+	tp.signer = rotated
+	tp.mu.Unlock()
+
+	// the keys were just fetched, so they aren't fetched again right away
+	_, err = login(t, tp, p, "kyle@example.com")
+	require.True(t, errors.Is(err, ErrInvalidToken))
+	require.Equal(t, 1, tp.keyFetches)
+
+	now = now.Add(keysRefetchInterval)
+
+	claims, err := login(t, tp, p, "kyle@example.com")
+	require.NoError(t, err)
+	require.Equal(t, "kyle@example.com", claims.Email)
+	require.Equal(t, 2, tp.keyFetches)
+}
+
+func TestDiscoveryIssuerMismatch(t *testing.T) {
+	tp := newTestProvider(t)
+
+	cfg := tp.config()
+	cfg.Issuer = tp.issuer() + "/"
+
+	// a trailing slash is fine
+	_, err := New(cfg).AuthCodeURL(context.Background(), testRedirectURL, &AuthRequest{})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(discoveryPath, tp.handleDiscovery)
+
+	impostor := httptest.NewServer(mux)
+	defer impostor.Close()
+
+	cfg.Issuer = impostor.URL
+
+	_, err = New(cfg).AuthCodeURL(context.Background(), testRedirectURL, &AuthRequest{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "says it's")
+}
+
+func TestAllowedEmail(t *testing.T) {
+	cfg := &Config{AllowedDomains: []string{"example.com", "@Corp.Example.org"}}
+
+	tests := []struct {
+		email   string
+		allowed bool
+	}{
+		{email: "kyle@example.com", allowed: true},
+		{email: "kyle@EXAMPLE.com", allowed: true},
+		{email: "kyle@corp.example.org", allowed: true},
+		{email: "kyle@example.org", allowed: false},
+		{email: "kyle@sub.example.com", allowed: false},
+		{email: "kyle@example.com.evil.com", allowed: false},
+		{email: "example.com", allowed: false},
+		{email: "@example.com", allowed: false},
+		{email: "kyle@", allowed: false},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.allowed, cfg.AllowedEmail(tt.email), tt.email)
+	}
+
+	require.True(t, cfg.CreatesUsers())
+	require.False(t, (&Config{}).CreatesUsers())
+}
+
+func TestFromConfig(t *testing.T) {
+	require.Nil(t, FromConfig(config.Config{}))
+
+	cfg := FromConfig(config.Config{
+		OIDCIssuer:         "https://id.example.com",
+		OIDCClientID:       "sufr",
+		OIDCClientSecret:   "secret",
+		OIDCAllowedDomains: []string{"example.com"},
+	})
+	require.Equal(t, "https://id.example.com", cfg.Issuer)
+	require.Equal(t, "sufr", cfg.ClientID)
+	require.Equal(t, "secret", cfg.ClientSecret)
+	require.Equal(t, []string{"example.com"}, cfg.AllowedDomains)
+}
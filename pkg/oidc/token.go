@@ -0,0 +1,391 @@
+package oidc
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	// hashes for the RS, PS and ES algorithms
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+)
+
+const (
+	// clockSkew is how far the provider's clock can be off from ours.
+	clockSkew = 2 * time.Minute
+	// keysRefetchInterval is how often the provider's keys can be fetched
+	// again for a token signed with one that isn't known yet.
+	keysRefetchInterval = time.Minute
+)
+
+// algorithm is a JWS algorithm ID tokens can be signed with.
+type algorithm struct {
+	kty  string
+	hash crypto.Hash
+	pss  bool
+	// curve is the curve of ES keys.
+	curve elliptic.Curve
+}
+
+var algorithms = map[string]algorithm{
+	"RS256": {kty: "RSA", hash: crypto.SHA256},
+	"RS384": {kty: "RSA", hash: crypto.SHA384},
+	"RS512": {kty: "RSA", hash: crypto.SHA512},
+	"PS256": {kty: "RSA", hash: crypto.SHA256, pss: true},
+	"PS384": {kty: "RSA", hash: crypto.SHA384, pss: true},
+	"PS512": {kty: "RSA", hash: crypto.SHA512, pss: true},
+	"ES256": {kty: "EC", hash: crypto.SHA256, curve: elliptic.P256()},
+	"ES384": {kty: "EC", hash: crypto.SHA384, curve: elliptic.P384()},
+	"ES512": {kty: "EC", hash: crypto.SHA512, curve: elliptic.P521()},
+}
+
+// verify checks that sig is a JWS signature of data made with key.
+func (a algorithm) verify(key crypto.PublicKey, data, sig []byte) bool {
+	h := a.hash.New()
+	h.Write(data)
+	sum := h.Sum(nil)
+
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		if a.kty != "RSA" {
+			return false
+		}
+
+		if a.pss {
+			return rsa.VerifyPSS(key, a.hash, sum, sig, nil) == nil
+		}
+
+		return rsa.VerifyPKCS1v15(key, a.hash, sum, sig) == nil
+	case *ecdsa.PublicKey:
+		if a.kty != "EC" || key.Curve != a.curve {
+			return false
+		}
+
+		// ES signatures are r and s side by side, each the size of the curve
+		size := (a.curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return false
+		}
+
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+
+		return ecdsa.Verify(key, sum, r, s)
+	default:
+		return false
+	}
+}
+
+// jwk is a key from the provider's JSON Web Key Set.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+var curves = map[string]elliptic.Curve{
+	"P-256": elliptic.P256(),
+	"P-384": elliptic.P384(),
+	"P-521": elliptic.P521(),
+}
+
+// publicKey decodes the key. Keys of types that aren't supported are nil.
+func (k *jwk) publicKey() crypto.PublicKey {
+	decode := func(s string) *big.Int {
+		b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+		if err != nil || len(b) == 0 {
+			return nil
+		}
+
+		return new(big.Int).SetBytes(b)
+	}
+
+	switch k.Kty {
+	case "RSA":
+		n, e := decode(k.N), decode(k.E)
+		if n == nil || e == nil || n.BitLen() < 2048 || !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}
+	case "EC":
+		curve, ok := curves[k.Crv]
+		x, y := decode(k.X), decode(k.Y)
+
+		if !ok || x == nil || y == nil || !curve.IsOnCurve(x, y) {
+			return nil
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+	default:
+		return nil
+	}
+}
+
+// keySet is the provider's signing keys. They're fetched when a token is
+// signed with one that isn't known, so keys the provider rotates in are
+// picked up.
+type keySet struct {
+	uri string
+	get func(ctx context.Context, u string, v interface{}) error
+
+	mu        sync.Mutex
+	keys      []jwk
+	fetchedAt time.Time
+}
+
+// find returns the keys that could have signed a token with the kid and alg.
+func (ks *keySet) find(ctx context.Context, kid, alg string, now time.Time) ([]crypto.PublicKey, error) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	found := ks.match(kid, alg)
+
+	if len(found) == 0 && now.Sub(ks.fetchedAt) >= keysRefetchInterval {
+		var set struct {
+			Keys []jwk `json:"keys"`
+		}
+
+		if err := ks.get(ctx, ks.uri, &set); err != nil {
+			return nil, fmt.Errorf("failed to fetch oidc keys: %w", err)
+		}
+
+		ks.keys, ks.fetchedAt = set.Keys, now
+		found = ks.match(kid, alg)
+	}
+
+	return found, nil
+}
+
+func (ks *keySet) match(kid, alg string) []crypto.PublicKey {
+	var found []crypto.PublicKey
+
+	for i := range ks.keys {
+		k := &ks.keys[i]
+
+		if (kid != "" && k.Kid != kid) || (k.Use != "" && k.Use != "sig") || (k.Alg != "" && k.Alg != alg) {
+			continue
+		}
+
+		if k.Kty != algorithms[alg].kty {
+			continue
+		}
+
+		if key := k.publicKey(); key != nil {
+			found = append(found, key)
+		}
+	}
+
+	return found
+}
+
+// Claims are what the ID token says about who logged in.
+type Claims struct {
+	// Issuer and Subject together name the account at the provider.
+	Issuer  string
+	Subject string
+	Email   string
+	// EmailVerified is nil when the provider doesn't say.
+	EmailVerified *bool
+	Name          string
+}
+
+// VerifiedEmail returns the email address and whether the provider said it
+// verified it. Addresses without email_verified aren't verified; see
+// Config.TrustedEmail for when they can be trusted anyway.
+func (c *Claims) VerifiedEmail() (string, bool) {
+	if c.Email == "" || c.EmailVerified == nil || !*c.EmailVerified {
+		return "", false
+	}
+
+	return c.Email, true
+}
+
+type header struct {
+	Alg  string   `json:"alg"`
+	Kid  string   `json:"kid"`
+	Crit []string `json:"crit"`
+}
+
+type idTokenClaims struct {
+	Issuer          string    `json:"iss"`
+	Subject         string    `json:"sub"`
+	Audience        audience  `json:"aud"`
+	AuthorizedParty string    `json:"azp"`
+	Expiry          float64   `json:"exp"`
+	IssuedAt        float64   `json:"iat"`
+	Nonce           string    `json:"nonce"`
+	Email           string    `json:"email"`
+	EmailVerified   *jsonBool `json:"email_verified"`
+	Name            string    `json:"name"`
+}
+
+// audience is the aud claim, which is either a string or a list of them.
+type audience []string
+
+func (a *audience) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*a = audience{s}
+
+		return nil
+	}
+
+	var l []string
+	if err := json.Unmarshal(b, &l); err != nil {
+		return err
+	}
+
+	*a = l
+
+	return nil
+}
+
+func (a audience) contains(s string) bool {
+	for _, v := range a {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// jsonBool is a boolean claim that some providers send as a string.
+type jsonBool bool
+
+func (jb *jsonBool) UnmarshalJSON(b []byte) error {
+	switch string(bytes.Trim(b, `"`)) {
+	case "true":
+		*jb = true
+	case "false":
+		*jb = false
+	default:
+		return fmt.Errorf("invalid boolean %s", b)
+	}
+
+	return nil
+}
+
+// VerifyIDToken checks that raw was signed by the provider for this client
+// and login and returns its claims.
+func (p *Provider) VerifyIDToken(ctx context.Context, raw, nonce string) (*Claims, error) {
+	meta, err := p.metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: not a signed JWT", ErrInvalidToken)
+	}
+
+	var h header
+
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidToken, err)
+	}
+
+	alg, ok := algorithms[h.Alg]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidToken, h.Alg)
+	}
+
+	if len(h.Crit) > 0 {
+		return nil, fmt.Errorf("%w: unsupported critical headers", ErrInvalidToken)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidToken, err)
+	}
+
+	now := p.now()
+
+	keys, err := p.keys.find(ctx, h.Kid, h.Alg, now)
+	if err != nil {
+		return nil, err
+	}
+
+	signed := []byte(parts[0] + "." + parts[1])
+	verified := false
+
+	for _, key := range keys {
+		if alg.verify(key, signed, sig) {
+			verified = true
+
+			break
+		}
+	}
+
+	if !verified {
+		return nil, fmt.Errorf("%w: signature doesn't match the provider's keys", ErrInvalidToken)
+	}
+
+	var c idTokenClaims
+
+	if err := decodeSegment(parts[1], &c); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidToken, err)
+	}
+
+	switch {
+	case c.Issuer != meta.Issuer:
+		return nil, fmt.Errorf("%w: issued by %q", ErrInvalidToken, c.Issuer)
+	case !c.Audience.contains(p.cfg.ClientID):
+		return nil, fmt.Errorf("%w: not issued for this client", ErrInvalidToken)
+	case (len(c.Audience) > 1 || c.AuthorizedParty != "") && c.AuthorizedParty != p.cfg.ClientID:
+		return nil, fmt.Errorf("%w: authorized party is %q", ErrInvalidToken, c.AuthorizedParty)
+	case c.Expiry == 0 || now.Add(-clockSkew).After(unixTime(c.Expiry)):
+		return nil, fmt.Errorf("%w: expired", ErrInvalidToken)
+	case c.IssuedAt == 0 || now.Add(clockSkew).Before(unixTime(c.IssuedAt)):
+		return nil, fmt.Errorf("%w: issued in the future", ErrInvalidToken)
+	case c.Subject == "":
+		return nil, fmt.Errorf("%w: no subject", ErrInvalidToken)
+	case nonce == "" || subtle.ConstantTimeCompare([]byte(c.Nonce), []byte(nonce)) != 1:
+		return nil, fmt.Errorf("%w: nonce doesn't match", ErrInvalidToken)
+	}
+
+	claims := &Claims{
+		Issuer:  c.Issuer,
+		Subject: c.Subject,
+		Email:   c.Email,
+		Name:    c.Name,
+	}
+
+	if c.EmailVerified != nil {
+		v := bool(*c.EmailVerified)
+		claims.EmailVerified = &v
+	}
+
+	return claims, nil
+}
+
+func decodeSegment(s string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, v)
+}
+
+func unixTime(f float64) time.Time {
+	return time.Unix(int64(f), 0)
+}
@@ -0,0 +1,768 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/sessions"
+	"github.com/kyleterry/sufr/pkg/api"
+	"github.com/kyleterry/sufr/pkg/archive"
+	"github.com/kyleterry/sufr/pkg/csrf"
+	"github.com/kyleterry/sufr/pkg/data"
+	"github.com/kyleterry/sufr/pkg/store"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	apiPrefix       = "/api/v1"
+	maxAPIBodyBytes = 1 << 20
+	apiTokenBytes   = 20
+)
+
+var apiMarshaler = protojson.MarshalOptions{UseProtoNames: true}
+
+var apiUnmarshaler = protojson.UnmarshalOptions{DiscardUnknown: true}
+
+type apiServer struct {
+	db           store.Manager
+	router       *http.ServeMux
+	sessionStore sessions.Store
+	proxyAuth    *proxyAuth
+	archiver     *archive.Archiver
+}
+
+func (s *apiServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.router.ServeHTTP(w, r)
+}
+
+func (s *apiServer) route() {
+	auth := NewAPIAuthenticationMiddleware(s.sessionStore, s.db, s.proxyAuth)
+	// only requests riding on the session cookie need a CSRF token, the
+	// X-CSRF-Token header from the page's csrf-token meta tag
+	protect := NewCSRFMiddleware(s.sessionStore,
+		csrf.WithSkip(func(r *http.Request) bool {
+			if _, ok := bearerToken(r.Header.Get("Authorization")); ok {
+				return true
+			}
+
+			_, _, ok := r.BasicAuth()
+
+			return ok
+		}),
+		csrf.WithFailureHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeAPIError(w, errAPIBadCSRFToken)
+		})),
+	)
+
+	s.router.HandleFunc("/", s.handleNotFound())
+	s.router.Handle(apiPrefix+"/urls", protect(auth(s.handleURLs())))
+	s.router.Handle(apiPrefix+"/urls/", protect(auth(s.handleURL())))
+	s.router.Handle(apiPrefix+"/tags", protect(auth(s.handleTags())))
+	s.router.Handle(apiPrefix+"/tags/", protect(auth(s.handleTag())))
+	s.router.Handle(apiPrefix+"/me", protect(auth(s.handleMe())))
+	s.router.Handle(apiPrefix+"/me/token", protect(auth(s.handleMeToken())))
+	s.router.Handle(apiPrefix+"/categories", protect(auth(s.handleCategories())))
+	s.router.Handle(apiPrefix+"/backup", protect(auth(s.handleBackup())))
+}
+
+func (s *apiServer) handleNotFound() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeAPIError(w, errAPINotFound)
+	}
+}
+
+// urlRequest is the body accepted when creating or updating a url. Fields
+// left out of an update are not changed.
+type urlRequest struct {
+	URL      string    `json:"url"`
+	Title    *string   `json:"title"`
+	Notes    *string   `json:"notes"`
+	Tags     *[]string `json:"tags"`
+	Favorite *bool     `json:"favorite"`
+	// Visibility is "private", "unlisted" or "public".
+	Visibility *string `json:"visibility"`
+}
+
+func (s *apiServer) handleURLs() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user := ctx.Value(userContextKey{}).(*api.User)
+		uum := s.db.UserURLs(user)
+
+		switch r.Method {
+		case http.MethodGet:
+			q := r.URL.Query()
+
+			filters := []store.FilterOption{
+				store.WithSearchTerm(q.Get("q")),
+				store.WithResultsAfter(q.Get("after")),
+			}
+
+			if tags := q["any_tag"]; len(tags) > 0 {
+				filters = append(filters, store.WithAnyTags(tags))
+			} else {
+				filters = append(filters, store.WithTags(q["tag"]))
+			}
+
+			if q.Get("broken") != "" {
+				broken, err := strconv.ParseBool(q.Get("broken"))
+				if err != nil {
+					writeAPIError(w, newAPIBadRequest("broken must be true or false"))
+
+					return
+				}
+
+				filters = append(filters, store.WithBroken(broken))
+			}
+
+			uus, err := uum.GetAll(ctx, filters...)
+			if err != nil {
+				writeAPIError(w, err)
+
+				return
+			}
+
+			list := &api.UserURLList{Items: uus}
+			if len(uus) > 0 {
+				list.Next = uus[len(uus)-1].Cursor
+			}
+
+			writeAPIResponse(w, http.StatusOK, list)
+		case http.MethodPost:
+			req := urlRequest{}
+
+			if err := decodeJSON(r.Body, &req); err != nil {
+				writeAPIError(w, err)
+
+				return
+			}
+
+			uu, err := createUserURL(ctx, s.db, user, req)
+			if err != nil {
+				writeAPIError(w, err)
+
+				return
+			}
+
+			writeAPIResponse(w, http.StatusCreated, uu)
+		default:
+			writeAPIError(w, errAPIMethodNotAllowed)
+		}
+	}
+}
+
+func (s *apiServer) handleURL() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user := ctx.Value(userContextKey{}).(*api.User)
+		uum := s.db.UserURLs(user)
+
+		id := strings.TrimPrefix(r.URL.Path, apiPrefix+"/urls/")
+
+		if strings.HasSuffix(id, "/checks") {
+			s.handleURLChecks(w, r, strings.TrimSuffix(id, "/checks"))
+
+			return
+		}
+
+		if id == "" || strings.Contains(id, "/") {
+			writeAPIError(w, errAPINotFound)
+
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			uu, err := uum.GetByID(ctx, id)
+			if err != nil {
+				writeAPIError(w, err)
+
+				return
+			}
+
+			writeAPIResponse(w, http.StatusOK, uu)
+		case http.MethodPut, http.MethodPatch:
+			req := urlRequest{}
+
+			if err := decodeJSON(r.Body, &req); err != nil {
+				writeAPIError(w, err)
+
+				return
+			}
+
+			uu, err := uum.GetByID(ctx, id)
+			if err != nil {
+				writeAPIError(w, err)
+
+				return
+			}
+
+			if err := applyURLRequest(ctx, s.db, uu, req); err != nil {
+				writeAPIError(w, err)
+
+				return
+			}
+
+			if err := uum.Update(ctx, uu); err != nil {
+				writeAPIError(w, err)
+
+				return
+			}
+
+			uu, err = uum.GetByID(ctx, id)
+			if err != nil {
+				writeAPIError(w, err)
+
+				return
+			}
+
+			writeAPIResponse(w, http.StatusOK, uu)
+		case http.MethodDelete:
+			if err := uum.Delete(ctx, id); err != nil {
+				writeAPIError(w, err)
+
+				return
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeAPIError(w, errAPIMethodNotAllowed)
+		}
+	}
+}
+
+// handleURLChecks responds with the link check history of one of the user's
+// urls.
+func (s *apiServer) handleURLChecks(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+	user := ctx.Value(userContextKey{}).(*api.User)
+
+	if id == "" || strings.Contains(id, "/") {
+		writeAPIError(w, errAPINotFound)
+
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		writeAPIError(w, errAPIMethodNotAllowed)
+
+		return
+	}
+
+	uu, err := s.db.UserURLs(user).GetByID(ctx, id)
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	checks, err := s.db.URLChecks().GetByURLID(ctx, uu.Url.Id)
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	writeAPIResponse(w, http.StatusOK, checks)
+}
+
+// tagRequest is the body accepted when creating or renaming a tag.
+type tagRequest struct {
+	Name string `json:"name"`
+}
+
+func (s *apiServer) handleTags() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user := ctx.Value(userContextKey{}).(*api.User)
+
+		switch r.Method {
+		case http.MethodGet:
+			tags, err := s.db.UserURLs(user).GetTags(ctx)
+			if err != nil {
+				writeAPIError(w, err)
+
+				return
+			}
+
+			writeAPIResponse(w, http.StatusOK, tags)
+		case http.MethodPost:
+			req := tagRequest{}
+
+			if err := decodeJSON(r.Body, &req); err != nil {
+				writeAPIError(w, err)
+
+				return
+			}
+
+			tags, err := getOrCreateTags(ctx, s.db, []string{req.Name})
+			if err != nil {
+				writeAPIError(w, err)
+
+				return
+			}
+
+			if len(tags.Items) == 0 {
+				writeAPIError(w, newAPIBadRequest("tag name is required"))
+
+				return
+			}
+
+			writeAPIResponse(w, http.StatusCreated, tags.Items[0])
+		default:
+			writeAPIError(w, errAPIMethodNotAllowed)
+		}
+	}
+}
+
+// handleTag gets, renames and deletes a tag. Tags are shared by everyone, so
+// renaming one moves the user's urls over to a tag with the new name and
+// deleting one takes it off the user's urls. Other users' urls keep it.
+func (s *apiServer) handleTag() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user := ctx.Value(userContextKey{}).(*api.User)
+		uum := s.db.UserURLs(user)
+
+		id := strings.TrimPrefix(r.URL.Path, apiPrefix+"/tags/")
+		if id == "" || strings.Contains(id, "/") {
+			writeAPIError(w, errAPINotFound)
+
+			return
+		}
+
+		tag, err := s.db.Tags().GetByID(ctx, id)
+		if err != nil {
+			writeAPIError(w, err)
+
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			writeAPIResponse(w, http.StatusOK, tag)
+		case http.MethodPut, http.MethodPatch:
+			req := tagRequest{}
+
+			if err := decodeJSON(r.Body, &req); err != nil {
+				writeAPIError(w, err)
+
+				return
+			}
+
+			tags, err := getOrCreateTags(ctx, s.db, []string{req.Name})
+			if err != nil {
+				writeAPIError(w, err)
+
+				return
+			}
+
+			if len(tags.Items) == 0 {
+				writeAPIError(w, newAPIBadRequest("tag name is required"))
+
+				return
+			}
+
+			if err := uum.ReplaceTag(ctx, tag, tags.Items[0]); err != nil {
+				writeAPIError(w, err)
+
+				return
+			}
+
+			writeAPIResponse(w, http.StatusOK, tags.Items[0])
+		case http.MethodDelete:
+			if err := uum.RemoveTag(ctx, tag); err != nil {
+				writeAPIError(w, err)
+
+				return
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeAPIError(w, errAPIMethodNotAllowed)
+		}
+	}
+}
+
+func (s *apiServer) handleMe() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := r.Context().Value(userContextKey{}).(*api.User)
+
+		if r.Method != http.MethodGet {
+			writeAPIError(w, errAPIMethodNotAllowed)
+
+			return
+		}
+
+		writeAPIResponse(w, http.StatusOK, publicUser(user))
+	}
+}
+
+// handleMeToken generates a new API token for the user, replacing the old
+// one. The token is only ever shown in this response. Pinboard clients use it
+// as the token part of their auth_token.
+func (s *apiServer) handleMeToken() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user := ctx.Value(userContextKey{}).(*api.User)
+
+		if r.Method != http.MethodPost {
+			writeAPIError(w, errAPIMethodNotAllowed)
+
+			return
+		}
+
+		token, err := generateAPIToken()
+		if err != nil {
+			writeAPIError(w, err)
+
+			return
+		}
+
+		updated := publicUser(user)
+		updated.ApiToken = token
+
+		if err := s.db.Users().UpdateAPIToken(ctx, updated); err != nil {
+			writeAPIError(w, err)
+
+			return
+		}
+
+		writeAPIResponse(w, http.StatusOK, updated)
+	}
+}
+
+func (s *apiServer) handleCategories() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user := ctx.Value(userContextKey{}).(*api.User)
+
+		switch r.Method {
+		case http.MethodGet:
+			writeAPIResponse(w, http.StatusOK, &api.CategoryList{Items: user.PinnedCategories})
+		case http.MethodPut:
+			b, err := ioutil.ReadAll(io.LimitReader(r.Body, maxAPIBodyBytes))
+			if err != nil {
+				writeAPIError(w, err)
+
+				return
+			}
+
+			list := &api.CategoryList{}
+
+			if err := apiUnmarshaler.Unmarshal(b, list); err != nil {
+				writeAPIError(w, newAPIBadRequest(err.Error()))
+
+				return
+			}
+
+			cats, err := updatePinnedCategories(ctx, s.db, user, list.Items)
+			if err != nil {
+				writeAPIError(w, err)
+
+				return
+			}
+
+			writeAPIResponse(w, http.StatusOK, &api.CategoryList{Items: cats})
+		default:
+			writeAPIError(w, errAPIMethodNotAllowed)
+		}
+	}
+}
+
+// createUserURL saves req as a new url for user.
+func createUserURL(ctx context.Context, db store.Manager, user *api.User, req urlRequest) (*api.UserURL, error) {
+	u, err := url.ParseRequestURI(req.URL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, newAPIBadRequest("url must be an absolute http or https url")
+	}
+
+	su, err := getOrCreateURL(ctx, db, u.String())
+	if err != nil {
+		return nil, err
+	}
+
+	uu := &api.UserURL{
+		Url:  su,
+		User: user,
+		Tags: &api.TagList{},
+	}
+
+	if err := applyURLRequest(ctx, db, uu, req); err != nil {
+		return nil, err
+	}
+
+	uum := db.UserURLs(user)
+
+	if err := uum.Create(ctx, uu); err != nil {
+		return nil, err
+	}
+
+	return uum.GetByID(ctx, uu.Id)
+}
+
+// applyURLRequest copies the fields set in req onto uu.
+func applyURLRequest(ctx context.Context, db store.Manager, uu *api.UserURL, req urlRequest) error {
+	if req.Title != nil {
+		uu.Title = *req.Title
+	}
+
+	if req.Notes != nil {
+		uu.Notes = *req.Notes
+	}
+
+	if req.Favorite != nil {
+		uu.Favorite = *req.Favorite
+	}
+
+	if req.Visibility != nil {
+		v, err := api.ParseVisibility(*req.Visibility)
+		if err != nil {
+			return newAPIBadRequest(err.Error())
+		}
+
+		uu.Visibility = v
+	}
+
+	if req.Tags != nil {
+		tags, err := getOrCreateTags(ctx, db, *req.Tags)
+		if err != nil {
+			return err
+		}
+
+		uu.Tags = tags
+	}
+
+	return nil
+}
+
+// updatePinnedCategories replaces the pinned categories of user with cats and
+// returns them as saved. Tags can be referenced by name so clients don't
+// need to look up ids first.
+func updatePinnedCategories(ctx context.Context, db store.Manager, user *api.User, cats []*api.Category) ([]*api.Category, error) {
+	for _, cat := range cats {
+		if cat.Label == "" {
+			return nil, newAPIBadRequest("category label is required")
+		}
+
+		if cat.Tags == nil {
+			cat.Tags = &api.TagList{}
+		}
+
+		for i, tag := range cat.Tags.Items {
+			if tag.Id != "" {
+				continue
+			}
+
+			tags, err := getOrCreateTags(ctx, db, []string{tag.Name})
+			if err != nil {
+				return nil, err
+			}
+
+			if len(tags.Items) == 0 {
+				return nil, newAPIBadRequest("tags need an id or a name")
+			}
+
+			cat.Tags.Items[i] = tags.Items[0]
+		}
+	}
+
+	updated := proto.Clone(user).(*api.User)
+	updated.PinnedCategories = cats
+
+	if err := db.Users().UpdatePinnedCategories(ctx, updated); err != nil {
+		return nil, err
+	}
+
+	updated, err := db.Users().GetByID(ctx, user.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated.PinnedCategories, nil
+}
+
+// getOrCreateURL returns the URL for rawurl, creating it first if it doesn't
+// exist yet. New urls get a placeholder title and a queued job to fetch
+// their metadata.
+func getOrCreateURL(ctx context.Context, db store.Manager, rawurl string) (*api.URL, error) {
+	u, err := db.URLs().GetByURL(ctx, rawurl)
+	if err == nil {
+		return u, nil
+	}
+
+	if !errors.Is(err, store.ErrNotFound) {
+		return nil, err
+	}
+
+	err = db.Transaction(ctx, func(ctx context.Context, tx store.Manager) error {
+		if err := tx.URLs().Create(ctx, &api.URL{Url: rawurl, Title: data.DefaultURLTitle}); err != nil {
+			return err
+		}
+
+		u, err = tx.URLs().GetByURL(ctx, rawurl)
+		if err != nil {
+			return err
+		}
+
+		return tx.FetchJobs().Enqueue(ctx, u)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// getOrCreateTags returns a TagList for names, creating any tags that don't
+// exist yet. Empty and duplicate names are skipped.
+func getOrCreateTags(ctx context.Context, db store.Manager, names []string) (*api.TagList, error) {
+	tl := &api.TagList{}
+	seen := make(map[string]bool, len(names))
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+
+		seen[name] = true
+
+		tag, err := db.Tags().GetByName(ctx, name)
+		if errors.Is(err, store.ErrNotFound) {
+			if err := db.Tags().Create(ctx, &api.Tag{Name: name}); err != nil {
+				return nil, err
+			}
+
+			tag, err = db.Tags().GetByName(ctx, name)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		tl.Items = append(tl.Items, tag)
+	}
+
+	return tl, nil
+}
+
+func generateAPIToken() (string, error) {
+	b := make([]byte, apiTokenBytes)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// publicUser returns a copy of user without any credentials.
+func publicUser(user *api.User) *api.User {
+	u := proto.Clone(user).(*api.User)
+	u.PasswordHash = nil
+	u.ApiToken = ""
+
+	return u
+}
+
+func decodeJSON(body io.Reader, v interface{}) error {
+	if err := json.NewDecoder(io.LimitReader(body, maxAPIBodyBytes)).Decode(v); err != nil {
+		return newAPIBadRequest(fmt.Sprintf("invalid request body: %s", err))
+	}
+
+	return nil
+}
+
+// apiError is an error that is safe to show to API clients as is.
+type apiError struct {
+	status  int
+	code    string
+	message string
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+var (
+	errAPINotFound         = &apiError{status: http.StatusNotFound, code: "not_found", message: "not found"}
+	errAPIMethodNotAllowed = &apiError{status: http.StatusMethodNotAllowed, code: "method_not_allowed", message: "method not allowed"}
+	errAPIBadCSRFToken     = &apiError{status: http.StatusForbidden, code: "bad_csrf_token", message: csrf.ErrBadToken.Error()}
+)
+
+func newAPIBadRequest(msg string) *apiError {
+	return &apiError{status: http.StatusBadRequest, code: "bad_request", message: msg}
+}
+
+// toAPIError maps err to an apiError. Store errors keep their message, while
+// anything unexpected is logged and hidden behind a generic message.
+func toAPIError(err error) *apiError {
+	var ae *apiError
+
+	switch {
+	case errors.As(err, &ae):
+		return ae
+	case errors.Is(err, store.ErrNotFound):
+		return &apiError{status: http.StatusNotFound, code: "not_found", message: store.ErrNotFound.Error()}
+	case errors.Is(err, store.ErrAlreadyExists):
+		return &apiError{status: http.StatusConflict, code: "already_exists", message: store.ErrAlreadyExists.Error()}
+	case errors.Is(err, store.ErrInvalidCursor):
+		return &apiError{status: http.StatusBadRequest, code: "invalid_cursor", message: store.ErrInvalidCursor.Error()}
+	case errors.Is(err, store.ErrInvalidDependency):
+		return &apiError{status: http.StatusUnprocessableEntity, code: "invalid_dependency", message: store.ErrInvalidDependency.Error()}
+	default:
+		log.Println(err)
+
+		return &apiError{status: http.StatusInternalServerError, code: "internal", message: "internal server error"}
+	}
+}
+
+func writeAPIError(w http.ResponseWriter, err error) {
+	ae := toAPIError(err)
+
+	body := struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}{}
+
+	body.Error.Code = ae.code
+	body.Error.Message = ae.message
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(ae.status)
+
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Println(err)
+	}
+}
+
+func writeAPIResponse(w http.ResponseWriter, status int, m proto.Message) {
+	b, err := apiMarshaler.Marshal(m)
+	if err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if _, err := w.Write(b); err != nil {
+		log.Println(err)
+	}
+}
@@ -0,0 +1,380 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kyleterry/sufr/pkg/api"
+	"github.com/kyleterry/sufr/pkg/store"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// apiRequest sends a request to the JSON API of srv as email, with
+// password "password", and returns the response.
+func apiRequest(t *testing.T, srv http.Handler, email, method, path string, body io.Reader) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, path, body)
+
+	if email != "" {
+		r.SetBasicAuth(email, "password")
+	}
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, r)
+
+	return w
+}
+
+// decodeAPIResponse decodes the body of w into m.
+func decodeAPIResponse(t *testing.T, w *httptest.ResponseRecorder, m proto.Message) {
+	require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	require.NoError(t, apiUnmarshaler.Unmarshal(w.Body.Bytes(), m))
+}
+
+// apiErrorCode returns the error code in the body of w.
+func apiErrorCode(t *testing.T, w *httptest.ResponseRecorder) string {
+	body := struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}{}
+
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+
+	return body.Error.Code
+}
+
+func TestAPIAuthentication(t *testing.T) {
+	db := newTestStore(t)
+
+	srv, err := New(WithStore(db))
+	require.NoError(t, err)
+
+	mustCreateUser(t, db, "kyle@example.com", false)
+	mustCreateUser(t, db, "gone@example.com", true)
+
+	totpUser := mustCreateUser(t, db, "totp@example.com", false)
+	require.NoError(t, db.Users().EnableTOTP(context.Background(), totpUser, "JBSWY3DPEHPK3PXP", nil))
+
+	tests := []struct {
+		name   string
+		email  string
+		status int
+	}{
+		{name: "password", email: "kyle@example.com", status: http.StatusOK},
+		{name: "nothing", status: http.StatusUnauthorized},
+		{name: "unknown user", email: "nobody@example.com", status: http.StatusUnauthorized},
+		{name: "disabled user", email: "gone@example.com", status: http.StatusUnauthorized},
+		{name: "two-factor user", email: "totp@example.com", status: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := apiRequest(t, srv, tt.email, http.MethodGet, apiPrefix+"/me", nil)
+			require.Equal(t, tt.status, w.Code)
+
+			if tt.status == http.StatusUnauthorized {
+				require.Equal(t, "unauthorized", apiErrorCode(t, w))
+				require.NotEmpty(t, w.Header().Get("WWW-Authenticate"))
+
+				return
+			}
+
+			me := &api.User{}
+			decodeAPIResponse(t, w, me)
+			require.Equal(t, tt.email, me.Email)
+			require.Empty(t, me.PasswordHash)
+		})
+	}
+
+	totpUser.ApiToken = "0123456789abcdef"
+	require.NoError(t, db.Users().UpdateAPIToken(context.Background(), totpUser))
+
+	t.Run("api token", func(t *testing.T) {
+		for token, status := range map[string]int{
+			"0123456789abcdef": http.StatusOK,
+			"0123456789abcdee": http.StatusUnauthorized,
+			"":                 http.StatusUnauthorized,
+		} {
+			// writes with a token don't need a CSRF token either
+			r := httptest.NewRequest(http.MethodPost, apiPrefix+"/urls", strings.NewReader(`{"url": "https://example.com"}`))
+			r.Header.Set("Authorization", "Bearer "+token)
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, r)
+
+			if status == http.StatusOK {
+				require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+			} else {
+				require.Equal(t, status, w.Code, token)
+			}
+		}
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, apiPrefix+"/me", nil)
+		r.SetBasicAuth("kyle@example.com", "wrong")
+
+		w := httptest.NewRecorder()
+		srv.ServeHTTP(w, r)
+		require.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestToAPIError(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{err: newAPIBadRequest("no"), status: http.StatusBadRequest, code: "bad_request"},
+		{err: fmt.Errorf("wrapped: %w", errAPIMethodNotAllowed), status: http.StatusMethodNotAllowed, code: "method_not_allowed"},
+		{err: fmt.Errorf("failed to get UserURL: %w", store.ErrNotFound), status: http.StatusNotFound, code: "not_found"},
+		{err: store.ErrAlreadyExists, status: http.StatusConflict, code: "already_exists"},
+		{err: store.ErrInvalidDependency, status: http.StatusUnprocessableEntity, code: "invalid_dependency"},
+		{err: store.ErrInvalidCursor, status: http.StatusBadRequest, code: "invalid_cursor"},
+		{err: errors.New("disk on fire"), status: http.StatusInternalServerError, code: "internal"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			ae := toAPIError(tt.err)
+			require.Equal(t, tt.status, ae.status)
+			require.Equal(t, tt.code, ae.code)
+
+			if tt.code == "internal" {
+				require.NotContains(t, ae.message, tt.err.Error())
+			}
+		})
+	}
+}
+
+func TestAPIURLs(t *testing.T) {
+	db := newTestStore(t)
+
+	srv, err := New(WithStore(db))
+	require.NoError(t, err)
+
+	const email = "kyle@example.com"
+
+	mustCreateUser(t, db, email, false)
+	mustCreateUser(t, db, "other@example.com", false)
+
+	create := func(t *testing.T, body string) *api.UserURL {
+		w := apiRequest(t, srv, email, http.MethodPost, apiPrefix+"/urls", strings.NewReader(body))
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		uu := &api.UserURL{}
+		decodeAPIResponse(t, w, uu)
+
+		return uu
+	}
+
+	list := func(t *testing.T, query string) *api.UserURLList {
+		w := apiRequest(t, srv, email, http.MethodGet, apiPrefix+"/urls"+query, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		l := &api.UserURLList{}
+		decodeAPIResponse(t, w, l)
+
+		return l
+	}
+
+	first := create(t, `{"url": "https://example.com/1", "title": "first", "tags": ["go"]}`)
+	second := create(t, `{"url": "https://example.com/2", "visibility": "public"}`)
+	third := create(t, `{"url": "https://example.com/3", "tags": ["go", "sql"]}`)
+
+	t.Run("creates", func(t *testing.T) {
+		require.Equal(t, "first", first.Title)
+		require.Len(t, first.Tags.Items, 1)
+		require.Equal(t, api.Visibility_VISIBILITY_PUBLIC, second.Visibility)
+	})
+
+	t.Run("refuses bad urls", func(t *testing.T) {
+		for _, body := range []string{`{"url": "ftp://example.com"}`, `{"url": "example.com"}`, `{"url": `, `{"url": "https://example.com/4", "visibility": "secret"}`} {
+			w := apiRequest(t, srv, email, http.MethodPost, apiPrefix+"/urls", strings.NewReader(body))
+			require.Equal(t, http.StatusBadRequest, w.Code, body)
+			require.Equal(t, "bad_request", apiErrorCode(t, w))
+		}
+	})
+
+	t.Run("lists newest first", func(t *testing.T) {
+		l := list(t, "")
+		require.Len(t, l.Items, 3)
+		require.Equal(t, third.Id, l.Items[0].Id)
+		require.Equal(t, first.Id, l.Items[2].Id)
+		require.Equal(t, l.Items[2].Cursor, l.Next)
+	})
+
+	t.Run("pages with after", func(t *testing.T) {
+		l := list(t, "")
+
+		page := list(t, "?after="+l.Items[0].Cursor)
+		require.Len(t, page.Items, 2)
+		require.Equal(t, second.Id, page.Items[0].Id)
+
+		page = list(t, "?after="+l.Next)
+		require.Empty(t, page.Items)
+		require.Empty(t, page.Next)
+
+		w := apiRequest(t, srv, email, http.MethodGet, apiPrefix+"/urls?after=12", nil)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Equal(t, "invalid_cursor", apiErrorCode(t, w))
+	})
+
+	t.Run("filters by tag", func(t *testing.T) {
+		l := list(t, "?tag=go&tag=sql")
+		require.Len(t, l.Items, 1)
+		require.Equal(t, third.Id, l.Items[0].Id)
+
+		l = list(t, "?any_tag=go&any_tag=sql")
+		require.Len(t, l.Items, 2)
+	})
+
+	t.Run("updates only what's set", func(t *testing.T) {
+		w := apiRequest(t, srv, email, http.MethodPatch, apiPrefix+"/urls/"+first.Id, strings.NewReader(`{"favorite": true}`))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		uu := &api.UserURL{}
+		decodeAPIResponse(t, w, uu)
+		require.True(t, uu.Favorite)
+		require.Equal(t, "first", uu.Title)
+		require.Len(t, uu.Tags.Items, 1)
+	})
+
+	t.Run("keeps users apart", func(t *testing.T) {
+		w := apiRequest(t, srv, "other@example.com", http.MethodGet, apiPrefix+"/urls/"+first.Id, nil)
+		require.Equal(t, http.StatusNotFound, w.Code)
+		require.Equal(t, "not_found", apiErrorCode(t, w))
+
+		w = apiRequest(t, srv, "other@example.com", http.MethodDelete, apiPrefix+"/urls/"+first.Id, nil)
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("deletes", func(t *testing.T) {
+		w := apiRequest(t, srv, email, http.MethodDelete, apiPrefix+"/urls/"+second.Id, nil)
+		require.Equal(t, http.StatusNoContent, w.Code)
+
+		w = apiRequest(t, srv, email, http.MethodGet, apiPrefix+"/urls/"+second.Id, nil)
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("refuses unknown methods and paths", func(t *testing.T) {
+		w := apiRequest(t, srv, email, http.MethodPut, apiPrefix+"/urls", nil)
+		require.Equal(t, http.StatusMethodNotAllowed, w.Code)
+		require.Equal(t, "method_not_allowed", apiErrorCode(t, w))
+
+		w = apiRequest(t, srv, email, http.MethodGet, apiPrefix+"/nothing", nil)
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestAPITags(t *testing.T) {
+	db := newTestStore(t)
+
+	srv, err := New(WithStore(db))
+	require.NoError(t, err)
+
+	const email = "kyle@example.com"
+
+	mustCreateUser(t, db, email, false)
+	mustCreateUser(t, db, "other@example.com", false)
+
+	for _, u := range []string{email, "other@example.com"} {
+		w := apiRequest(t, srv, u, http.MethodPost, apiPrefix+"/urls", strings.NewReader(`{"url": "https://example.com", "tags": ["golang"]}`))
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	tags := func(t *testing.T, user string) []string {
+		w := apiRequest(t, srv, user, http.MethodGet, apiPrefix+"/tags", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		l := &api.TagList{}
+		decodeAPIResponse(t, w, l)
+
+		names := []string{}
+		for _, tag := range l.Items {
+			names = append(names, tag.Name)
+		}
+
+		return names
+	}
+
+	golang, err := db.Tags().GetByName(context.Background(), "golang")
+	require.NoError(t, err)
+
+	t.Run("gets", func(t *testing.T) {
+		w := apiRequest(t, srv, email, http.MethodGet, apiPrefix+"/tags/"+golang.Id, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		tag := &api.Tag{}
+		decodeAPIResponse(t, w, tag)
+		require.Equal(t, "golang", tag.Name)
+
+		w = apiRequest(t, srv, email, http.MethodGet, apiPrefix+"/tags/missing", nil)
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("renames for the user only", func(t *testing.T) {
+		w := apiRequest(t, srv, email, http.MethodPut, apiPrefix+"/tags/"+golang.Id, strings.NewReader(`{"name": "go"}`))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		tag := &api.Tag{}
+		decodeAPIResponse(t, w, tag)
+		require.Equal(t, "go", tag.Name)
+
+		require.Equal(t, []string{"go"}, tags(t, email))
+		require.Equal(t, []string{"golang"}, tags(t, "other@example.com"))
+
+		w = apiRequest(t, srv, email, http.MethodPut, apiPrefix+"/tags/"+tag.Id, strings.NewReader(`{"name": " "}`))
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("deletes for the user only", func(t *testing.T) {
+		w := apiRequest(t, srv, "other@example.com", http.MethodDelete, apiPrefix+"/tags/"+golang.Id, nil)
+		require.Equal(t, http.StatusNoContent, w.Code)
+
+		require.Empty(t, tags(t, "other@example.com"))
+		require.Equal(t, []string{"go"}, tags(t, email))
+	})
+}
+
+func TestAPIBackup(t *testing.T) {
+	db := newTestStore(t)
+	ctx := context.Background()
+
+	srv, err := New(WithStore(db))
+	require.NoError(t, err)
+
+	admin := mustCreateUser(t, db, "admin@example.com", false)
+	admin.Admin = true
+	require.NoError(t, db.Users().UpdateAccess(ctx, admin))
+
+	admin.ApiToken = "0123456789abcdef"
+	require.NoError(t, db.Users().UpdateAPIToken(ctx, admin))
+
+	mustCreateUser(t, db, "kyle@example.com", false)
+
+	t.Run("admins take backups with their api token", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, apiPrefix+"/backup", nil)
+		r.Header.Set("Authorization", "Bearer "+admin.ApiToken)
+
+		w := httptest.NewRecorder()
+		srv.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NoError(t, db.RestoreBackup(ctx, w.Body))
+	})
+
+	t.Run("everyone else gets not found", func(t *testing.T) {
+		w := apiRequest(t, srv, "kyle@example.com", http.MethodGet, apiPrefix+"/backup", nil)
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
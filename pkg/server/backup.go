@@ -0,0 +1,111 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/kyleterry/sufr/pkg/api"
+	"github.com/kyleterry/sufr/pkg/archive"
+	"github.com/kyleterry/sufr/pkg/store"
+)
+
+// maxBackupFileBytes is the biggest backup that can be uploaded to restore.
+const maxBackupFileBytes = 1 << 30
+
+// handleAdminBackup downloads a backup on GET and restores one on POST.
+// Restoring replaces every account and bookmark, so the admin has to give
+// their password again.
+func (s *uiServer) handleAdminBackup() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user := ctx.Value(userContextKey{}).(*api.User)
+
+		backuper, ok := s.db.(store.Backuper)
+		if !ok {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			writeBackup(w, r, backuper, s.archiver)
+		case http.MethodPost:
+			if _, err := s.db.Users().GetByEmailAndPassword(ctx, user.Email, r.PostFormValue("password")); err != nil {
+				s.addFlash(w, r, "danger", "The password is wrong.")
+				http.Redirect(w, r, "/admin/users", http.StatusSeeOther)
+
+				return
+			}
+
+			f, _, err := r.FormFile("backup")
+			if err != nil {
+				s.addFlash(w, r, "danger", "A backup file is required.")
+				http.Redirect(w, r, "/admin/users", http.StatusSeeOther)
+
+				return
+			}
+			defer f.Close()
+
+			if err := backuper.RestoreBackup(ctx, f); err != nil {
+				if !errors.Is(err, store.ErrInvalidBackup) {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+
+					return
+				}
+
+				s.addFlash(w, r, "danger", fmt.Sprintf("The backup can't be restored: %s.", err))
+				http.Redirect(w, r, "/admin/users", http.StatusSeeOther)
+
+				return
+			}
+
+			log.Printf("%s restored a backup", user.Email)
+
+			s.addFlash(w, r, "success", "Backup restored.")
+			http.Redirect(w, r, "/admin/users", http.StatusSeeOther)
+		default:
+			http.NotFound(w, r)
+		}
+	}
+}
+
+// handleBackup downloads a backup, for admins that take them from cron jobs
+// with their API token.
+func (s *apiServer) handleBackup() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := r.Context().Value(userContextKey{}).(*api.User)
+
+		if r.Method != http.MethodGet {
+			writeAPIError(w, errAPIMethodNotAllowed)
+
+			return
+		}
+
+		backuper, ok := s.db.(store.Backuper)
+		if !user.Admin || !ok {
+			writeAPIError(w, errAPINotFound)
+
+			return
+		}
+
+		writeBackup(w, r, backuper, s.archiver)
+	}
+}
+
+// writeBackup sends a gzipped tarball of the database and archived pages.
+func writeBackup(w http.ResponseWriter, r *http.Request, backuper store.Backuper, archiver *archive.Archiver) {
+	filename := fmt.Sprintf("sufr-backup-%s.tar.gz", time.Now().UTC().Format("2006-01-02"))
+
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	// the download has started by the time anything can go wrong so all
+	// that's left to do is log it
+	if err := backuper.WriteBackup(r.Context(), w, archiver); err != nil {
+		log.Println(err)
+	}
+}
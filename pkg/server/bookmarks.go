@@ -0,0 +1,324 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/kyleterry/sufr/pkg/api"
+	"github.com/kyleterry/sufr/pkg/csrf"
+	"github.com/kyleterry/sufr/pkg/netscape"
+	"github.com/kyleterry/sufr/pkg/store"
+)
+
+const maxBookmarkFileBytes = 32 << 20
+
+// folderMode decides what bookmark folders turn into on import.
+type folderMode string
+
+const (
+	// folderModeTags tags every bookmark with the names of all the folders
+	// it was found in.
+	folderModeTags folderMode = "tags"
+	// folderModeCategories turns each top level folder into a pinned
+	// category. Bookmarks are tagged with the name of the folder they were
+	// found in and the category collects those tags.
+	folderModeCategories folderMode = "categories"
+)
+
+type importSummary struct {
+	Imported   int
+	Duplicates int
+	Skipped    int
+	Categories int
+}
+
+type bookmarksData struct {
+	templateData
+	Summary *importSummary
+	Error   string
+}
+
+// importBookmarks saves bookmarks as urls for user. Everything happens in
+// one transaction so a failed import leaves nothing behind. Bookmarks the
+// user already has are counted as duplicates and left alone.
+func importBookmarks(ctx context.Context, db store.Manager, user *api.User, bookmarks []*netscape.Bookmark, mode folderMode) (*importSummary, error) {
+	summary := &importSummary{}
+
+	err := db.Transaction(ctx, func(ctx context.Context, tx store.Manager) error {
+		*summary = importSummary{}
+
+		// category labels in the order they were first seen, and the tags
+		// each one collects
+		labels := []string{}
+		categoryTags := map[string][]string{}
+
+		for _, b := range bookmarks {
+			u, err := url.ParseRequestURI(b.URL)
+			if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+				summary.Skipped++
+
+				continue
+			}
+
+			names := append([]string{}, b.Tags...)
+
+			switch mode {
+			case folderModeCategories:
+				if len(b.Folders) > 0 {
+					label := b.Folders[0]
+					tag := folderTag(b.Folders[len(b.Folders)-1])
+					names = append(names, tag)
+
+					if _, ok := categoryTags[label]; !ok {
+						labels = append(labels, label)
+					}
+
+					categoryTags[label] = append(categoryTags[label], tag)
+				}
+			default:
+				for _, folder := range b.Folders {
+					names = append(names, folderTag(folder))
+				}
+			}
+
+			su, err := getOrCreateURL(ctx, tx, u.String())
+			if err != nil {
+				return err
+			}
+
+			tags, err := getOrCreateTags(ctx, tx, names)
+			if err != nil {
+				return err
+			}
+
+			uu := &api.UserURL{
+				Url:   su,
+				User:  user,
+				Title: b.Title,
+				Notes: b.Notes,
+				Tags:  tags,
+			}
+
+			// only bookmarks marked PRIVATE="0" are made public. Browsers
+			// don't mark bookmarks at all, and those start out private.
+			if b.Public {
+				uu.Visibility = api.Visibility_VISIBILITY_PUBLIC
+			}
+
+			if !b.AddDate.IsZero() {
+				uu.CreatedAt = timestamp(b.AddDate)
+			}
+
+			if !b.LastModified.IsZero() {
+				uu.UpdatedAt = timestamp(b.LastModified)
+			}
+
+			if err := tx.UserURLs(user).Create(ctx, uu); err != nil {
+				if errors.Is(err, store.ErrAlreadyExists) {
+					summary.Duplicates++
+
+					continue
+				}
+
+				return fmt.Errorf("failed to import %s: %w", b.URL, err)
+			}
+
+			summary.Imported++
+		}
+
+		if len(labels) == 0 {
+			return nil
+		}
+
+		current, err := tx.Users().GetByID(ctx, user.Id)
+		if err != nil {
+			return err
+		}
+
+		cats := current.PinnedCategories
+
+		for _, label := range labels {
+			cat := findCategory(cats, label)
+			if cat == nil {
+				cat = &api.Category{Label: label, Tags: &api.TagList{}}
+				cats = append(cats, cat)
+				summary.Categories++
+			}
+
+			for _, name := range categoryTags[label] {
+				if !hasTag(cat.Tags, name) {
+					cat.Tags.Items = append(cat.Tags.Items, &api.Tag{Name: name})
+				}
+			}
+		}
+
+		_, err = updatePinnedCategories(ctx, tx, current, cats)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// exportBookmarks returns every url user has saved as bookmarks.
+func exportBookmarks(ctx context.Context, db store.Manager, user *api.User) ([]*netscape.Bookmark, error) {
+	bookmarks := []*netscape.Bookmark{}
+
+	err := eachUserURL(ctx, db.UserURLs(user), store.WithTags(nil), 0, func(uu *api.UserURL) bool {
+		b := &netscape.Bookmark{
+			URL:     uu.Url.Url,
+			Title:   uu.DerivedTitle,
+			Notes:   uu.Notes,
+			Private: uu.Visibility != api.Visibility_VISIBILITY_PUBLIC,
+			AddDate: uu.CreatedAt.AsTime(),
+		}
+
+		if uu.UpdatedAt != nil {
+			b.LastModified = uu.UpdatedAt.AsTime()
+		}
+
+		if uu.Tags != nil {
+			for _, t := range uu.Tags.Items {
+				b.Tags = append(b.Tags, t.Name)
+			}
+		}
+
+		bookmarks = append(bookmarks, b)
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return bookmarks, nil
+}
+
+func (s *uiServer) handleBookmarks() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user := ctx.Value(userContextKey{}).(*api.User)
+		td := bookmarksData{
+			templateData: templateData{
+				User:      user,
+				Title:     "bookmarks",
+				CSRFToken: csrf.Token(r),
+			},
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPost:
+			r.Body = http.MaxBytesReader(w, r.Body, maxBookmarkFileBytes)
+
+			summary, err := s.importBookmarkFile(r, user)
+			if err != nil {
+				td.Error = err.Error()
+			}
+
+			td.Summary = summary
+		default:
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		err := s.templates.withWriter("bookmarks/index", func(tw *templateWriter) error {
+			return tw.write(w, r, td)
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+	}
+}
+
+func (s *uiServer) importBookmarkFile(r *http.Request, user *api.User) (*importSummary, error) {
+	f, _, err := r.FormFile("file")
+	if err != nil {
+		return nil, errors.New("a bookmark file is required")
+	}
+	defer f.Close()
+
+	bookmarks, err := netscape.Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bookmark file: %w", err)
+	}
+
+	mode := folderMode(r.FormValue("folders"))
+	if mode != folderModeCategories {
+		mode = folderModeTags
+	}
+
+	return importBookmarks(r.Context(), s.db, user, bookmarks, mode)
+}
+
+func (s *uiServer) handleBookmarksExport() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user := ctx.Value(userContextKey{}).(*api.User)
+
+		bookmarks, err := exportBookmarks(ctx, s.db, user)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		filename := fmt.Sprintf("sufr-bookmarks-%s.html", time.Now().UTC().Format("2006-01-02"))
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+		if err := netscape.Write(w, bookmarks); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+	}
+}
+
+// folderTag turns a folder name into a tag. Tags can't contain spaces.
+func folderTag(folder string) string {
+	return strings.Join(strings.Fields(strings.ToLower(folder)), "-")
+}
+
+func findCategory(cats []*api.Category, label string) *api.Category {
+	for _, cat := range cats {
+		if cat.Label == label {
+			if cat.Tags == nil {
+				cat.Tags = &api.TagList{}
+			}
+
+			return cat
+		}
+	}
+
+	return nil
+}
+
+func hasTag(tl *api.TagList, name string) bool {
+	for _, t := range tl.Items {
+		if t.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func timestamp(t time.Time) *api.Timestamp {
+	ts := &api.Timestamp{}
+	ts.SetFromGoTime(t)
+
+	return ts
+}
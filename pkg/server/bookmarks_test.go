@@ -0,0 +1,46 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/kyleterry/sufr/pkg/api"
+	"github.com/kyleterry/sufr/pkg/netscape"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportBookmarksVisibility(t *testing.T) {
+	db := newTestStore(t)
+	ctx := context.Background()
+
+	user := mustCreateUser(t, db, "kyle@example.com", false)
+
+	bookmarks, err := netscape.Parse(strings.NewReader(`<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<DL><p>
+    <DT><A HREF="https://example.com/public" PRIVATE="0">Public</A>
+    <DT><A HREF="https://example.com/private" PRIVATE="1">Private</A>
+    <DT><A HREF="https://example.com/unmarked">Unmarked</A>
+</DL><p>
+`))
+	require.NoError(t, err)
+
+	summary, err := importBookmarks(ctx, db, user, bookmarks, folderModeTags)
+	require.NoError(t, err)
+	require.Equal(t, 3, summary.Imported)
+
+	uus, err := db.UserURLs(user).GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, uus, 3)
+
+	visibilities := map[string]api.Visibility{}
+	for _, uu := range uus {
+		visibilities[uu.Title] = uu.Visibility
+	}
+
+	require.Equal(t, map[string]api.Visibility{
+		"Public":   api.Visibility_VISIBILITY_PUBLIC,
+		"Private":  api.Visibility_VISIBILITY_PRIVATE,
+		"Unmarked": api.Visibility_VISIBILITY_PRIVATE,
+	}, visibilities)
+}
@@ -0,0 +1,222 @@
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/kyleterry/sufr/pkg/api"
+	"github.com/kyleterry/sufr/pkg/feed"
+	"github.com/kyleterry/sufr/pkg/store"
+)
+
+// feedSize is how many of the newest urls a feed has.
+const feedSize = 50
+
+// handleFeed serves the Atom and RSS feeds of a user at
+// /feeds/{id}/{name}.atom and /feeds/{id}/{name}.rss. The names are
+// timeline, favorites, search, which takes q like /search, and tags/{tag}.
+//
+// Feeds only have the urls the user made public, unless their API token is
+// passed as token. Feed readers can't log in, so that's the only way to
+// follow everything they saved.
+func (s *uiServer) handleFeed() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		id, name := strings.TrimPrefix(r.URL.Path, "/feeds/"), ""
+
+		if i := strings.IndexByte(id, '/'); i >= 0 {
+			id, name = id[:i], id[i+1:]
+		}
+
+		ext := path.Ext(name)
+		name = strings.TrimSuffix(name, ext)
+
+		write, contentType := feed.WriteAtom, "application/atom+xml; charset=utf-8"
+
+		switch ext {
+		case ".atom":
+		case ".rss":
+			write, contentType = feed.WriteRSS, "application/rss+xml; charset=utf-8"
+		default:
+			http.NotFound(w, r)
+
+			return
+		}
+
+		owner, err := s.db.Users().GetByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				http.NotFound(w, r)
+
+				return
+			}
+
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		if owner.Disabled {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		private := false
+
+		if token := r.URL.Query().Get("token"); token != "" {
+			// only lookups by email come with credentials
+			creds, err := s.db.Users().GetByEmail(ctx, owner.Email)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+
+				return
+			}
+
+			if creds.ApiToken == "" || subtle.ConstantTimeCompare([]byte(creds.ApiToken), []byte(token)) != 1 {
+				http.Error(w, "401 Unauthorized", http.StatusUnauthorized)
+
+				return
+			}
+
+			private = true
+		}
+
+		site := s.siteURL(r)
+		link := func(p string, q url.Values) string {
+			u := *site
+			u.Path = p
+			u.RawQuery = q.Encode()
+
+			return u.String()
+		}
+
+		f := &feed.Feed{
+			ID:         link(r.URL.Path, nil),
+			Link:       link("/u/"+owner.Id, nil),
+			AuthorName: owner.Id,
+			AuthorURI:  link("/u/"+owner.Id, nil),
+			Updated:    owner.CreatedAt.AsTime(),
+		}
+
+		filters := []store.FilterOption{
+			store.WithPublic(!private),
+			store.WithLimit(feedSize),
+		}
+
+		switch {
+		case name == "timeline":
+			f.Title = "Bookmarks"
+
+			if private {
+				f.Link = link("/timeline", nil)
+			}
+		case name == "favorites":
+			f.Title = "Favorites"
+			filters = append(filters, store.WithFavorite(true))
+
+			if private {
+				f.Link = link("/timeline", nil)
+			}
+		case name == "search":
+			q := r.URL.Query().Get("q")
+
+			f.Title = "Search: " + q
+			// the query is part of what the feed is
+			f.ID = link(r.URL.Path, url.Values{"q": {q}})
+			filters = append(filters, store.WithSearchTerm(q))
+
+			if private {
+				f.Link = link("/search", url.Values{"q": {q}})
+			}
+		case strings.HasPrefix(name, "tags/") && len(name) > len("tags/"):
+			tag := strings.TrimPrefix(name, "tags/")
+
+			f.Title = "Tagged " + tag
+			filters = append(filters, store.WithTags([]string{tag}))
+
+			if private {
+				f.Link = link("/timeline", url.Values{"tag": {tag}})
+			}
+		default:
+			http.NotFound(w, r)
+
+			return
+		}
+
+		uus, err := s.db.UserURLs(owner).GetAll(ctx, filters...)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		for _, uu := range uus {
+			f.Entries = append(f.Entries, feedEntry(link("/url/"+uu.Id, nil), uu))
+		}
+
+		b := &bytes.Buffer{}
+
+		if err := write(b, f); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		sum := sha256.Sum256(b.Bytes())
+
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+
+		if private {
+			w.Header().Set("Cache-Control", "private")
+			w.Header().Set("X-Robots-Tag", "noindex")
+		}
+
+		// ServeContent answers If-None-Match and If-Modified-Since with 304s
+		http.ServeContent(w, r, "", f.LastUpdated(), bytes.NewReader(b.Bytes()))
+	}
+}
+
+// feedEntry returns the feed entry of uu, which is known by id.
+func feedEntry(id string, uu *api.UserURL) *feed.Entry {
+	e := &feed.Entry{
+		ID:        id,
+		Title:     uu.DerivedTitle,
+		Link:      uu.Url.Url,
+		Summary:   uu.Url.Description,
+		Content:   string(uu.NotesHTML()),
+		Published: uu.CreatedAt.AsTime(),
+	}
+
+	if e.Title == "" {
+		e.Title = uu.Url.Url
+	}
+
+	// AsTime of a missing timestamp is the epoch rather than the zero time
+	if uu.UpdatedAt != nil {
+		e.Updated = uu.UpdatedAt.AsTime()
+	}
+
+	if uu.Tags != nil {
+		for _, t := range uu.Tags.Items {
+			e.Categories = append(e.Categories, t.Name)
+		}
+	}
+
+	return e
+}
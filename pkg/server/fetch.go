@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/kyleterry/sufr/pkg/archive"
+	"github.com/kyleterry/sufr/pkg/data"
+	"github.com/kyleterry/sufr/pkg/embed"
+	"github.com/kyleterry/sufr/pkg/fetchqueue"
+	"github.com/kyleterry/sufr/pkg/linkcheck"
+	"github.com/kyleterry/sufr/pkg/store"
+)
+
+// newFetchHandler returns a fetchqueue.Handler that fetches the page of a
+// job's url with fetcher and saves the metadata found on it. If archiver isn't
+// nil a snapshot of the page is archived as well, and if embeds isn't nil the
+// url's embed is looked for so pages don't have to.
+func newFetchHandler(db store.Manager, fetcher data.URLMetadataFetcher, archiver *archive.Archiver, embeds *embed.Registry) fetchqueue.Handler {
+	return func(ctx context.Context, job *fetchqueue.Job) error {
+		pm, err := fetcher.FetchMetadata(ctx, job.URL)
+		if err != nil {
+			return fmt.Errorf("failed to fetch page: %w", err)
+		}
+
+		var snap *archive.Snapshot
+
+		if archiver != nil {
+			snap, err = archiver.Archive(ctx, job.URLID, job.URL)
+			if err != nil && !errors.Is(err, archive.ErrNotHTML) {
+				return fmt.Errorf("failed to archive page: %w", err)
+			}
+		}
+
+		u, err := db.URLs().GetByID(ctx, job.URLID)
+		if err != nil {
+			// the url was deleted before we got to it
+			if errors.Is(err, store.ErrNotFound) {
+				return nil
+			}
+
+			return err
+		}
+
+		if (u.Title == "" || u.Title == data.DefaultURLTitle) && pm.Title != "" {
+			u.Title = pm.Title
+		}
+
+		u.ContentType = pm.ContentType
+		u.Description = pm.Description
+		u.ImageUrl = pm.ImageURL
+		u.SiteName = pm.SiteName
+		u.Author = pm.Author
+		u.CanonicalUrl = pm.CanonicalURL
+		u.Language = pm.Language
+		u.FaviconUrl = pm.FaviconURL
+		u.PublishedAt = nil
+
+		if !pm.PublishedAt.IsZero() {
+			u.PublishedAt = timestamp(pm.PublishedAt)
+		}
+
+		if snap != nil {
+			u.ArchivedAt = timestamp(snap.CreatedAt)
+		}
+
+		if embeds != nil {
+			// a provider being down shouldn't fetch the page all over again
+			if _, err := embeds.Resolve(ctx, u.Id, u.Url, pm.OEmbedURL); err != nil {
+				log.Printf("failed to find embed of %s: %s", u.Url, err)
+			}
+		}
+
+		return db.URLs().Update(ctx, u)
+	}
+}
+
+func newFetchPool(db store.Manager, workers int, archiver *archive.Archiver) *fetchqueue.Pool {
+	return fetchqueue.New(
+		db.FetchJobs(),
+		newFetchHandler(db, data.HTTPMetadataFetcher{}, archiver, embed.New(embed.WithCache(db.Embeds()))),
+		fetchqueue.WithWorkers(workers),
+	)
+}
+
+// newLinkChecker returns a linkcheck.Checker that checks every saved url once
+// per interval.
+func newLinkChecker(db store.Manager, interval time.Duration, deadAfter int) *linkcheck.Checker {
+	return linkcheck.New(
+		db.URLChecks(),
+		linkcheck.WithInterval(interval),
+		linkcheck.WithDeadAfter(deadAfter),
+	)
+}
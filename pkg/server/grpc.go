@@ -0,0 +1,301 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/kyleterry/sufr/pkg/api"
+	"github.com/kyleterry/sufr/pkg/store"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var errGRPCUnauthenticated = status.Error(codes.Unauthenticated, "authentication required")
+
+// grpcServer implements api.SufrServiceServer on top of the same store and
+// helpers as the JSON API.
+type grpcServer struct {
+	api.UnimplementedSufrServiceServer
+	db store.Manager
+}
+
+func (s *grpcServer) ListUserURLs(req *api.ListUserURLsRequest, stream api.SufrService_ListUserURLsServer) error {
+	ctx := stream.Context()
+	user := ctx.Value(userContextKey{}).(*api.User)
+
+	filters := []store.FilterOption{
+		tagFilter(req.Tags, req.AnyTags),
+		store.WithBroken(req.Broken),
+	}
+
+	return grpcError(streamUserURLs(ctx, s.db.UserURLs(user), filters, req.After, req.Limit, stream.Send))
+}
+
+func (s *grpcServer) SearchUserURLs(req *api.SearchUserURLsRequest, stream api.SufrService_SearchUserURLsServer) error {
+	ctx := stream.Context()
+	user := ctx.Value(userContextKey{}).(*api.User)
+
+	if req.Query == "" {
+		return status.Error(codes.InvalidArgument, "query is required")
+	}
+
+	filters := []store.FilterOption{
+		store.WithSearchTerm(req.Query),
+		tagFilter(req.Tags, req.AnyTags),
+	}
+
+	return grpcError(streamUserURLs(ctx, s.db.UserURLs(user), filters, req.After, req.Limit, stream.Send))
+}
+
+func (s *grpcServer) GetUserURL(ctx context.Context, req *api.GetUserURLRequest) (*api.UserURL, error) {
+	user := ctx.Value(userContextKey{}).(*api.User)
+
+	uu, err := s.db.UserURLs(user).GetByID(ctx, req.Id)
+
+	return uu, grpcError(err)
+}
+
+func (s *grpcServer) CreateUserURL(ctx context.Context, req *api.CreateUserURLRequest) (*api.UserURL, error) {
+	user := ctx.Value(userContextKey{}).(*api.User)
+
+	visibility := req.Visibility.String()
+
+	uu, err := createUserURL(ctx, s.db, user, urlRequest{
+		URL:        req.Url,
+		Title:      &req.Title,
+		Notes:      &req.Notes,
+		Tags:       &req.Tags,
+		Favorite:   &req.Favorite,
+		Visibility: &visibility,
+	})
+
+	return uu, grpcError(err)
+}
+
+func (s *grpcServer) UpdateUserURL(ctx context.Context, req *api.UpdateUserURLRequest) (*api.UserURL, error) {
+	user := ctx.Value(userContextKey{}).(*api.User)
+	uum := s.db.UserURLs(user)
+
+	uu, err := uum.GetByID(ctx, req.Id)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	visibility := req.Visibility.String()
+
+	err = applyURLRequest(ctx, s.db, uu, urlRequest{
+		Title:      &req.Title,
+		Notes:      &req.Notes,
+		Tags:       &req.Tags,
+		Favorite:   &req.Favorite,
+		Visibility: &visibility,
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	if err := uum.Update(ctx, uu); err != nil {
+		return nil, grpcError(err)
+	}
+
+	uu, err = uum.GetByID(ctx, req.Id)
+
+	return uu, grpcError(err)
+}
+
+func (s *grpcServer) DeleteUserURL(ctx context.Context, req *api.DeleteUserURLRequest) (*api.DeleteUserURLResponse, error) {
+	user := ctx.Value(userContextKey{}).(*api.User)
+
+	if err := s.db.UserURLs(user).Delete(ctx, req.Id); err != nil {
+		return nil, grpcError(err)
+	}
+
+	return &api.DeleteUserURLResponse{}, nil
+}
+
+func (s *grpcServer) ListTags(req *api.ListTagsRequest, stream api.SufrService_ListTagsServer) error {
+	ctx := stream.Context()
+	user := ctx.Value(userContextKey{}).(*api.User)
+
+	tags, err := s.db.UserURLs(user).GetTags(ctx)
+	if err != nil {
+		return grpcError(err)
+	}
+
+	for _, tag := range tags.Items {
+		if err := stream.Send(tag); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *grpcServer) GetPinnedCategories(ctx context.Context, req *api.GetPinnedCategoriesRequest) (*api.CategoryList, error) {
+	user := ctx.Value(userContextKey{}).(*api.User)
+
+	return &api.CategoryList{Items: user.PinnedCategories}, nil
+}
+
+func (s *grpcServer) UpdatePinnedCategories(ctx context.Context, req *api.UpdatePinnedCategoriesRequest) (*api.CategoryList, error) {
+	user := ctx.Value(userContextKey{}).(*api.User)
+
+	cats, err := updatePinnedCategories(ctx, s.db, user, req.Categories)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return &api.CategoryList{Items: cats}, nil
+}
+
+// authenticate returns the user for the API token or basic auth credentials
+// in the authorization metadata of ctx.
+func (s *grpcServer) authenticate(ctx context.Context) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, errGRPCUnauthenticated
+	}
+
+	r := http.Request{Header: http.Header{"Authorization": md.Get("authorization")}}
+
+	var (
+		user *api.User
+		err  error
+	)
+
+	if token, ok := bearerToken(r.Header.Get("Authorization")); ok {
+		user, err = s.db.Users().GetByAPIToken(ctx, token)
+	} else if email, password, ok := r.BasicAuth(); ok {
+		user, err = passwordUser(ctx, s.db, email, password)
+	} else {
+		return nil, errGRPCUnauthenticated
+	}
+
+	if err != nil {
+		return nil, errGRPCUnauthenticated
+	}
+
+	return context.WithValue(ctx, userContextKey{}, user), nil
+}
+
+func (s *grpcServer) unaryAuthInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := s.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func (s *grpcServer) streamAuthInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := s.authenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticatedStream replaces the context of a stream with one carrying the
+// authenticated user.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// streamUserURLs sends every url matching filters after the one with the
+// cursor after to send, fetching them a page at a time. A limit of 0 sends
+// everything.
+func streamUserURLs(ctx context.Context, uum store.UserURLManager, filters []store.FilterOption, after string, limit int64, send func(*api.UserURL) error) error {
+	var sent int64
+
+	for {
+		uus, err := uum.GetAll(ctx, append(filters, store.WithResultsAfter(after))...)
+		if err != nil {
+			return err
+		}
+
+		if len(uus) == 0 {
+			return nil
+		}
+
+		for _, uu := range uus {
+			if limit > 0 && sent >= limit {
+				return nil
+			}
+
+			if err := send(uu); err != nil {
+				return err
+			}
+
+			sent++
+		}
+
+		after = uus[len(uus)-1].Cursor
+	}
+}
+
+func tagFilter(tags []string, any bool) store.FilterOption {
+	if any {
+		return store.WithAnyTags(tags)
+	}
+
+	return store.WithTags(tags)
+}
+
+// grpcError converts err into a gRPC status error using the same mapping as
+// the JSON API.
+func grpcError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return status.Error(codes.Canceled, err.Error())
+	}
+
+	ae := toAPIError(err)
+
+	var code codes.Code
+
+	switch ae.status {
+	case http.StatusBadRequest:
+		code = codes.InvalidArgument
+	case http.StatusUnauthorized:
+		code = codes.Unauthenticated
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusConflict:
+		code = codes.AlreadyExists
+	case http.StatusUnprocessableEntity:
+		code = codes.FailedPrecondition
+	default:
+		code = codes.Internal
+	}
+
+	return status.Error(code, ae.message)
+}
+
+func newGRPCServer(db store.Manager) *grpc.Server {
+	s := &grpcServer{db: db}
+
+	gs := grpc.NewServer(
+		grpc.UnaryInterceptor(s.unaryAuthInterceptor),
+		grpc.StreamInterceptor(s.streamAuthInterceptor),
+	)
+
+	api.RegisterSufrServiceServer(gs, s)
+
+	return gs
+}
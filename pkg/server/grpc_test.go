@@ -0,0 +1,258 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/kyleterry/sufr/pkg/api"
+	"github.com/kyleterry/sufr/pkg/store"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestGRPCClient serves the gRPC API for db over an in-memory listener and
+// returns a client connected to it.
+func newTestGRPCClient(t *testing.T, db store.Manager) api.SufrServiceClient {
+	lis := bufconn.Listen(1 << 20)
+	gs := newGRPCServer(db)
+
+	go gs.Serve(lis)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithInsecure(),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		conn.Close()
+		gs.Stop()
+	})
+
+	return api.NewSufrServiceClient(conn)
+}
+
+// grpcContext returns a context carrying basic auth metadata for email with
+// password "password".
+func grpcContext(email string) context.Context {
+	creds := base64.StdEncoding.EncodeToString([]byte(email + ":password"))
+
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Basic "+creds)
+}
+
+// listUserURLs drains a ListUserURLs stream.
+func listUserURLs(ctx context.Context, client api.SufrServiceClient, req *api.ListUserURLsRequest) ([]*api.UserURL, error) {
+	stream, err := client.ListUserURLs(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	uus := []*api.UserURL{}
+
+	for {
+		uu, err := stream.Recv()
+		if err == io.EOF {
+			return uus, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		uus = append(uus, uu)
+	}
+}
+
+func TestGRPCAuthentication(t *testing.T) {
+	db := newTestStore(t)
+	client := newTestGRPCClient(t, db)
+
+	mustCreateUser(t, db, "kyle@example.com", false)
+	mustCreateUser(t, db, "gone@example.com", true)
+
+	totpUser := mustCreateUser(t, db, "totp@example.com", false)
+	require.NoError(t, db.Users().EnableTOTP(context.Background(), totpUser, "JBSWY3DPEHPK3PXP", nil))
+
+	totpUser.ApiToken = "0123456789abcdef"
+	require.NoError(t, db.Users().UpdateAPIToken(context.Background(), totpUser))
+
+	token := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer 0123456789abcdef")
+	wrongToken := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer 0123456789abcdee")
+
+	wrongPassword := metadata.AppendToOutgoingContext(context.Background(), "authorization",
+		"Basic "+base64.StdEncoding.EncodeToString([]byte("kyle@example.com:wrong")))
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		code codes.Code
+	}{
+		{name: "password", ctx: grpcContext("kyle@example.com"), code: codes.OK},
+		{name: "no metadata", ctx: context.Background(), code: codes.Unauthenticated},
+		{name: "wrong password", ctx: wrongPassword, code: codes.Unauthenticated},
+		{name: "unknown user", ctx: grpcContext("nobody@example.com"), code: codes.Unauthenticated},
+		{name: "disabled user", ctx: grpcContext("gone@example.com"), code: codes.Unauthenticated},
+		{name: "two-factor user", ctx: grpcContext("totp@example.com"), code: codes.Unauthenticated},
+		{name: "two-factor user's api token", ctx: token, code: codes.OK},
+		{name: "wrong api token", ctx: wrongToken, code: codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.GetPinnedCategories(tt.ctx, &api.GetPinnedCategoriesRequest{})
+			require.Equal(t, tt.code, status.Code(err))
+
+			// streams go through their own interceptor
+			_, err = listUserURLs(tt.ctx, client, &api.ListUserURLsRequest{})
+			require.Equal(t, tt.code, status.Code(err))
+		})
+	}
+}
+
+func TestGRPCUserURLs(t *testing.T) {
+	db := newTestStore(t)
+	client := newTestGRPCClient(t, db)
+
+	mustCreateUser(t, db, "kyle@example.com", false)
+	mustCreateUser(t, db, "other@example.com", false)
+
+	ctx := grpcContext("kyle@example.com")
+
+	created := []*api.UserURL{}
+
+	for i := 0; i < 5; i++ {
+		uu, err := client.CreateUserURL(ctx, &api.CreateUserURLRequest{
+			Url:  fmt.Sprintf("https://example.com/%d", i),
+			Tags: []string{"go"},
+		})
+		require.NoError(t, err)
+
+		created = append(created, uu)
+	}
+
+	t.Run("lists newest first", func(t *testing.T) {
+		uus, err := listUserURLs(ctx, client, &api.ListUserURLsRequest{})
+		require.NoError(t, err)
+		require.Len(t, uus, 5)
+		require.Equal(t, created[4].Id, uus[0].Id)
+		require.Equal(t, created[0].Id, uus[4].Id)
+	})
+
+	t.Run("limits and pages", func(t *testing.T) {
+		uus, err := listUserURLs(ctx, client, &api.ListUserURLsRequest{Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, uus, 2)
+
+		rest, err := listUserURLs(ctx, client, &api.ListUserURLsRequest{After: uus[1].Cursor})
+		require.NoError(t, err)
+		require.Len(t, rest, 3)
+		require.Equal(t, created[2].Id, rest[0].Id)
+
+		_, err = listUserURLs(ctx, client, &api.ListUserURLsRequest{After: "12"})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("keeps users apart", func(t *testing.T) {
+		other := grpcContext("other@example.com")
+
+		uus, err := listUserURLs(other, client, &api.ListUserURLsRequest{})
+		require.NoError(t, err)
+		require.Empty(t, uus)
+
+		_, err = client.GetUserURL(other, &api.GetUserURLRequest{Id: created[0].Id})
+		require.Equal(t, codes.NotFound, status.Code(err))
+
+		_, err = client.DeleteUserURL(other, &api.DeleteUserURLRequest{Id: created[0].Id})
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("updates", func(t *testing.T) {
+		uu, err := client.UpdateUserURL(ctx, &api.UpdateUserURLRequest{
+			Id:       created[0].Id,
+			Title:    "first",
+			Tags:     []string{"sql"},
+			Favorite: true,
+		})
+		require.NoError(t, err)
+		require.Equal(t, "first", uu.Title)
+		require.True(t, uu.Favorite)
+		require.Len(t, uu.Tags.Items, 1)
+		require.Equal(t, "sql", uu.Tags.Items[0].Name)
+		require.Equal(t, api.Visibility_VISIBILITY_PRIVATE, uu.Visibility)
+	})
+
+	t.Run("sets visibility", func(t *testing.T) {
+		uu, err := client.CreateUserURL(ctx, &api.CreateUserURLRequest{
+			Url:        "https://example.com/public",
+			Visibility: api.Visibility_VISIBILITY_PUBLIC,
+		})
+		require.NoError(t, err)
+		require.Equal(t, api.Visibility_VISIBILITY_PUBLIC, uu.Visibility)
+
+		uu, err = client.UpdateUserURL(ctx, &api.UpdateUserURLRequest{
+			Id:         uu.Id,
+			Visibility: api.Visibility_VISIBILITY_UNLISTED,
+		})
+		require.NoError(t, err)
+		require.Equal(t, api.Visibility_VISIBILITY_UNLISTED, uu.Visibility)
+
+		_, err = client.UpdateUserURL(ctx, &api.UpdateUserURLRequest{
+			Id:         uu.Id,
+			Visibility: api.Visibility(7),
+		})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = client.DeleteUserURL(ctx, &api.DeleteUserURLRequest{Id: uu.Id})
+		require.NoError(t, err)
+	})
+
+	t.Run("refuses bad urls", func(t *testing.T) {
+		_, err := client.CreateUserURL(ctx, &api.CreateUserURLRequest{Url: "example.com"})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = client.CreateUserURL(ctx, &api.CreateUserURLRequest{Url: created[0].Url.Url})
+		require.Equal(t, codes.AlreadyExists, status.Code(err))
+	})
+
+	t.Run("deletes", func(t *testing.T) {
+		_, err := client.DeleteUserURL(ctx, &api.DeleteUserURLRequest{Id: created[1].Id})
+		require.NoError(t, err)
+
+		_, err = client.GetUserURL(ctx, &api.GetUserURLRequest{Id: created[1].Id})
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestGRPCError(t *testing.T) {
+	tests := []struct {
+		err  error
+		code codes.Code
+	}{
+		{err: nil, code: codes.OK},
+		{err: status.Error(codes.PermissionDenied, "no"), code: codes.PermissionDenied},
+		{err: context.Canceled, code: codes.Canceled},
+		{err: newAPIBadRequest("no"), code: codes.InvalidArgument},
+		{err: fmt.Errorf("failed to get UserURL: %w", store.ErrNotFound), code: codes.NotFound},
+		{err: store.ErrAlreadyExists, code: codes.AlreadyExists},
+		{err: store.ErrInvalidDependency, code: codes.FailedPrecondition},
+		{err: store.ErrInvalidCursor, code: codes.InvalidArgument},
+		{err: errors.New("disk on fire"), code: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			require.Equal(t, tt.code, status.Code(grpcError(tt.err)))
+		})
+	}
+}
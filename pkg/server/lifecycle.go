@@ -2,11 +2,82 @@ package server
 
 import (
 	"context"
+	"errors"
+	"net"
 	"net/http"
+	"sync"
+	"time"
 )
 
+const shutdownTimeout = 10 * time.Second
+
+// listenAndServe runs the HTTP server, the gRPC server if it has an address,
+// the metadata fetch workers and the link checker until ctx is done or a
+// server fails.
 func listenAndServe(ctx context.Context, s *server) error {
 	hs := http.Server{Addr: s.bindAddr, Handler: s}
+	errs := make(chan error, 2)
+
+	workCtx, stopWork := context.WithCancel(ctx)
+
+	var work sync.WaitGroup
+
+	work.Add(1)
+
+	go func() {
+		defer work.Done()
+
+		newFetchPool(s.db, s.fetchWorkers, s.archiver).Run(workCtx)
+	}()
+
+	if s.checkInterval > 0 {
+		work.Add(1)
+
+		go func() {
+			defer work.Done()
+
+			newLinkChecker(s.db, s.checkInterval, s.checkDeadAfter).Run(workCtx)
+		}()
+	}
+
+	defer func() {
+		stopWork()
+		work.Wait()
+	}()
+
+	go func() {
+		if err := hs.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			errs <- err
+		}
+	}()
+
+	if s.grpcBindAddr != "" {
+		gs := newGRPCServer(s.db)
+
+		l, err := net.Listen("tcp", s.grpcBindAddr)
+		if err != nil {
+			hs.Close()
+
+			return err
+		}
+
+		go func() {
+			errs <- gs.Serve(l)
+		}()
+
+		defer gs.GracefulStop()
+	}
+
+	select {
+	case err := <-errs:
+		hs.Close()
+
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
 
-	return hs.ListenAndServe()
+	return hs.Shutdown(shutdownCtx)
 }
@@ -2,10 +2,14 @@ package server
 
 import (
 	"context"
+	"errors"
 	"log"
 	"net/http"
+	"strings"
 
 	"github.com/gorilla/sessions"
+	"github.com/kyleterry/sufr/pkg/api"
+	"github.com/kyleterry/sufr/pkg/csrf"
 	"github.com/kyleterry/sufr/pkg/store"
 )
 
@@ -13,40 +17,110 @@ type userContextKey struct{}
 
 type middlewareFunc func(http.Handler) http.Handler
 
-func NewSessionAuthenticationMiddleware(store sessions.Store, db store.Manager) middlewareFunc {
+var (
+	errNoSessionUser = errors.New("no user in session")
+	errSecondFactor  = errors.New("two-factor authentication required")
+)
+
+// NewSessionAuthenticationMiddleware lets logged in users through and sends
+// everyone else to the login page. With proxy set, the user comes from the
+// auth proxy's header instead of the session cookie and requests it didn't
+// log in are turned away.
+func NewSessionAuthenticationMiddleware(store sessions.Store, db store.Manager, proxy *proxyAuth) middlewareFunc {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ctx := r.Context()
 
-			session, err := store.New(r, userAuthSessionKey)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+			if proxy != nil {
+				user, err := proxy.user(r, db)
+				if err != nil {
+					log.Printf("proxy auth from %s: %s", r.RemoteAddr, err)
+					http.Error(w, "401 Unauthorized", http.StatusUnauthorized)
+
+					return
+				}
+
+				next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, userContextKey{}, user)))
 
 				return
 			}
 
-			doRedirect := func(w http.ResponseWriter, r *http.Request) {
+			user, err := sessionUser(r, store, db)
+			if err != nil {
+				if !errors.Is(err, errNoSessionUser) {
+					log.Println(err)
+				}
+
 				http.Redirect(w, r, "/login", http.StatusSeeOther)
+
+				return
 			}
 
-			raw, ok := session.Values["userID"]
-			if !ok {
-				doRedirect(w, r)
+			ctx = context.WithValue(ctx, userContextKey{}, user)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// NewCSRFMiddleware turns away requests that change something without the
+// CSRF token of their session. Templates get the token from csrf.Token.
+func NewCSRFMiddleware(sessionStore sessions.Store, opts ...csrf.ProtectorOption) middlewareFunc {
+	return csrf.New(sessionStore, opts...).Handler
+}
+
+// NewAdminAuthorizationMiddleware only lets admins through. It has to come
+// after NewSessionAuthenticationMiddleware.
+func NewAdminAuthorizationMiddleware() middlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user := r.Context().Value(userContextKey{}).(*api.User)
+
+			if !user.Admin {
+				http.Error(w, "403 Forbidden", http.StatusForbidden)
 
 				return
 			}
 
-			id, ok := raw.(string)
-			if !ok || id == "" {
-				doRedirect(w, r)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
 
-				return
+// NewAPIAuthenticationMiddleware authenticates API requests with the user's
+// API token as a bearer token, HTTP basic auth or an existing UI session,
+// which comes from the auth proxy instead when proxy is set. Users with
+// two-factor authentication can't use basic auth, so scripts use their token.
+// Unauthenticated requests get a JSON error instead of a redirect to the
+// login page.
+func NewAPIAuthenticationMiddleware(store sessions.Store, db store.Manager, proxy *proxyAuth) middlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			var (
+				user *api.User
+				err  error
+			)
+
+			if token, ok := bearerToken(r.Header.Get("Authorization")); ok {
+				user, err = db.Users().GetByAPIToken(ctx, token)
+			} else if email, password, ok := r.BasicAuth(); ok {
+				user, err = passwordUser(ctx, db, email, password)
+			} else if proxy != nil {
+				user, err = proxy.user(r, db)
+			} else {
+				user, err = sessionUser(r, store, db)
 			}
 
-			user, err := db.Users().GetByID(ctx, id)
 			if err != nil {
-				log.Println(err)
-				doRedirect(w, r)
+				w.Header().Add("WWW-Authenticate", `Bearer realm="sufr"`)
+				w.Header().Add("WWW-Authenticate", `Basic realm="sufr"`)
+				writeAPIError(w, &apiError{
+					status:  http.StatusUnauthorized,
+					code:    "unauthorized",
+					message: "authentication required",
+				})
 
 				return
 			}
@@ -57,3 +131,60 @@ func NewSessionAuthenticationMiddleware(store sessions.Store, db store.Manager)
 		})
 	}
 }
+
+// bearerToken returns the token of a bearer authorization header.
+func bearerToken(authorization string) (string, bool) {
+	const prefix = "Bearer "
+
+	if len(authorization) < len(prefix) || !strings.EqualFold(authorization[:len(prefix)], prefix) {
+		return "", false
+	}
+
+	return strings.TrimSpace(authorization[len(prefix):]), true
+}
+
+// sessionUser returns the user logged in with the session cookie on r.
+func sessionUser(r *http.Request, sessionStore sessions.Store, db store.Manager) (*api.User, error) {
+	session, err := sessionStore.New(r, userAuthSessionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, ok := session.Values["userID"]
+	if !ok {
+		return nil, errNoSessionUser
+	}
+
+	id, ok := raw.(string)
+	if !ok || id == "" {
+		return nil, errNoSessionUser
+	}
+
+	user, err := db.Users().GetByID(r.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	// disabling a user logs them out everywhere
+	if user.Disabled {
+		return nil, store.ErrDisabled
+	}
+
+	return user, nil
+}
+
+// passwordUser returns the user with email and password. A password on its
+// own isn't enough for users with two-factor authentication, so they're
+// turned away with errSecondFactor.
+func passwordUser(ctx context.Context, db store.Manager, email, password string) (*api.User, error) {
+	user, err := db.Users().GetByEmailAndPassword(ctx, email, password)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TotpEnabled {
+		return nil, errSecondFactor
+	}
+
+	return user, nil
+}
@@ -0,0 +1,16 @@
+// +build !sqlite_fts5 !sqlite_json1
+
+package server
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+// TestMain skips every test when sqlite is built without the json1 and fts5
+// modules the store needs, so a plain go test ./... still passes.
+func TestMain(m *testing.M) {
+	fmt.Println(`skipping server tests: they need the sql store, run them with -tags "sqlite_json1 sqlite_fts5"`)
+	os.Exit(0)
+}
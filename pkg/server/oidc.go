@@ -0,0 +1,288 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/sessions"
+	"github.com/kyleterry/sufr/pkg/api"
+	"github.com/kyleterry/sufr/pkg/oidc"
+	"github.com/kyleterry/sufr/pkg/store"
+)
+
+const (
+	oidcCallbackPath = "/login/oidc/callback"
+
+	oidcStateSessionKey    = "oidcState"
+	oidcNonceSessionKey    = "oidcNonce"
+	oidcVerifierSessionKey = "oidcVerifier"
+	oidcAtSessionKey       = "oidcAt"
+)
+
+var (
+	errOIDCNoEmail      = errors.New("the identity provider didn't give a verified email address")
+	errOIDCNotAllowed   = errors.New("email address isn't allowed to log in")
+	errOIDCOtherSubject = errors.New("account is tied to another account at the identity provider")
+)
+
+// handleLoginOIDC sends the browser to the identity provider to log in.
+func (s *uiServer) handleLoginOIDC() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.oidc == nil || r.Method != http.MethodGet {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		req, err := oidc.NewAuthRequest()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		authURL, err := s.oidc.AuthCodeURL(r.Context(), s.oidcRedirectURL(r), req)
+		if err != nil {
+			log.Println(err)
+			s.addFlash(w, r, "danger", "The identity provider couldn't be reached. Try again in a bit.")
+			http.Redirect(w, r, "/login", http.StatusSeeOther)
+
+			return
+		}
+
+		session, err := s.sessionStore.Get(r, userAuthSessionKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		session.Values[oidcStateSessionKey] = req.State
+		session.Values[oidcNonceSessionKey] = req.Nonce
+		session.Values[oidcVerifierSessionKey] = req.Verifier
+		session.Values[oidcAtSessionKey] = time.Now().Unix()
+
+		if err := session.Save(r, w); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		http.Redirect(w, r, authURL, http.StatusSeeOther)
+	}
+}
+
+// handleLoginOIDCCallback is where the identity provider sends the browser
+// back to. The user is logged in as the account with the email address the
+// provider vouches for, which is made if it's their first time.
+func (s *uiServer) handleLoginOIDCCallback() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		if s.oidc == nil || r.Method != http.MethodGet {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		session, err := s.sessionStore.Get(r, userAuthSessionKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		req := popOIDCRequest(session)
+
+		if err := session.Save(r, w); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		q := r.URL.Query()
+
+		if e := q.Get("error"); e != "" {
+			log.Printf("logging in with oidc: %s: %s", e, q.Get("error_description"))
+			s.addFlash(w, r, "danger", "The identity provider didn't log you in.")
+			http.Redirect(w, r, "/login", http.StatusSeeOther)
+
+			return
+		}
+
+		if req == nil || req.CheckState(q.Get("state")) != nil {
+			s.addFlash(w, r, "danger", "That login expired. Try again.")
+			http.Redirect(w, r, "/login", http.StatusSeeOther)
+
+			return
+		}
+
+		claims, err := s.oidc.Exchange(ctx, s.oidcRedirectURL(r), q.Get("code"), req)
+		if err != nil {
+			log.Printf("logging in with oidc: %s", err)
+			s.addFlash(w, r, "danger", "Logging in with the identity provider didn't work.")
+			http.Redirect(w, r, "/login", http.StatusSeeOther)
+
+			return
+		}
+
+		user, err := s.oidcUser(ctx, claims)
+		if err != nil {
+			switch {
+			case errors.Is(err, store.ErrDisabled):
+				s.addFlash(w, r, "danger", "This account has been disabled.")
+			case errors.Is(err, errOIDCNoEmail), errors.Is(err, errOIDCNotAllowed), errors.Is(err, errOIDCOtherSubject), errors.Is(err, store.ErrNotFound):
+				log.Printf("logging in with oidc as %s: %s", claims.Subject, err)
+				s.addFlash(w, r, "danger", "Your account at the identity provider can't log in here.")
+			default:
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+
+				return
+			}
+
+			http.Redirect(w, r, "/login", http.StatusSeeOther)
+
+			return
+		}
+
+		// the provider is one factor as far as sufr knows, so users with
+		// two-factor authentication still need their code
+		if user.TotpEnabled {
+			session.Values[pendingUserIDSessionKey] = user.Id
+			session.Values[pendingAtSessionKey] = time.Now().Unix()
+		} else {
+			session.Values["userID"] = user.Id
+		}
+
+		if err := session.Save(r, w); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		if user.TotpEnabled {
+			http.Redirect(w, r, "/login/2fa", http.StatusSeeOther)
+
+			return
+		}
+
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+	}
+}
+
+// oidcUser returns the account the provider's account in claims logs in as.
+// Accounts are tied to the provider's issuer and subject the first time they
+// log in, by the email address the provider vouches for, so a changed or
+// reused address at the provider can't log in as someone else. When the
+// allowed domains are set, only addresses in them can log in for the first
+// time and accounts are made for them as they're needed.
+func (s *uiServer) oidcUser(ctx context.Context, claims *oidc.Claims) (*api.User, error) {
+	cfg := s.oidc.Config()
+
+	user, err := s.db.Users().GetByIdentity(ctx, claims.Issuer, claims.Subject)
+	if err == nil {
+		return user, nil
+	}
+
+	if !errors.Is(err, store.ErrNotFound) {
+		return nil, err
+	}
+
+	email, ok := cfg.TrustedEmail(claims)
+	if !ok || !validEmail(email) {
+		return nil, errOIDCNoEmail
+	}
+
+	if cfg.CreatesUsers() && !cfg.AllowedEmail(email) {
+		return nil, errOIDCNotAllowed
+	}
+
+	user, err = s.db.Users().GetByEmail(ctx, email)
+	if err == nil {
+		if user.Disabled {
+			return nil, store.ErrDisabled
+		}
+
+		if err := s.db.Users().AddIdentity(ctx, user, claims.Issuer, claims.Subject); err != nil {
+			if errors.Is(err, store.ErrAlreadyExists) {
+				return nil, errOIDCOtherSubject
+			}
+
+			return nil, err
+		}
+
+		log.Printf("tied user %s to oidc subject %s", user.Id, claims.Subject)
+
+		return user, nil
+	}
+
+	if !errors.Is(err, store.ErrNotFound) || !cfg.CreatesUsers() {
+		return nil, err
+	}
+
+	// they log in with the provider, so the password is one nobody knows
+	password := make([]byte, 32)
+	if _, err := rand.Read(password); err != nil {
+		return nil, err
+	}
+
+	ph, err := api.GeneratePasswordHash(hex.EncodeToString(password))
+	if err != nil {
+		return nil, err
+	}
+
+	user = &api.User{
+		Email:        email,
+		PasswordHash: ph,
+	}
+
+	err = s.db.Transaction(ctx, func(ctx context.Context, tx store.Manager) error {
+		if err := tx.Users().Create(ctx, user); err != nil {
+			return err
+		}
+
+		return tx.Users().AddIdentity(ctx, user, claims.Issuer, claims.Subject)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("created user %s for %s on first oidc login", user.Id, email)
+
+	return user, nil
+}
+
+// oidcRedirectURL returns where the identity provider sends the browser back
+// to.
+func (s *uiServer) oidcRedirectURL(r *http.Request) string {
+	u := *s.siteURL(r)
+	u.Path = oidcCallbackPath
+
+	return u.String()
+}
+
+// popOIDCRequest removes the login in progress from session and returns it.
+// It's nil if there isn't one or it expired.
+func popOIDCRequest(session *sessions.Session) *oidc.AuthRequest {
+	req := &oidc.AuthRequest{}
+	req.State, _ = session.Values[oidcStateSessionKey].(string)
+	req.Nonce, _ = session.Values[oidcNonceSessionKey].(string)
+	req.Verifier, _ = session.Values[oidcVerifierSessionKey].(string)
+	at, _ := session.Values[oidcAtSessionKey].(int64)
+
+	delete(session.Values, oidcStateSessionKey)
+	delete(session.Values, oidcNonceSessionKey)
+	delete(session.Values, oidcVerifierSessionKey)
+	delete(session.Values, oidcAtSessionKey)
+
+	if req.State == "" || time.Since(time.Unix(at, 0)) > oidc.Timeout {
+		return nil
+	}
+
+	return req
+}
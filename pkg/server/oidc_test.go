@@ -0,0 +1,88 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kyleterry/sufr/pkg/oidc"
+	"github.com/kyleterry/sufr/pkg/store"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOIDCUser(t *testing.T) {
+	db := newTestStore(t)
+	ctx := context.Background()
+
+	const issuer = "https://accounts.example.com"
+
+	s := &uiServer{
+		db: db,
+		oidc: oidc.New(oidc.Config{
+			Issuer:         issuer,
+			ClientID:       "sufr",
+			AllowedDomains: []string{"example.com"},
+		}),
+	}
+
+	kyle := mustCreateUser(t, db, "kyle@example.com", false)
+	mustCreateUser(t, db, "gone@example.com", true)
+
+	yes := true
+
+	claims := func(subject, email string) *oidc.Claims {
+		return &oidc.Claims{Issuer: issuer, Subject: subject, Email: email, EmailVerified: &yes}
+	}
+
+	t.Run("ties accounts to the subject by email", func(t *testing.T) {
+		user, err := s.oidcUser(ctx, claims("1", "kyle@example.com"))
+		require.NoError(t, err)
+		require.Equal(t, kyle.Id, user.Id)
+
+		has, err := db.Users().HasIdentity(ctx, kyle)
+		require.NoError(t, err)
+		require.True(t, has)
+	})
+
+	t.Run("follows the subject when the email changes", func(t *testing.T) {
+		user, err := s.oidcUser(ctx, claims("1", "kyle.terry@example.com"))
+		require.NoError(t, err)
+		require.Equal(t, kyle.Id, user.Id)
+	})
+
+	t.Run("another subject with the email can't take the account", func(t *testing.T) {
+		_, err := s.oidcUser(ctx, claims("2", "kyle@example.com"))
+		require.True(t, errors.Is(err, errOIDCOtherSubject))
+	})
+
+	t.Run("makes accounts for new subjects", func(t *testing.T) {
+		user, err := s.oidcUser(ctx, claims("3", "new@example.com"))
+		require.NoError(t, err)
+		require.Equal(t, "new@example.com", user.Email)
+
+		again, err := s.oidcUser(ctx, claims("3", "new@example.com"))
+		require.NoError(t, err)
+		require.Equal(t, user.Id, again.Id)
+	})
+
+	t.Run("turns away", func(t *testing.T) {
+		no := false
+
+		tests := []struct {
+			name   string
+			claims *oidc.Claims
+			err    error
+		}{
+			{name: "disabled users", claims: claims("4", "gone@example.com"), err: store.ErrDisabled},
+			{name: "other domains", claims: claims("5", "kyle@example.org"), err: errOIDCNotAllowed},
+			{name: "unverified emails", claims: &oidc.Claims{Issuer: issuer, Subject: "6", Email: "six@example.com", EmailVerified: &no}, err: errOIDCNoEmail},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := s.oidcUser(ctx, tt.claims)
+				require.True(t, errors.Is(err, tt.err), "got %v, want %v", err, tt.err)
+			})
+		}
+	})
+}
@@ -0,0 +1,390 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/sessions"
+	"github.com/kyleterry/sufr/pkg/api"
+	"github.com/kyleterry/sufr/pkg/store"
+	"github.com/kyleterry/sufr/pkg/webauthn"
+)
+
+const (
+	relyingPartyName = "SUFR"
+
+	webauthnChallengeSessionKey   = "webauthnChallenge"
+	webauthnChallengeAtSessionKey = "webauthnChallengeAt"
+
+	defaultPasskeyName = "Passkey"
+)
+
+var (
+	errAPIInvalidPasskey  = &apiError{status: http.StatusUnauthorized, code: "invalid_passkey", message: "That passkey didn't work."}
+	errAPIPasskeyNotAdded = &apiError{status: http.StatusBadRequest, code: "invalid_passkey", message: "The passkey couldn't be added."}
+	errAPIPasskeyExists   = &apiError{status: http.StatusConflict, code: "already_exists", message: "That passkey is already added."}
+	errAPIAccountDisabled = &apiError{status: http.StatusUnauthorized, code: "disabled", message: "This account has been disabled."}
+)
+
+type passkeyRequest struct {
+	Name       string                        `json:"name"`
+	Credential *webauthn.AttestationResponse `json:"credential"`
+}
+
+type passkeyResult struct {
+	Redirect string `json:"redirect"`
+}
+
+// handleSettingsPasskeyOptions hands out the options for adding a passkey to
+// the logged in user.
+func (s *uiServer) handleSettingsPasskeyOptions() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := r.Context().Value(userContextKey{}).(*api.User)
+
+		if r.Method != http.MethodPost {
+			writeAPIError(w, errAPIMethodNotAllowed)
+
+			return
+		}
+
+		exclude := make([][]byte, len(user.WebauthnCredentials))
+		for i, cred := range user.WebauthnCredentials {
+			exclude[i] = cred.Id
+		}
+
+		opts, err := s.relyingParty(r).CreationOptions(webauthn.UserEntity{
+			ID:          []byte(user.Id),
+			Name:        user.Email,
+			DisplayName: user.Email,
+		}, exclude)
+		if err != nil {
+			writeAPIError(w, err)
+
+			return
+		}
+
+		if err := s.saveWebAuthnChallenge(w, r, opts.Challenge); err != nil {
+			writeAPIError(w, err)
+
+			return
+		}
+
+		writeJSON(w, http.StatusOK, opts)
+	}
+}
+
+// handleSettingsPasskeys adds the passkey the browser made with the options
+// from handleSettingsPasskeyOptions.
+func (s *uiServer) handleSettingsPasskeys() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user := ctx.Value(userContextKey{}).(*api.User)
+
+		if r.Method != http.MethodPost {
+			writeAPIError(w, errAPIMethodNotAllowed)
+
+			return
+		}
+
+		var req passkeyRequest
+
+		if err := decodeJSON(r.Body, &req); err != nil {
+			writeAPIError(w, err)
+
+			return
+		}
+
+		if req.Credential == nil {
+			writeAPIError(w, newAPIBadRequest("credential is required"))
+
+			return
+		}
+
+		session, err := s.sessionStore.Get(r, userAuthSessionKey)
+		if err != nil {
+			writeAPIError(w, err)
+
+			return
+		}
+
+		challenge := popWebAuthnChallenge(session)
+
+		if err := session.Save(r, w); err != nil {
+			writeAPIError(w, err)
+
+			return
+		}
+
+		cred, err := s.relyingParty(r).VerifyRegistration(challenge, req.Credential)
+		if err != nil {
+			log.Printf("adding passkey for %s: %s", user.Id, err)
+			writeAPIError(w, errAPIPasskeyNotAdded)
+
+			return
+		}
+
+		name := strings.TrimSpace(req.Name)
+		if name == "" {
+			name = defaultPasskeyName
+		}
+
+		err = s.db.Users().AddWebAuthnCredential(ctx, user, &api.WebAuthnCredential{
+			Id:        cred.ID,
+			Name:      name,
+			PublicKey: cred.PublicKey,
+			SignCount: cred.SignCount,
+		})
+		if err != nil {
+			if errors.Is(err, store.ErrAlreadyExists) {
+				writeAPIError(w, errAPIPasskeyExists)
+
+				return
+			}
+
+			writeAPIError(w, err)
+
+			return
+		}
+
+		s.addFlash(w, r, "success", "Added passkey "+name+".")
+		writeJSON(w, http.StatusCreated, passkeyResult{Redirect: "/settings"})
+	}
+}
+
+// handleSettingsPasskey removes the passkey at /settings/passkeys/{id}.
+func (s *uiServer) handleSettingsPasskey() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user := ctx.Value(userContextKey{}).(*api.User)
+
+		if r.Method != http.MethodPost {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		if r.PostFormValue("action") != "delete" {
+			http.Error(w, "unknown action", http.StatusBadRequest)
+
+			return
+		}
+
+		id, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(r.URL.Path, "/settings/passkeys/"))
+		if err != nil {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		if err := s.db.Users().DeleteWebAuthnCredential(ctx, user, id); err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				http.NotFound(w, r)
+
+				return
+			}
+
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		s.addFlash(w, r, "success", "Removed the passkey.")
+		http.Redirect(w, r, "/settings", http.StatusSeeOther)
+	}
+}
+
+// handleLoginPasskeyOptions hands out the options for logging in with a
+// passkey. No credentials are listed, so the browser offers whichever ones it
+// has for the site and the account comes from the one picked.
+func (s *uiServer) handleLoginPasskeyOptions() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeAPIError(w, errAPIMethodNotAllowed)
+
+			return
+		}
+
+		opts, err := s.relyingParty(r).RequestOptions(nil)
+		if err != nil {
+			writeAPIError(w, err)
+
+			return
+		}
+
+		if err := s.saveWebAuthnChallenge(w, r, opts.Challenge); err != nil {
+			writeAPIError(w, err)
+
+			return
+		}
+
+		writeJSON(w, http.StatusOK, opts)
+	}
+}
+
+// handleLoginPasskey logs in with a passkey picked with the options from
+// handleLoginPasskeyOptions. Passkeys that checked a PIN or biometric count
+// as two factors; others still need a code from users with two-factor
+// authentication.
+func (s *uiServer) handleLoginPasskey() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		if r.Method != http.MethodPost {
+			writeAPIError(w, errAPIMethodNotAllowed)
+
+			return
+		}
+
+		var req webauthn.AssertionResponse
+
+		if err := decodeJSON(r.Body, &req); err != nil {
+			writeAPIError(w, err)
+
+			return
+		}
+
+		session, err := s.sessionStore.Get(r, userAuthSessionKey)
+		if err != nil {
+			writeAPIError(w, err)
+
+			return
+		}
+
+		challenge := popWebAuthnChallenge(session)
+
+		user, assertion, err := s.verifyPasskeyLogin(ctx, s.relyingParty(r), challenge, &req)
+		if err != nil {
+			if err := session.Save(r, w); err != nil {
+				log.Println(err)
+			}
+
+			writeAPIError(w, err)
+
+			return
+		}
+
+		result := passkeyResult{Redirect: "/"}
+
+		if user.TotpEnabled && !assertion.UserVerified {
+			session.Values[pendingUserIDSessionKey] = user.Id
+			session.Values[pendingAtSessionKey] = time.Now().Unix()
+			result.Redirect = "/login/2fa"
+		} else {
+			session.Values["userID"] = user.Id
+		}
+
+		if err := session.Save(r, w); err != nil {
+			writeAPIError(w, err)
+
+			return
+		}
+
+		writeJSON(w, http.StatusOK, result)
+	}
+}
+
+// verifyPasskeyLogin checks req and returns the user whose passkey made it.
+func (s *uiServer) verifyPasskeyLogin(ctx context.Context, rp *webauthn.RelyingParty, challenge []byte, req *webauthn.AssertionResponse) (*api.User, *webauthn.Assertion, error) {
+	cred, err := s.db.Users().GetWebAuthnCredential(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, nil, errAPIInvalidPasskey
+		}
+
+		return nil, nil, err
+	}
+
+	// discoverable credentials say who they were made for
+	if len(req.UserHandle) > 0 && string(req.UserHandle) != cred.UserId {
+		return nil, nil, errAPIInvalidPasskey
+	}
+
+	user, err := s.db.Users().GetByID(ctx, cred.UserId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if user.Disabled {
+		return nil, nil, errAPIAccountDisabled
+	}
+
+	assertion, err := rp.VerifyAssertion(challenge, &webauthn.Credential{
+		ID:        cred.Id,
+		PublicKey: cred.PublicKey,
+		SignCount: cred.SignCount,
+	}, req)
+	if err != nil {
+		log.Printf("logging in with passkey for %s: %s", user.Id, err)
+
+		return nil, nil, errAPIInvalidPasskey
+	}
+
+	cred.SignCount = assertion.SignCount
+
+	if err := s.db.Users().UseWebAuthnCredential(ctx, cred); err != nil {
+		return nil, nil, err
+	}
+
+	return user, assertion, nil
+}
+
+// relyingParty returns who passkeys are made for, the site at siteURL.
+func (s *uiServer) relyingParty(r *http.Request) *webauthn.RelyingParty {
+	u := s.siteURL(r)
+
+	return &webauthn.RelyingParty{
+		ID:     u.Hostname(),
+		Name:   relyingPartyName,
+		Origin: u.Scheme + "://" + u.Host,
+	}
+}
+
+// saveWebAuthnChallenge keeps the challenge of the options handed out in the
+// session until the browser answers it.
+func (s *uiServer) saveWebAuthnChallenge(w http.ResponseWriter, r *http.Request, challenge []byte) error {
+	session, err := s.sessionStore.Get(r, userAuthSessionKey)
+	if err != nil {
+		return err
+	}
+
+	session.Values[webauthnChallengeSessionKey] = []byte(challenge)
+	session.Values[webauthnChallengeAtSessionKey] = time.Now().Unix()
+
+	return session.Save(r, w)
+}
+
+// popWebAuthnChallenge removes the challenge from session and returns it, so
+// each one is only good for one try. It's nil if it expired.
+func popWebAuthnChallenge(session *sessions.Session) []byte {
+	challenge, _ := session.Values[webauthnChallengeSessionKey].([]byte)
+	at, _ := session.Values[webauthnChallengeAtSessionKey].(int64)
+
+	delete(session.Values, webauthnChallengeSessionKey)
+	delete(session.Values, webauthnChallengeAtSessionKey)
+
+	if time.Since(time.Unix(at, 0)) > webauthn.Timeout {
+		return nil
+	}
+
+	return challenge
+}
+
+// passkeyID returns how a credential id appears in urls.
+func passkeyID(id []byte) string {
+	return base64.RawURLEncoding.EncodeToString(id)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Println(err)
+	}
+}
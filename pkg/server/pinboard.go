@@ -0,0 +1,680 @@
+package server
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kyleterry/sufr/pkg/api"
+	"github.com/kyleterry/sufr/pkg/store"
+)
+
+const (
+	pinboardPrefix      = "/v1"
+	pinboardTimeFormat  = "2006-01-02T15:04:05Z"
+	pinboardDateFormat  = "2006-01-02"
+	pinboardRecentCount = 15
+	pinboardMaxRecent   = 100
+	pinboardMaxTags     = 3
+)
+
+// pinboardServer implements the subset of the Pinboard v1 API that bookmark
+// clients rely on. See https://pinboard.in/api/ for the reference.
+type pinboardServer struct {
+	db     store.Manager
+	router *http.ServeMux
+}
+
+func (s *pinboardServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.router.ServeHTTP(w, r)
+}
+
+func (s *pinboardServer) route() {
+	auth := s.authenticate
+
+	s.router.Handle(pinboardPrefix+"/posts/update", auth(s.handlePostsUpdate()))
+	s.router.Handle(pinboardPrefix+"/posts/add", auth(s.handlePostsAdd()))
+	s.router.Handle(pinboardPrefix+"/posts/delete", auth(s.handlePostsDelete()))
+	s.router.Handle(pinboardPrefix+"/posts/get", auth(s.handlePostsGet()))
+	s.router.Handle(pinboardPrefix+"/posts/recent", auth(s.handlePostsRecent()))
+	s.router.Handle(pinboardPrefix+"/posts/all", auth(s.handlePostsAll()))
+	s.router.Handle(pinboardPrefix+"/tags/get", auth(s.handleTagsGet()))
+	s.router.Handle(pinboardPrefix+"/tags/rename", auth(s.handleTagsRename()))
+}
+
+// authenticate accepts either an auth_token of the form email:token or HTTP
+// basic auth, like Pinboard does.
+func (s *pinboardServer) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var (
+			user *api.User
+			err  error
+		)
+
+		if email, password, ok := r.BasicAuth(); ok {
+			user, err = passwordUser(ctx, s.db, email, password)
+		} else {
+			user, err = s.userForToken(ctx, r.URL.Query().Get("auth_token"))
+		}
+
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Basic realm="sufr"`)
+			http.Error(w, "401 Forbidden", http.StatusUnauthorized)
+
+			return
+		}
+
+		ctx = context.WithValue(ctx, userContextKey{}, user)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (s *pinboardServer) userForToken(ctx context.Context, authToken string) (*api.User, error) {
+	i := strings.LastIndex(authToken, ":")
+	if i < 1 {
+		return nil, store.ErrNotFound
+	}
+
+	user, err := s.db.Users().GetByEmail(ctx, authToken[:i])
+	if err != nil {
+		return nil, err
+	}
+
+	token := authToken[i+1:]
+
+	if user.ApiToken == "" || subtle.ConstantTimeCompare([]byte(user.ApiToken), []byte(token)) != 1 {
+		return nil, store.ErrNotFound
+	}
+
+	if user.Disabled {
+		return nil, store.ErrDisabled
+	}
+
+	return user, nil
+}
+
+func (s *pinboardServer) handlePostsUpdate() http.HandlerFunc {
+	type update struct {
+		XMLName xml.Name `xml:"update" json:"-"`
+		Time    string   `xml:"time,attr" json:"update_time"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user := ctx.Value(userContextKey{}).(*api.User)
+
+		last, err := s.db.UserURLs(user).GetLastUpdated(ctx)
+		if err != nil {
+			writePinboardError(w, r, err)
+
+			return
+		}
+
+		// a user with no urls has never updated anything, so use the epoch
+		writePinboard(w, r, update{Time: last.AsTime().Format(pinboardTimeFormat)})
+	}
+}
+
+func (s *pinboardServer) handlePostsAdd() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user := ctx.Value(userContextKey{}).(*api.User)
+		q := r.URL.Query()
+		uum := s.db.UserURLs(user)
+
+		if q.Get("url") == "" || q.Get("description") == "" {
+			writePinboardResult(w, r, "missing url or description")
+
+			return
+		}
+
+		title := q.Get("description")
+		notes := q.Get("extended")
+		tags := pinboardTags(q.Get("tags"))
+
+		req := urlRequest{
+			URL:   q.Get("url"),
+			Title: &title,
+			Notes: &notes,
+			Tags:  &tags,
+		}
+
+		existing, err := s.findPost(ctx, uum, req.URL)
+		if err != nil && !errors.Is(err, store.ErrNotFound) {
+			writePinboardError(w, r, err)
+
+			return
+		}
+
+		if existing == nil {
+			if _, err := createUserURL(ctx, s.db, user, req); err != nil {
+				writePinboardError(w, r, err)
+
+				return
+			}
+
+			writePinboardResult(w, r, "done")
+
+			return
+		}
+
+		if q.Get("replace") == "no" {
+			writePinboardResult(w, r, "item already exists")
+
+			return
+		}
+
+		if err := applyURLRequest(ctx, s.db, existing, req); err != nil {
+			writePinboardError(w, r, err)
+
+			return
+		}
+
+		if err := uum.Update(ctx, existing); err != nil {
+			writePinboardError(w, r, err)
+
+			return
+		}
+
+		writePinboardResult(w, r, "done")
+	}
+}
+
+func (s *pinboardServer) handlePostsDelete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user := ctx.Value(userContextKey{}).(*api.User)
+		uum := s.db.UserURLs(user)
+
+		uu, err := s.findPost(ctx, uum, r.URL.Query().Get("url"))
+		if err != nil {
+			writePinboardError(w, r, err)
+
+			return
+		}
+
+		if err := uum.Delete(ctx, uu.Id); err != nil {
+			writePinboardError(w, r, err)
+
+			return
+		}
+
+		writePinboardResult(w, r, "done")
+	}
+}
+
+func (s *pinboardServer) handlePostsGet() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user := ctx.Value(userContextKey{}).(*api.User)
+		q := r.URL.Query()
+		uum := s.db.UserURLs(user)
+
+		posts := pinboardPosts{User: user.Email}
+
+		if href := q.Get("url"); href != "" {
+			uu, err := s.findPost(ctx, uum, href)
+			if err != nil && !errors.Is(err, store.ErrNotFound) {
+				writePinboardError(w, r, err)
+
+				return
+			}
+
+			if uu != nil {
+				posts.Date = pinboardTime(uu.CreatedAt)
+				posts.Posts = []pinboardPost{newPinboardPost(uu)}
+			}
+
+			writePinboard(w, r, posts)
+
+			return
+		}
+
+		var day string
+
+		if dt := q.Get("dt"); dt != "" {
+			t, err := time.Parse(pinboardDateFormat, dt)
+			if err != nil {
+				writePinboardResult(w, r, "invalid dt")
+
+				return
+			}
+
+			day = t.Format(pinboardDateFormat)
+		}
+
+		// without a dt, pinboard returns the posts from the most recent day
+		// that has any.
+		err := eachUserURL(ctx, uum, pinboardTagFilter(q.Get("tag")), 0, func(uu *api.UserURL) bool {
+			created := uu.CreatedAt.AsTime().Format(pinboardDateFormat)
+
+			if day == "" {
+				day = created
+			}
+
+			if created > day {
+				return true
+			}
+
+			if created < day {
+				return false
+			}
+
+			posts.Posts = append(posts.Posts, newPinboardPost(uu))
+
+			return true
+		})
+		if err != nil {
+			writePinboardError(w, r, err)
+
+			return
+		}
+
+		if len(posts.Posts) > 0 {
+			posts.Date = posts.Posts[0].Time
+		}
+
+		writePinboard(w, r, posts)
+	}
+}
+
+func (s *pinboardServer) handlePostsRecent() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user := ctx.Value(userContextKey{}).(*api.User)
+		q := r.URL.Query()
+
+		count := pinboardRecentCount
+
+		if c, err := strconv.Atoi(q.Get("count")); err == nil && c > 0 {
+			count = c
+		}
+
+		if count > pinboardMaxRecent {
+			count = pinboardMaxRecent
+		}
+
+		posts := pinboardPosts{User: user.Email}
+
+		err := eachUserURL(ctx, s.db.UserURLs(user), pinboardTagFilter(q.Get("tag")), 0, func(uu *api.UserURL) bool {
+			posts.Posts = append(posts.Posts, newPinboardPost(uu))
+
+			return len(posts.Posts) < count
+		})
+		if err != nil {
+			writePinboardError(w, r, err)
+
+			return
+		}
+
+		if len(posts.Posts) > 0 {
+			posts.Date = posts.Posts[0].Time
+		}
+
+		writePinboard(w, r, posts)
+	}
+}
+
+func (s *pinboardServer) handlePostsAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user := ctx.Value(userContextKey{}).(*api.User)
+		q := r.URL.Query()
+
+		start, _ := strconv.ParseInt(q.Get("start"), 10, 64)
+		if start < 0 {
+			start = 0
+		}
+
+		results, err := strconv.Atoi(q.Get("results"))
+		if err != nil || results < 0 {
+			results = 0
+		}
+
+		var fromdt, todt time.Time
+
+		if v := q.Get("fromdt"); v != "" {
+			if fromdt, err = time.Parse(pinboardTimeFormat, v); err != nil {
+				writePinboardResult(w, r, "invalid fromdt")
+
+				return
+			}
+		}
+
+		if v := q.Get("todt"); v != "" {
+			if todt, err = time.Parse(pinboardTimeFormat, v); err != nil {
+				writePinboardResult(w, r, "invalid todt")
+
+				return
+			}
+		}
+
+		posts := pinboardPosts{User: user.Email}
+
+		err = eachUserURL(ctx, s.db.UserURLs(user), pinboardTagFilter(q.Get("tag")), start, func(uu *api.UserURL) bool {
+			created := uu.CreatedAt.AsTime()
+
+			if !todt.IsZero() && created.After(todt) {
+				return true
+			}
+
+			if !fromdt.IsZero() && created.Before(fromdt) {
+				return false
+			}
+
+			posts.Posts = append(posts.Posts, newPinboardPost(uu))
+
+			return results == 0 || len(posts.Posts) < results
+		})
+		if err != nil {
+			writePinboardError(w, r, err)
+
+			return
+		}
+
+		// posts/all is a bare array in json, unlike the other posts calls.
+		if pinboardFormat(r) == "json" {
+			if posts.Posts == nil {
+				posts.Posts = []pinboardPost{}
+			}
+
+			writePinboard(w, r, posts.Posts)
+
+			return
+		}
+
+		writePinboard(w, r, posts)
+	}
+}
+
+func (s *pinboardServer) handleTagsGet() http.HandlerFunc {
+	type tag struct {
+		Count int64  `xml:"count,attr"`
+		Tag   string `xml:"tag,attr"`
+	}
+
+	type tags struct {
+		XMLName xml.Name `xml:"tags"`
+		Tags    []tag    `xml:"tag"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user := ctx.Value(userContextKey{}).(*api.User)
+
+		tl, err := s.db.UserURLs(user).GetTags(ctx)
+		if err != nil {
+			writePinboardError(w, r, err)
+
+			return
+		}
+
+		if pinboardFormat(r) == "json" {
+			counts := make(map[string]int64, len(tl.Items))
+
+			for _, t := range tl.Items {
+				counts[t.Name] = t.Count
+			}
+
+			writePinboard(w, r, counts)
+
+			return
+		}
+
+		resp := tags{}
+
+		for _, t := range tl.Items {
+			resp.Tags = append(resp.Tags, tag{Count: t.Count, Tag: t.Name})
+		}
+
+		writePinboard(w, r, resp)
+	}
+}
+
+func (s *pinboardServer) handleTagsRename() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user := ctx.Value(userContextKey{}).(*api.User)
+		q := r.URL.Query()
+
+		oldName, newName := strings.TrimSpace(q.Get("old")), strings.TrimSpace(q.Get("new"))
+		if oldName == "" || newName == "" {
+			writePinboardResult(w, r, "missing old or new")
+
+			return
+		}
+
+		old, err := s.db.Tags().GetByName(ctx, oldName)
+		if err != nil {
+			writePinboardError(w, r, err)
+
+			return
+		}
+
+		tags, err := getOrCreateTags(ctx, s.db, []string{newName})
+		if err != nil {
+			writePinboardError(w, r, err)
+
+			return
+		}
+
+		if err := s.db.UserURLs(user).ReplaceTag(ctx, old, tags.Items[0]); err != nil {
+			writePinboardError(w, r, err)
+
+			return
+		}
+
+		writePinboardResult(w, r, "done")
+	}
+}
+
+// findPost returns the user's UserURL for href.
+func (s *pinboardServer) findPost(ctx context.Context, uum store.UserURLManager, href string) (*api.UserURL, error) {
+	// urls are saved in the form url.URL.String() returns them
+	if pu, err := url.ParseRequestURI(href); err == nil {
+		href = pu.String()
+	}
+
+	u, err := s.db.URLs().GetByURL(ctx, href)
+	if err != nil {
+		return nil, err
+	}
+
+	return uum.GetByURLID(ctx, u.Id)
+}
+
+// eachUserURL calls fn with every url matching filter, newest first,
+// skipping the first start of them like Pinboard's offsets do. It stops when
+// fn returns false.
+func eachUserURL(ctx context.Context, uum store.UserURLManager, filter store.FilterOption, start int64, fn func(*api.UserURL) bool) error {
+	var after string
+
+	for {
+		uus, err := uum.GetAll(ctx, filter, store.WithResultsAfter(after))
+		if err != nil {
+			return err
+		}
+
+		if len(uus) == 0 {
+			return nil
+		}
+
+		for _, uu := range uus {
+			if start > 0 {
+				start--
+
+				continue
+			}
+
+			if !fn(uu) {
+				return nil
+			}
+		}
+
+		after = uus[len(uus)-1].Cursor
+	}
+}
+
+type pinboardPost struct {
+	XMLName     xml.Name `xml:"post" json:"-"`
+	Href        string   `xml:"href,attr" json:"href"`
+	Description string   `xml:"description,attr" json:"description"`
+	Extended    string   `xml:"extended,attr" json:"extended"`
+	Meta        string   `xml:"meta,attr" json:"meta"`
+	Hash        string   `xml:"hash,attr" json:"hash"`
+	Time        string   `xml:"time,attr" json:"time"`
+	Shared      string   `xml:"shared,attr" json:"shared"`
+	ToRead      string   `xml:"toread,attr" json:"toread"`
+	Tags        string   `xml:"tag,attr" json:"tags"`
+}
+
+type pinboardPosts struct {
+	XMLName xml.Name       `xml:"posts" json:"-"`
+	Date    string         `xml:"dt,attr" json:"date"`
+	User    string         `xml:"user,attr" json:"user"`
+	Posts   []pinboardPost `xml:"post" json:"posts"`
+}
+
+func newPinboardPost(uu *api.UserURL) pinboardPost {
+	names := []string{}
+
+	if uu.Tags != nil {
+		for _, t := range uu.Tags.Items {
+			names = append(names, t.Name)
+		}
+	}
+
+	tags := strings.Join(names, " ")
+	updated := uu.UpdatedAt
+	if updated == nil {
+		updated = uu.CreatedAt
+	}
+
+	return pinboardPost{
+		Href:        uu.Url.Url,
+		Description: uu.DerivedTitle,
+		Extended:    uu.Notes,
+		Hash:        md5Hex(uu.Url.Url),
+		Meta:        md5Hex(strings.Join([]string{uu.DerivedTitle, uu.Notes, tags, pinboardTime(updated)}, "\n")),
+		Time:        pinboardTime(uu.CreatedAt),
+		Shared:      pinboardBool(uu.Visibility == api.Visibility_VISIBILITY_PUBLIC),
+		ToRead:      "no",
+		Tags:        tags,
+	}
+}
+
+// pinboardBool is how pinboard writes booleans.
+func pinboardBool(b bool) string {
+	if b {
+		return "yes"
+	}
+
+	return "no"
+}
+
+// pinboardTags splits a pinboard tag list. Pinboard separates tags with
+// spaces, but some clients send commas.
+func pinboardTags(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == ','
+	})
+}
+
+func pinboardTagFilter(s string) store.FilterOption {
+	tags := pinboardTags(s)
+
+	if len(tags) > pinboardMaxTags {
+		tags = tags[:pinboardMaxTags]
+	}
+
+	return store.WithTags(tags)
+}
+
+func pinboardTime(ts *api.Timestamp) string {
+	return ts.AsTime().Format(pinboardTimeFormat)
+}
+
+func md5Hex(s string) string {
+	sum := md5.Sum([]byte(s))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func pinboardFormat(r *http.Request) string {
+	if r.URL.Query().Get("format") == "json" {
+		return "json"
+	}
+
+	return "xml"
+}
+
+// writePinboardResult writes the result code pinboard uses to report the
+// outcome of a write, or why a request was rejected.
+func writePinboardResult(w http.ResponseWriter, r *http.Request, code string) {
+	type result struct {
+		XMLName xml.Name `xml:"result" json:"-"`
+		Code    string   `xml:"code,attr" json:"result_code"`
+	}
+
+	writePinboard(w, r, result{Code: code})
+}
+
+func writePinboardError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, store.ErrNotFound) {
+		writePinboardResult(w, r, "item not found")
+
+		return
+	}
+
+	var ae *apiError
+	if errors.As(err, &ae) {
+		writePinboardResult(w, r, ae.message)
+
+		return
+	}
+
+	log.Println(err)
+	http.Error(w, "500 Internal Server Error", http.StatusInternalServerError)
+}
+
+func writePinboard(w http.ResponseWriter, r *http.Request, v interface{}) {
+	var (
+		b   []byte
+		err error
+	)
+
+	if pinboardFormat(r) == "json" {
+		w.Header().Set("Content-Type", "application/json")
+
+		b, err = json.Marshal(v)
+	} else {
+		w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+
+		b, err = xml.Marshal(v)
+		b = append([]byte(xml.Header), b...)
+	}
+
+	if err != nil {
+		log.Println(err)
+		http.Error(w, "500 Internal Server Error", http.StatusInternalServerError)
+
+		return
+	}
+
+	if _, err := w.Write(b); err != nil {
+		log.Println(err)
+	}
+}
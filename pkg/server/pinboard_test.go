@@ -0,0 +1,194 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// pinboardRequest calls the Pinboard API of srv with query and returns the
+// response.
+func pinboardRequest(t *testing.T, srv http.Handler, path string, query url.Values) *httptest.ResponseRecorder {
+	query.Set("format", "json")
+
+	r := httptest.NewRequest(http.MethodGet, pinboardPrefix+path+"?"+query.Encode(), nil)
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, r)
+
+	return w
+}
+
+func TestPinboardAuthentication(t *testing.T) {
+	db := newTestStore(t)
+	ctx := context.Background()
+
+	srv, err := New(WithStore(db))
+	require.NoError(t, err)
+
+	user := mustCreateUser(t, db, "kyle@example.com", false)
+	user.ApiToken = "0123456789abcdef"
+	require.NoError(t, db.Users().UpdateAPIToken(ctx, user))
+
+	mustCreateUser(t, db, "notoken@example.com", false)
+
+	gone := mustCreateUser(t, db, "gone@example.com", true)
+	gone.ApiToken = "fedcba9876543210"
+	require.NoError(t, db.Users().UpdateAPIToken(ctx, gone))
+
+	totpUser := mustCreateUser(t, db, "totp@example.com", false)
+	require.NoError(t, db.Users().EnableTOTP(ctx, totpUser, "JBSWY3DPEHPK3PXP", nil))
+
+	tests := []struct {
+		name      string
+		authToken string
+		basicAuth []string
+		status    int
+	}{
+		{name: "token", authToken: "kyle@example.com:0123456789abcdef", status: http.StatusOK},
+		{name: "wrong token", authToken: "kyle@example.com:0123456789abcdee", status: http.StatusUnauthorized},
+		{name: "someone else's token", authToken: "kyle@example.com:fedcba9876543210", status: http.StatusUnauthorized},
+		{name: "token prefix", authToken: "kyle@example.com:0123", status: http.StatusUnauthorized},
+		{name: "user without a token", authToken: "notoken@example.com:", status: http.StatusUnauthorized},
+		{name: "disabled user", authToken: "gone@example.com:fedcba9876543210", status: http.StatusUnauthorized},
+		{name: "unknown user", authToken: "nobody@example.com:0123456789abcdef", status: http.StatusUnauthorized},
+		{name: "no email", authToken: ":0123456789abcdef", status: http.StatusUnauthorized},
+		{name: "no separator", authToken: "0123456789abcdef", status: http.StatusUnauthorized},
+		{name: "nothing", status: http.StatusUnauthorized},
+		{name: "basic auth", basicAuth: []string{"kyle@example.com", "password"}, status: http.StatusOK},
+		{name: "basic auth with the wrong password", basicAuth: []string{"kyle@example.com", "0123456789abcdef"}, status: http.StatusUnauthorized},
+		{name: "basic auth for a two-factor user", basicAuth: []string{"totp@example.com", "password"}, status: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := url.Values{}
+			if tt.authToken != "" {
+				q.Set("auth_token", tt.authToken)
+			}
+
+			r := httptest.NewRequest(http.MethodGet, pinboardPrefix+"/posts/update?"+q.Encode(), nil)
+			if tt.basicAuth != nil {
+				r.SetBasicAuth(tt.basicAuth[0], tt.basicAuth[1])
+			}
+
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, r)
+			require.Equal(t, tt.status, w.Code)
+		})
+	}
+}
+
+func TestPinboardPosts(t *testing.T) {
+	db := newTestStore(t)
+	ctx := context.Background()
+
+	srv, err := New(WithStore(db))
+	require.NoError(t, err)
+
+	user := mustCreateUser(t, db, "kyle@example.com", false)
+	user.ApiToken = "0123456789abcdef"
+	require.NoError(t, db.Users().UpdateAPIToken(ctx, user))
+
+	auth := func(q url.Values) url.Values {
+		q.Set("auth_token", "kyle@example.com:0123456789abcdef")
+
+		return q
+	}
+
+	result := func(t *testing.T, w *httptest.ResponseRecorder) string {
+		require.Equal(t, http.StatusOK, w.Code)
+
+		body := struct {
+			Code string `json:"result_code"`
+		}{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+
+		return body.Code
+	}
+
+	all := func(t *testing.T, q url.Values) []string {
+		w := pinboardRequest(t, srv, "/posts/all", auth(q))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		posts := []pinboardPost{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &posts))
+
+		hrefs := []string{}
+		for _, p := range posts {
+			hrefs = append(hrefs, p.Href)
+		}
+
+		return hrefs
+	}
+
+	for i := 0; i < 5; i++ {
+		w := pinboardRequest(t, srv, "/posts/add", auth(url.Values{
+			"url":         {fmt.Sprintf("https://example.com/%d", i)},
+			"description": {fmt.Sprintf("post %d", i)},
+			"tags":        {"go"},
+		}))
+		require.Equal(t, "done", result(t, w))
+	}
+
+	t.Run("adds", func(t *testing.T) {
+		w := pinboardRequest(t, srv, "/posts/add", auth(url.Values{"url": {"https://example.com/new"}}))
+		require.Equal(t, "missing url or description", result(t, w))
+
+		w = pinboardRequest(t, srv, "/posts/add", auth(url.Values{
+			"url":         {"https://example.com/0"},
+			"description": {"again"},
+			"replace":     {"no"},
+		}))
+		require.Equal(t, "item already exists", result(t, w))
+	})
+
+	t.Run("lists newest first", func(t *testing.T) {
+		require.Equal(t, []string{
+			"https://example.com/4",
+			"https://example.com/3",
+			"https://example.com/2",
+			"https://example.com/1",
+			"https://example.com/0",
+		}, all(t, url.Values{}))
+	})
+
+	t.Run("pages with start and results", func(t *testing.T) {
+		require.Equal(t, []string{"https://example.com/3", "https://example.com/2"}, all(t, url.Values{"start": {"1"}, "results": {"2"}}))
+		require.Equal(t, []string{"https://example.com/0"}, all(t, url.Values{"start": {"4"}, "results": {"2"}}))
+		require.Empty(t, all(t, url.Values{"start": {"5"}}))
+		require.Len(t, all(t, url.Values{"start": {"-1"}, "results": {"junk"}}), 5)
+	})
+
+	t.Run("filters by tag", func(t *testing.T) {
+		require.Len(t, all(t, url.Values{"tag": {"go"}}), 5)
+		require.Empty(t, all(t, url.Values{"tag": {"go sql"}}))
+	})
+
+	t.Run("renames tags", func(t *testing.T) {
+		w := pinboardRequest(t, srv, "/tags/rename", auth(url.Values{"old": {"go"}, "new": {"golang"}}))
+		require.Equal(t, "done", result(t, w))
+
+		w = pinboardRequest(t, srv, "/tags/get", auth(url.Values{}))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		counts := map[string]int64{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &counts))
+		require.Equal(t, map[string]int64{"golang": 5}, counts)
+	})
+
+	t.Run("deletes", func(t *testing.T) {
+		w := pinboardRequest(t, srv, "/posts/delete", auth(url.Values{"url": {"https://example.com/4"}}))
+		require.Equal(t, "done", result(t, w))
+
+		w = pinboardRequest(t, srv, "/posts/delete", auth(url.Values{"url": {"https://example.com/4"}}))
+		require.Equal(t, "item not found", result(t, w))
+
+		require.Len(t, all(t, url.Values{}), 4)
+	})
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/kyleterry/sufr/pkg/api"
+	"github.com/kyleterry/sufr/pkg/csrf"
+	"github.com/kyleterry/sufr/pkg/store"
+)
+
+// profilePageSize is how many urls a page of a profile lists.
+const profilePageSize = 40
+
+type profileData struct {
+	templateData
+	// ProfileURL is the path of the profile the urls are from. The urls
+	// link to their own pages under it when it's set.
+	ProfileURL string
+	// PageURL is the path of the page, which the following one is linked
+	// from.
+	PageURL string
+	// FeedURL is the path of the page's feeds without the .atom or .rss
+	// extension. Pages without feeds leave it empty.
+	FeedURL string
+	URLs    []*api.UserURL
+	// Next is the after parameter of the following page, or empty on the
+	// last one.
+	Next string
+}
+
+// handleProfile serves the public profile of a user at /u/{id}, which lists
+// the urls they made public, and the page of one of their public or
+// unlisted urls at /u/{id}/{url id}. Nobody has to be logged in, so nothing
+// about the user besides those urls is shown.
+func (s *uiServer) handleProfile() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		if r.Method != http.MethodGet {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		id, urlID := strings.TrimPrefix(r.URL.Path, "/u/"), ""
+
+		if i := strings.IndexByte(id, '/'); i >= 0 {
+			id, urlID = id[:i], id[i+1:]
+		}
+
+		owner, err := s.db.Users().GetByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				http.NotFound(w, r)
+
+				return
+			}
+
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		if owner.Disabled {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		data := profileData{
+			templateData: templateData{
+				Title:     "Shared bookmarks",
+				CSRFToken: csrf.Token(r),
+			},
+			ProfileURL: "/u/" + owner.Id,
+			PageURL:    "/u/" + owner.Id,
+			FeedURL:    "/feeds/" + owner.Id + "/timeline",
+		}
+
+		uum := s.db.UserURLs(owner)
+
+		if urlID == "" {
+			after := r.URL.Query().Get("after")
+
+			data.URLs, err = uum.GetAll(ctx, store.WithPublic(true), store.WithResultsAfter(after), store.WithLimit(profilePageSize))
+			if errors.Is(err, store.ErrInvalidCursor) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+
+				return
+			}
+
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+
+				return
+			}
+
+			if len(data.URLs) == profilePageSize {
+				data.Next = data.URLs[len(data.URLs)-1].Cursor
+			}
+		} else {
+			uu, err := uum.GetByID(ctx, urlID)
+			if err != nil && !errors.Is(err, store.ErrNotFound) {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+
+				return
+			}
+
+			if uu == nil || uu.Visibility == api.Visibility_VISIBILITY_PRIVATE {
+				http.NotFound(w, r)
+
+				return
+			}
+
+			// unlisted urls shouldn't end up in search engines
+			if uu.Visibility == api.Visibility_VISIBILITY_UNLISTED {
+				w.Header().Set("X-Robots-Tag", "noindex")
+			}
+
+			data.Title = uu.DerivedTitle
+			data.URLs = []*api.UserURL{uu}
+		}
+
+		err = s.templates.withWriter("users/profile", func(tw *templateWriter) error {
+			return tw.write(w, r, data)
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/kyleterry/sufr/pkg/api"
+	"github.com/kyleterry/sufr/pkg/store"
+)
+
+// DefaultProxyAuthHeader is the header auth proxies like oauth2-proxy put
+// the logged in email address in.
+const DefaultProxyAuthHeader = "X-Forwarded-Email"
+
+var (
+	errUntrustedProxy = errors.New("request isn't from a trusted proxy")
+	errNoProxyUser    = errors.New("no user from proxy")
+)
+
+// proxyAuth logs requests in as the user whose email address an auth proxy
+// in front of sufr puts in a header. The header is only believed on
+// requests straight from the proxy's addresses; anyone else could set it.
+type proxyAuth struct {
+	header  string
+	trusted []*net.IPNet
+}
+
+// newProxyAuth trusts header on requests from the cidrs. Bare addresses
+// are trusted on their own.
+func newProxyAuth(header string, cidrs []string) (*proxyAuth, error) {
+	if header == "" {
+		header = DefaultProxyAuthHeader
+	}
+
+	if len(cidrs) == 0 {
+		return nil, errors.New("proxy auth needs at least one trusted address")
+	}
+
+	p := &proxyAuth{header: http.CanonicalHeaderKey(header)}
+
+	for _, c := range cidrs {
+		c = strings.TrimSpace(c)
+
+		if !strings.Contains(c, "/") {
+			ip := net.ParseIP(c)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy address %q", c)
+			}
+
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+
+			p.trusted = append(p.trusted, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+
+			continue
+		}
+
+		_, n, err := net.ParseCIDR(c)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy cidr %q", c)
+		}
+
+		p.trusted = append(p.trusted, n)
+	}
+
+	return p, nil
+}
+
+// trusts reports whether r came straight from a trusted proxy.
+func (p *proxyAuth) trusts(r *http.Request) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+
+	for _, n := range p.trusted {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// user returns the user the proxy logged r in as.
+func (p *proxyAuth) user(r *http.Request, db store.Manager) (*api.User, error) {
+	if !p.trusts(r) {
+		return nil, errUntrustedProxy
+	}
+
+	// more than one means the proxy passed along a header the client sent
+	values := r.Header.Values(p.header)
+	if len(values) != 1 || !validEmail(strings.TrimSpace(values[0])) {
+		return nil, errNoProxyUser
+	}
+
+	user, err := db.Users().GetByEmail(r.Context(), strings.TrimSpace(values[0]))
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Disabled {
+		return nil, store.ErrDisabled
+	}
+
+	return user, nil
+}
@@ -0,0 +1,236 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/gorilla/sessions"
+	"github.com/kyleterry/sufr/pkg/api"
+	"github.com/kyleterry/sufr/pkg/service/sqlitestore"
+	"github.com/kyleterry/sufr/pkg/store"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStore(t *testing.T) *sqlitestore.Store {
+	db, err := sqlitestore.New(sqlitestore.WithPath(filepath.Join(t.TempDir(), "sufr.db")))
+	require.NoError(t, err)
+	require.NoError(t, db.Migrate(context.Background()))
+
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+func mustCreateUser(t *testing.T, db store.Manager, email string, disabled bool) *api.User {
+	ctx := context.Background()
+
+	ph, err := api.GeneratePasswordHash("password")
+	require.NoError(t, err)
+
+	user := &api.User{Email: email, PasswordHash: ph}
+	require.NoError(t, db.Users().Create(ctx, user))
+
+	if disabled {
+		user.Disabled = true
+		require.NoError(t, db.Users().UpdateAccess(ctx, user))
+	}
+
+	return user
+}
+
+func TestNewProxyAuth(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		cidrs   []string
+		wantErr bool
+	}{
+		{name: "cidr", cidrs: []string{"10.0.0.0/8"}},
+		{name: "bare addresses", cidrs: []string{"10.0.0.5", " ::1 "}},
+		{name: "custom header", header: "x-auth-email", cidrs: []string{"10.0.0.5"}},
+		{name: "no addresses", wantErr: true},
+		{name: "malformed cidr", cidrs: []string{"10.0.0.0/33"}, wantErr: true},
+		{name: "malformed address", cidrs: []string{"10.0.0.5", "proxy.local"}, wantErr: true},
+		{name: "empty address", cidrs: []string{""}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := newProxyAuth(tt.header, tt.cidrs)
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, p.trusted, len(tt.cidrs))
+
+			if tt.header == "" {
+				require.Equal(t, DefaultProxyAuthHeader, p.header)
+			} else {
+				require.Equal(t, http.CanonicalHeaderKey(tt.header), p.header)
+			}
+		})
+	}
+}
+
+func TestProxyAuthUser(t *testing.T) {
+	db := newTestStore(t)
+
+	user := mustCreateUser(t, db, "kyle@example.com", false)
+	mustCreateUser(t, db, "gone@example.com", true)
+
+	p, err := newProxyAuth("", []string{"10.0.0.0/8", "192.168.1.5", "fd00::/8"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		emails     []string
+		err        error
+	}{
+		{name: "trusted cidr", remoteAddr: "10.1.2.3:51000", emails: []string{"kyle@example.com"}},
+		{name: "trusted address", remoteAddr: "192.168.1.5:51000", emails: []string{"kyle@example.com"}},
+		{name: "trusted ipv6", remoteAddr: "[fd00::1]:51000", emails: []string{"kyle@example.com"}},
+		{name: "address without a port", remoteAddr: "10.1.2.3", emails: []string{"kyle@example.com"}},
+		{name: "spaces around the email", remoteAddr: "10.1.2.3:51000", emails: []string{" kyle@example.com "}},
+		{name: "spoofed from an untrusted peer", remoteAddr: "203.0.113.9:51000", emails: []string{"kyle@example.com"}, err: errUntrustedProxy},
+		{name: "next to a trusted address", remoteAddr: "192.168.1.6:51000", emails: []string{"kyle@example.com"}, err: errUntrustedProxy},
+		{name: "garbage peer", remoteAddr: "not-an-address", emails: []string{"kyle@example.com"}, err: errUntrustedProxy},
+		{name: "no header", remoteAddr: "10.1.2.3:51000", err: errNoProxyUser},
+		{name: "two headers", remoteAddr: "10.1.2.3:51000", emails: []string{"kyle@example.com", "admin@localhost"}, err: errNoProxyUser},
+		{name: "empty header", remoteAddr: "10.1.2.3:51000", emails: []string{""}, err: errNoProxyUser},
+		{name: "not an email", remoteAddr: "10.1.2.3:51000", emails: []string{"kyle"}, err: errNoProxyUser},
+		{name: "unknown user", remoteAddr: "10.1.2.3:51000", emails: []string{"nobody@example.com"}, err: store.ErrNotFound},
+		{name: "disabled user", remoteAddr: "10.1.2.3:51000", emails: []string{"gone@example.com"}, err: store.ErrDisabled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/timeline", nil)
+			r.RemoteAddr = tt.remoteAddr
+
+			for _, e := range tt.emails {
+				r.Header.Add("x-forwarded-email", e)
+			}
+
+			got, err := p.user(r, db)
+			if tt.err != nil {
+				require.True(t, errors.Is(err, tt.err), "got %v, want %v", err, tt.err)
+				require.Nil(t, got)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, user.Id, got.Id)
+		})
+	}
+}
+
+func TestProxyAuthMiddleware(t *testing.T) {
+	db := newTestStore(t)
+	sessionStore := sessions.NewCookieStore(make([]byte, 32), make([]byte, 16))
+
+	user := mustCreateUser(t, db, "kyle@example.com", false)
+
+	p, err := newProxyAuth("", []string{"10.0.0.0/8"})
+	require.NoError(t, err)
+
+	// a cookie that logs user in when there's no proxy
+	rec := httptest.NewRecorder()
+	session, err := sessionStore.New(httptest.NewRequest(http.MethodGet, "/", nil), userAuthSessionKey)
+	require.NoError(t, err)
+	session.Values["userID"] = user.Id
+	require.NoError(t, session.Save(httptest.NewRequest(http.MethodGet, "/", nil), rec))
+	cookie := rec.Result().Cookies()[0]
+
+	var loggedIn *api.User
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		loggedIn = r.Context().Value(userContextKey{}).(*api.User)
+	})
+
+	middlewares := []struct {
+		name      string
+		withProxy http.Handler
+		noProxy   http.Handler
+	}{
+		{
+			name:      "ui",
+			withProxy: NewSessionAuthenticationMiddleware(sessionStore, db, p)(next),
+			noProxy:   NewSessionAuthenticationMiddleware(sessionStore, db, nil)(next),
+		},
+		{
+			name:      "api",
+			withProxy: NewAPIAuthenticationMiddleware(sessionStore, db, p)(next),
+			noProxy:   NewAPIAuthenticationMiddleware(sessionStore, db, nil)(next),
+		},
+	}
+
+	tests := []struct {
+		name       string
+		proxy      bool
+		remoteAddr string
+		email      string
+		cookie     bool
+		loggedIn   bool
+	}{
+		{name: "proxy logs in", proxy: true, remoteAddr: "10.0.0.2:1234", email: "kyle@example.com", loggedIn: true},
+		{name: "session cookies are ignored behind a proxy", proxy: true, remoteAddr: "10.0.0.2:1234", cookie: true},
+		{name: "session cookies don't help untrusted peers", proxy: true, remoteAddr: "203.0.113.9:1234", email: "kyle@example.com", cookie: true},
+		{name: "session cookies log in without a proxy", remoteAddr: "203.0.113.9:1234", cookie: true, loggedIn: true},
+		{name: "proxy headers are ignored without a proxy", remoteAddr: "10.0.0.2:1234", email: "kyle@example.com"},
+	}
+
+	for _, m := range middlewares {
+		for _, tt := range tests {
+			t.Run(m.name+"/"+tt.name, func(t *testing.T) {
+				loggedIn = nil
+
+				r := httptest.NewRequest(http.MethodGet, "/", nil)
+				r.RemoteAddr = tt.remoteAddr
+
+				if tt.email != "" {
+					r.Header.Set(DefaultProxyAuthHeader, tt.email)
+				}
+
+				if tt.cookie {
+					r.AddCookie(cookie)
+				}
+
+				h := m.noProxy
+				if tt.proxy {
+					h = m.withProxy
+				}
+
+				w := httptest.NewRecorder()
+				h.ServeHTTP(w, r)
+
+				if !tt.loggedIn {
+					require.Nil(t, loggedIn)
+					require.NotEqual(t, http.StatusOK, w.Code)
+
+					return
+				}
+
+				require.NotNil(t, loggedIn)
+				require.Equal(t, user.Id, loggedIn.Id)
+			})
+		}
+	}
+}
+
+func TestNewRejectsBadProxySettings(t *testing.T) {
+	_, err := New(WithStore(newTestStore(t)), WithProxyAuth("", "10.0.0.0/33"))
+	require.Error(t, err)
+
+	srv, err := New(WithStore(newTestStore(t)), WithProxyAuth("", "10.0.0.0/8"))
+	require.NoError(t, err)
+	require.NotNil(t, srv.proxyAuth)
+}
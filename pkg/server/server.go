@@ -2,20 +2,45 @@ package server
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/gorilla/sessions"
+	"github.com/kyleterry/sufr/pkg/archive"
+	"github.com/kyleterry/sufr/pkg/config"
+	"github.com/kyleterry/sufr/pkg/embed"
+	"github.com/kyleterry/sufr/pkg/fetchqueue"
+	"github.com/kyleterry/sufr/pkg/linkcheck"
+	"github.com/kyleterry/sufr/pkg/oidc"
+	"github.com/kyleterry/sufr/pkg/sessionkeys"
 	"github.com/kyleterry/sufr/pkg/store"
 	"github.com/kyleterry/sufr/pkg/ui"
 )
 
-const defaultBindAddr = "127.0.0.1:8090"
+const (
+	defaultBindAddr     = "127.0.0.1:8090"
+	defaultGRPCBindAddr = "127.0.0.1:8091"
+)
 
 type serverOptions struct {
 	db             store.Manager
 	bindAddr       string
+	grpcBindAddr   string
 	sessionAuthKey []byte
 	sessionEncKey  []byte
+	previousKeys   [][]byte
+	fetchWorkers   int
+	checkInterval  time.Duration
+	checkDeadAfter int
+	archiveDir     string
+	publicURL      string
+	oidc           *oidc.Config
+	proxyHeader    string
+	proxyTrusted   []string
 }
 
 type serverOptionFunc struct {
@@ -46,6 +71,108 @@ func WithBindAddr(addr string) ServerOption {
 	}
 }
 
+// WithGRPCBindAddr sets the address the gRPC service listens on. An empty
+// address disables it.
+func WithGRPCBindAddr(addr string) ServerOption {
+	return &serverOptionFunc{
+		f: func(opts *serverOptions) {
+			opts.grpcBindAddr = addr
+		},
+	}
+}
+
+// WithFetchWorkers sets how many url metadata fetches run at once.
+func WithFetchWorkers(n int) ServerOption {
+	return &serverOptionFunc{
+		f: func(opts *serverOptions) {
+			opts.fetchWorkers = n
+		},
+	}
+}
+
+// WithLinkCheckInterval sets how often saved urls are checked to see if they
+// still work. An interval of 0 disables link checking.
+func WithLinkCheckInterval(d time.Duration) ServerOption {
+	return &serverOptionFunc{
+		f: func(opts *serverOptions) {
+			opts.checkInterval = d
+		},
+	}
+}
+
+// WithLinkCheckDeadAfter sets how many link checks in a row have to fail
+// before a url is flagged dead.
+func WithLinkCheckDeadAfter(n int) ServerOption {
+	return &serverOptionFunc{
+		f: func(opts *serverOptions) {
+			opts.checkDeadAfter = n
+		},
+	}
+}
+
+// WithArchiveDir sets the directory snapshots of saved pages are archived in.
+// An empty dir disables archiving.
+func WithArchiveDir(dir string) ServerOption {
+	return &serverOptionFunc{
+		f: func(opts *serverOptions) {
+			opts.archiveDir = dir
+		},
+	}
+}
+
+// WithPublicURL sets the URL people reach the server at, like
+// "https://sufr.example.com". Passkeys are tied to its host. Without it the
+// host each request was sent to is used, which doesn't work behind a proxy
+// that terminates TLS.
+func WithPublicURL(u string) ServerOption {
+	return &serverOptionFunc{
+		f: func(opts *serverOptions) {
+			opts.publicURL = u
+		},
+	}
+}
+
+// WithOIDC sets the OpenID Connect provider people can log in with. It's
+// reached lazily, so the server starts even when the provider is down.
+// oidc.FromConfig gets it from the config.
+func WithOIDC(cfg *oidc.Config) ServerOption {
+	return &serverOptionFunc{
+		f: func(opts *serverOptions) {
+			opts.oidc = cfg
+		},
+	}
+}
+
+// WithProxyAuth logs requests in as the user whose email address an auth
+// proxy like oauth2-proxy or Authelia puts in header, in place of session
+// cookies. Only requests straight from the trusted addresses or CIDRs are
+// believed. An empty header means DefaultProxyAuthHeader.
+func WithProxyAuth(header string, trusted ...string) ServerOption {
+	return &serverOptionFunc{
+		f: func(opts *serverOptions) {
+			opts.proxyHeader = header
+			opts.proxyTrusted = trusted
+		},
+	}
+}
+
+// WithConfig sets the options that come from SUFR_* settings in cfg: the
+// public URL, single sign-on and auth proxy. New returns an error if any of
+// them are invalid.
+func WithConfig(cfg *config.Config) ServerOption {
+	return &serverOptionFunc{
+		f: func(opts *serverOptions) {
+			opts.publicURL = cfg.PublicURL
+			opts.oidc = oidc.FromConfig(*cfg)
+			opts.proxyHeader = cfg.ProxyAuthHeader
+			opts.proxyTrusted = cfg.ProxyAuthTrustedCIDRs
+		},
+	}
+}
+
+// WithSessionKeyPair sets the keys session cookies are signed and encrypted
+// with. Random keys are made up when none are set, which logs everyone out
+// when the server restarts. sessionkeys.Load gets keys that last.
 func WithSessionKeyPair(auth, enc []byte) ServerOption {
 	return &serverOptionFunc{
 		f: func(opts *serverOptions) {
@@ -55,13 +182,47 @@ func WithSessionKeyPair(auth, enc []byte) ServerOption {
 	}
 }
 
+// WithPreviousSessionKeyPair adds a key pair that was rotated out. Sessions
+// made with it are still accepted. It can be given more than once.
+func WithPreviousSessionKeyPair(auth, enc []byte) ServerOption {
+	return &serverOptionFunc{
+		f: func(opts *serverOptions) {
+			opts.previousKeys = append(opts.previousKeys, auth, enc)
+		},
+	}
+}
+
+// WithSessionKeys sets the current and previous session key pairs from keys.
+func WithSessionKeys(keys *sessionkeys.Keys) ServerOption {
+	return &serverOptionFunc{
+		f: func(opts *serverOptions) {
+			opts.sessionAuthKey = keys.Current.Auth
+			opts.sessionEncKey = keys.Current.Encryption
+			opts.previousKeys = nil
+
+			for _, p := range keys.Previous {
+				opts.previousKeys = append(opts.previousKeys, p.Auth, p.Encryption)
+			}
+		},
+	}
+}
+
 type server struct {
-	db     store.Manager
-	router *http.ServeMux
+	db           store.Manager
+	router       *http.ServeMux
+	sessionStore sessions.Store
+	archiver     *archive.Archiver
+	publicURL    *url.URL
+	oidc         *oidc.Provider
+	proxyAuth    *proxyAuth
 
 	bindAddr       string
+	grpcBindAddr   string
 	sessionAuthKey []byte
 	sessionEncKey  []byte
+	fetchWorkers   int
+	checkInterval  time.Duration
+	checkDeadAfter int
 }
 
 func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -75,17 +236,21 @@ func (s *server) Run(ctx context.Context) error {
 func (s *server) route() {
 	s.router.HandleFunc("/", s.handleUI())
 	s.router.HandleFunc("/api/", s.handleAPI())
+	s.router.HandleFunc(pinboardPrefix+"/", s.handlePinboard())
 }
 
 func (s *server) handleUI() http.HandlerFunc {
 	srv := uiServer{
-		db:     s.db,
-		router: http.NewServeMux(),
-		uifs:   ui.NewFileSystem(),
-		sessionStore: sessions.NewCookieStore(
-			s.sessionAuthKey,
-			s.sessionEncKey,
-		),
+		db:           s.db,
+		router:       http.NewServeMux(),
+		uifs:         ui.NewFileSystem(),
+		sessionStore: s.sessionStore,
+		archiver:     s.archiver,
+		publicURL:    s.publicURL,
+		oidc:         s.oidc,
+		proxyAuth:    s.proxyAuth,
+		totpFailures: newFailureLimiter(totpMaxFailures, totpFailureWindow),
+		embeds:       embed.New(embed.WithCache(s.db.Embeds())),
 	}
 
 	srv.setupTemplates()
@@ -97,29 +262,106 @@ func (s *server) handleUI() http.HandlerFunc {
 }
 
 func (s *server) handleAPI() http.HandlerFunc {
+	srv := apiServer{
+		db:           s.db,
+		router:       http.NewServeMux(),
+		sessionStore: s.sessionStore,
+		proxyAuth:    s.proxyAuth,
+		archiver:     s.archiver,
+	}
+
+	srv.route()
+
 	return func(w http.ResponseWriter, r *http.Request) {
-		http.Error(w, "not implemented", http.StatusNotImplemented)
+		srv.ServeHTTP(w, r)
 	}
 }
 
-func New(opts ...ServerOption) *server {
+func (s *server) handlePinboard() http.HandlerFunc {
+	srv := pinboardServer{
+		db:     s.db,
+		router: http.NewServeMux(),
+	}
+
+	srv.route()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		srv.ServeHTTP(w, r)
+	}
+}
+
+// New returns a server with opts. It returns an error when the options
+// don't make sense, like a malformed public URL or trusted proxy address.
+func New(opts ...ServerOption) (*server, error) {
 	so := serverOptions{
-		bindAddr: defaultBindAddr,
+		bindAddr:       defaultBindAddr,
+		grpcBindAddr:   defaultGRPCBindAddr,
+		fetchWorkers:   fetchqueue.DefaultWorkers,
+		checkInterval:  linkcheck.DefaultInterval,
+		checkDeadAfter: linkcheck.DefaultDeadAfter,
 	}
 
 	for _, opt := range opts {
 		opt.apply(&so)
 	}
 
+	if len(so.sessionAuthKey) == 0 {
+		log.Println("no session keys set; using random keys that last until the server stops")
+
+		pair, err := sessionkeys.Generate()
+		if err != nil {
+			return nil, err
+		}
+
+		so.sessionAuthKey, so.sessionEncKey = pair.Auth, pair.Encryption
+	}
+
+	keyPairs := append([][]byte{so.sessionAuthKey, so.sessionEncKey}, so.previousKeys...)
+
 	srv := &server{
 		db:             so.db,
 		bindAddr:       so.bindAddr,
+		grpcBindAddr:   so.grpcBindAddr,
 		router:         http.NewServeMux(),
 		sessionAuthKey: so.sessionAuthKey,
 		sessionEncKey:  so.sessionEncKey,
+		fetchWorkers:   so.fetchWorkers,
+		checkInterval:  so.checkInterval,
+		checkDeadAfter: so.checkDeadAfter,
+		sessionStore:   sessions.NewCookieStore(keyPairs...),
+	}
+
+	if so.archiveDir != "" {
+		srv.archiver = archive.New(so.archiveDir)
+	}
+
+	if so.publicURL != "" {
+		u, err := url.Parse(so.publicURL)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid public url %q", so.publicURL)
+		}
+
+		srv.publicURL = u
+	}
+
+	if so.oidc != nil {
+		if so.oidc.Issuer == "" || so.oidc.ClientID == "" {
+			return nil, errors.New("oidc needs an issuer and client id")
+		}
+
+		srv.oidc = oidc.New(*so.oidc)
+	}
+
+	if len(so.proxyTrusted) > 0 {
+		pa, err := newProxyAuth(so.proxyHeader, so.proxyTrusted)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy auth settings: %w", err)
+		}
+
+		srv.proxyAuth = pa
 	}
 
 	srv.route()
 
-	return srv
+	return srv, nil
 }
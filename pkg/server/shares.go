@@ -0,0 +1,290 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kyleterry/sufr/pkg/api"
+	"github.com/kyleterry/sufr/pkg/csrf"
+	"github.com/kyleterry/sufr/pkg/store"
+)
+
+// shareTokenBytes is how much randomness is in the secret of a share link.
+const shareTokenBytes = 24
+
+// shareExpiry is one of the choices for how long a share link works.
+type shareExpiry struct {
+	Name string
+	// Days is how many days the link works for. 0 is forever.
+	Days int
+}
+
+var shareExpiries = []shareExpiry{
+	{Name: "Never", Days: 0},
+	{Name: "After a day", Days: 1},
+	{Name: "After a week", Days: 7},
+	{Name: "After 30 days", Days: 30},
+}
+
+func validShareExpiry(days int) bool {
+	for _, e := range shareExpiries {
+		if e.Days == days {
+			return true
+		}
+	}
+
+	return false
+}
+
+// handleSettingsShares makes a share link for one of the user's urls, given
+// its id as url, or for everything they tagged with a tag, given its name as
+// tag.
+func (s *uiServer) handleSettingsShares() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user := ctx.Value(userContextKey{}).(*api.User)
+
+		if r.Method != http.MethodPost {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		days, err := strconv.Atoi(r.PostFormValue("expires"))
+		if err != nil || !validShareExpiry(days) {
+			http.Error(w, "invalid expiry", http.StatusBadRequest)
+
+			return
+		}
+
+		share := &api.Share{}
+		back := "/settings"
+
+		if id := r.PostFormValue("url"); id != "" {
+			uu, err := s.db.UserURLs(user).GetByID(ctx, id)
+			if err != nil {
+				if errors.Is(err, store.ErrNotFound) {
+					http.NotFound(w, r)
+
+					return
+				}
+
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+
+				return
+			}
+
+			share.UserUrlId = uu.Id
+			back = "/url/" + uu.Id
+		} else {
+			tag, err := s.db.Tags().GetByName(ctx, strings.TrimSpace(r.PostFormValue("tag")))
+			if err != nil {
+				if errors.Is(err, store.ErrNotFound) {
+					s.addFlash(w, r, "danger", "There's no tag with that name.")
+					http.Redirect(w, r, back, http.StatusSeeOther)
+
+					return
+				}
+
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+
+				return
+			}
+
+			share.TagId = tag.Id
+		}
+
+		if days > 0 {
+			share.ExpiresAt = &api.Timestamp{}
+			share.ExpiresAt.SetFromGoTime(time.Now().AddDate(0, 0, days))
+		}
+
+		share.Id, err = generateShareToken()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		if err := s.db.Shares().Create(ctx, user, share); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		s.addFlash(w, r, "success", "Anyone with this link can see it until you revoke it in settings: "+s.shareURL(r, share.Id))
+		http.Redirect(w, r, back, http.StatusSeeOther)
+	}
+}
+
+// handleSettingsShare revokes the share link at /settings/shares/{id}.
+func (s *uiServer) handleSettingsShare() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user := ctx.Value(userContextKey{}).(*api.User)
+
+		if r.Method != http.MethodPost {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		if r.PostFormValue("action") != "revoke" {
+			http.Error(w, "unknown action", http.StatusBadRequest)
+
+			return
+		}
+
+		id := strings.TrimPrefix(r.URL.Path, "/settings/shares/")
+
+		if err := s.db.Shares().Delete(ctx, user, id); err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				http.NotFound(w, r)
+
+				return
+			}
+
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		s.addFlash(w, r, "success", "Revoked the share link.")
+		http.Redirect(w, r, "/settings", http.StatusSeeOther)
+	}
+}
+
+// handleShare serves the read-only page of the share link at /s/{id}.
+// Nobody has to be logged in. Visits to the first page are counted.
+func (s *uiServer) handleShare() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		if r.Method != http.MethodGet {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		id := strings.TrimPrefix(r.URL.Path, "/s/")
+
+		share, err := s.db.Shares().GetByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				http.NotFound(w, r)
+
+				return
+			}
+
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		if share.Expired() {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		owner, err := s.db.Users().GetByID(ctx, share.UserId)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		if owner.Disabled {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		after := r.URL.Query().Get("after")
+
+		data := profileData{
+			templateData: templateData{
+				Title:     share.Title,
+				CSRFToken: csrf.Token(r),
+			},
+			PageURL: "/s/" + share.Id,
+		}
+
+		uum := s.db.UserURLs(owner)
+
+		if share.UserUrlId != "" {
+			uu, err := uum.GetByID(ctx, share.UserUrlId)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+
+				return
+			}
+
+			data.URLs = []*api.UserURL{uu}
+		} else {
+			data.Title = "Tagged " + share.Title
+
+			data.URLs, err = uum.GetAll(ctx, store.WithTags([]string{share.Title}), store.WithResultsAfter(after), store.WithLimit(profilePageSize))
+			if errors.Is(err, store.ErrInvalidCursor) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+
+				return
+			}
+
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+
+				return
+			}
+
+			if len(data.URLs) == profilePageSize {
+				data.Next = data.URLs[len(data.URLs)-1].Cursor
+			}
+		}
+
+		if after == "" {
+			if err := s.db.Shares().View(ctx, share.Id); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+
+				return
+			}
+		}
+
+		// the secret is in the url, so keep it out of search engines and
+		// Referer headers
+		w.Header().Set("X-Robots-Tag", "noindex")
+		w.Header().Set("Referrer-Policy", "no-referrer")
+
+		err = s.templates.withWriter("users/profile", func(tw *templateWriter) error {
+			return tw.write(w, r, data)
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+	}
+}
+
+// shareURL returns the link of the share with id.
+func (s *uiServer) shareURL(r *http.Request, id string) string {
+	u := *s.siteURL(r)
+	u.Path = "/s/" + id
+
+	return u.String()
+}
+
+func generateShareToken() (string, error) {
+	b := make([]byte, shareTokenBytes)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
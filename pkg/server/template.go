@@ -1,15 +1,18 @@
 package server
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"html/template"
+	"log"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/kyleterry/sufr/pkg/api"
 	"github.com/kyleterry/sufr/pkg/data"
+	"github.com/kyleterry/sufr/pkg/embed"
 	"github.com/oxtoacart/bpool"
 )
 
@@ -58,6 +61,48 @@ type templateData struct {
 	Count      int
 	Flashes    map[string][]interface{}
 	Title      string
+	CSRFToken  string
+}
+
+type urlViewData struct {
+	templateData
+	URL           *api.UserURL
+	Checks        []*api.URLCheck
+	Visibilities  []api.Visibility
+	ShareExpiries []shareExpiry
+}
+
+type urlArchiveData struct {
+	templateData
+	OriginalURL string
+	PageURL     string
+	ArchivedAt  time.Time
+	Paragraphs  []string
+}
+
+type loginData struct {
+	templateData
+	// SSO is whether logging in with an identity provider is set up.
+	SSO bool
+}
+
+type settingsData struct {
+	templateData
+	PerPageOptions    []int32
+	RecoveryCodesLeft int
+	Shares            []*api.Share
+	// ShareURL is the link of a share without its id.
+	ShareURL      string
+	ShareExpiries []shareExpiry
+	Tags          []*api.Tag
+	// EmailLocked is set when the email address belongs to the auth proxy
+	// or identity provider, and can't be changed here.
+	EmailLocked bool
+}
+
+type adminUsersData struct {
+	templateData
+	Users []*api.User
 }
 
 type timelineData struct {
@@ -85,10 +130,37 @@ func dict(values ...interface{}) (map[string]interface{}, error) {
 	return dict, nil
 }
 
+// routes are the paths of the named routes templates link to.
+var routes = map[string]string{
+	"url-index":    "/timeline",
+	"login":        "/login",
+	"login-2fa":    "/login/2fa",
+	"login-oidc":   "/login/oidc",
+	"logout":       "/logout",
+	"settings":     "/settings",
+	"settings-2fa": "/settings/2fa",
+}
+
+// reverse returns the path of a named route. Routes this server doesn't
+// have are empty.
+func reverse(name string, params ...interface{}) string {
+	return routes[name]
+}
+
 func formatTimestamp(t time.Time) string {
 	return t.Format(time.RFC1123)
 }
 
+// highlight escapes a search result snippet and wraps the matched terms in
+// mark elements.
+func highlight(snippet string) template.HTML {
+	s := template.HTMLEscapeString(snippet)
+	s = strings.ReplaceAll(s, api.SnippetMatchStart, "<mark>")
+	s = strings.ReplaceAll(s, api.SnippetMatchEnd, "</mark>")
+
+	return template.HTML(s)
+}
+
 func tagNames(tl *api.TagList) string {
 	names := []string{}
 
@@ -98,3 +170,30 @@ func tagNames(tl *api.TagList) string {
 
 	return strings.Join(names, ",")
 }
+
+// embedFor returns the embed of u if user wants to see it in place of the
+// link, or nil. Only embeds made from the link alone or cached when it was
+// fetched are shown, so pages never wait on a provider.
+func (s *uiServer) embedFor(user *api.User, u *api.URL) *embed.Embed {
+	if user == nil || u == nil {
+		return nil
+	}
+
+	opts := embed.Options{Photos: user.EmbedPhotos, Videos: user.EmbedVideos}
+	if !opts.Photos && !opts.Videos {
+		return nil
+	}
+
+	e, err := s.embeds.Lookup(context.Background(), u.Id, u.Url)
+	if err != nil {
+		log.Printf("failed to look up embed of %s: %s", u.Url, err)
+
+		return nil
+	}
+
+	if e == nil || !opts.Allows(e.Type) {
+		return nil
+	}
+
+	return e
+}
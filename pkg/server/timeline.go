@@ -1,10 +1,12 @@
 package server
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
-	"strconv"
 
 	"github.com/kyleterry/sufr/pkg/api"
+	"github.com/kyleterry/sufr/pkg/csrf"
 	"github.com/kyleterry/sufr/pkg/store"
 )
 
@@ -28,22 +30,36 @@ func (s *timelineServer) handleTimeline() http.HandlerFunc {
 		ctx := r.Context()
 		user := ctx.Value(userContextKey{}).(*api.User)
 		after := r.URL.Query().Get("after")
+		query := r.URL.Query().Get("q")
+		tags := r.URL.Query()["tag"]
 
-		a, err := strconv.ParseInt(after, 10, 64)
-		if err != nil {
-			a = 0
+		// /broken is the timeline of urls the link checker flagged dead
+		broken := r.URL.Path == "/broken" || r.URL.Query().Get("broken") != ""
+
+		title := "timeline"
+		if query != "" {
+			title = fmt.Sprintf("search: %s", query)
+		} else if broken {
+			title = "broken links"
 		}
 
-		err = s.templates.withWriter("timeline/index", func(tw *templateWriter) error {
-			all, err := s.db.UserURLs(user).GetAll(ctx, store.WithResultsAfter(a))
+		err := s.templates.withWriter("timeline/index", func(tw *templateWriter) error {
+			all, err := s.db.UserURLs(user).GetAll(ctx,
+				store.WithResultsAfter(after),
+				store.WithSearchTerm(query),
+				store.WithTags(tags),
+				store.WithBroken(broken),
+				store.WithLimit(int(user.PerPage)),
+			)
 			if err != nil {
 				return err
 			}
 
 			td := timelineData{
 				templateData: templateData{
-					User:  user,
-					Title: "timeline",
+					User:      user,
+					Title:     title,
+					CSRFToken: csrf.Token(r),
 				},
 				URLs:  all,
 				Count: len(all),
@@ -51,6 +67,12 @@ func (s *timelineServer) handleTimeline() http.HandlerFunc {
 
 			return tw.write(w, r, td)
 		})
+		if errors.Is(err, store.ErrInvalidCursor) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 
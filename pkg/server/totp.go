@@ -0,0 +1,420 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"html/template"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/kyleterry/sufr/pkg/api"
+	"github.com/kyleterry/sufr/pkg/csrf"
+	"github.com/kyleterry/sufr/pkg/store"
+	"github.com/kyleterry/sufr/pkg/totp"
+)
+
+const (
+	// totpIssuer is the name authenticator apps show next to codes.
+	totpIssuer = "SUFR"
+	// totpLoginTimeout is how long someone has to enter their code after
+	// their password.
+	totpLoginTimeout = 5 * time.Minute
+	// totpMaxFailures is how many wrong codes are allowed for an account
+	// within totpFailureWindow before it has to wait.
+	totpMaxFailures   = 5
+	totpFailureWindow = 5 * time.Minute
+
+	pendingUserIDSessionKey = "pendingUserID"
+	pendingAtSessionKey     = "pendingAt"
+	totpSetupSessionKey     = "totpSetupSecret"
+)
+
+type totpSetupData struct {
+	templateData
+	Secret        string
+	QRCode        template.URL
+	RecoveryCodes []string
+}
+
+// handleLoginTOTP is the second login step for users with two-factor
+// authentication. handleLogin sends them here once their password checks
+// out.
+func (s *uiServer) handleLoginTOTP() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		session, err := s.sessionStore.Get(r, userAuthSessionKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		id, _ := session.Values[pendingUserIDSessionKey].(string)
+		at, _ := session.Values[pendingAtSessionKey].(int64)
+
+		if id == "" || time.Since(time.Unix(at, 0)) > totpLoginTimeout {
+			s.addFlash(w, r, "danger", "Log in again to continue.")
+			http.Redirect(w, r, "/login", http.StatusSeeOther)
+
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			err := s.templates.withWriter("users/login-2fa", func(tw *templateWriter) error {
+				return tw.write(w, r, templateData{
+					Title:     "login",
+					Flashes:   s.flashes(w, r),
+					CSRFToken: csrf.Token(r),
+				})
+			})
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+
+				return
+			}
+		case http.MethodPost:
+			if !s.totpFailures.allowed(id, time.Now()) {
+				s.addFlash(w, r, "danger", "Too many wrong codes. Wait a few minutes and try again.")
+				http.Redirect(w, r, "/login/2fa", http.StatusSeeOther)
+
+				return
+			}
+
+			user, err := s.db.Users().GetByID(ctx, id)
+			if err != nil || user.Disabled {
+				s.addFlash(w, r, "danger", "Log in again to continue.")
+				http.Redirect(w, r, "/login", http.StatusSeeOther)
+
+				return
+			}
+
+			if err := verifySecondFactor(ctx, s.db, user, r.PostFormValue("code"), time.Now()); err != nil {
+				if !errors.Is(err, totp.ErrInvalidCode) {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+
+					return
+				}
+
+				s.totpFailures.fail(id, time.Now())
+				s.addFlash(w, r, "danger", "That code is wrong.")
+				http.Redirect(w, r, "/login/2fa", http.StatusSeeOther)
+
+				return
+			}
+
+			s.totpFailures.reset(id)
+
+			delete(session.Values, pendingUserIDSessionKey)
+			delete(session.Values, pendingAtSessionKey)
+			session.Values["userID"] = user.Id
+
+			if err := session.Save(r, w); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+
+				return
+			}
+
+			http.Redirect(w, r, "/", http.StatusSeeOther)
+		default:
+			http.NotFound(w, r)
+		}
+	}
+}
+
+// handleSettingsTOTP sets up, changes and turns off two-factor
+// authentication for the logged in user. Turning it on takes a code from the
+// new authenticator so nobody is locked out by a bad scan. The other changes
+// take the user's password.
+func (s *uiServer) handleSettingsTOTP() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user := ctx.Value(userContextKey{}).(*api.User)
+
+		session, err := s.sessionStore.Get(r, userAuthSessionKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			if user.TotpEnabled {
+				http.Redirect(w, r, "/settings", http.StatusSeeOther)
+
+				return
+			}
+
+			secret, err := totp.GenerateSecret()
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+
+				return
+			}
+
+			// the secret waits in the session until a code proves the
+			// authenticator has it
+			session.Values[totpSetupSessionKey] = secret
+
+			if err := session.Save(r, w); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+
+				return
+			}
+
+			png, err := totp.QRCode(totp.URI(totpIssuer, user.Email, secret))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+
+				return
+			}
+
+			s.writeTOTPSetup(w, r, totpSetupData{
+				templateData: templateData{
+					User:      user,
+					Title:     "two-factor authentication",
+					Flashes:   s.flashes(w, r),
+					CSRFToken: csrf.Token(r),
+				},
+				Secret: secret,
+				QRCode: template.URL("data:image/png;base64," + base64.StdEncoding.EncodeToString(png)),
+			})
+		case http.MethodPost:
+			switch r.PostFormValue("action") {
+			case "enable":
+				secret, _ := session.Values[totpSetupSessionKey].(string)
+				if secret == "" || user.TotpEnabled {
+					http.Redirect(w, r, "/settings", http.StatusSeeOther)
+
+					return
+				}
+
+				step, err := totp.Verify(secret, r.PostFormValue("code"), time.Now(), 0)
+				if err != nil {
+					s.addFlash(w, r, "danger", "That code is wrong. Scan the new QR code and try again.")
+					http.Redirect(w, r, "/settings/2fa", http.StatusSeeOther)
+
+					return
+				}
+
+				codes, err := s.enableTOTP(ctx, user, secret, step)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+
+					return
+				}
+
+				delete(session.Values, totpSetupSessionKey)
+
+				if err := session.Save(r, w); err != nil {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+
+					return
+				}
+
+				s.writeTOTPSetup(w, r, totpSetupData{
+					templateData: templateData{
+						User:      user,
+						Title:     "two-factor authentication",
+						CSRFToken: csrf.Token(r),
+					},
+					RecoveryCodes: codes,
+				})
+			case "recovery-codes", "disable":
+				if _, err := s.db.Users().GetByEmailAndPassword(ctx, user.Email, r.PostFormValue("password")); err != nil {
+					s.addFlash(w, r, "danger", "The password is wrong.")
+					http.Redirect(w, r, "/settings", http.StatusSeeOther)
+
+					return
+				}
+
+				if r.PostFormValue("action") == "disable" {
+					if err := s.db.Users().DisableTOTP(ctx, user); err != nil {
+						http.Error(w, err.Error(), http.StatusInternalServerError)
+
+						return
+					}
+
+					s.addFlash(w, r, "success", "Two-factor authentication is off.")
+					http.Redirect(w, r, "/settings", http.StatusSeeOther)
+
+					return
+				}
+
+				current, err := s.db.Users().GetTOTP(ctx, user)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+
+					return
+				}
+
+				codes, err := s.enableTOTP(ctx, user, current.Secret, current.LastStep)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+
+					return
+				}
+
+				s.writeTOTPSetup(w, r, totpSetupData{
+					templateData: templateData{
+						User:      user,
+						Title:     "two-factor authentication",
+						CSRFToken: csrf.Token(r),
+					},
+					RecoveryCodes: codes,
+				})
+			default:
+				http.Error(w, "unknown action", http.StatusBadRequest)
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}
+}
+
+// enableTOTP turns on two-factor authentication with secret and new
+// recovery codes, which are returned. Codes up to lastStep stay used.
+func (s *uiServer) enableTOTP(ctx context.Context, user *api.User, secret string, lastStep int64) ([]string, error) {
+	codes, err := totp.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([][]byte, len(codes))
+	for i, code := range codes {
+		hashes[i] = totp.HashRecoveryCode(code)
+	}
+
+	err = s.db.Transaction(ctx, func(ctx context.Context, tx store.Manager) error {
+		if err := tx.Users().EnableTOTP(ctx, user, secret, hashes); err != nil {
+			return err
+		}
+
+		if lastStep > 0 {
+			return tx.Users().UseTOTPStep(ctx, user, lastStep)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func (s *uiServer) writeTOTPSetup(w http.ResponseWriter, r *http.Request, td totpSetupData) {
+	// the page can hold the secret or recovery codes
+	w.Header().Set("Cache-Control", "no-store")
+
+	err := s.templates.withWriter("users/totp-setup", func(tw *templateWriter) error {
+		return tw.write(w, r, td)
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+}
+
+// verifySecondFactor checks code, either from user's authenticator app or
+// one of their recovery codes, and uses it up. totp.ErrInvalidCode is
+// returned for codes that are wrong or already used.
+func verifySecondFactor(ctx context.Context, db store.Manager, user *api.User, code string, now time.Time) error {
+	if totp.IsRecoveryCode(code) {
+		err := db.Users().UseRecoveryCode(ctx, user, totp.HashRecoveryCode(code))
+		if errors.Is(err, store.ErrNotFound) {
+			return totp.ErrInvalidCode
+		}
+
+		return err
+	}
+
+	t, err := db.Users().GetTOTP(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	step, err := totp.Verify(t.Secret, code, now, t.LastStep)
+	if err != nil {
+		return err
+	}
+
+	// someone else could have used the same code in the meantime
+	if err := db.Users().UseTOTPStep(ctx, user, step); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return totp.ErrInvalidCode
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// failureLimiter counts failed attempts by key and turns a key away once it
+// has failed too often within a window.
+type failureLimiter struct {
+	mu       sync.Mutex
+	max      int
+	window   time.Duration
+	failures map[string][]time.Time
+}
+
+func newFailureLimiter(max int, window time.Duration) *failureLimiter {
+	return &failureLimiter{
+		max:      max,
+		window:   window,
+		failures: map[string][]time.Time{},
+	}
+}
+
+// allowed returns true if key has failed fewer than max times in the window
+// before now.
+func (l *failureLimiter) allowed(key string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return len(l.recent(key, now)) < l.max
+}
+
+func (l *failureLimiter) fail(key string, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.failures[key] = append(l.recent(key, now), now)
+}
+
+func (l *failureLimiter) reset(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.failures, key)
+}
+
+// recent drops the failures of key that are older than the window and
+// returns the rest. l.mu has to be held.
+func (l *failureLimiter) recent(key string, now time.Time) []time.Time {
+	fs := l.failures[key]
+
+	i := 0
+	for i < len(fs) && now.Sub(fs[i]) >= l.window {
+		i++
+	}
+
+	fs = fs[i:]
+
+	if len(fs) == 0 {
+		delete(l.failures, key)
+
+		return nil
+	}
+
+	l.failures[key] = fs
+
+	return fs
+}
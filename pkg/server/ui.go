@@ -1,17 +1,34 @@
 package server
 
 import (
+	"errors"
 	"fmt"
 	"html/template"
+	"log"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/gorilla/sessions"
+	"github.com/kyleterry/sufr/pkg/api"
+	"github.com/kyleterry/sufr/pkg/archive"
+	"github.com/kyleterry/sufr/pkg/csrf"
+	"github.com/kyleterry/sufr/pkg/embed"
+	"github.com/kyleterry/sufr/pkg/oidc"
 	"github.com/kyleterry/sufr/pkg/store"
 	"github.com/kyleterry/sufr/pkg/ui"
 	"github.com/shurcooL/httpfs/html/vfstemplate"
 )
 
-const userAuthSessionKey = "user-auth"
+const (
+	userAuthSessionKey = "user-auth"
+	flashSessionKey    = "flash"
+)
+
+// flashKinds are the kinds of flash messages, named after the alert classes
+// they're shown with.
+var flashKinds = []string{"success", "danger"}
 
 type uiServer struct {
 	db           store.Manager
@@ -19,6 +36,12 @@ type uiServer struct {
 	uifs         http.FileSystem
 	sessionStore sessions.Store
 	templates    *templates
+	archiver     *archive.Archiver
+	totpFailures *failureLimiter
+	publicURL    *url.URL
+	oidc         *oidc.Provider
+	proxyAuth    *proxyAuth
+	embeds       *embed.Registry
 }
 
 func (s *uiServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -26,13 +49,42 @@ func (s *uiServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *uiServer) route() {
-	auth := NewSessionAuthenticationMiddleware(s.sessionStore, s.db)
+	auth := NewSessionAuthenticationMiddleware(s.sessionStore, s.db, s.proxyAuth)
+	admin := NewAdminAuthorizationMiddleware()
+	protect := NewCSRFMiddleware(s.sessionStore, csrf.WithMaxFormBytes(maxBookmarkFileBytes))
+	protectBackup := NewCSRFMiddleware(s.sessionStore, csrf.WithMaxFormBytes(maxBackupFileBytes))
 
 	s.router.HandleFunc("/", s.handleRootRedirect())
-	s.router.Handle("/timeline", auth(s.handleTimeline()))
-	s.router.Handle("/url", auth(s.handleURL()))
-	s.router.Handle("/login", s.handleLogin())
-	s.router.Handle("/logout", s.handleLogout())
+	s.router.Handle("/timeline", protect(auth(s.handleTimeline())))
+	s.router.Handle("/search", protect(auth(s.handleTimeline())))
+	s.router.Handle("/broken", protect(auth(s.handleTimeline())))
+	s.router.Handle("/url", protect(auth(s.handleURL())))
+	s.router.Handle("/url/", protect(auth(s.handleURLView())))
+	s.router.Handle("/bookmarks", protect(auth(s.handleBookmarks())))
+	s.router.Handle("/bookmarks/export", protect(auth(s.handleBookmarksExport())))
+	s.router.Handle("/bookmarks/export/warc", protect(auth(s.handleBookmarksExportWARC())))
+	s.router.Handle("/settings", protect(auth(s.handleSettings())))
+	s.router.Handle("/settings/password", protect(auth(s.handleSettingsPassword())))
+	s.router.Handle("/settings/token", protect(auth(s.handleSettingsToken())))
+	s.router.Handle("/settings/2fa", protect(auth(s.handleSettingsTOTP())))
+	s.router.Handle("/settings/passkeys", protect(auth(s.handleSettingsPasskeys())))
+	s.router.Handle("/settings/passkeys/options", protect(auth(s.handleSettingsPasskeyOptions())))
+	s.router.Handle("/settings/passkeys/", protect(auth(s.handleSettingsPasskey())))
+	s.router.Handle("/settings/shares", protect(auth(s.handleSettingsShares())))
+	s.router.Handle("/settings/shares/", protect(auth(s.handleSettingsShare())))
+	s.router.Handle("/admin/users", protect(auth(admin(s.handleAdminUsers()))))
+	s.router.Handle("/admin/users/", protect(auth(admin(s.handleAdminUser()))))
+	s.router.Handle("/admin/backup", protectBackup(auth(admin(s.handleAdminBackup()))))
+	s.router.Handle("/u/", protect(s.handleProfile()))
+	s.router.Handle("/s/", protect(s.handleShare()))
+	s.router.Handle("/feeds/", s.handleFeed())
+	s.router.Handle("/login", protect(s.handleLogin()))
+	s.router.Handle("/login/2fa", protect(s.handleLoginTOTP()))
+	s.router.Handle("/login/passkey", protect(s.handleLoginPasskey()))
+	s.router.Handle("/login/passkey/options", protect(s.handleLoginPasskeyOptions()))
+	s.router.Handle("/login/oidc", protect(s.handleLoginOIDC()))
+	s.router.Handle(oidcCallbackPath, protect(s.handleLoginOIDCCallback()))
+	s.router.Handle("/logout", protect(s.handleLogout()))
 	s.router.Handle("/static/", s.handleStatic())
 }
 
@@ -42,10 +94,11 @@ func (s *uiServer) setupTemplates() {
 	f := template.FuncMap{
 		"dict":            dict,
 		"formatTimestamp": formatTimestamp,
+		"highlight":       highlight,
+		"passkeyID":       passkeyID,
 		"tagNames":        tagNames,
-		"reverse":         func(name string, p ...interface{}) string { return "" },
-		"isyoutube":       func(name string, p ...interface{}) string { return "" },
-		"youtubevid":      func(name string, p ...interface{}) string { return "" },
+		"reverse":         reverse,
+		"embed":           s.embedFor,
 		"updatePage":      func(name string, p ...interface{}) string { return "" },
 	}
 
@@ -55,9 +108,33 @@ func (s *uiServer) setupTemplates() {
 	tm["urls/new"] = template.Must(
 		vfstemplate.ParseFiles(s.uifs, template.New("base").Funcs(f),
 			"templates/base.html", "templates/url-new.html"))
+	tm["urls/view"] = template.Must(
+		vfstemplate.ParseFiles(s.uifs, template.New("base").Funcs(f),
+			"templates/base.html", "templates/url-view.html"))
+	tm["urls/archive"] = template.Must(
+		vfstemplate.ParseFiles(s.uifs, template.New("base").Funcs(f),
+			"templates/base.html", "templates/url-archive.html"))
+	tm["bookmarks/index"] = template.Must(
+		vfstemplate.ParseFiles(s.uifs, template.New("base").Funcs(f),
+			"templates/base.html", "templates/bookmarks.html"))
 	tm["users/login"] = template.Must(
 		vfstemplate.ParseFiles(s.uifs, template.New("base").Funcs(f),
 			"templates/base.html", "templates/login.html"))
+	tm["users/login-2fa"] = template.Must(
+		vfstemplate.ParseFiles(s.uifs, template.New("base").Funcs(f),
+			"templates/base.html", "templates/login-2fa.html"))
+	tm["users/totp-setup"] = template.Must(
+		vfstemplate.ParseFiles(s.uifs, template.New("base").Funcs(f),
+			"templates/base.html", "templates/totp-setup.html"))
+	tm["users/settings"] = template.Must(
+		vfstemplate.ParseFiles(s.uifs, template.New("base").Funcs(f),
+			"templates/base.html", "templates/user-settings.html"))
+	tm["admin/users"] = template.Must(
+		vfstemplate.ParseFiles(s.uifs, template.New("base").Funcs(f),
+			"templates/base.html", "templates/admin-users.html"))
+	tm["users/profile"] = template.Must(
+		vfstemplate.ParseFiles(s.uifs, template.New("base").Funcs(f),
+			"templates/base.html", "templates/profile.html"))
 	tm["errors/404"] = template.Must(
 		vfstemplate.ParseFiles(s.uifs, template.New("base").Funcs(f),
 			"templates/base.html", "templates/404.html"))
@@ -92,6 +169,175 @@ func (s *uiServer) handleURL() http.HandlerFunc {
 	}
 }
 
+// handleURLView serves /url/{id} and the archived snapshot of its page at
+// /url/{id}/archive and /url/{id}/archive/text.
+func (s *uiServer) handleURLView() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user := ctx.Value(userContextKey{}).(*api.User)
+		id, view := strings.TrimPrefix(r.URL.Path, "/url/"), ""
+
+		if i := strings.IndexByte(id, '/'); i >= 0 {
+			id, view = id[:i], id[i+1:]
+		}
+
+		uu, err := s.db.UserURLs(user).GetByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				http.NotFound(w, r)
+
+				return
+			}
+
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		switch view {
+		case "":
+			s.writeURLView(w, r, user, uu)
+		case "visibility":
+			s.updateURLVisibility(w, r, user, uu)
+		case "archive":
+			s.writeURLArchive(w, r, uu)
+		case "archive/text":
+			s.writeURLArchiveText(w, r, user, uu)
+		default:
+			http.NotFound(w, r)
+		}
+	}
+}
+
+func (s *uiServer) writeURLView(w http.ResponseWriter, r *http.Request, user *api.User, uu *api.UserURL) {
+	checks, err := s.db.URLChecks().GetByURLID(r.Context(), uu.Url.Id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	err = s.templates.withWriter("urls/view", func(tw *templateWriter) error {
+		return tw.write(w, r, urlViewData{
+			templateData: templateData{
+				User:      user,
+				Title:     uu.DerivedTitle,
+				CSRFToken: csrf.Token(r),
+				Flashes:   s.flashes(w, r),
+			},
+			URL:           uu,
+			Checks:        checks.Items,
+			Visibilities:  api.Visibilities,
+			ShareExpiries: shareExpiries,
+		})
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+}
+
+// updateURLVisibility changes who can see uu.
+func (s *uiServer) updateURLVisibility(w http.ResponseWriter, r *http.Request, user *api.User, uu *api.UserURL) {
+	if r.Method != http.MethodPost {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	v, err := api.ParseVisibility(r.PostFormValue("visibility"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	uu.Visibility = v
+
+	if err := s.db.UserURLs(user).Update(r.Context(), uu); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	s.addFlash(w, r, "success", "This url is now "+v.Name()+".")
+	http.Redirect(w, r, "/url/"+uu.Id, http.StatusSeeOther)
+}
+
+// writeURLArchive serves the archived page of uu. The page is served with a
+// content security policy that blocks scripts and anything loaded from the
+// network in case something slipped past the archiver.
+func (s *uiServer) writeURLArchive(w http.ResponseWriter, r *http.Request, uu *api.UserURL) {
+	if s.archiver == nil || uu.Url.ArchivedAt == nil {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	f, err := s.archiver.Open(uu.Url.Id, archive.PageFile)
+	if err != nil {
+		if errors.Is(err, archive.ErrNotFound) {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	defer f.Close()
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Security-Policy", archive.ContentSecurityPolicy)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+
+	http.ServeContent(w, r, archive.PageFile, uu.Url.ArchivedAt.AsTime(), f)
+}
+
+func (s *uiServer) writeURLArchiveText(w http.ResponseWriter, r *http.Request, user *api.User, uu *api.UserURL) {
+	if s.archiver == nil || uu.Url.ArchivedAt == nil {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	text, err := s.archiver.ReadText(uu.Url.Id)
+	if err != nil {
+		if errors.Is(err, archive.ErrNotFound) {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	err = s.templates.withWriter("urls/archive", func(tw *templateWriter) error {
+		return tw.write(w, r, urlArchiveData{
+			templateData: templateData{
+				User:      user,
+				Title:     uu.DerivedTitle,
+				CSRFToken: csrf.Token(r),
+			},
+			OriginalURL: uu.Url.Url,
+			PageURL:     "/url/" + uu.Id + "/archive",
+			ArchivedAt:  uu.Url.ArchivedAt.AsTime(),
+			Paragraphs:  archive.Paragraphs(text),
+		})
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+}
+
 func (s *uiServer) handleLogin() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -99,7 +345,14 @@ func (s *uiServer) handleLogin() http.HandlerFunc {
 		switch r.Method {
 		case http.MethodGet:
 			err := s.templates.withWriter("users/login", func(tw *templateWriter) error {
-				return tw.write(w, r, templateData{Title: "login"})
+				return tw.write(w, r, loginData{
+					templateData: templateData{
+						Title:     "login",
+						CSRFToken: csrf.Token(r),
+						Flashes:   s.flashes(w, r),
+					},
+					SSO: s.oidc != nil,
+				})
 			})
 			if err != nil {
 				http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -114,6 +367,12 @@ func (s *uiServer) handleLogin() http.HandlerFunc {
 
 			user, err := s.db.Users().GetByEmailAndPassword(ctx, email, password)
 			if err != nil {
+				if errors.Is(err, store.ErrDisabled) {
+					s.addFlash(w, r, "danger", "This account has been disabled.")
+				} else {
+					s.addFlash(w, r, "danger", "The email or password is wrong.")
+				}
+
 				http.Redirect(w, r, "/login", http.StatusSeeOther)
 
 				return
@@ -126,7 +385,14 @@ func (s *uiServer) handleLogin() http.HandlerFunc {
 				return
 			}
 
-			session.Values["userID"] = user.Id
+			// with two-factor authentication the password only gets them as
+			// far as the code
+			if user.TotpEnabled {
+				session.Values[pendingUserIDSessionKey] = user.Id
+				session.Values[pendingAtSessionKey] = time.Now().Unix()
+			} else {
+				session.Values["userID"] = user.Id
+			}
 
 			if err := session.Save(r, w); err != nil {
 				http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -134,6 +400,12 @@ func (s *uiServer) handleLogin() http.HandlerFunc {
 				return
 			}
 
+			if user.TotpEnabled {
+				http.Redirect(w, r, "/login/2fa", http.StatusSeeOther)
+
+				return
+			}
+
 			http.Redirect(w, r, "/", http.StatusSeeOther)
 
 			return
@@ -145,8 +417,33 @@ func (s *uiServer) handleLogin() http.HandlerFunc {
 	}
 }
 
+// siteURL returns the URL the site is reached at: the public URL when one is
+// set, otherwise the host r was sent to.
+func (s *uiServer) siteURL(r *http.Request) *url.URL {
+	if s.publicURL != nil {
+		return s.publicURL
+	}
+
+	u := &url.URL{Scheme: "http", Host: r.Host}
+
+	if r.TLS != nil {
+		u.Scheme = "https"
+	}
+
+	return u
+}
+
+// handleLogout logs the user out. It only takes POSTs so a link or image on
+// another site can't do it.
 func (s *uiServer) handleLogout() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+			return
+		}
+
 		session, err := s.sessionStore.Get(r, userAuthSessionKey)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -166,6 +463,51 @@ func (s *uiServer) handleLogout() http.HandlerFunc {
 	}
 }
 
+// addFlash adds a message to show on the next page rendered.
+func (s *uiServer) addFlash(w http.ResponseWriter, r *http.Request, kind, message string) {
+	session, err := s.sessionStore.Get(r, flashSessionKey)
+	if err != nil {
+		log.Println(err)
+
+		return
+	}
+
+	session.AddFlash(message, kind)
+
+	if err := session.Save(r, w); err != nil {
+		log.Println(err)
+	}
+}
+
+// flashes removes and returns the waiting flash messages by kind. It has to
+// be called before anything is written to w.
+func (s *uiServer) flashes(w http.ResponseWriter, r *http.Request) map[string][]interface{} {
+	session, err := s.sessionStore.Get(r, flashSessionKey)
+	if err != nil {
+		log.Println(err)
+
+		return nil
+	}
+
+	flashes := map[string][]interface{}{}
+
+	for _, kind := range flashKinds {
+		if fs := session.Flashes(kind); len(fs) > 0 {
+			flashes[kind] = fs
+		}
+	}
+
+	if len(flashes) == 0 {
+		return nil
+	}
+
+	if err := session.Save(r, w); err != nil {
+		log.Println(err)
+	}
+
+	return flashes
+}
+
 func (s *uiServer) handleStatic() http.Handler {
 	return http.FileServer(s.uifs)
 }
@@ -176,6 +518,7 @@ func newUIServer(db store.Manager) *uiServer {
 		router:       http.NewServeMux(),
 		uifs:         ui.NewFileSystem(),
 		sessionStore: sessions.NewCookieStore(),
+		totpFailures: newFailureLimiter(totpMaxFailures, totpFailureWindow),
 	}
 
 	s.route()
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/kyleterry/sufr/pkg/api"
+	"github.com/kyleterry/sufr/pkg/csrf"
 	"github.com/kyleterry/sufr/pkg/store"
 )
 
@@ -38,8 +39,9 @@ func (s *urlServer) handleURLNew() http.HandlerFunc {
 		case http.MethodGet:
 			err := s.templates.withWriter("urls/new", func(tw *templateWriter) error {
 				td := templateData{
-					User:  user,
-					Title: "New URL",
+					User:      user,
+					Title:     "New URL",
+					CSRFToken: csrf.Token(r),
 				}
 
 				return tw.write(w, r, td)
@@ -0,0 +1,403 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/kyleterry/sufr/pkg/api"
+	"github.com/kyleterry/sufr/pkg/csrf"
+	"github.com/kyleterry/sufr/pkg/store"
+)
+
+// perPageOptions are the timeline page sizes users can pick from.
+var perPageOptions = []int32{20, 40, 60, 100}
+
+// handleSettings shows and saves the settings of the logged in user.
+func (s *uiServer) handleSettings() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user := ctx.Value(userContextKey{}).(*api.User)
+
+		switch r.Method {
+		case http.MethodGet:
+			var recoveryCodes int
+
+			if user.TotpEnabled {
+				t, err := s.db.Users().GetTOTP(ctx, user)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+
+					return
+				}
+
+				recoveryCodes = t.RecoveryCodes
+			}
+
+			shares, err := s.db.Shares().GetAll(ctx, user)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+
+				return
+			}
+
+			tags, err := s.db.UserURLs(user).GetTags(ctx)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+
+				return
+			}
+
+			emailLocked, err := s.emailLocked(ctx, user)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+
+				return
+			}
+
+			err = s.templates.withWriter("users/settings", func(tw *templateWriter) error {
+				return tw.write(w, r, settingsData{
+					templateData: templateData{
+						User:      user,
+						Title:     "settings",
+						CSRFToken: csrf.Token(r),
+						Flashes:   s.flashes(w, r),
+					},
+					PerPageOptions:    perPageOptions,
+					RecoveryCodesLeft: recoveryCodes,
+					Shares:            shares,
+					ShareURL:          s.shareURL(r, ""),
+					ShareExpiries:     shareExpiries,
+					Tags:              tags.Items,
+					EmailLocked:       emailLocked,
+				})
+			})
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+
+				return
+			}
+		case http.MethodPost:
+			email := strings.TrimSpace(r.PostFormValue("email"))
+			if !validEmail(email) {
+				s.addFlash(w, r, "danger", "An email address is required.")
+				http.Redirect(w, r, "/settings", http.StatusSeeOther)
+
+				return
+			}
+
+			// the email address is what users log in with, so changing it
+			// takes the current password like changing the password does
+			if email != user.Email {
+				locked, err := s.emailLocked(ctx, user)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+
+					return
+				}
+
+				if locked {
+					s.addFlash(w, r, "danger", "Your email address comes from your single sign-on account and can't be changed here.")
+					http.Redirect(w, r, "/settings", http.StatusSeeOther)
+
+					return
+				}
+
+				if _, err := s.db.Users().GetByEmailAndPassword(ctx, user.Email, r.PostFormValue("current")); err != nil {
+					s.addFlash(w, r, "danger", "The current password is needed to change your email address.")
+					http.Redirect(w, r, "/settings", http.StatusSeeOther)
+
+					return
+				}
+			}
+
+			perPage, err := strconv.ParseInt(r.PostFormValue("perpage"), 10, 32)
+			if err != nil || !validPerPage(int32(perPage)) {
+				http.Error(w, "invalid items per page", http.StatusBadRequest)
+
+				return
+			}
+
+			updated := publicUser(user)
+			updated.Email = email
+			updated.EmbedPhotos = r.PostFormValue("embedphotos") != ""
+			updated.EmbedVideos = r.PostFormValue("embedvideos") != ""
+			updated.PerPage = int32(perPage)
+
+			if err := s.db.Users().Update(ctx, updated); err != nil {
+				if !errors.Is(err, store.ErrAlreadyExists) {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+
+					return
+				}
+
+				s.addFlash(w, r, "danger", "That email address is already in use.")
+			} else {
+				s.addFlash(w, r, "success", "Settings saved.")
+			}
+
+			http.Redirect(w, r, "/settings", http.StatusSeeOther)
+		default:
+			http.NotFound(w, r)
+		}
+	}
+}
+
+// emailLocked reports whether the email address of user belongs to an auth
+// proxy or identity provider. The proxy logs people in by their address, so
+// changing it would hand the account to whoever the proxy knows by the new
+// one.
+func (s *uiServer) emailLocked(ctx context.Context, user *api.User) (bool, error) {
+	if s.proxyAuth != nil {
+		return true, nil
+	}
+
+	return s.db.Users().HasIdentity(ctx, user)
+}
+
+// handleSettingsPassword changes the password of the logged in user. Their
+// current password has to be given.
+func (s *uiServer) handleSettingsPassword() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user := ctx.Value(userContextKey{}).(*api.User)
+
+		if r.Method != http.MethodPost {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		current := r.PostFormValue("current")
+		password := r.PostFormValue("password")
+
+		if _, err := s.db.Users().GetByEmailAndPassword(ctx, user.Email, current); err != nil {
+			s.addFlash(w, r, "danger", "The current password is wrong.")
+			http.Redirect(w, r, "/settings", http.StatusSeeOther)
+
+			return
+		}
+
+		if password == "" || password != r.PostFormValue("confirm") {
+			s.addFlash(w, r, "danger", "The new passwords don't match.")
+			http.Redirect(w, r, "/settings", http.StatusSeeOther)
+
+			return
+		}
+
+		if err := setPassword(ctx, s.db, user, password); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		s.addFlash(w, r, "success", "Password changed.")
+		http.Redirect(w, r, "/settings", http.StatusSeeOther)
+	}
+}
+
+// handleSettingsToken replaces the API token of the logged in user and shows
+// the new one. Users with two-factor authentication need it for the API,
+// which can't ask them for a code.
+func (s *uiServer) handleSettingsToken() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user := ctx.Value(userContextKey{}).(*api.User)
+
+		if r.Method != http.MethodPost {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		token, err := generateAPIToken()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		updated := publicUser(user)
+		updated.ApiToken = token
+
+		if err := s.db.Users().UpdateAPIToken(ctx, updated); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		s.addFlash(w, r, "success", "Your new API token is "+token+". It won't be shown again.")
+		http.Redirect(w, r, "/settings", http.StatusSeeOther)
+	}
+}
+
+// handleAdminUsers lists every account and creates new ones. Accounts can
+// only be registered by an admin.
+func (s *uiServer) handleAdminUsers() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user := ctx.Value(userContextKey{}).(*api.User)
+
+		switch r.Method {
+		case http.MethodGet:
+			users, err := s.db.Users().GetAll(ctx)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+
+				return
+			}
+
+			err = s.templates.withWriter("admin/users", func(tw *templateWriter) error {
+				return tw.write(w, r, adminUsersData{
+					templateData: templateData{
+						User:      user,
+						Title:     "users",
+						CSRFToken: csrf.Token(r),
+						Flashes:   s.flashes(w, r),
+					},
+					Users: users,
+				})
+			})
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+
+				return
+			}
+		case http.MethodPost:
+			email := strings.TrimSpace(r.PostFormValue("email"))
+			password := r.PostFormValue("password")
+
+			if !validEmail(email) || password == "" {
+				s.addFlash(w, r, "danger", "An email address and password are required.")
+				http.Redirect(w, r, "/admin/users", http.StatusSeeOther)
+
+				return
+			}
+
+			ph, err := api.GeneratePasswordHash(password)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+
+				return
+			}
+
+			newUser := &api.User{
+				Email:        email,
+				PasswordHash: ph,
+				Admin:        r.PostFormValue("admin") != "",
+			}
+
+			if err := s.db.Users().Create(ctx, newUser); err != nil {
+				if !errors.Is(err, store.ErrAlreadyExists) {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+
+					return
+				}
+
+				s.addFlash(w, r, "danger", "That email address is already in use.")
+			} else {
+				s.addFlash(w, r, "success", "Added "+email+".")
+			}
+
+			http.Redirect(w, r, "/admin/users", http.StatusSeeOther)
+		default:
+			http.NotFound(w, r)
+		}
+	}
+}
+
+// handleAdminUser disables, enables or deletes the account at
+// /admin/users/{id}. Admins can't do any of those to themselves so there's
+// always an admin left.
+func (s *uiServer) handleAdminUser() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user := ctx.Value(userContextKey{}).(*api.User)
+		id := strings.TrimPrefix(r.URL.Path, "/admin/users/")
+
+		if r.Method != http.MethodPost {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		if id == user.Id {
+			s.addFlash(w, r, "danger", "You can't change your own account here.")
+			http.Redirect(w, r, "/admin/users", http.StatusSeeOther)
+
+			return
+		}
+
+		target, err := s.db.Users().GetByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				http.NotFound(w, r)
+
+				return
+			}
+
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		var message string
+
+		switch r.PostFormValue("action") {
+		case "disable":
+			target.Disabled = true
+			err = s.db.Users().UpdateAccess(ctx, target)
+			message = "Disabled " + target.Email + "."
+		case "enable":
+			target.Disabled = false
+			err = s.db.Users().UpdateAccess(ctx, target)
+			message = "Enabled " + target.Email + "."
+		case "delete":
+			err = s.db.Users().Delete(ctx, target.Id)
+			message = "Deleted " + target.Email + " and everything they saved."
+		default:
+			http.Error(w, "unknown action", http.StatusBadRequest)
+
+			return
+		}
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		s.addFlash(w, r, "success", message)
+		http.Redirect(w, r, "/admin/users", http.StatusSeeOther)
+	}
+}
+
+func setPassword(ctx context.Context, db store.Manager, user *api.User, password string) error {
+	ph, err := api.GeneratePasswordHash(password)
+	if err != nil {
+		return err
+	}
+
+	updated := publicUser(user)
+	updated.PasswordHash = ph
+
+	return db.Users().UpdatePassword(ctx, updated)
+}
+
+func validEmail(email string) bool {
+	i := strings.IndexByte(email, '@')
+
+	return i > 0 && i < len(email)-1
+}
+
+func validPerPage(n int32) bool {
+	for _, o := range perPageOptions {
+		if n == o {
+			return true
+		}
+	}
+
+	return false
+}
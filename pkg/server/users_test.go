@@ -0,0 +1,116 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/sessions"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettingsEmail(t *testing.T) {
+	db := newTestStore(t)
+	ctx := context.Background()
+
+	s := &uiServer{
+		db:           db,
+		sessionStore: sessions.NewCookieStore(make([]byte, 32), make([]byte, 16)),
+	}
+
+	mustCreateUser(t, db, "taken@example.com", false)
+
+	tests := []struct {
+		name     string
+		email    string
+		current  string
+		identity bool
+		proxy    bool
+		want     string
+	}{
+		{name: "same email without a password", email: "kyle@example.com", want: "kyle@example.com"},
+		{name: "new email without a password", email: "new@example.com", want: "kyle@example.com"},
+		{name: "new email with the wrong password", email: "new@example.com", current: "wrong", want: "kyle@example.com"},
+		{name: "new email with the password", email: "new@example.com", current: "password", want: "new@example.com"},
+		{name: "email someone else has", email: "taken@example.com", current: "password", want: "kyle@example.com"},
+		{name: "email from single sign-on", email: "new@example.com", current: "password", identity: true, want: "kyle@example.com"},
+		{name: "email from an auth proxy", email: "new@example.com", current: "password", proxy: true, want: "kyle@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := mustCreateUser(t, db, "kyle@example.com", false)
+
+			t.Cleanup(func() {
+				require.NoError(t, db.Users().Delete(ctx, user.Id))
+			})
+
+			if tt.identity {
+				require.NoError(t, db.Users().AddIdentity(ctx, user, "https://accounts.example.com", "1"))
+			}
+
+			s.proxyAuth = nil
+			if tt.proxy {
+				pa, err := newProxyAuth("", []string{"10.0.0.0/8"})
+				require.NoError(t, err)
+
+				s.proxyAuth = pa
+			}
+
+			form := url.Values{
+				"email":   {tt.email},
+				"current": {tt.current},
+				"perpage": {"20"},
+			}
+
+			r := httptest.NewRequest(http.MethodPost, "/settings", strings.NewReader(form.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			r = r.WithContext(context.WithValue(r.Context(), userContextKey{}, user))
+
+			w := httptest.NewRecorder()
+			s.handleSettings().ServeHTTP(w, r)
+			require.Equal(t, http.StatusSeeOther, w.Code)
+
+			got, err := db.Users().GetByID(ctx, user.Id)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got.Email)
+		})
+	}
+}
+
+func TestSettingsToken(t *testing.T) {
+	db := newTestStore(t)
+	ctx := context.Background()
+
+	s := &uiServer{
+		db:           db,
+		sessionStore: sessions.NewCookieStore(make([]byte, 32), make([]byte, 16)),
+	}
+
+	user := mustCreateUser(t, db, "kyle@example.com", false)
+	require.NoError(t, db.Users().EnableTOTP(ctx, user, "JBSWY3DPEHPK3PXP", nil))
+
+	for i := 0; i < 2; i++ {
+		r := httptest.NewRequest(http.MethodPost, "/settings/token", nil)
+		r = r.WithContext(context.WithValue(r.Context(), userContextKey{}, user))
+
+		w := httptest.NewRecorder()
+		s.handleSettingsToken().ServeHTTP(w, r)
+		require.Equal(t, http.StatusSeeOther, w.Code)
+
+		got, err := db.Users().GetByEmail(ctx, user.Email)
+		require.NoError(t, err)
+		require.NotEmpty(t, got.ApiToken)
+		require.NotEqual(t, user.ApiToken, got.ApiToken)
+
+		// the token is what two-factor users reach the API with
+		byToken, err := db.Users().GetByAPIToken(ctx, got.ApiToken)
+		require.NoError(t, err)
+		require.Equal(t, user.Id, byToken.Id)
+
+		user.ApiToken = got.ApiToken
+	}
+}
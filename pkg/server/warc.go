@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kyleterry/sufr/pkg/api"
+	"github.com/kyleterry/sufr/pkg/store"
+	"github.com/kyleterry/sufr/pkg/warc"
+)
+
+// filterOptions applies each of its filters in turn.
+type filterOptions []store.FilterOption
+
+func (fs filterOptions) Apply(opts *store.FilterOptions) {
+	for _, f := range fs {
+		f.Apply(opts)
+	}
+}
+
+// warcURLs returns every url user has saved that matches filters.
+func warcURLs(ctx context.Context, db store.Manager, user *api.User, filters ...store.FilterOption) ([]string, error) {
+	urls := []string{}
+
+	err := eachUserURL(ctx, db.UserURLs(user), filterOptions(filters), 0, func(uu *api.UserURL) bool {
+		urls = append(urls, uu.Url.Url)
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return urls, nil
+}
+
+// handleBookmarksExportWARC downloads the urls matching the same q, tag and
+// broken parameters the timeline takes as a WARC file.
+func (s *uiServer) handleBookmarksExportWARC() http.HandlerFunc {
+	capturer := warc.New()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user := ctx.Value(userContextKey{}).(*api.User)
+		q := r.URL.Query()
+
+		broken := false
+
+		if v := q.Get("broken"); v != "" {
+			var err error
+
+			broken, err = strconv.ParseBool(v)
+			if err != nil {
+				http.Error(w, "broken must be a boolean", http.StatusBadRequest)
+
+				return
+			}
+		}
+
+		urls, err := warcURLs(ctx, s.db, user,
+			store.WithSearchTerm(q.Get("q")),
+			store.WithTags(nonEmpty(q["tag"])),
+			store.WithBroken(broken),
+		)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		filename := fmt.Sprintf("sufr-%s%s", time.Now().UTC().Format("2006-01-02"), warc.FileExt)
+
+		w.Header().Set("Content-Type", "application/gzip")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+		// the download has started by the time anything can go wrong so all
+		// that's left to do is log it
+		if _, err := capturer.Export(ctx, w, urls); err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// nonEmpty returns the values of vs that aren't blank.
+func nonEmpty(vs []string) []string {
+	out := []string{}
+
+	for _, v := range vs {
+		if v != "" {
+			out = append(out, v)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,330 @@
+package sqlitestore
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/kyleterry/sufr/pkg/archive"
+	"github.com/kyleterry/sufr/pkg/config"
+	"github.com/kyleterry/sufr/pkg/store"
+	"github.com/mattn/go-sqlite3"
+)
+
+// sqliteHeader starts every SQLite database file.
+var sqliteHeader = []byte("SQLite format 3\x00")
+
+// Backup writes a copy of the database to a new file at path. It uses
+// SQLite's online backup API, so the copy is consistent even while requests
+// and workers use the store. Writes wait until it's done.
+func (s *Store) Backup(ctx context.Context, path string) error {
+	dest, err := sqlx.Open("sqlite3", path)
+	if err != nil {
+		return err
+	}
+
+	defer dest.Close()
+
+	if err := copyDatabase(ctx, dest.DB, s.db.DB); err != nil {
+		return fmt.Errorf("failed to back up database: %w", err)
+	}
+
+	return dest.Close()
+}
+
+// WriteBackup writes a gzipped tarball to w holding a Backup of the database
+// and, if archiver isn't nil, every archived page snapshot under archive/.
+func (s *Store) WriteBackup(ctx context.Context, w io.Writer, archiver *archive.Archiver) error {
+	tmp, err := ioutil.TempDir("", "sufr-backup-*")
+	if err != nil {
+		return err
+	}
+
+	defer os.RemoveAll(tmp)
+
+	dbPath := path.Join(tmp, config.DefaultSQLDatabaseName)
+
+	if err := s.Backup(ctx, dbPath); err != nil {
+		return err
+	}
+
+	f, err := os.Open(dbPath)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	hdr := &tar.Header{
+		Name:    config.DefaultSQLDatabaseName,
+		Mode:    0600,
+		Size:    info.Size(),
+		ModTime: time.Now().UTC(),
+	}
+
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(tw, f); err != nil {
+		return err
+	}
+
+	if archiver != nil {
+		if err := archiver.AddToTar(tw, "archive"); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	return gw.Close()
+}
+
+// Restore replaces everything in the store with the database file at path.
+// The file has to be a sufr database that isn't newer than this store; it's
+// migrated to the current version before it's copied in. The copy is made
+// with SQLite's online backup API in one transaction, so requests see either
+// the old data or the new and nothing in between.
+func (s *Store) Restore(ctx context.Context, path string) error {
+	if err := checkHeader(path); err != nil {
+		return err
+	}
+
+	candidate, err := New(WithPath(path))
+	if err != nil {
+		return err
+	}
+
+	defer candidate.Close()
+
+	if err := candidate.checkBackup(ctx); err != nil {
+		return err
+	}
+
+	if err := candidate.Migrate(ctx); err != nil {
+		return fmt.Errorf("failed to migrate backup: %w", err)
+	}
+
+	if err := copyDatabase(ctx, s.db.DB, candidate.db.DB); err != nil {
+		return fmt.Errorf("failed to restore database: %w", err)
+	}
+
+	return nil
+}
+
+// RestoreBackup restores the database from r, which is either a database
+// file or a tarball made by WriteBackup, gzipped or not. Archived pages in
+// a tarball are left alone.
+func (s *Store) RestoreBackup(ctx context.Context, r io.Reader) error {
+	tmp, err := ioutil.TempFile("", "sufr-restore-*.db")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if err := extractDatabase(tmp, r); err != nil {
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return s.Restore(ctx, tmp.Name())
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// MigrationVersion returns the version of the last migration run on the
+// database.
+func (s *Store) MigrationVersion(ctx context.Context) (string, error) {
+	var version sql.NullString
+
+	if err := s.db.GetContext(ctx, &version, `select max(version) from migrations`); err != nil {
+		return "", err
+	}
+
+	return version.String, nil
+}
+
+// checkBackup makes sure the database is an intact sufr database this
+// version knows how to migrate.
+func (s *Store) checkBackup(ctx context.Context) error {
+	var result string
+
+	if err := s.db.GetContext(ctx, &result, `pragma integrity_check`); err != nil {
+		return fmt.Errorf("%w: not a database: %v", store.ErrInvalidBackup, err)
+	}
+
+	if result != "ok" {
+		return fmt.Errorf("%w: database is corrupt: %s", store.ErrInvalidBackup, result)
+	}
+
+	version, err := s.MigrationVersion(ctx)
+	if err != nil || version == "" {
+		return fmt.Errorf("%w: not a sufr database", store.ErrInvalidBackup)
+	}
+
+	if latest := migrations[len(migrations)-1].Version(); version > latest {
+		return fmt.Errorf("%w: made by a newer version of sufr (migration %s, this one knows up to %s)", store.ErrInvalidBackup, version, latest)
+	}
+
+	var violations int
+
+	if err := s.db.GetContext(ctx, &violations, `select count(*) from pragma_foreign_key_check`); err != nil {
+		return err
+	}
+
+	if violations > 0 {
+		return fmt.Errorf("%w: %d rows point at rows that don't exist", store.ErrInvalidBackup, violations)
+	}
+
+	return nil
+}
+
+// checkHeader makes sure the file at path starts like a database.
+func checkHeader(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	header := make([]byte, len(sqliteHeader))
+
+	if _, err := io.ReadFull(f, header); err != nil || !bytes.Equal(header, sqliteHeader) {
+		return fmt.Errorf("%w: not a database", store.ErrInvalidBackup)
+	}
+
+	return nil
+}
+
+// extractDatabase copies the database in r to w. r is either the database
+// file itself or a tarball with it at its root, gzipped or not.
+func extractDatabase(w io.Writer, r io.Reader) error {
+	br := bufio.NewReader(r)
+
+	magic, _ := br.Peek(len(sqliteHeader))
+	if bytes.Equal(magic, sqliteHeader) {
+		_, err := io.Copy(w, br)
+
+		return err
+	}
+
+	var tr *tar.Reader
+
+	if len(magic) >= 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		gr, err := gzip.NewReader(br)
+		if err != nil {
+			return fmt.Errorf("%w: not a database or backup tarball", store.ErrInvalidBackup)
+		}
+
+		defer gr.Close()
+
+		tr = tar.NewReader(gr)
+	} else {
+		tr = tar.NewReader(br)
+	}
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return fmt.Errorf("%w: no %s in backup", store.ErrInvalidBackup, config.DefaultSQLDatabaseName)
+		}
+
+		if err != nil {
+			return fmt.Errorf("%w: not a database or backup tarball", store.ErrInvalidBackup)
+		}
+
+		if path.Clean(hdr.Name) == config.DefaultSQLDatabaseName && hdr.Typeflag == tar.TypeReg {
+			_, err := io.Copy(w, tr)
+
+			return err
+		}
+	}
+}
+
+// copyDatabase copies the main database of src over dest with the online
+// backup API.
+func copyDatabase(ctx context.Context, dest, src *sql.DB) error {
+	destConn, err := dest.Conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer destConn.Close()
+
+	srcConn, err := src.Conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer srcConn.Close()
+
+	return destConn.Raw(func(destDriverConn interface{}) error {
+		return srcConn.Raw(func(srcDriverConn interface{}) error {
+			d, ok := destDriverConn.(*sqlite3.SQLiteConn)
+			s, ok2 := srcDriverConn.(*sqlite3.SQLiteConn)
+
+			if !ok || !ok2 {
+				return errors.New("backups need sqlite3 connections")
+			}
+
+			b, err := d.Backup("main", s, "main")
+			if err != nil {
+				return err
+			}
+
+			// one step copies everything in a single transaction. Steps
+			// that find the database locked are tried again.
+			for {
+				done, err := b.Step(-1)
+				if err != nil {
+					b.Finish()
+
+					return err
+				}
+
+				if done {
+					return b.Finish()
+				}
+
+				select {
+				case <-ctx.Done():
+					b.Finish()
+
+					return ctx.Err()
+				case <-time.After(50 * time.Millisecond):
+				}
+			}
+		})
+	})
+}
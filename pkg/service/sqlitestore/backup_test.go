@@ -0,0 +1,153 @@
+package sqlitestore
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/kyleterry/sufr/pkg/config"
+	"github.com/kyleterry/sufr/pkg/embed"
+	"github.com/kyleterry/sufr/pkg/store"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackup(t *testing.T) {
+	WithTempDatabase(t, func(db *Store) {
+		ctx := context.Background()
+		dir := t.TempDir()
+
+		tag := MustCreateRandomTag(t, db)
+
+		t.Run("copies the database", func(t *testing.T) {
+			path := filepath.Join(dir, "copy.db")
+			require.NoError(t, db.Backup(ctx, path))
+
+			copied, err := New(WithPath(path))
+			require.NoError(t, err)
+			defer copied.Close()
+
+			got, err := copied.Tags().GetByName(ctx, tag.Name)
+			require.NoError(t, err)
+			require.Equal(t, tag.Id, got.Id)
+		})
+
+		t.Run("writes a tarball", func(t *testing.T) {
+			var b bytes.Buffer
+			require.NoError(t, db.WriteBackup(ctx, &b, nil))
+
+			gr, err := gzip.NewReader(&b)
+			require.NoError(t, err)
+
+			hdr, err := tar.NewReader(gr).Next()
+			require.NoError(t, err)
+			require.Equal(t, config.DefaultSQLDatabaseName, hdr.Name)
+		})
+	})
+}
+
+func TestRestore(t *testing.T) {
+	WithTempDatabase(t, func(db *Store) {
+		ctx := context.Background()
+		dir := t.TempDir()
+
+		kept := MustCreateRandomTag(t, db)
+
+		var tarball bytes.Buffer
+		require.NoError(t, db.WriteBackup(ctx, &tarball, nil))
+
+		path := filepath.Join(dir, "backup.db")
+		require.NoError(t, db.Backup(ctx, path))
+
+		t.Run("replaces everything with a database file", func(t *testing.T) {
+			lost := MustCreateRandomTag(t, db)
+
+			require.NoError(t, db.Restore(ctx, path))
+
+			_, err := db.Tags().GetByName(ctx, kept.Name)
+			require.NoError(t, err)
+
+			_, err = db.Tags().GetByName(ctx, lost.Name)
+			require.True(t, errors.Is(err, store.ErrNotFound))
+		})
+
+		t.Run("replaces everything with a tarball", func(t *testing.T) {
+			lost := MustCreateRandomTag(t, db)
+
+			require.NoError(t, db.RestoreBackup(ctx, &tarball))
+
+			_, err := db.Tags().GetByName(ctx, kept.Name)
+			require.NoError(t, err)
+
+			_, err = db.Tags().GetByName(ctx, lost.Name)
+			require.True(t, errors.Is(err, store.ErrNotFound))
+		})
+
+		t.Run("refuses what it can't restore", func(t *testing.T) {
+			garbage := filepath.Join(dir, "garbage.db")
+			require.NoError(t, ioutil.WriteFile(garbage, []byte("not a database at all, not even close"), 0600))
+
+			empty := filepath.Join(dir, "empty.db")
+			e, err := New(WithPath(empty))
+			require.NoError(t, err)
+			_, err = e.db.ExecContext(ctx, `create table things (id integer)`)
+			require.NoError(t, err)
+			require.NoError(t, e.Close())
+
+			newer := filepath.Join(dir, "newer.db")
+			require.NoError(t, db.Backup(ctx, newer))
+			n, err := New(WithPath(newer))
+			require.NoError(t, err)
+			_, err = n.db.ExecContext(ctx, `insert into migrations (version) values ('999')`)
+			require.NoError(t, err)
+			require.NoError(t, n.Close())
+
+			for _, p := range []string{garbage, empty, newer} {
+				err := db.Restore(ctx, p)
+				require.True(t, errors.Is(err, store.ErrInvalidBackup), "%s: %v", p, err)
+			}
+
+			err = db.RestoreBackup(ctx, strings.NewReader("\x1f\x8bnot gzip"))
+			require.True(t, errors.Is(err, store.ErrInvalidBackup), "%v", err)
+
+			_, err = db.Tags().GetByName(ctx, kept.Name)
+			require.NoError(t, err)
+		})
+
+		t.Run("migrates older backups", func(t *testing.T) {
+			old := filepath.Join(dir, "old.db")
+
+			s, err := New(WithPath(old))
+			require.NoError(t, err)
+
+			// run every migration but the last, like an older sufr would
+			err = s.withTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+				require.NoError(t, createMigrationsTable(ctx, tx))
+
+				for _, m := range migrations[:len(migrations)-1] {
+					require.NoError(t, m.Run(ctx, tx))
+					require.NoError(t, recordMigration(ctx, tx, m))
+				}
+
+				return nil
+			})
+			require.NoError(t, err)
+			require.NoError(t, s.Close())
+
+			require.NoError(t, db.Restore(ctx, old))
+
+			version, err := db.MigrationVersion(ctx)
+			require.NoError(t, err)
+			require.Equal(t, migrations[len(migrations)-1].Version(), version)
+
+			_, err = db.Embeds().Get(ctx, "missing")
+			require.True(t, errors.Is(err, embed.ErrNotCached), "%v", err)
+		})
+	})
+}
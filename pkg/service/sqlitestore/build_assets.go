@@ -23,156 +23,528 @@ var assets = func() http.FileSystem {
 		},
 		"/sql": &vfsgen۰DirInfo{
 			name:    "sql",
-			modTime: time.Date(2020, 12, 3, 8, 18, 2, 8711659, time.UTC),
+			modTime: time.Date(2026, 10, 17, 7, 19, 46, 680849997, time.UTC),
 		},
 		"/sql/migrations": &vfsgen۰DirInfo{
 			name:    "migrations",
-			modTime: time.Date(2020, 12, 10, 18, 56, 33, 109743082, time.UTC),
+			modTime: time.Date(2026, 10, 17, 7, 35, 11, 972849997, time.UTC),
 		},
 		"/sql/migrations/001-init.sql": &vfsgen۰CompressedFileInfo{
 			name:             "001-init.sql",
-			modTime:          time.Date(2020, 12, 11, 19, 8, 36, 931755289, time.UTC),
-			uncompressedSize: 1370,
+			modTime:          time.Date(2020, 12, 21, 2, 24, 23, 0, time.UTC),
+			uncompressedSize: 1359,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xbc\x53\xc1\x4e\xc3\x30\x0c\xbd\xf7\x2b\x7c\xdb\x2a\xed\x0f\x10\x07\x84\x76\xe0\x30\x84\xc6\x38\x21\x14\x79\xab\xdb\x85\xa5\x49\x71\x5c\xd8\xfe\x1e\xb5\x69\x4b\xb7\x95\xa9\x1c\xd8\x31\xf1\xb3\xfd\xde\xcb\x4b\xc1\x98\xe5\x08\xa9\x63\xd2\x99\x55\x3b\x3a\x78\xb8\x05\x67\x6f\xa2\x68\xc3\x84\x42\x20\xb8\x36\x04\x3a\x05\xeb\x04\x68\xaf\xbd\x78\x28\xd9\x78\x98\x46\x00\x00\x3a\x01\xa1\xbd\xd4\x55\x5b\x1a\x03\x05\xeb\x1c\xf9\x00\x3b\x3a\xcc\x6a\x44\xc9\xe6\x04\x52\x5a\xfd\x51\x52\xa8\x8a\x16\x43\x75\x3d\x9c\xc3\xd6\x44\xa1\x80\xe8\x9c\xbc\x60\x5e\x40\x42\x29\x96\x46\xe0\xfe\x65\xb9\x9c\x3f\xae\xd4\xea\x61\x31\x7f\x5e\xdd\x2d\x9e\x9a\x0d\x45\x72\xd6\x13\xc5\x97\x25\x08\x66\xe3\x25\x58\xcc\xe9\x82\x86\x6b\x71\x2e\x3d\xf1\x78\xd2\x94\xa3\xbe\xe4\x7c\x81\xde\x7f\x39\x4e\xd4\x16\xfd\xf6\x18\x17\x00\x58\x68\x25\x6e\x47\xb6\xf7\x3c\x94\xaf\x29\x51\x1b\x67\x85\xac\xc0\xda\x39\x43\x68\x7f\xe6\xb7\xa2\x53\x34\xbe\x5d\xa3\xad\xad\x5a\x50\x28\x73\xac\xc9\xc3\xbb\x77\xb6\x43\x4e\x5e\xdf\x26\xd7\x77\x51\xfd\x2d\xc1\x55\xc7\x29\xac\x0b\xf7\x2f\x95\xd3\x60\xa7\xf8\xe9\x58\x0b\x8d\x32\xad\x4e\xe7\x3f\xfb\xd4\xd0\x0a\x3f\xbf\x12\x3b\x6d\x74\xc6\xc0\x94\x12\x93\xdd\x50\x93\xb9\xa9\x4e\xe2\x01\x78\xad\xfd\x18\xcd\xa6\x07\x0e\x69\x6b\xc7\xce\x1a\xb3\xe2\xb1\x0f\xa4\xba\x3f\xda\xdd\x0c\x59\x2d\x98\x0d\xde\x9f\x29\x1b\xe2\xdb\x66\xa1\x25\xdd\x6f\x0a\x83\x8f\xf0\x15\xa3\x16\xda\x57\x17\x46\xcf\x1a\x2e\xb5\xc2\xef\x00\x00\x00\xff\xff\x75\x2c\xa0\xd2\x5a\x05\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xbc\x53\xc1\x6e\xe2\x30\x10\xbd\xe7\x2b\xe6\x06\x48\xfc\xc1\x9e\x56\x2b\x0e\x7b\x60\xb5\xa2\xf4\x54\x55\xd1\x90\xbc\x04\x17\xc7\x4e\xed\x49\x0b\x7f\x5f\x39\x09\xa9\x20\x01\xf9\x52\x8e\x99\x79\x33\xef\xcd\xf3\x4b\xe6\xc0\x02\x12\xde\x69\x90\x2a\xc8\x58\x21\x1c\x95\x17\x4f\x8d\xd3\x9e\xe6\x09\x91\xca\x49\x70\x94\xb6\x67\x1a\xad\xa9\x76\xaa\x62\x77\xa2\x03\x4e\xcb\x84\x02\xf0\x0a\xd0\x18\xf5\xde\x20\xf4\x44\x89\x46\xdb\x0d\x5f\x1d\x5b\x9e\xb2\x90\xa8\x0a\x5e\xb8\xaa\x29\x47\xc1\x8d\x16\xfa\xf3\xbc\xd9\xac\xfe\x6d\xd3\xed\xdf\xf5\xea\x69\xfb\x7b\xfd\xbf\xdd\x5d\xe7\xa3\x89\x64\xf1\x2b\x49\xee\x08\x17\x2e\xe3\x84\x1b\xae\x70\x53\xf9\x63\xb4\x36\x1e\x2e\x4e\x2c\x2a\x56\xb7\x7d\xae\xd9\xfb\x4f\xeb\xf2\x74\xcf\x7e\x7f\x89\x0a\x6d\xae\x55\x2a\xf6\x00\x33\x3c\x05\xaa\x1d\xf2\x34\xb3\x46\x60\x84\x76\xd6\x6a\xb0\xf9\xde\x7c\x3e\xb4\x60\xed\x3b\x02\x65\x4c\x18\x60\x41\x69\x9d\x82\xa7\x37\x6f\xcd\x80\x9b\xbd\xbc\xce\x1e\xeb\x5a\x1a\x9f\xcf\x80\xbe\x06\xf5\xc1\x9d\xac\x5f\x86\xb6\xe0\x0f\xeb\x94\x20\xc2\xa4\x36\x79\x3f\xe8\x4b\x2b\xc7\x3a\xa8\xd2\x84\xe3\xe6\xfd\x65\x0b\x72\x28\xe0\x60\x32\xf4\x89\x9a\x87\x62\x2b\x43\x43\x40\x19\xfb\x8c\x73\x8c\xc6\x9d\x1e\x4d\x3b\x7d\x67\xb8\xcb\xdb\x99\x76\xd9\x1b\xb8\x88\x7d\xae\x74\xf8\x33\x87\xca\xa4\xfd\x5c\x4e\xd6\x47\x97\x4f\xe9\xef\xeb\x91\x0e\x74\x54\x17\x1b\x82\xc6\x38\x07\x3a\xfa\x65\xaf\xb7\x75\xe1\x6b\x00\xc3\x7d\x7d\x08\x4f\x05\x00\x00"),
+		},
+		"/sql/migrations/003-user-url-search.sql": &vfsgen۰CompressedFileInfo{
+			name:             "003-user-url-search.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 19, 46, 680849997, time.UTC),
+			uncompressedSize: 3629,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xe4\x96\xcf\x92\x3a\x27\x10\xc7\xef\x3c\xc5\xf7\xa6\x56\xf9\x9b\xaa\x1c\x92\xcb\xd6\x3e\x8b\x85\x43\xcf\x0c\x59\x04\x0b\x1a\xdd\xcd\xd3\xa7\x1a\x46\x1d\xff\x24\xeb\xba\x5e\xb2\x39\x39\x40\xd3\xfd\xed\x4f\x37\x88\x76\x4c\x11\xac\xd7\x8e\x90\x13\xc5\x55\x8e\x2e\x41\x1b\x83\x36\xb8\xbc\xf1\xf0\x81\x29\x81\xe9\x9d\x5f\x94\xfa\xf5\xeb\x68\xb4\x4a\xa4\x63\x3b\xc0\x26\x68\x74\xd9\xb9\x62\x03\xeb\x0d\xbd\x23\x74\xa0\x1d\xc5\x0f\x1e\xac\xef\xa1\xcb\x26\xb4\xda\x63\xdc\xd4\x85\x88\xe0\xc5\x5d\xf0\x24\xd6\x3c\x90\x8d\x90\xd8\xcd\x29\x82\x35\xe2\x9d\x07\x82\x35\xa3\xd1\x44\x63\x0c\x7b\x90\x6e\x07\xf9\x10\x4f\x25\x32\xa5\x25\xf6\x83\xad\xba\x86\xb0\x47\xa4\x94\x1d\x27\xe8\x48\xf8\x33\x58\x4f\x06\x6b\xdd\xbe\x81\xc3\xc9\xd5\x29\x64\xc2\xa0\x93\x38\xf3\x01\xd6\x33\xf5\x14\xb1\x8d\x76\xa3\xe3\x07\xde\xe8\x63\x89\x14\x60\xb9\xc4\xb6\x26\x95\x8c\xda\x41\xfb\x9e\x10\x3c\x76\xba\xcd\x79\x03\xed\x8d\x2c\xcc\x18\x6b\x1a\x81\x99\x92\x30\x0f\x36\x35\xaa\x8d\xa4\x99\xb0\xb3\x91\xb3\x76\x23\x79\xdb\x09\x67\xd0\xbb\x4d\x9c\xae\x10\xe7\x24\x14\x3b\x4e\xbf\xcf\x15\x04\xd2\x52\x01\x6c\xd9\x91\x7c\x18\x8a\x76\x47\x66\x75\x9c\x28\x15\x2b\x26\xba\x2f\xbf\x53\xa0\xd9\x57\x4e\xa6\x18\x84\x37\xf2\xf6\x2f\xc2\x2b\x66\xdb\x10\x99\xa2\xac\xb7\xc1\xd0\x1f\xbf\xcd\xd4\xe2\x45\x9d\xd4\xd2\xfe\xdf\x45\xae\x52\xc8\xb1\x25\xe8\xa4\x12\x39\x6a\x59\xe2\xe6\xc6\x1a\xe8\x34\x15\x50\xf4\x34\x39\xba\x32\x5f\x53\xc9\xb9\x29\xe2\x65\xea\x98\x45\x1b\xb4\xa3\xd4\x92\xa4\x0c\xf8\xec\x9c\xed\xe6\x07\xcb\x25\x66\xb3\xc5\xb2\xac\x8c\x33\x0a\x58\xc8\xfe\x2b\x1a\x39\x37\xb5\x85\x75\x3a\x91\x39\x3a\xaf\xde\xab\x62\xf4\x31\xe4\xed\xaa\x0d\xbe\xd5\x3c\xe7\xc6\xeb\x8d\x04\xc2\x6c\x51\x8c\xba\x18\x36\xa7\x4c\x84\x2d\x32\x97\x15\xe9\xab\x02\x1b\x2c\x7d\xc0\x92\xf5\x2b\x32\x37\xac\xfb\x95\x35\xc5\x66\x3f\x50\x24\x99\x9b\x16\xe3\xb5\x22\x12\xed\x25\xa3\x02\x40\xf7\x49\x9d\xc5\x4a\xc8\x59\x95\x18\x75\x20\x31\x72\x73\xd8\x5e\x5d\xbd\x28\x65\x7d\xa2\x28\x07\x90\xc3\x65\x71\x30\x17\xd2\x23\xdb\x0b\x44\x23\x94\xda\x2c\xd3\x4a\x2d\xc6\x42\xe2\x81\xbd\xe7\x09\x9c\xb7\xc8\xa9\xab\x38\xda\x5e\x4e\xd8\xed\xc6\x4a\x87\x6d\x35\x31\xa5\x3b\x16\xdb\x32\x28\x08\x0e\x76\x6a\x4d\xbd\xf5\x0a\x78\x3e\x81\x63\x6b\x3c\xc2\xe0\xb2\x65\xce\x0f\xca\xd8\x10\x67\xdd\xe0\x69\xdf\x48\x2d\xc9\x9b\x2f\x53\xca\x5b\xa3\x99\x46\x4a\x75\x70\x9b\x92\x21\x47\x4c\x37\xb5\xdd\x14\x15\x9c\x29\xa2\xfe\xe7\x7c\x2b\xb6\x91\x6f\x1d\x3c\x97\xef\x3d\xa2\x3e\xab\x77\x37\x01\x59\xd4\xdd\x29\x4c\xe1\x86\x34\xeb\x31\x1f\xcb\x63\x0d\x2e\xae\xa4\xd1\xfc\x9c\xec\xe2\xbf\xd6\x25\xcf\xcb\xfb\x0b\x2d\x55\xfe\x3b\xee\xbc\xdc\x8a\xed\xc3\x15\xac\xfa\x26\x33\x3f\xa2\x3e\x37\xb2\x7a\x94\xfe\x27\x87\xfa\xbb\xf4\xe5\x68\xff\x3c\xfa\x57\x59\xdd\x41\x7f\x0a\xfd\x1f\x6e\x2e\x79\x6e\x95\xe7\xd3\x77\x98\xcb\xd9\x9d\x3e\xe9\xa6\x6b\xd7\x0f\xb8\xc9\xcb\xac\x3e\xd5\x7e\xe0\x4d\xf6\x24\x1a\xa5\xc6\x7f\x0f\x00\xd2\x6d\xb8\x6e\x2d\x0e\x00\x00"),
+		},
+		"/sql/migrations/004-user-url-private.sql": &vfsgen۰FileInfo{
+			name:    "004-user-url-private.sql",
+			modTime: time.Date(2026, 10, 17, 4, 33, 31, 571400721, time.UTC),
+			content: []byte("\x61\x6c\x74\x65\x72\x20\x74\x61\x62\x6c\x65\x20\x75\x73\x65\x72\x5f\x75\x72\x6c\x73\x20\x61\x64\x64\x20\x63\x6f\x6c\x75\x6d\x6e\x20\x70\x72\x69\x76\x61\x74\x65\x20\x62\x6f\x6f\x6c\x65\x61\x6e\x20\x6e\x6f\x74\x20\x6e\x75\x6c\x6c\x20\x64\x65\x66\x61\x75\x6c\x74\x20\x66\x61\x6c\x73\x65\x3b\x0a"),
+		},
+		"/sql/migrations/005-fetch-jobs.sql": &vfsgen۰CompressedFileInfo{
+			name:             "005-fetch-jobs.sql",
+			modTime:          time.Date(2026, 10, 17, 4, 52, 41, 290717266, time.UTC),
+			uncompressedSize: 806,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x74\x52\x31\x6e\xdc\x30\x10\xec\xf9\x8a\xe9\x4e\x02\xce\x46\x92\xd6\x48\x11\x04\x2e\x52\x38\x08\x1c\xa7\x16\x56\xe2\xe8\x44\x9b\x22\xcf\xe4\xca\xba\xfb\x7d\x40\x49\x4e\x7c\x31\xd2\xee\xce\xce\xce\xcc\xae\x78\x65\x82\x4a\xeb\x89\x29\xf9\x0c\xb1\x16\x5d\xf4\xd3\x18\xd0\xc5\xa0\x0c\xda\xe8\xf9\x48\x28\x4f\x7a\x63\xcc\xd5\x15\x7a\x6a\x37\x34\x8f\xb1\xcd\x70\x19\x3a\x10\xcf\x13\x27\x22\xf6\x2b\xc3\x2c\x4e\x5d\x38\xa0\x8f\xa9\x74\x5d\xc2\x48\x15\x2b\x2a\xd0\x88\x96\x2b\x01\xed\x75\x21\x4b\x53\x68\x44\x0b\xd1\x71\xca\x03\x6d\x99\x9a\x25\x59\xcc\x83\xf3\x84\x60\x8e\xe9\x89\x09\x83\x64\x08\x1e\x63\x8b\xce\x8b\x1b\x69\x21\xc1\x42\xfa\xa2\x5e\x16\x55\xe2\x7c\xa9\xaa\x72\x3c\xea\xb5\xe9\x12\x45\xb9\x39\x73\x3d\x42\x54\xf0\xe4\xb2\xe6\xb7\x06\x2a\x03\x38\xbb\x98\x5b\x10\x61\xf2\x1e\xc7\xe4\x46\x49\x67\x3c\xf1\xbc\x37\x28\xa6\x9a\x77\x98\x29\xb8\xe7\x89\xa5\xbd\x6d\xcc\x70\x41\x79\x60\xfa\x8b\xb1\xec\x65\xf2\x8a\x0f\x05\xb6\x19\x55\x37\x32\xab\x8c\xc7\x3f\xb0\xd2\xf4\x92\xb5\x61\x4a\x25\x31\x9e\xb4\x94\x56\xfd\xf6\x72\xe6\x95\xf1\xeb\xaf\xfb\xfb\xdb\xef\x0f\xcd\xc3\xb7\xbb\xdb\x9f\x0f\x5f\xee\x7e\x94\x89\x3e\x26\xba\x43\x28\xb2\xab\x55\x73\x8d\xc4\x9e\x89\xa1\x63\x5e\x6e\x53\x95\x5a\x0c\xb0\xf4\x54\xa2\x93\xdc\x89\xa5\xa9\x6f\xcc\x6b\x5e\x2e\x58\x9e\xfe\x9b\x57\xb3\xb9\x88\xe1\x22\xc4\xb5\x5a\xaf\xef\x51\xf6\x20\xcb\x0b\x2d\x5a\x16\x4d\x6f\x5e\x64\x21\xa4\x45\xe0\xcb\x72\x53\xfb\xef\x83\x6c\xaf\x61\x5c\xc8\x4c\x5a\x22\x8d\x17\x9b\x9c\xdd\x6f\xf7\xd8\x6f\x89\xd6\x26\xd3\xb3\x53\xf8\x38\x33\x55\x03\x4f\x55\x92\x60\xe3\xd8\xfa\xd8\x56\x1f\x3f\xd5\x75\xbd\x47\x81\xbf\xcb\xcc\xf4\x29\x8e\x8b\x5c\x33\x0f\x4c\x44\x17\xc5\x33\x77\xac\xd4\xa9\xe7\x1e\xbb\x5d\x8d\xcf\xd8\xed\x6e\xcc\xef\x01\x00\xed\xad\x9a\x27\x26\x03\x00\x00"),
+		},
+		"/sql/migrations/006-url-metadata.sql": &vfsgen۰CompressedFileInfo{
+			name:             "006-url-metadata.sql",
+			modTime:          time.Date(2026, 10, 17, 4, 55, 18, 776849997, time.UTC),
+			uncompressedSize: 364,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8c\xce\xc1\xad\x03\x21\x0c\x84\xe1\xfb\xab\xc2\x7d\xbc\x62\xd0\x2c\x38\xac\x25\x63\x10\xd8\x51\xca\xcf\x35\xa7\x95\xef\xdf\x8c\x7e\xa8\xf3\x26\xc7\xa5\x4c\xb1\xf5\x10\x5a\xa3\x3a\x35\x86\x51\xe3\x53\xb7\x2c\x97\x69\xe4\xfc\xf1\xff\xbf\x27\x2d\x03\x9d\x4b\x6c\x4d\xd8\x23\xce\xc5\x30\x38\x61\x11\x7e\xcf\x9d\x80\x2b\x2e\x95\x73\x73\x2b\x70\x72\x19\x7c\x1c\x63\x3d\x6f\x2a\x6c\x9a\x54\x68\x32\x5c\x61\x3d\xd0\x33\xdd\x2f\xbc\xa5\x4e\xfb\x39\xfe\x0e\x00\x6f\x2e\x9e\x27\x6c\x01\x00\x00"),
+		},
+		"/sql/migrations/007-url-checks.sql": &vfsgen۰CompressedFileInfo{
+			name:             "007-url-checks.sql",
+			modTime:          time.Date(2026, 10, 17, 5, 21, 59, 191208519, time.UTC),
+			uncompressedSize: 1081,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x52\xcb\x8e\xe2\x30\x10\xbc\xe7\x2b\xea\xb6\x20\x0d\x68\xef\x7c\x4c\xd4\xd8\x95\x89\x85\x63\xa3\x76\x5b\xc0\xdf\xaf\x9c\x4c\x60\x66\x59\x0d\xab\xb9\x25\x9d\xea\xea\xd4\x63\xb7\x83\x8d\x44\xae\xe6\xf2\x44\xe4\x61\x7e\x8d\x62\x2c\x86\x18\xd2\x09\x6e\xa4\x3b\xed\x31\x48\x88\x55\x59\xe0\x72\x4d\x56\x66\xd8\xfc\xa9\x3d\x8a\x75\xbb\xdd\x0c\xa1\x47\x48\x10\x68\xbe\x40\x92\x87\xa7\x78\x84\x82\x42\x43\x4e\x8e\x08\x06\xa5\xb8\x91\x9f\x28\xa8\xbf\x0a\x62\x98\x82\xed\x3b\x89\x46\x85\xc9\x31\x12\x55\x63\x81\x78\x0f\x97\x63\x9d\x12\x8a\x89\xd5\xd2\xbb\xec\x89\x90\x8c\xef\x54\xa4\x6c\x48\x35\x46\x78\x0e\x52\xa3\xe1\xf7\xe1\x5b\x8e\xbb\x8e\x9f\x12\xcc\x8a\x8e\x39\x47\x4a\x7a\x5e\x1e\x24\x16\x7e\x4f\xb0\x48\xf6\xbd\x18\x2c\x4c\x2c\x26\xd3\xf9\xd0\x75\x4e\x29\xd6\x74\x79\x5e\x11\x86\x99\x9a\xd7\x50\xac\xcc\x0c\xfd\xa7\xb5\x9c\x16\xd2\xcd\x63\xb6\x3d\x74\x2d\x82\xaa\xb1\xff\x48\x25\x2c\x06\x8f\xa1\x58\xd6\x5b\x4b\xf6\x11\x67\xd9\x63\x08\x49\x62\x5f\x35\x36\xe0\x65\xa4\x12\x4a\x1f\x94\xce\x4a\x63\x62\xf2\xf4\xa8\xe7\x39\x45\xaa\x66\x5d\x63\xbc\x8c\x6c\xc2\xa1\x2c\xe7\x9c\x0a\xe1\x64\x22\x8e\xe2\x4e\x10\x83\xc4\xb8\x5f\xb5\x2c\xfa\x9f\xb4\xac\x7f\xb8\xe9\x80\xe0\x61\xbc\xda\xc3\xc8\xb3\x86\x49\xf4\x86\x13\x6f\x6f\x1d\x66\xf8\xdf\x98\x36\xcf\xa7\x17\x19\x34\xd0\xff\xf5\xa5\x21\x1f\x6e\xb4\x4b\x6d\x72\x37\xe3\xc5\xe6\xea\x42\xdf\xb2\xec\xa7\x57\xf0\xc5\xc9\xf5\xc8\xbf\xaa\xf0\x45\xe6\x90\x95\xe1\x3d\x35\x37\x36\x8b\x15\x5b\x28\x07\x2a\x93\xe3\x52\x8c\x4d\x9b\xe5\x04\xcf\x48\x23\x9c\x14\x27\x9e\xdd\xf6\x65\xa5\x3e\x62\xe8\x17\xde\xe7\x7e\xdd\x63\x5a\x00\x6f\xf8\xd2\xb6\x3f\x03\x00\x99\x3a\xbc\x0f\x39\x04\x00\x00"),
+		},
+		"/sql/migrations/008-url-archive.sql": &vfsgen۰FileInfo{
+			name:    "008-url-archive.sql",
+			modTime: time.Date(2026, 10, 17, 5, 37, 53, 492335710, time.UTC),
+			content: []byte("\x61\x6c\x74\x65\x72\x20\x74\x61\x62\x6c\x65\x20\x75\x72\x6c\x73\x20\x61\x64\x64\x20\x63\x6f\x6c\x75\x6d\x6e\x20\x61\x72\x63\x68\x69\x76\x65\x64\x5f\x61\x74\x20\x74\x69\x6d\x65\x73\x74\x61\x6d\x70\x3b\x0a"),
+		},
+		"/sql/migrations/009-user-accounts.sql": &vfsgen۰CompressedFileInfo{
+			name:             "009-user-accounts.sql",
+			modTime:          time.Date(2026, 10, 17, 5, 48, 45, 250308066, time.UTC),
+			uncompressedSize: 366,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xa4\x8f\xc1\x6a\xc4\x30\x0c\x44\xef\xfe\x8a\x39\xb6\xd0\x85\xf6\x1c\xf6\x5b\x16\xc5\x9a\x6c\x0c\x8a\x1d\x64\x99\xd0\xbf\x2f\xe9\xb6\xa7\x42\x2f\x7b\x12\xd2\x43\x6f\x18\xb1\xa0\x23\x64\x36\x62\x74\x7a\x87\xa8\x22\x37\x1b\x5b\x85\xe8\x56\x2a\xe6\xd6\x8c\x52\x51\x5b\xa0\x0e\x33\x28\x17\x19\x16\x58\xc4\x3a\xa7\xf4\xaf\x42\x4b\x3f\x81\x3e\x67\xd9\xe9\xb7\x5d\xee\x44\xa9\xc1\x3b\xfd\xaf\xe5\x7d\x4a\xe9\x72\x41\xac\x44\x33\x65\x0f\x48\xce\x6d\xd4\x40\xe9\x8f\x6b\xe5\xf7\x2c\xb5\x87\xd4\x4c\x1c\xd2\xd1\x19\x18\x3b\x8e\x12\x6b\x1a\xbb\x4a\xfc\x66\x9f\xe0\xd1\xfe\x8a\xf0\xc1\x74\xac\x74\xa2\x28\xae\x78\xe9\x34\xe6\x38\x97\xc5\xdb\xf6\xf3\xd1\x5c\xe9\x98\x3f\x91\x9d\x12\xd4\x9b\xc4\x1b\xbc\x1d\x45\x61\x65\x2b\x81\x8f\xd7\x29\x7d\x0d\x00\x52\x6b\x9f\x5a\x6e\x01\x00\x00"),
+		},
+		"/sql/migrations/010-user-totp.sql": &vfsgen۰CompressedFileInfo{
+			name:             "010-user-totp.sql",
+			modTime:          time.Date(2026, 10, 17, 6, 9, 16, 400849997, time.UTC),
+			uncompressedSize: 746,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8c\x51\xbb\x8e\xdb\x30\x10\xec\xf5\x15\xd3\xd9\x06\x6c\x23\x48\x4a\xd7\xf9\x0e\x61\x4d\x8e\x2c\x22\x14\x57\x20\x57\xe7\xd3\xdf\x07\xa4\x94\xdc\x19\x97\x22\xe5\xce\x0e\x77\x1e\xbc\x5c\x60\x6a\x73\x5f\xe8\x32\x0d\xa1\xc0\x46\xe2\x2e\x85\x3f\xbe\x63\x07\x75\x80\x60\x29\xcc\x87\x02\x59\x6c\x64\xb2\xe0\xc4\x34\x43\xe6\xf9\x0c\x4e\xb3\xad\xdd\xe5\x82\xe7\xc8\x04\x7b\xea\x65\x10\xd7\xb6\x1f\xdc\xa0\xa9\xde\xd6\x61\xb8\x6e\x7a\x51\x8a\xf5\xc5\x38\xff\x91\xb4\x30\x11\x0d\xd0\xa1\x1e\xab\x58\x25\xc1\xa9\x67\x55\xf7\x28\x0a\xd9\x46\x27\xe9\x60\xb8\xef\xb8\x3d\x83\xe3\xb5\x93\x68\xcc\x30\xb9\xc7\x86\xe7\x02\xf1\x1e\x4e\xe3\x32\xa5\x97\x90\xc6\x77\x43\x52\x43\x5a\x62\x84\xe7\x20\x4b\x34\x1c\x0e\xb7\xff\x38\xf1\xc9\x77\x32\x3e\x98\xbf\x1e\xfa\x76\xeb\x6a\x80\xfa\xbe\xcf\x74\xfa\xc6\xbc\xf6\xd5\x76\x81\x64\xb6\x60\xa3\x94\x91\xb5\x8e\x36\x6d\x3b\x1b\xc5\x10\xf5\xb1\x77\x8d\x90\x5a\xa1\x7b\x17\xeb\xe1\x8d\x88\x5a\xac\x0e\x21\xbf\xfe\xc3\x15\x3f\xc5\x8d\xd0\xc4\xda\xa6\x67\xa4\xd1\x43\x93\xdb\x0a\xba\x76\x2e\x53\x8c\x7b\xb0\x30\x34\xcf\x7c\x0f\xc5\xca\x3f\x6d\x1e\x3b\x6c\x78\xf0\xaf\x65\x9d\x3b\x34\xb7\x7d\x0d\x80\x7b\xd4\xfb\xeb\xaa\xc9\xf8\x5e\xac\x7d\x67\x31\x99\xe6\xaf\xfd\xb8\x25\x67\x26\xeb\xff\x52\xea\xd3\x39\x87\x49\xf2\x8a\x5f\x5c\x8f\xbb\xf4\xf9\x43\xea\x54\x29\x83\x66\x86\x47\xfa\x4c\x39\x21\x73\x60\x66\x72\xdc\x92\x94\x63\x05\x35\xed\x25\xc0\x49\x71\xe2\xd9\x9d\x6e\xdd\xef\x01\x00\xc6\x6f\x7e\x56\xea\x02\x00\x00"),
+		},
+		"/sql/migrations/011-user-webauthn.sql": &vfsgen۰CompressedFileInfo{
+			name:             "011-user-webauthn.sql",
+			modTime:          time.Date(2026, 10, 17, 6, 16, 37, 144849997, time.UTC),
+			uncompressedSize: 730,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8c\x92\xb1\x8e\xdb\x30\x10\x44\x7b\x7d\xc5\x74\xb9\x03\xce\x46\xfa\x2b\x83\x54\x29\x52\xe4\x03\x04\x8a\x1c\x4b\x84\xa9\xa5\xc0\x5d\xc2\xd6\xdf\x07\xe4\xf9\x1c\x23\x97\x04\x69\x67\x76\x86\xbb\x0f\x3c\x1c\x50\x95\x65\xbc\x70\x72\xd5\x16\x19\x7d\x61\xa0\x58\x74\x49\xe1\x0a\x61\x0b\xb1\x39\xd5\x33\x77\x85\x93\x00\xa5\xaf\x25\xda\x8e\xae\xb4\xb0\x22\xe5\x19\x51\x86\xc3\x01\x97\x68\xcb\x11\x31\x20\x6a\x8f\xfe\xaa\x6b\x62\x53\xda\x33\x4d\xf1\xce\x72\xc1\xea\x02\x7b\xed\x56\xa7\x14\xfd\x78\xe6\xde\xa2\xd1\xb4\xb5\x7d\xf9\xfe\xe3\xeb\xf8\x8d\xfb\x11\x1a\x67\x19\x7d\xae\x62\xef\xcd\xc9\xa9\x75\xd9\x59\x2d\x44\xf7\x58\x10\x0d\x4a\xb1\x17\x5c\x96\xe8\x17\x64\x49\x3b\xe6\xcc\x5e\x57\x37\x54\x49\x54\xfd\xc3\x22\x21\x53\xe5\x93\xe1\x4c\x6e\xc8\xc2\xe3\xe0\x0b\x9d\x11\xe6\xa6\x44\xc4\x13\x24\x1b\x78\x8d\x6a\xfa\x0f\x64\x4f\x03\xda\xa1\x53\xca\x13\xb6\x12\x57\x57\x3a\xa8\x97\x01\x6f\xa1\x06\x81\x57\xeb\x65\x52\x53\x6a\x86\xb8\x95\x1f\xd5\x07\x20\xbd\xed\xd1\x7b\xa4\x21\xc6\x99\xe5\x6e\x23\xf0\xe4\x6a\x32\x7c\x6e\x83\x6f\x37\x84\xd1\x19\x2c\xae\x54\x73\xeb\xf6\x71\xd4\xd7\x52\x28\x36\xde\x47\x5a\xb4\xf1\x1d\xab\xfe\x16\x6e\xce\x29\x17\xc6\x59\xda\x5d\x4f\xb7\xa3\x9e\x51\x78\x62\xa1\x78\xde\xfe\xc4\x53\x13\xb3\x20\x30\xd1\x08\xef\xd4\xbb\xc0\xe1\xf9\x75\x78\x07\x1b\x25\xf0\xfa\xbf\x60\xc7\xdb\x43\x03\x5a\xe9\x5f\xc7\xee\xfb\xbc\x0e\x3f\x07\x00\xe6\x90\x05\xfb\xda\x02\x00\x00"),
+		},
+		"/sql/migrations/012-user-url-visibility.sql": &vfsgen۰CompressedFileInfo{
+			name:             "012-user-url-visibility.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 44, 676849997, time.UTC),
+			uncompressedSize: 540,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x4c\x90\x4d\x6e\xeb\x30\x0c\x84\xf7\x3a\xc5\xec\x9c\x00\xb1\x37\xc1\x5b\x05\xef\x16\xdd\x07\xb2\x34\x8e\x85\xaa\xb2\x2b\x52\xf9\xb9\x7d\x21\xd7\x71\xbd\x23\xc1\xe1\x37\x43\xb6\x2d\xee\x41\x42\x1f\x62\xd0\x17\x32\xe7\x68\x1d\x05\x3a\x12\x73\x0e\x77\xab\xc4\x10\xed\xad\x43\xc9\x51\x96\xf2\x46\xbf\x8d\x44\xed\xeb\xdd\x98\xb6\x85\x4d\x7e\x59\xcd\x14\xc5\x83\x99\x98\x4b\x1f\x83\x43\x49\x9e\x79\x19\x55\xc4\x09\x32\xd5\xe6\x55\x01\x59\x31\x15\x5d\x85\x1d\x3e\xc6\x85\xf4\x76\x70\x53\x2c\x5f\x09\x41\x10\x39\x28\x7a\x8e\x21\x79\x94\x54\x84\x1e\x3d\x9d\x2d\x42\xc8\x77\x0c\x55\x6b\x53\xa3\xf0\x79\x9a\xd7\x35\xa9\xa4\x9e\xc3\x94\x89\x73\x77\xfe\xd7\x19\x1b\xb5\xe6\xb0\x7d\x24\x8a\x30\x5f\x97\xb3\xac\xf7\x6f\xa3\xdd\x33\x94\x4f\x45\x9a\x14\xa9\xc4\x08\xcf\xc1\x96\xa8\x68\xd6\x64\x8d\x01\xdc\x48\xf7\x89\xc3\x6e\x27\x24\x1c\x36\xc5\x09\x4d\x49\x31\x88\xd2\xd7\xfa\xf7\xc2\xe6\x78\xbc\x18\x53\x66\x6f\x75\x1f\x41\xa8\x7b\xef\xff\x70\x56\x88\xc7\xc8\xb4\xbd\x42\x6b\xb3\xc1\xc1\x28\xdc\xa0\x60\xf2\x17\x63\x5c\x66\x55\x86\xe4\xf9\xfc\x83\x5f\x97\x2a\xf8\xeb\xce\x60\x4a\x3b\xf3\xc3\x2a\x38\xed\x22\x1c\x2f\xe6\x67\x00\x1a\x22\xe3\x3a\x1c\x02\x00\x00"),
+		},
+		"/sql/migrations/013-shares.sql": &vfsgen۰CompressedFileInfo{
+			name:             "013-shares.sql",
+			modTime:          time.Date(2026, 10, 17, 6, 33, 35, 672016508, time.UTC),
+			uncompressedSize: 784,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8c\x52\xcb\x8e\xdb\x40\x0c\xbb\xfb\x2b\xd8\xd3\x3a\xc0\x26\xe8\x7d\xd1\x63\xbf\xc3\x98\xb5\x69\x5b\xc8\x64\x1c\x8c\xe4\xc4\xfe\xfb\x42\x7e\xa4\x4d\x37\x28\x7a\x14\x29\x92\x92\x66\x8e\x47\x68\x1f\x32\x15\x21\x13\xca\x3a\xd3\x10\x25\x9d\x15\x36\x60\x48\xc4\xd0\x22\x60\x54\xe6\x37\xc5\x98\xa3\x62\xc8\x4e\xf1\xc6\x3c\x5b\x2f\xa9\x83\xf5\x9c\x8b\xe3\x11\x16\xba\x8e\x0d\xee\x62\x3d\x82\x57\xef\xb8\xf7\xc1\xbc\xd3\x7b\x16\xf5\x1b\x6e\xa2\xf2\x29\x51\x6c\x3e\x41\x1a\x88\x2e\xdc\x96\x2c\xc9\x2b\x37\xf3\x19\x4e\xf8\x39\x85\xda\xe2\xbc\x0f\xe2\x63\x54\x63\x8e\x95\x34\x08\xa9\xf1\x8c\x6a\xf5\x50\xda\xa9\xa8\x33\x83\x11\x16\x3e\x23\x21\x2d\xd2\x60\xe0\x24\x6a\xba\x2f\x59\x16\xf0\x50\xe3\x64\xb8\x66\xb9\x84\x3c\xe3\xcc\xf9\xbd\xc0\xea\xbd\x73\xae\x4c\x63\x8c\x0f\x62\x0b\x75\xd2\xb1\x2d\x78\x2f\x6f\xc2\xbb\x42\x92\xb1\x63\x7e\x88\xd1\xb0\x0d\x63\x34\x7c\xf7\x1e\x4e\x57\xc9\xd4\x2a\x18\x4c\x2e\x54\x0b\x97\xab\xe3\xeb\xd0\xcd\x13\xfe\xd5\xa2\x1e\x73\x66\xb2\xea\x49\x1a\x83\x5a\xe5\xd9\x7f\xc9\x9d\x6b\x87\x4c\xe9\x92\x6f\x57\x6e\xab\x1d\x90\xd9\x32\x33\xd5\xd4\x65\x2b\x2d\x1d\x1c\x12\x1a\x46\x1a\x51\x07\xad\x43\xc3\x97\xf2\xf5\x00\x5f\x2c\x1c\xff\x4f\x9b\xf5\x66\x4f\x0e\x16\xba\x7f\x88\xeb\x9e\xf5\x19\xe5\x9f\xf9\x10\x5d\xee\x72\xc0\xb7\x1f\x28\x7f\x3f\xff\x82\x1d\x8a\xc3\x47\xb1\x7f\x02\x49\x0d\xa7\x97\x9f\xa0\xda\x5f\x7a\x48\x1b\xf2\x38\xd0\x47\xf1\x6b\x00\x3f\x0b\xfe\x34\x10\x03\x00\x00"),
+		},
+		"/sql/migrations/014-embeds.sql": &vfsgen۰CompressedFileInfo{
+			name:             "014-embeds.sql",
+			modTime:          time.Date(2026, 10, 17, 6, 45, 53, 514778637, time.UTC),
+			uncompressedSize: 911,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8c\x52\xcd\x8e\x1a\x3d\x10\xbc\xcf\x53\xd4\x0d\x56\x02\xf4\x49\xab\xef\x84\x72\xcc\x73\x20\x8f\x5d\x83\xad\x78\xda\x13\x77\x3b\x2c\x79\xfa\xc8\xfc\x44\x02\xa2\x6c\xae\xe5\xea\x72\x57\x55\x6f\xb7\xe0\x3c\x32\x1c\x96\x58\xac\x28\x9c\x84\x1b\xf0\x23\x05\x16\x45\xe5\x92\x9d\xe7\x0d\xf4\x45\x8c\x62\x1b\x9c\x62\xf2\x11\xd6\xaa\x30\x60\x2c\x16\x51\x64\xd8\x6e\xe1\x0c\x45\x3c\x77\x8f\x7c\x24\x45\xe6\x64\x18\x19\x93\x04\x34\x69\xda\xe7\xe8\x5d\x53\x42\xbf\xe7\x64\x84\x77\xb2\x32\x84\x5a\x96\xae\xe4\x4b\x6e\xb3\x28\x46\x4e\xa5\x12\xef\xbb\xf7\xff\x77\x83\xcb\xc6\x0a\x73\x63\x26\x9a\xb2\x2a\x5c\x08\x37\xea\xa3\x91\xb1\x94\x4c\x27\x90\x62\x90\x96\x33\x02\x27\xd7\xb2\x61\x72\x59\xb9\xff\x17\xa5\x5b\x02\x9f\x29\x0d\x6d\x09\xce\xee\x2a\x4a\x7b\x5c\xe4\xcb\x73\x74\x0f\xe2\x4f\xaf\xfb\x61\xb8\x37\xa2\xf0\xce\x47\x2a\x4e\xb1\x87\xfa\xb5\x63\xa0\x84\xa5\x24\xb1\x5e\x94\x9e\x58\x19\x30\x95\x8a\x56\xb3\x6e\x70\x4a\x16\x61\x91\x88\x36\xe7\x4b\x19\xb9\xd2\x85\x33\xd4\x49\xb2\xf4\x93\x61\x37\xf8\xca\xbe\xeb\xd5\x77\x9a\x2e\xa6\xf8\x91\xd4\xf4\xfe\xeb\x7a\x40\xd7\x3b\xa4\x00\xe3\x87\x61\xa9\x69\x76\xf5\x8c\x6f\x3c\x6f\xae\x4f\x57\xfc\x1e\x47\x07\xed\xbc\xf0\x0f\x68\xb2\xfc\x04\xff\xce\x6e\xb5\xea\x8c\xa5\x96\x9e\x43\x3d\x88\x9b\xff\xce\xec\x96\x5e\x7f\x38\xa5\x60\x11\x49\x8c\x47\xd6\xd7\xd1\xff\x2e\x93\x4c\xc7\x68\x9f\x90\x2c\xb6\x79\x14\x97\xf2\xe1\xc5\xdf\xd3\x22\x13\xcd\x47\x86\x83\x33\x58\x9a\xa9\xe6\xe6\xe5\x61\xa7\x7e\xad\xe9\x28\x3d\xaf\xf5\x35\xc7\x37\x54\x4e\xac\x14\x4f\xbd\x54\xb5\xee\x58\x11\x04\x66\x5e\xee\x5e\xbd\x0b\x1c\xde\xf6\xc3\xaf\x01\x00\xc5\x08\x41\xd3\x8f\x03\x00\x00"),
+		},
+		"/sql/migrations/015-user-identities.sql": &vfsgen۰CompressedFileInfo{
+			name:             "015-user-identities.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 35, 11, 976849997, time.UTC),
+			uncompressedSize: 509,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x64\x8f\x51\x0e\x9b\x40\x0c\x44\xff\x39\xc5\xfc\x85\x48\x90\x0b\xf4\xbb\xe7\x88\xcc\xae\x01\x37\x8b\x37\x5d\x7b\xdb\x70\xfb\x0a\x48\x50\xa5\xfc\xec\x4a\x7e\x9e\xf1\x4c\xdf\xa3\x1a\x97\xbb\x44\x56\x17\x17\x36\xec\x0f\x85\x90\xab\xba\xc1\x33\x7c\x66\x98\xe8\x94\xb6\x6f\xd2\x3e\xeb\x7f\x78\x26\x47\xca\x13\x44\x9b\xbe\x07\x6d\x13\x5e\x3a\x0c\xeb\x2e\x7b\x96\xfc\x47\x22\x97\x8b\x41\xcc\x2a\x17\x90\xc6\xc3\xb0\x0e\xbf\x38\x38\xc4\xf1\xd0\xfc\xf7\xd0\x61\x58\x6f\xf8\xb9\x90\x24\xdb\xdd\x1c\x74\x5a\x20\x90\x22\xcc\xa4\x13\x63\x26\x8d\xd6\x7d\x3c\x6c\x43\x17\xbf\x35\xa1\x30\x39\xc3\x69\x48\x0c\x19\xa1\xd9\xc1\x2f\x31\xb7\xaf\x92\x6d\x83\x4f\x22\xe7\x97\xef\xab\x5a\x53\xea\x1a\x9c\xd1\xbe\xc0\xdb\xe4\x1b\x1c\x87\xe3\x9d\x1c\x2e\x0b\x9b\xd3\xf2\x3c\x17\x10\x79\xa4\x9a\x1c\xa1\x96\xc2\xea\xf7\x73\x65\x93\x3e\x8b\x2c\x54\x56\x3c\x78\x45\x7b\x24\x3a\x8b\x5d\xf7\xa3\x2a\xbf\x2b\xa3\x7d\x1f\xef\xde\xb1\x77\x36\xe6\xc2\x32\xe9\x26\xfe\xf0\x2b\x0a\x8f\x5c\x58\x03\x1f\xad\xad\xdd\x86\x59\x11\x39\xb1\x33\x02\x59\xa0\xc8\xcd\xf5\x47\xf3\x6f\x00\x0a\xcd\x1e\xa7\xfd\x01\x00\x00"),
 		},
 		"/sql/migrations/migrations-table.sql": &vfsgen۰FileInfo{
 			name:    "migrations-table.sql",
-			modTime: time.Date(2020, 11, 30, 5, 25, 19, 259532019, time.UTC),
-			content: []byte("\x63\x72\x65\x61\x74\x65\x20\x74\x61\x62\x6c\x65\x20\x69\x66\x20\x6e\x6f\x74\x20\x65\x78\x69\x73\x74\x73\x20\x6d\x69\x67\x72\x61\x74\x69\x6f\x6e\x73\x20\x28\x0a\x20\x20\x20\x20\x76\x65\x72\x73\x69\x6f\x6e\x20\x74\x65\x78\x74\x20\x70\x72\x69\x6d\x61\x72\x79\x20\x6b\x65\x79\x2c\x0a\x20\x20\x20\x20\x63\x72\x65\x61\x74\x65\x64\x5f\x61\x74\x20\x74\x69\x6d\x65\x73\x74\x61\x6d\x70\x20\x6e\x6f\x74\x20\x6e\x75\x6c\x6c\x20\x64\x65\x66\x61\x75\x6c\x74\x20\x43\x55\x52\x52\x45\x4e\x54\x5f\x54\x49\x4d\x45\x53\x54\x41\x4d\x50\x0a\x29\x3b\x0a"),
+			modTime: time.Date(2020, 12, 21, 2, 24, 23, 0, time.UTC),
+			content: []byte("\x63\x72\x65\x61\x74\x65\x20\x74\x61\x62\x6c\x65\x20\x69\x66\x20\x6e\x6f\x74\x20\x65\x78\x69\x73\x74\x73\x20\x6d\x69\x67\x72\x61\x74\x69\x6f\x6e\x73\x20\x28\x0a\x20\x20\x76\x65\x72\x73\x69\x6f\x6e\x20\x74\x65\x78\x74\x20\x70\x72\x69\x6d\x61\x72\x79\x20\x6b\x65\x79\x2c\x0a\x20\x20\x63\x72\x65\x61\x74\x65\x64\x5f\x61\x74\x20\x74\x69\x6d\x65\x73\x74\x61\x6d\x70\x20\x6e\x6f\x74\x20\x6e\x75\x6c\x6c\x20\x64\x65\x66\x61\x75\x6c\x74\x20\x43\x55\x52\x52\x45\x4e\x54\x5f\x54\x49\x4d\x45\x53\x54\x41\x4d\x50\x0a\x29\x3b\x0a"),
 		},
 		"/sql/queries.sql": &vfsgen۰CompressedFileInfo{
 			name:             "queries.sql",
-			modTime:          time.Date(2020, 12, 12, 0, 12, 52, 776294577, time.UTC),
-			uncompressedSize: 5590,
+			modTime:          time.Date(2026, 10, 17, 7, 35, 12, 79494284, time.UTC),
+			uncompressedSize: 21964,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xec\x57\x4d\x6f\xdb\x46\x13\xbe\xf3\x57\xcc\x0b\xbc\x00\x49\x83\x61\x0e\xbd\xa9\x55\x05\xc7\x35\x82\x00\x71\x10\xb8\xf6\xa9\x28\x88\xb5\x38\x92\xd7\xa5\x48\x65\x3f\xec\xea\xdf\x17\x3b\xb3\xa4\x96\x34\x45\x49\x09\xd0\xa0\x45\x2f\x22\xb9\x3b\x3b\x3b\xf3\xcc\x33\x1f\x7a\xf3\x06\xb4\x5d\xa9\x59\x29\x45\x85\x4b\x03\xfa\x4b\x25\x0d\xfe\x10\x45\xed\xc6\x46\x6c\x8b\x2f\x16\xd5\x0e\xee\xc4\xfa\x46\xd4\x62\x8d\x2a\xbf\x52\x28\x0c\x46\xb2\xd6\xa8\x0c\x34\x0a\xe4\xba\x6e\x14\x82\xac\x4d\x03\x46\xac\x35\x24\xb2\xcc\xa0\x16\x1b\xcc\x60\x49\xc2\x65\x21\x4c\x06\x76\x5b\xfa\xf7\x34\x7a\x16\x95\x45\x0d\xc9\xcc\x89\xce\x58\x76\x16\x0a\xcf\x42\xe9\x69\x83\xde\xa3\x79\xb7\xfb\xf0\x4b\xa4\xd1\x79\x11\x01\xc8\x32\x8b\x80\x0d\x88\x20\x34\x21\x82\xc0\x88\x68\xa5\x9a\x0d\x19\x1c\xbd\x3c\xa2\x73\xa0\x84\x39\x2c\x4e\xb9\xec\x93\xd8\xe0\x37\x5f\xe7\x0e\x1c\xba\xf0\xfe\xf6\xe3\x49\x70\x5b\x55\xe9\x08\x18\x70\xab\xaa\x0c\x8c\x34\xd5\x51\xd8\xdd\x09\x06\x9e\xce\xcc\xfc\xa1\xb3\xf0\x0f\x2c\x24\x48\xee\x6f\x3f\x7a\x44\x80\x20\x01\xa1\x3d\x30\x56\x55\xee\xc3\x5d\x15\x01\x1b\xe8\xbe\xf9\xd2\x1e\x60\x6e\xf9\x10\x7c\xa4\x62\x00\x26\x79\xcf\x60\xba\x4b\x0e\x61\xa9\x51\x8d\x83\xc9\x10\x6a\x54\x1d\x86\xb8\x11\xb2\xca\x60\x2b\xb4\x7e\x69\x54\x59\x3c\x0a\xfd\x78\x3a\x9a\xfe\xf4\x6c\x70\xfc\x3c\x5c\x03\x6b\xef\x49\xf4\xb3\xac\x6b\x2c\xaf\x84\xc1\x75\xa3\x24\xea\x88\x35\x74\x86\x6b\x34\xb0\x25\x99\x62\xd9\x09\xc1\x1c\x12\xda\xf3\x1c\x05\x78\xd2\x4d\x5d\xac\x55\x63\xb7\x85\x50\x4a\xec\x12\x5a\xf5\xeb\xcd\xc3\x13\x2e\x4d\x12\x57\xe2\x01\xab\x38\x03\x7a\x66\x10\x3b\xba\xc6\x19\xb1\x36\x4d\x23\x00\x82\x9d\x4f\x06\xaa\xbd\x12\xfc\xd3\x28\xb1\x34\xc9\x52\x18\x9d\x13\x36\x19\xc4\xff\xcf\x59\x67\xea\x02\xc8\x6a\xc3\x33\x23\x06\x0d\x4c\x92\x65\x9c\x75\x3b\x83\x9b\xa4\xc1\x4d\x78\x95\x2c\xe3\x34\xa5\x9b\x28\xcf\x9c\x3c\x59\xcc\x87\xc4\xf2\x31\x71\x6f\xc9\x22\x4d\xc1\x19\xc9\xb8\x34\xb2\x0e\x04\x06\xc6\x3b\x3d\x39\x5d\x13\xa7\x40\x4f\x3a\x44\x66\xc3\xc3\xee\x14\xc7\xd3\xf4\x68\x7d\x09\x83\x4e\xd9\x74\xed\x98\xb4\xaf\x30\x14\xea\xbc\x97\x54\xb4\x42\x84\x73\x8b\xcc\xbc\x6e\xbd\x47\x40\xb7\xdf\x67\xa4\x4b\xba\x46\x54\xa8\x97\xc8\xa0\xd7\xb6\xaa\xe4\x2a\xe1\xc3\x62\x2b\x0b\xd3\xfc\x81\x75\x06\x71\x9c\xba\x9f\x08\x80\x40\xdd\xef\x04\x16\x3c\x38\xde\x35\xb5\xc1\xda\xb0\x25\xc1\xc2\x5e\x6e\x32\xc9\x49\xe2\x48\xaa\x13\xd9\x7d\xae\x07\xbe\xcf\x61\xf1\xe3\x49\x88\x86\xfd\xe1\x5c\x38\xbf\x9f\x93\x4c\x99\xa3\x1e\xae\xd1\xbc\xaa\x12\x9d\xb7\xe7\xe6\x66\x3f\xfb\x88\xfa\xd9\xbe\x82\xbc\xae\x1d\x1a\x4d\xf2\x64\x02\xa5\xae\xb1\xb9\xa2\x41\x2f\x29\x89\xa6\x2d\x85\x28\x2f\x03\x67\x2b\x5c\x99\x61\x0a\x7a\x0e\x0f\x4b\x9a\xcf\xd9\xb1\x13\x53\x2e\x52\x09\x4b\x53\x78\x32\xc1\x51\x1a\x53\x0c\x34\x35\x18\xc6\xb8\xa7\xa1\xe7\x8d\xab\x29\x23\x31\x01\x51\x97\x70\xc0\x52\xf8\xdf\x1c\xe2\xdf\x7e\x8f\x0f\x85\xed\x60\x77\xef\x1a\x52\xd1\x6b\xec\x6e\xc1\x77\x78\x7a\xfa\x7e\xbd\x12\xcf\x8d\x92\xe6\x9c\x76\xaf\x51\xe5\x6d\xe3\xe7\x97\xb6\xf7\xef\x95\x9d\xdd\xae\x02\x77\xb8\x63\x85\x1d\xaa\xf5\xc4\x75\x29\xa2\x02\x0f\x00\xf3\xf6\x66\x2e\xd2\xfe\x72\xb7\xdc\x19\x42\x3b\x41\xc2\xcc\xe1\xea\xfe\xf6\xf6\xfa\xd3\x5d\x71\xf7\xe1\xe6\xfa\xd7\xbb\xcb\x9b\xcf\x41\x5c\x0a\x0a\x4b\xeb\x21\x45\x87\x57\x64\x79\x82\xdd\xcb\x0a\x85\xba\x73\xdc\x2c\xb1\x42\x83\xd0\x51\xd4\xd9\x5f\x10\x5d\x82\xbb\x38\x10\x53\xd5\x3c\xd0\xcd\x2e\x90\xf2\xb1\x30\x17\xbe\x55\x25\x81\x66\xea\xb8\x85\x2c\xc3\x08\x2e\x32\x58\x9c\x12\x82\xf7\x68\x2e\xab\xb0\x77\xd8\x7e\xa5\xf3\x5f\x31\x33\x20\xe6\x35\x3f\xa3\xd1\xa2\x55\x95\x5f\xed\x66\x35\x5a\xa7\x2f\xde\xb1\x79\x0b\x39\x6d\x32\xe6\xed\xd6\xc8\x84\x37\xda\x6c\xbc\x24\x77\x19\x0e\x36\xaf\xb4\xb5\xa2\x44\x25\x9f\xb1\x2c\x3a\x3d\xd3\x95\xe9\xd8\x6c\xe3\x2c\xa4\x74\xcf\x20\x3e\x56\xa2\xbc\x2b\x1d\x2f\x85\x86\x90\x97\xd6\xe6\x83\xea\x6e\xf3\x41\x31\xf7\x25\x9f\xe8\x0f\xd6\x46\x54\x76\xf8\xc3\x95\x1d\xcb\x65\xc4\x12\xf4\x85\x2c\xfd\x7e\x8f\x70\x96\x0a\x94\x35\x79\x9f\x73\x14\xd0\x68\xbc\x8c\x59\x93\x33\x73\xda\xcc\xd8\x47\xca\x91\xb5\x1b\x5b\x58\xc7\xa9\x64\xba\x5c\x19\x54\xc1\x74\x4f\xfe\x5e\x04\xd0\x8f\xc5\xe4\xac\x01\x6f\x22\x2e\x1c\x99\x57\xed\x63\x30\xdd\xaa\xe6\xa5\xa8\xed\xe6\x01\x55\x92\x42\xf3\x8c\x0a\xda\xdb\x1a\x55\xa2\xea\x7c\x26\x2d\xaa\x79\xc9\x5a\x37\xc2\xdc\x18\xcf\x8e\x43\xf9\x31\x95\x21\x93\x39\x72\x20\x4b\x86\x79\x32\x95\x29\x61\xae\x1c\xca\x96\x49\x06\x0f\x38\xfc\x7a\x5e\x19\x70\x7a\x30\xae\xf0\x5c\xcd\x62\x21\xcb\xfd\x38\x3d\xc1\x73\x80\x51\x66\x92\x0b\xd6\x06\x2d\xfa\x8c\x5c\x98\xe8\xeb\xc3\x84\x50\xcd\x0b\xfc\xec\xbb\xb7\x7b\xff\xe9\x6b\xd3\x82\xfe\xf1\xf6\x86\xca\xff\xea\xec\xd7\xd5\xd9\xb7\x17\xfc\x77\xca\xcd\xd3\x4b\x61\x12\x2f\xdc\x89\xc0\xc5\xdb\x7f\x65\x35\xe6\x09\xb2\xbd\xf2\x5b\xea\xf3\xbb\x1d\x4d\x17\xdf\x89\x8a\x1d\xeb\x46\x09\xd7\xea\x4c\x7b\x4c\x9d\x88\xe6\xdf\xde\xe4\xff\x21\xdc\x19\x10\x87\xc4\x64\x9d\x2c\xd2\x21\x6b\xfe\x0a\x00\x00\xff\xff\x40\xbc\xb4\xce\xd6\x15\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xec\x3c\x6d\x73\xdb\xb8\xd1\xdf\xf9\x2b\xf6\x99\x79\x9e\x91\xf4\x1c\xc3\x89\xaf\x73\xed\x0c\x2f\x3a\x4f\x9a\xa4\x6d\x3a\xb9\x6b\xc6\x71\xda\x8f\x1c\x98\x84\x2d\xd8\x14\xa9\x03\x40\x3b\xfe\xd6\x5f\xd3\x1f\xd6\x5f\xd2\xc1\xe2\x1d\xa4\x24\x3a\x97\xbb\x99\xbb\xca\x1f\x2c\x12\x58\x60\x5f\xb1\x0b\x2c\x56\x7a\xf6\x0c\xc4\x70\xcd\xcb\x86\x91\x96\xd6\x12\xc4\x8f\x2d\x93\xf4\x77\x59\x66\x3b\xb6\x64\x57\xfd\x38\x50\xfe\x08\x97\xe4\xe6\x7b\xd2\x91\x1b\xca\x8b\x57\x9c\x12\x49\x33\xd6\x09\xca\x25\xf4\x1c\xd8\x4d\xd7\x73\x0a\xac\x93\x3d\x48\x72\x23\x60\xc9\x9a\x1c\x3a\xb2\xa5\x39\xd4\x08\xdc\x54\x44\xe6\x30\xec\x1a\xf3\xbc\xca\xee\x49\x3b\x50\x01\xcb\x52\x81\x96\x06\xb6\x27\x2d\x15\x35\x5d\x96\xe1\xa8\x57\x1f\x2f\x2e\xde\xfc\x70\x59\x5d\xbe\xfd\xfe\xcd\x87\xcb\x97\xdf\xbf\x5f\xe5\x50\x86\x53\x1d\xa6\xf6\xcf\x54\xfe\xf1\xf1\xed\xeb\x4c\x50\xc5\x62\x06\xc0\x9a\x3c\x03\x4d\x5d\x06\x21\x7d\x19\x04\x14\x66\xd7\xbc\xdf\x22\x37\xd9\xc3\x86\x2a\xee\x1a\x58\xc3\xf9\x1c\x64\x3f\x90\x2d\xfd\xc9\xe8\xd4\x80\x7d\x08\x3f\x5e\xbc\x9b\xa5\x8b\x81\xb7\x22\x03\xad\x8d\x81\xb7\x39\x48\x26\xdb\xa3\x3a\xc9\xc0\x6a\x05\xc7\x94\x76\xd0\x17\x53\x4e\x40\x3e\xca\xeb\xe3\xc5\x3b\x23\x2e\x40\x79\x01\x11\x46\x6a\x03\x6f\xd5\x8b\xa2\x23\x03\x4d\xbd\x7a\xd7\x14\x65\xe0\x69\xaa\xfb\x4e\xd2\x4e\x56\xf2\x71\x47\x73\x58\x2c\x56\x0a\x2c\x6a\x0c\xa1\x1b\x2a\x6a\xce\x76\x92\xf5\x9d\x03\x0e\xdb\x42\x58\xb6\x25\x37\xb4\x42\x49\x18\x48\xdf\x12\xc2\x09\x26\x69\xa5\xcd\xd8\xc0\xf9\x96\x10\x8e\x0c\x72\xd3\x73\x07\x64\x5e\x33\x80\xdd\x70\xd5\x32\xb1\x41\xb1\xa9\x9e\xf0\x3d\xe6\x95\x74\x7d\xc7\x6a\xd2\x46\x54\xc5\xad\x21\x7c\x4b\xba\x9b\x81\xdc\x78\xc2\x5c\x43\x08\x75\x4d\xee\x59\xdd\x77\xd1\x9c\x61\x5b\x06\x20\x24\x91\x83\xa8\xea\xbe\x41\x2d\x04\xaf\xaa\xf7\x9a\xb0\x76\xe0\x54\xe8\x81\xfa\x59\xb5\x37\x94\x34\x5a\xc0\x04\x75\x5a\x6f\x68\x7d\xe7\xb8\xf4\x6f\xaa\x8f\xf0\x7a\xc3\xee\x5d\x67\xf0\x1a\xaf\x1d\x1c\xb9\x67\x25\xa9\xbe\x74\x5d\xe1\x42\xd0\xeb\x4a\x99\xd4\xf1\x65\x35\xe1\x34\x4e\x56\x79\xb2\xca\x9f\xcd\x2a\x59\x33\xc3\x28\x3f\xe2\xf8\x4c\x4f\x63\x9d\xbb\xa0\x32\x03\xb0\x66\xb8\xb6\xee\x1a\xdb\x42\x53\x53\x5d\xa9\xe9\x41\x68\x5f\x0a\x20\x31\x37\xf0\x56\xa5\x7a\x23\x13\x03\x6f\x49\xaa\x2f\x32\x2b\x30\x06\xa4\x3a\xbc\x29\x25\xc6\xb4\x86\x32\x35\x26\x88\xed\x05\x49\x4e\x0d\x08\x9c\xa1\xa8\xee\xd0\x68\x20\xb4\x0c\xd5\x99\x18\x4a\xac\x48\x45\x5a\xac\xc8\x48\x5d\xeb\x71\x64\x0b\x55\x55\xb2\x66\x4a\x59\x7f\xa2\xb2\xde\xfc\xb5\xbf\xb2\x1a\x7b\xd3\xfd\x38\xd0\x61\x5f\x78\xbe\x56\xd0\xd5\x6d\x7f\x15\x06\xe9\x4a\x7d\xf2\xa1\xdb\x17\x90\xb1\xbf\xb4\x00\x33\x68\xe8\xe8\x27\xe9\xfd\xd8\x6d\x11\x7a\xb2\xdb\x42\xcf\x68\xdc\x59\xa5\x5b\x87\x22\xf6\x70\xb7\x05\x91\x92\x6e\x77\x12\x57\x91\x7d\xd6\x3d\x9a\x10\xd5\xae\x9f\xa2\xd5\x7b\x5b\xb4\x44\xc8\x8a\x72\x1e\xf8\x96\xa0\x09\x67\xd8\xb7\x7e\xf4\x1a\xf1\x32\x82\xdb\xec\xb6\x67\x1d\xda\x3d\x0c\xd0\x77\x30\x14\xa8\x0b\xcb\x84\xd1\x8f\xa3\xe9\x85\x5a\x51\x3d\x6f\x28\x87\xab\x47\xd7\x9c\xb5\x6c\xcb\x24\x9c\xcd\x11\x5d\xdd\x12\xb6\xb5\xeb\x2d\xa0\x44\x50\x69\xd8\x55\xab\x16\xc2\x25\x0c\xa4\x6b\x20\x24\x60\x06\x9a\x57\xfd\x76\xd7\x52\x49\xb3\x86\xaa\x0f\x48\x19\x3f\xe6\x22\xd2\xf9\x2e\xa8\xe4\x8f\x63\xb2\x03\x67\xe1\xf4\xa9\x56\x41\xa0\x4f\xf0\x6c\x95\x5e\x9f\x10\xe8\x4c\x2f\x3a\xfb\x36\x63\x49\x7c\xbc\x78\xf7\x4a\x79\x53\x4b\xdc\xeb\x21\xd8\x1b\x0f\x91\x35\x86\x76\xe7\x3d\x24\x0c\x06\xcb\x72\x28\x02\x2f\xcd\x04\x74\x43\xdb\x42\xcf\x21\x6a\x57\x42\x5f\x65\x80\x7a\xa0\x9f\x98\x90\x02\x96\x1a\x1f\x9c\x69\xc9\x0e\x82\xf2\x4a\xcf\x3c\x18\xd9\x0e\x83\x5d\x08\x6b\xa4\x69\xe5\xed\x66\x84\xb4\x97\x88\x38\x8f\x7a\x8c\x55\x9d\xcf\x91\x40\x1d\x6d\xd9\xed\x46\xbd\xc2\xc9\x46\x9e\xa0\xbf\xcb\xa3\x90\x06\xd7\xac\xb3\x31\x96\xd3\x86\x71\x5a\x4b\xa1\x1e\xc5\xae\xef\x04\xad\x24\xdb\xd2\x6a\x2b\x72\x30\x8b\xce\x93\x78\xc0\x9b\x28\x24\x65\x84\xa5\x0c\xd0\x94\x01\x9e\x72\x8c\xa8\x34\x98\xca\x10\xd5\x0c\x31\x68\xfb\x54\x7b\xff\xe9\x80\x16\xd0\x83\x01\x26\x8e\xeb\x41\x64\x5f\x43\x4d\x04\x55\x9a\xec\x14\x2b\x20\xd5\xc3\x73\xa0\xad\xa0\x1e\xe8\x2b\x38\x03\xda\x35\x36\xea\x91\x66\x7a\xd8\x35\x51\xa3\xc6\x43\xbf\xc3\xe0\x48\x9a\x8a\x5c\x4b\xca\xfd\x4c\x9e\x67\x0c\x56\xee\x2d\x5a\x18\xc6\x3d\xcd\x90\xc9\x8e\x0f\x5d\xec\x06\x02\xcb\x70\xfb\xd7\x2a\x9a\x56\x9b\x3a\x6b\xd0\x32\x59\x07\x4b\x2d\x3d\x6d\xf2\xac\x19\x4d\xa3\x7a\xf7\x4e\x05\xe0\x0c\x3f\x60\x4d\x6d\x0b\xb0\x53\x5b\x79\x79\x47\xe9\x2e\x03\x98\xa5\x66\x7b\xc2\x3b\xb0\x99\x1e\x05\xa0\xfe\x4e\xbd\xf7\x77\xc7\xb7\x77\x7e\xa3\xe8\xed\xd5\x6e\x13\x5d\x4b\x06\x7e\xa9\xa8\x1e\xf7\xa2\x7b\x62\x83\xd6\x00\x71\x5b\x84\x29\x8e\x66\x2e\x90\xed\xdb\x40\x66\x47\xd4\x18\x84\xa8\x54\xe2\x87\x7c\x8a\xa0\x7c\x3a\x05\xa0\xfd\x89\xa0\xdc\xb9\x12\xba\x25\xac\xcd\x61\x47\x84\x78\xe8\x79\x53\x6d\x88\xd8\xcc\xcf\x01\x98\xd1\x65\x3a\xfc\xcb\x65\x03\x02\x56\xf4\x0e\xf7\x3d\xeb\x3a\xda\xbc\x22\x92\xde\xf4\x9c\x51\xe1\x1c\x84\xe1\x4a\x50\x09\x3b\x84\xa9\x6a\x07\x04\x6b\xb4\x7c\x67\x63\x00\xb7\xa2\xef\xaa\x1b\xde\x0f\xbb\x8a\x70\x4e\x1e\xf5\xc2\x30\xed\xfd\xd5\x2d\xad\xe5\x72\xd1\x92\x2b\xda\x2e\x72\xc0\xcf\x1c\x16\x2a\x03\xb3\xc8\x31\x11\xb3\x52\x61\x04\xb5\x17\x2e\xa9\x70\x12\xfa\x49\x72\x52\xcb\x65\x4d\xa4\x28\x50\x70\x39\x2c\xfe\xb7\xd0\x73\x9a\xcd\x8e\x9a\x36\x1c\x33\x41\x50\x42\x12\x6b\x16\xb9\xeb\x49\x30\x31\x49\xb7\x21\x2a\xd6\x2c\x56\x2b\xc4\xa4\x28\xd6\x7e\x51\x51\xac\x07\x91\x7a\xb3\x54\x4f\xcb\xf3\xd5\x0a\x14\x91\x5a\x2e\x6a\x17\xe5\x01\x12\xe2\xd5\x3c\x05\xa2\x59\xac\x00\x3f\x71\x10\x92\x8d\x46\xaa\xc0\xef\xe8\x63\xe2\x2c\x4c\xeb\x6a\x75\xfc\x40\x33\xd2\xf7\xcb\xf7\x6f\x2f\xfb\x3b\xda\x4d\xe8\x19\xb1\x90\x1d\xab\xa4\x02\x50\x53\x3e\x75\xa7\x7e\x94\x06\x74\x50\x6f\x94\x95\x07\xfb\x12\x45\x41\xbc\x37\xc1\x16\x5c\x0c\xaa\x11\x1f\x7c\x7b\xb4\x38\x54\x7f\xd4\x10\xb9\x0f\x24\x5f\x6d\x20\xd8\xf5\x52\x0f\x76\xec\xa1\x53\x51\xff\x94\x6f\x55\xb3\xf8\x9e\x80\x82\x2b\xda\x54\xbb\x4d\x2f\x7b\xa1\x09\xf1\xef\x29\xd4\x3d\x6b\x68\x08\xa5\xdf\x3d\x14\x69\xb6\xac\x43\x3c\xea\xc1\xb7\x37\x4c\x90\xab\x96\xea\xf3\xb1\x79\x0e\x78\xa5\xbc\xda\xa9\xe3\x97\x62\xd3\x3c\xfb\x5e\xd9\xcb\x5d\x25\x68\xcd\xa9\x84\xff\x59\xc3\x62\xa1\xc0\xb0\x91\x76\xc9\x44\x07\x0f\xce\x08\x71\xe4\xf8\x8c\x66\x62\xdc\x69\xa0\x9e\x35\x9c\x7f\x3b\x4b\xe9\x6f\x1b\xda\x49\x26\x1f\x4f\x7a\xff\x15\xea\x5d\x9f\x05\x05\xe5\x15\xd3\x6a\x64\x54\xc0\xc0\xf0\x5c\xc8\x0a\xd3\x01\x6b\xa7\x52\x6b\x28\xac\x60\x42\x0c\x94\xbb\xc3\xda\xc0\x0a\x31\xa0\xeb\x9d\x6f\x39\xce\x63\x9d\x2c\xe7\xd7\xed\x31\xa2\xd0\xa2\xcd\x21\x69\x47\x92\x66\xfa\x93\xd7\x9f\x6f\x0f\x27\x2d\x1d\xd0\x92\x0e\xe4\x73\x94\xf0\xb2\x6d\x4f\x3a\xf8\x62\x3a\xf0\x49\x90\x64\xd2\xdc\xb4\xf0\xfe\x81\x35\xdf\xce\xdb\xe5\xed\xdb\xdd\xd9\xa0\x5d\x3a\x6d\x40\x24\x79\xdd\x15\x6b\x02\x22\xa9\x7b\x08\xaf\x05\x70\xf2\x53\xbd\xa1\x2c\xbf\x40\x9a\x77\xe2\xc8\x62\xbc\xf7\x3e\x26\x63\x77\xff\x73\x6c\x63\xcd\x56\xba\xae\xa9\x10\xfb\xa8\xd0\x46\xe9\xb0\x3b\x5b\xfc\x39\xe8\x79\x4d\x47\x29\x4d\x24\x07\x9e\xb8\x37\xbf\xfc\xdb\xe5\xfb\x74\x49\x87\x0b\x81\x08\xd0\x4f\xc9\x3a\xc1\x24\xa5\x90\x74\xe7\x72\xce\xea\x45\x01\x45\x09\x92\xba\x1f\x3a\xb9\xfc\xff\x95\xa7\xb0\xe2\xb4\xee\xef\x29\x7f\xc4\x24\x43\x94\x2f\x19\xf7\x4e\x6d\x34\x4c\x28\x4e\xa6\x39\xe8\xda\x82\xc8\x33\x5a\xe4\x47\x97\x97\x56\x1a\xca\x69\x8f\xde\xc3\x39\x9d\xae\x13\x29\xad\xe1\xf9\x97\xb7\x49\x81\x64\x7d\x90\x74\x37\x41\xda\x98\x82\xf3\x6c\x94\x4a\x9f\xf0\x79\x5d\x93\x8e\x7c\x71\x9c\x94\xba\xa5\x84\x5f\x18\x95\xbc\x42\x8d\xa4\xa6\x99\xa8\x36\x54\xfa\x3c\x76\x49\xd3\x84\x18\x46\x19\x99\x14\xc1\xd2\xcc\x9d\x83\x7a\x47\xd7\xb0\x02\x5b\x17\x73\x9e\xab\x3c\xf6\x71\x01\x47\x18\x9f\xcc\x12\x4a\xd3\x61\x9f\xc3\xe4\xcb\xa6\xf9\x07\xbd\x7a\x39\xc8\x4d\xf7\x8a\x53\xdc\x7a\x93\x76\xcc\xea\x03\xbd\x22\x0a\xa6\xaa\x1d\x90\xcf\x6d\x5b\xb6\xf5\xc5\x34\xde\x02\xd6\xd5\x1d\x7d\xcc\x41\xb0\x9b\xae\xc2\x35\x19\x66\xa8\x26\x52\xd7\x76\x0a\x53\x39\x54\x86\x93\x94\xd1\x2c\x33\x13\x55\xab\x19\xbe\x68\x82\xf1\xe9\x9c\xa6\x11\x30\x11\x8e\x57\x53\x02\xa4\x9a\xec\x2d\xa9\x27\xd9\x5d\xac\x6b\x06\x32\x08\xe4\xa0\xef\xec\x1d\x3f\x47\x2e\x9c\x71\x4d\x0c\xc2\xf5\x86\xef\xde\x05\x4d\x6b\xe7\x89\x2b\x7b\x42\x18\xc1\x1a\xdf\x67\x00\x2e\xbb\xef\x19\x74\x1e\x29\x22\xfe\xcb\xc4\x9f\x09\x22\x47\x6b\x64\x8a\xd2\x3d\x2b\x65\x1e\xea\x9b\x29\x4b\x11\xbf\x3d\x53\x09\x5d\xa3\xcf\x04\x7a\x24\x80\xdb\xc3\x19\x0e\xc5\x25\x62\x46\x6e\x24\x38\xdb\x2f\xf5\xb9\x3d\x07\x73\x5a\x77\x7e\x24\xf6\x99\x73\xdc\xe6\x5f\x88\x48\x72\x3f\x87\xae\x0a\x03\x1a\x46\x7c\xaf\x66\xd8\xc2\x28\x9d\xed\x2f\xe3\x9f\x98\x44\x8e\xd3\xc4\x98\xa3\xcd\xe7\xa7\xba\x55\x5e\x19\x64\xa1\xbc\xe6\x42\x19\x16\xbe\xa9\x87\x15\x42\xaf\xec\xc6\x05\x33\xc9\x69\xa6\xc5\xe7\x8b\xcd\x49\x25\xcd\xbf\x9b\x04\x73\x02\x7c\x88\x43\x85\x67\xb1\x5a\xc1\xad\xd4\xa3\xd4\x3b\x48\xe8\x3b\x90\xe6\x62\x3f\x1c\x7c\x2b\x93\xb4\xf7\xc4\x36\x2a\x1b\x67\xa9\x47\x19\xea\x7d\x0a\xdb\x5b\x53\xe9\x8d\x31\x2a\xa7\xb4\x01\xc8\x5e\xa2\x9a\x2a\xc9\xae\x97\x54\xe4\xaa\x12\xa5\xe7\x4c\xd2\x1c\xee\x99\x60\x57\xac\x65\xf2\xf1\x09\x75\x97\x82\xf2\x42\x3f\xf1\x56\x3f\x98\xe9\x4b\x33\x7f\xe9\x11\x94\x11\x86\x2f\x7a\x2d\xb3\xbf\xf6\x28\x10\x87\xa0\x12\xf6\x56\x20\x21\xb9\xaa\x4d\xd3\x6d\xab\x74\x90\x74\x53\xa2\xa3\xd9\xc0\x1e\xcf\x89\xea\xf3\x6f\x4f\xd8\x98\xfa\xb5\x69\xa5\xe8\x1d\xf7\x81\xd3\x5c\xc0\x29\xee\x14\x2f\xd5\x1a\x18\x45\x0a\xa5\x6b\xb4\xd2\x00\x57\x70\x91\x77\x7c\x6e\xb3\x55\x57\x93\x4f\x59\x57\x65\x2e\x71\x96\xc1\xcc\x78\x17\xa5\xbc\x5c\x60\x25\x07\x9d\x5c\x5c\xc5\x18\x27\x44\xd2\x7a\x0b\xfd\xb6\xd0\x56\xb6\x88\x6a\x30\xb0\x71\xe0\xad\x69\x75\x55\x8e\xd8\x8e\x6f\x8b\x28\x4b\x39\x14\x93\xd5\x8e\x08\x1e\xf6\xa4\xa3\xa6\xaa\x1e\x71\x50\xd0\x91\x8e\x19\x57\x3f\x6a\x16\x6c\x73\x0a\x3f\xae\x82\x44\x78\xd7\x9c\xc2\x27\xd5\x90\x08\xac\xdb\x8c\x34\xd2\xaa\x48\x84\x08\x1b\x47\xb2\x99\xac\x8e\xd4\xc2\x09\xbb\xd2\x71\xa3\x2a\x49\x1c\x62\x5b\x53\xe8\xa9\x6a\x49\x1c\x10\x74\x18\x0e\x92\x6b\x75\x2d\x0f\xdf\x66\xa0\xc2\xea\x49\x33\x91\x6e\x30\xfd\xb6\x8a\xd2\x28\x8c\x58\x1b\x8a\x2f\xc3\x35\x9b\xae\xc9\xc0\x24\x55\x95\x5a\xc8\xbe\x4d\x43\x0d\x45\xb0\x41\x5a\x98\x15\x6d\xbb\x0e\x54\xde\x46\x39\x73\x03\xa9\x93\xe5\xd8\x63\x5a\x6c\xc4\x6b\x28\x47\xac\xe3\x79\x86\xa1\x30\x1e\xd7\x88\xd3\xf9\xb1\x28\x8b\xb0\x37\x30\x3f\xe9\xd2\xf7\x40\x70\xd6\xe1\xd9\xfe\x9f\xf0\x48\x83\xf4\x77\xbb\xa3\x40\x3a\xc8\x42\x3b\x91\x30\x99\x21\x8b\xd8\x7f\xa1\x73\x08\xf7\x00\x46\xca\xce\x5d\xeb\xd2\x5b\xe7\xae\x87\xa1\x08\xfc\x35\x11\x10\xfb\x6b\xe4\x4e\x73\x3c\x0c\x71\xea\x50\x21\x42\x2c\xf5\xc0\x85\xae\x9e\x88\x41\x74\x43\x92\x93\xcc\xc0\x87\x1d\x18\x86\x3d\xc5\x80\xae\x92\x2b\xf3\x95\x5d\x71\x48\xf0\x35\x33\x5a\x15\xa5\x12\x8d\x3d\x89\x3c\x37\xd7\xda\x60\xd5\x14\xa5\x89\x1a\x26\x24\xeb\x6a\x99\xa8\x66\xbf\x3a\xe6\x29\xe4\xa8\x4a\xf4\x9f\x22\x59\x23\xc6\x32\x1f\x43\x19\x46\x85\xf4\xd2\xbf\x74\xf5\x0b\x4a\x9f\xdf\x45\xa5\x4e\xa4\x7b\xd4\x34\x62\xc1\xd3\x99\x2e\x76\x0a\x84\x40\x3b\xb4\x02\x2b\xa3\xae\x97\x50\x5e\x71\xbc\x0b\xc1\x5a\x3b\xb5\xcc\xe3\x5e\x7d\x1e\xc1\xde\xc8\x26\xd6\xb0\xd0\x5d\x8b\x18\xde\x59\x94\x1e\x61\x5f\x57\xb1\x5a\xb0\xd2\x0a\x8f\x3c\xa6\xd4\xcf\xaa\x26\xb2\x15\x78\x11\x40\x3a\xe5\xc5\x20\xeb\x70\x32\x85\x41\xc7\x41\x37\x92\x21\x3f\x61\xcd\x5f\x34\x5c\x05\x22\x63\xb5\x61\x5d\x4e\x89\x1f\xf3\xa2\xf0\xa8\x02\xea\x14\x89\x4f\x91\xf8\x14\x89\x4f\x91\xf8\x97\x88\xc4\xbf\x4c\x6c\x3d\xb7\x9e\xf5\x49\x07\xa2\xf1\xbd\xf9\xc9\x31\x9e\x1c\xe3\xc9\x31\x9e\x1c\xe3\x6f\xcf\x31\xce\x76\x8a\x7b\xae\xce\x35\x11\x9f\x73\x31\x11\x3b\x5c\x4c\x3f\x39\x87\x2b\x23\x7f\xab\x15\x1a\x5e\x3d\xe8\x93\x4f\x70\x66\xb3\x77\x0b\xf2\x60\x91\x89\x3c\x52\x60\xa2\x0d\x20\xa8\xe2\x0b\xcc\x04\x25\x6b\xed\x01\xd6\x48\x62\x0c\xa9\x74\x81\x50\x83\xb3\x9e\xc0\x52\x26\xb5\xe0\x73\xc3\x38\x9d\xdb\xeb\x6b\x8e\xe7\x49\xee\x1d\x11\x52\x27\x42\x1b\x23\x40\xd8\x92\x4f\x4b\xbf\x14\x1d\x93\xd1\xed\xe5\x2a\x8b\x75\x98\xcd\xbe\x59\x0e\xd0\x73\xba\x6b\x49\xad\x72\x87\x2f\x9b\x66\xdf\xf7\xfd\x67\xe5\x11\x0d\xe5\xb1\xcc\x72\x38\xcf\xa6\xd7\xec\x67\x08\x3e\xd0\x9d\xdf\x16\x7c\x26\xb7\x17\x74\xdb\xdf\xd3\xfd\xb9\x58\x83\xd3\x23\x34\xa7\xc8\x80\xac\xf0\xb8\xec\xbe\x10\xb3\x7f\x41\xcd\xc9\xac\x7e\xa0\x2a\x0e\x9c\xb6\x2d\xa7\x6d\xcb\x69\xdb\x72\xda\xb6\xfc\xb2\xdb\x16\xd1\xb1\xdd\x8e\x4a\xef\xdc\x05\x3a\xa3\x1c\x9e\x9d\xe5\x50\x6e\x89\xfa\xbe\xaf\x90\x84\x4b\xf7\x46\x3b\xc5\xfc\xbf\xff\xf9\xaf\x45\x0e\x67\xbf\x47\x32\xcc\x24\x6a\xbe\x67\x57\xdb\xaf\xbf\x19\xcf\x76\x56\x3c\xcf\xe1\xec\xb9\xff\xff\xb5\xfa\xf7\x4d\xf1\x1c\xc7\x73\xd2\xdd\x25\x69\xde\x1d\x67\x9d\xbc\x5e\x2e\xfe\xaf\x38\xfb\xc3\xcd\x22\x7f\xfa\xbc\xab\xcf\xce\x0d\x43\x82\xe5\x48\xd0\x8a\x81\xa3\x08\x36\x73\x83\x17\xcf\x00\x28\x66\x28\x0d\x72\x18\xc7\xbc\x53\xe6\xf9\xb7\x90\x79\x7e\xfa\x4a\x79\xa1\xf8\x97\xcb\x60\x4a\x22\x80\x53\xd2\xae\x9c\xba\x9f\x3e\xe9\x7a\xef\xa4\xb3\xb2\xdb\x6a\xe9\x3e\x3d\xa9\xfd\x61\x43\x38\x3d\x50\x21\x21\x54\xff\x44\x79\xc4\x78\x07\x9a\x03\xfd\xb4\x63\x9c\x8a\x74\x9f\xbc\xbf\xca\xcf\xc4\x9b\x32\x9a\x4d\xc5\x1c\xd7\x63\xa7\xc6\xc6\x32\x42\xf0\x53\x0a\x00\x23\xae\x47\xc9\xaa\xf8\xcb\x05\xa2\x98\x2e\xe6\x72\x04\x88\x22\xa5\xdf\x41\xfa\xef\x3e\x07\xd0\x01\x4f\x26\x72\xa4\x30\x68\x44\x23\x87\x61\x3a\x27\x83\xb4\x0d\xd0\x93\x1e\x04\x8f\xb8\x81\xf7\x38\xe0\x07\x23\xef\x61\x7a\x23\xfe\xb4\x07\xd0\xb1\xda\x7a\x0c\xe3\x43\x82\x03\xa0\x99\x41\xda\x09\xcc\x19\x45\x0f\xf3\x5f\x6b\x72\xdb\x07\x51\xdc\x33\xfa\x20\x74\x50\xa4\x0f\x42\xb7\x79\x75\xab\x0e\xff\xa6\x7b\x0f\x1d\x53\x85\xfe\x85\x12\x35\x57\x5c\x08\xe7\x5a\x74\x7c\xd1\xd6\x0d\xf6\xb4\x21\x0e\x1c\xe6\x53\x93\x89\x2a\x30\x4e\x16\xf3\x5f\x6b\x31\x93\xd5\x92\x62\x7c\xd1\x68\xbe\x55\x83\x6f\x47\xed\xeb\xef\x8c\x3e\xd8\xa2\x2c\xe7\x81\x6d\x8d\xad\x66\x7c\x6d\x3e\xbf\x82\xb3\xa0\xcc\xd6\xd3\xff\x39\x85\xb6\x11\x0d\x13\xe9\x2a\xc3\xfc\x53\x73\x55\x6f\xd4\x97\x78\x82\xa5\x13\x9c\xaf\xe3\x9f\x5c\x7b\xdc\xe9\xd3\x89\xf9\x1d\xab\xf1\x71\x65\xc7\x7b\xf5\x5d\x20\x5e\xd9\x74\x56\xd4\xa0\x20\x36\x72\x8b\x53\xaa\x4f\xf5\xfe\xc0\x1a\x89\x5f\xc4\xc4\x07\x84\xa0\xec\x66\x83\xfa\xd5\x4f\x88\x69\x33\x6c\xaf\x3a\xc2\xf0\x48\x89\x18\xc3\x06\x05\x81\xbf\xb7\xe3\x2c\xc3\xbf\x69\xab\xc0\xaf\x29\x4d\xfc\x06\xc4\x31\x61\xbc\x1f\x64\x14\x6d\xcd\x3c\x00\x4b\xeb\x1b\x06\xfc\x7d\x47\x25\x10\x23\x85\x84\x65\xcd\xa8\xe1\xce\x72\x94\x90\x1f\x90\x1b\x05\x63\x8b\xc3\xfe\x20\x24\x62\x71\x25\x89\x09\x9e\x52\x23\x2a\x0d\xa6\xd2\xa2\x2a\x13\x5c\x65\x88\xac\xef\xa0\xee\xbb\xeb\x96\xd5\xd2\x70\xb4\x82\xa6\x37\x59\xc3\xc0\xa4\xf5\x2f\x7b\xd1\x4f\x75\x3b\x34\xb4\x29\x8c\xcc\x8d\x45\x04\x1d\xfe\x17\xce\x6c\x55\xa2\xef\xf2\xd5\x89\xb1\x95\x04\x30\x23\x6b\x31\xf6\x12\x80\x58\xbb\xb1\x96\x13\x74\x39\x0b\x72\x36\x14\x8e\x73\xb6\x94\x5a\x53\x48\x63\x6a\x55\x91\x5d\x05\x80\xbe\x35\xfb\xcf\x00\xdb\x95\x46\x61\xcc\x55\x00\x00"),
 		},
 		"/sql/sqlite3": &vfsgen۰DirInfo{
 			name:    "sqlite3",
-			modTime: time.Date(2020, 12, 11, 19, 29, 45, 870706517, time.UTC),
+			modTime: time.Date(2026, 10, 17, 7, 35, 12, 204849997, time.UTC),
 		},
 		"/sql/sqlite3/.keep": &vfsgen۰FileInfo{
 			name:    ".keep",
-			modTime: time.Date(2020, 12, 3, 8, 10, 13, 164846303, time.UTC),
+			modTime: time.Date(2020, 12, 21, 2, 24, 23, 0, time.UTC),
 			content: []byte(""),
 		},
+		"/sql/sqlite3/EmbedManager.Get.generated.sql": &vfsgen۰CompressedFileInfo{
+			name:             "EmbedManager.Get.generated.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 282,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x54\x8f\x41\x6e\x84\x30\x0c\x45\xf7\x39\x85\x0f\x50\xb8\x40\x55\x75\x51\xba\xe8\xa6\x6c\xd8\x47\x09\x36\x24\x6a\x02\x34\x31\x83\xb8\xfd\xc8\x9e\x91\x46\xac\xfe\xff\x2f\x3f\x89\xdd\x34\xf0\xb5\x22\xc1\x4c\x0b\x15\xc7\x84\xe0\x4f\xf0\x7b\x4c\x68\xeb\x7f\x6a\xdd\xf1\xf7\x0e\x5d\x0f\xbf\xfd\x00\xdf\xdd\xcf\xd0\x9a\x4a\x89\x46\x36\x00\x7b\x49\xe0\xaa\xc8\x9b\x01\xe0\x73\x23\x89\xa2\x9a\x23\xa7\x07\x10\x23\x64\x2b\xeb\x2d\x22\x15\xbb\xb8\xac\x27\x17\x20\x8d\xc0\x59\x9f\x14\x95\x7c\x44\xe4\x20\x40\x8d\x36\x28\xce\x81\xb5\xa3\x4e\x7f\x0a\x7b\xf6\x8b\x8b\xc9\x3e\x27\xba\x00\x69\x4c\xc4\x63\x20\xb4\x4e\x6f\xbe\x92\x99\xca\x9a\x81\xb2\x27\xac\xe6\x08\x54\x48\xb6\xb1\x11\xe1\x03\x3e\xcd\x7d\x00\xda\x77\xb2\x94\x1a\x01\x00\x00"),
+		},
+		"/sql/sqlite3/EmbedManager.Put.generated.sql": &vfsgen۰CompressedFileInfo{
+			name:             "EmbedManager.Put.generated.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 592,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x5c\x90\xb1\x72\xf2\x30\x10\x84\x7b\x3f\xc5\x96\x3f\x33\x82\x07\xd0\x3f\xa9\x42\x8a\x34\xa1\xa1\xf7\xc8\xbe\x03\x6b\x22\xcb\xc4\x3e\x41\x78\xfb\x8c\x4f\x66\x90\x69\xb5\x7b\xfa\x76\x77\xbb\xc5\xfb\x40\x8c\x33\x47\x1e\x9d\x30\xa1\xb9\xa3\x49\x3e\x50\x3d\xfd\x84\x9d\xbb\x7d\xff\xc7\xfe\x80\xaf\xc3\x11\x1f\xfb\xcf\xe3\xae\xf2\x71\xe2\x51\xe0\xa3\x0c\xe0\xbe\x61\x9a\x2a\xe0\x5f\x1a\x43\xed\xc9\x20\x8d\xc1\x40\xee\x17\x36\x10\x2f\x81\x0d\x2e\xe3\x70\xf5\xc4\x63\x1d\x5d\xcf\x06\x9d\xf4\xc1\xe0\xe6\x49\x3a\x83\x8e\xfd\xb9\x13\x03\xe9\x52\xdf\x44\xe7\x43\xad\xf7\x27\x96\xb6\x63\xaa\x9d\x6c\xaa\xab\x0b\x89\x15\x61\x1f\x0c\xab\x26\x9b\x29\x76\xc1\xd8\x17\x8e\xcd\x20\xbb\x90\xec\x03\x65\x5f\x58\xb6\x84\x0d\x11\xed\x10\x4f\xc1\xb7\xb2\x34\xda\x80\x06\xa4\x0b\x39\xe1\x0a\x98\x58\x2a\x00\x73\x4b\xbc\x81\x7f\xdb\x90\x88\x69\x37\x7f\xa4\xef\x73\xa4\x52\xd0\x88\x59\x99\x53\xae\x24\x8d\xad\xda\x2a\x79\xe9\x59\x57\x52\xef\xdc\xaa\xb4\x68\x4b\x55\xb4\x68\x29\xe5\xe6\xf9\x4a\xcb\xaf\xee\xf2\x1c\x39\x5b\xb9\xc8\x2a\xe3\x6a\x2a\xf5\x3e\xd7\x2a\x8d\xcf\xd7\xea\x6f\x00\x48\xc2\x01\x7a\x50\x02\x00\x00"),
+		},
+		"/sql/sqlite3/FetchJobManager.Complete.generated.sql": &vfsgen۰FileInfo{
+			name:    "FetchJobManager.Complete.generated.sql",
+			modTime: time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			content: []byte("\x2d\x2d\x20\x43\x6f\x64\x65\x20\x67\x65\x6e\x65\x72\x61\x74\x65\x64\x20\x62\x79\x20\x62\x75\x69\x6c\x64\x5f\x73\x71\x6c\x2e\x61\x77\x6b\x3b\x20\x44\x4f\x20\x4e\x4f\x54\x20\x45\x44\x49\x54\x2e\x0a\x64\x65\x6c\x65\x74\x65\x20\x66\x72\x6f\x6d\x20\x66\x65\x74\x63\x68\x5f\x6a\x6f\x62\x73\x20\x77\x68\x65\x72\x65\x20\x69\x64\x20\x3d\x20\x3f\x0a"),
+		},
+		"/sql/sqlite3/FetchJobManager.Enqueue.generated.sql": &vfsgen۰CompressedFileInfo{
+			name:             "FetchJobManager.Enqueue.generated.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 138,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x34\xca\x31\x0e\xc2\x30\x10\x44\xd1\xde\xa7\x98\x12\x24\x92\x03\x98\x92\x50\xd0\x90\x26\xbd\x65\xe3\x25\x18\x2c\xaf\x58\xaf\x41\xdc\x1e\x25\x52\xaa\x91\xe6\xfd\xae\xc3\x89\x23\x61\xa6\x42\xe2\x95\x22\xc2\x0f\xa1\xa5\x1c\x5d\x7d\xe7\xde\x7f\x5f\x47\x0c\x23\xae\xe3\x84\xf3\x70\x99\x7a\x93\x4a\x25\x51\xb0\x20\xcd\x85\x85\x90\x8a\x32\xee\xa4\xb7\x87\x7b\x72\xa8\x06\xd8\xa5\x78\x40\x93\xec\x96\x95\x56\x9c\xd7\xbd\xf9\xf8\xdc\x68\x55\xbb\xdc\x76\x73\xbb\x05\xff\x01\x00\xfa\x45\xb0\x8c\x8a\x00\x00\x00"),
+		},
+		"/sql/sqlite3/FetchJobManager.Retry.generated.sql": &vfsgen۰CompressedFileInfo{
+			name:             "FetchJobManager.Retry.generated.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 165,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x44\xc9\x31\x12\x82\x30\x10\x85\xe1\x3e\xa7\x78\x07\x10\x0e\xa0\x63\x25\x16\x36\xd2\xd0\x67\x16\x77\x15\x34\x12\xdc\x6c\x86\xf1\xf6\x4e\x64\x1c\xbb\xf7\xbe\xbf\xaa\x70\x88\x2c\xb8\xc9\x24\x4a\x26\x8c\xfe\x8d\x3e\x8f\x81\x7d\x7a\x85\x9a\x96\xc7\x0e\x4d\x8b\x73\xdb\xe1\xd8\x9c\xba\xda\xe5\x99\xc9\x04\x57\xb1\xcb\xe0\xef\xb1\x4f\x0e\x48\x62\x0e\x00\xc8\x4c\x9e\xb3\x25\xec\xb1\xfd\xed\xcd\xb7\x68\x9e\x3c\x59\xf1\x75\xad\x1a\x28\x99\x17\xd5\xa8\xa5\xfc\x9f\x5b\x06\x51\xc1\xc8\x85\x47\x76\x9f\x01\x00\xc9\x86\xb5\x85\xa5\x00\x00\x00"),
+		},
+		"/sql/sqlite3/FetchJobManager.claim.generated.sql": &vfsgen۰FileInfo{
+			name:    "FetchJobManager.claim.generated.sql",
+			modTime: time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			content: []byte("\x2d\x2d\x20\x43\x6f\x64\x65\x20\x67\x65\x6e\x65\x72\x61\x74\x65\x64\x20\x62\x79\x20\x62\x75\x69\x6c\x64\x5f\x73\x71\x6c\x2e\x61\x77\x6b\x3b\x20\x44\x4f\x20\x4e\x4f\x54\x20\x45\x44\x49\x54\x2e\x0a\x75\x70\x64\x61\x74\x65\x20\x66\x65\x74\x63\x68\x5f\x6a\x6f\x62\x73\x20\x73\x65\x74\x20\x72\x75\x6e\x5f\x61\x74\x20\x3d\x20\x3f\x20\x77\x68\x65\x72\x65\x20\x69\x64\x20\x3d\x20\x3f\x20\x61\x6e\x64\x20\x72\x75\x6e\x5f\x61\x74\x20\x3c\x3d\x20\x3f\x0a"),
+		},
+		"/sql/sqlite3/FetchJobManager.next.generated.sql": &vfsgen۰CompressedFileInfo{
+			name:             "FetchJobManager.next.generated.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 324,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x4c\xd0\xb1\x4e\x03\x31\x0c\x06\xe0\x3d\x4f\xf1\x6f\x05\xa9\x3d\x89\x19\x2a\x06\xca\xc0\x42\x97\xee\x51\x2e\x71\x69\x42\xee\x02\x8e\xa3\x8a\xb7\x47\x6e\x5a\x8e\xcd\xfe\x62\x59\xfe\xb3\xd9\xe0\xa5\x04\xc2\x07\xcd\xc4\x4e\x28\x60\xfc\xc1\xd8\x62\x0e\xb6\x7e\xe7\xc1\x9d\x3f\x1f\xb1\xdb\xe3\x7d\x7f\xc0\xeb\xee\xed\x30\x98\x4a\x99\xbc\x18\x20\x0d\x31\xc0\x55\xc4\xb0\xbe\x74\x8d\xb3\xed\xd2\x2b\xd5\xa6\x7a\xa5\x3e\xe5\x44\x68\xfa\x92\xaa\x78\xab\xfb\x0b\xb7\xd9\x3a\x51\xef\x95\xaa\x2f\x2e\x53\xf5\x74\x97\x86\xec\xaa\x58\x62\x2e\xbc\xc6\x6a\x75\xaf\x73\xff\xe8\xb2\xc1\x33\x69\x82\xeb\x96\xa5\x33\x47\x2e\x13\x8e\x24\xfe\x64\x53\x19\x2b\x92\x49\x25\xce\x7a\x54\x45\x43\x99\xd1\x34\xcb\xf6\x2f\x84\x39\x9f\x88\x69\xb9\xe9\x69\x8b\x67\x53\x38\x10\xeb\xef\xdc\xd8\xe4\x38\x45\xc1\x83\xf9\x1d\x00\x1b\xc5\x50\x60\x44\x01\x00\x00"),
+		},
+		"/sql/sqlite3/ShareManager.Create.generated.sql": &vfsgen۰CompressedFileInfo{
+			name:             "ShareManager.Create.generated.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 256,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x54\x8d\x3b\x6e\x85\x30\x10\x45\x7b\xaf\x62\xba\x87\x25\x60\x01\x4e\x15\x01\x05\x05\x10\x11\xa7\xb6\x06\x3c\x21\x56\x2c\x93\xf8\x93\xcf\xee\x23\x88\x1e\x88\x6a\x3e\xf7\xea\x9c\xa2\x80\x6a\xd5\x04\x0b\x39\xf2\x18\x49\xc3\xf4\x0b\x53\x32\x56\xab\xf0\x69\x4b\xfc\x7e\x7f\x80\x7a\x80\x7e\x90\xd0\xd4\xad\x2c\x99\x71\x81\x7c\x04\xe3\xe2\x0a\xe1\x0d\x3d\x05\x06\x90\x19\x9d\x43\x0a\xe4\xd5\xb1\x24\x6f\xf7\x23\xe2\xb2\x4f\xfa\xf9\x30\x9e\x82\xc2\x98\xc3\xec\x69\x53\x29\x8c\x9c\x7d\xa1\x4d\xff\x0c\xb1\xd5\xc4\x41\x71\xc9\x5a\xf3\x9a\x89\x0b\xed\x76\xe3\x67\x72\x47\xef\x4f\x71\x11\xac\x68\x29\xcc\x94\x89\x53\x95\x43\xf5\x32\x8e\x4d\x2f\x95\x6c\xbb\xe6\x59\x3e\x76\x4f\x9c\xb3\xbf\x01\x00\x71\x9d\x1b\xef\x00\x01\x00\x00"),
+		},
+		"/sql/sqlite3/ShareManager.Delete.generated.sql": &vfsgen۰FileInfo{
+			name:    "ShareManager.Delete.generated.sql",
+			modTime: time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			content: []byte("\x2d\x2d\x20\x43\x6f\x64\x65\x20\x67\x65\x6e\x65\x72\x61\x74\x65\x64\x20\x62\x79\x20\x62\x75\x69\x6c\x64\x5f\x73\x71\x6c\x2e\x61\x77\x6b\x3b\x20\x44\x4f\x20\x4e\x4f\x54\x20\x45\x44\x49\x54\x2e\x0a\x64\x65\x6c\x65\x74\x65\x20\x66\x72\x6f\x6d\x20\x73\x68\x61\x72\x65\x73\x20\x77\x68\x65\x72\x65\x20\x75\x73\x65\x72\x5f\x69\x64\x20\x3d\x20\x3f\x20\x61\x6e\x64\x20\x69\x64\x20\x3d\x20\x3f\x0a"),
+		},
+		"/sql/sqlite3/ShareManager.GetAll.generated.sql": &vfsgen۰CompressedFileInfo{
+			name:             "ShareManager.GetAll.generated.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 618,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x54\x52\x3d\x73\xe3\x20\x10\xed\xf9\x15\xaf\x93\x3d\x23\xf3\x07\x6e\x3c\x57\x9c\xaf\xb8\xe6\xdc\xb8\xd7\x60\xb1\xb6\x49\xb0\x48\x58\x88\x92\x7f\x9f\x01\x36\x96\xd5\x68\x78\x1f\x2c\xef\x09\x76\x3b\xfc\x09\x96\x70\xa5\x89\xa2\x49\x64\x71\xfe\xc2\x39\x3b\x6f\x07\x7e\xf7\xda\xcc\xaf\xbf\x70\x38\xe2\xff\xf1\x84\xbf\x87\x7f\x27\xad\x98\x3c\x8d\x49\x01\xac\x9d\x85\x61\x38\xdb\x57\x94\x99\xe2\xd0\x28\x59\x16\x7e\x0c\xc6\x13\x8f\xb4\x11\x43\x8e\xbe\x28\xe8\xba\xed\xc3\x29\xdc\xda\x9d\xcc\xf5\xd9\x28\xf0\xd9\xa3\x00\xa0\x7d\x81\x16\x6b\x11\xa7\xec\xbd\xbb\x6c\x72\xd6\xc9\x25\x4f\x75\x4e\x0f\x41\x5b\xd9\x74\x89\xe1\xfe\x88\xc0\xc8\x59\xf8\x97\xe0\x26\x34\x0a\x61\x42\x2e\x4d\xf7\xc8\x59\xb7\xa4\xe2\x9a\x6f\x14\x09\x59\xd4\x55\xbf\xea\xd8\xf6\x2d\xa1\x44\x4b\x7a\x32\x77\x6a\x67\x26\x73\x65\x24\x99\x90\x7e\x06\xb4\x8e\xb2\xad\xeb\x14\xd0\xaa\xd7\x02\xf5\x1f\x7f\x38\x9a\xb9\x70\x75\xd1\x38\xfa\x7c\x73\x91\x78\x30\xa9\x08\x0b\x6a\xea\x18\xa9\xdc\xaa\xa8\x0b\x6a\xaa\x37\x9c\x86\x32\xeb\xe1\x58\x33\xaa\xa6\xe5\x9b\x89\xc4\x60\xd5\xf2\x2e\x57\xbd\xc7\x6f\x15\xa2\xa5\x58\x1e\xcd\xea\x2c\x4b\x3c\xf6\x60\x1d\xc3\xec\x6c\x45\xea\x7b\x00\xa6\x10\x1c\x49\x6a\x02\x00\x00"),
+		},
+		"/sql/sqlite3/ShareManager.GetByID.generated.sql": &vfsgen۰CompressedFileInfo{
+			name:             "ShareManager.GetByID.generated.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 572,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x54\x51\xbb\x52\xc3\x30\x10\xec\xf5\x15\xdb\x39\x99\x49\xfc\x03\x0c\x43\x41\x28\x68\x48\x93\xde\xa3\xd8\x97\x44\xa0\xd8\xa0\xd3\x11\xf8\x7b\x46\xba\x23\x89\x1b\x8f\xf6\xa1\xf5\xae\xbd\x5e\xe3\x79\x1a\x08\x47\x1a\x29\xf9\x4c\x03\xf6\xbf\xd8\x4b\x88\x43\xc7\x5f\xb1\xf5\x97\x8f\x07\x6c\xb6\x78\xdb\xee\xf0\xb2\x79\xdd\xb5\x8e\x29\x52\x9f\x1d\xc0\x6d\x18\xe0\x19\x61\x58\x55\x24\x4c\xa9\x53\xca\x8e\x85\xef\x27\x1f\x89\x7b\x5a\x98\x41\x52\x2c\x0a\x9a\x66\x79\x75\x1a\x37\x77\x67\x7f\xbc\x37\x1a\xbc\xf7\x38\x00\xd0\x27\xa0\xb5\x6e\xe2\x28\x31\x86\xc3\x42\xa4\xcd\x21\x47\xaa\x39\x2b\x18\x5a\xda\xa5\x43\x9a\xce\xd7\x0a\x0c\x11\xe3\xdf\xa7\x30\x42\x29\x4c\x23\xa4\x2c\x7d\x84\x48\xab\x4d\xcd\x75\x39\x51\x22\x88\xa9\xb3\x7d\xd5\xb1\x5c\x69\x43\xab\x96\xdb\xd1\x9f\x49\xdf\x99\xfd\x91\x91\x2d\x21\xff\x07\xe8\x46\xbb\xd6\x34\x0e\xd0\xe9\x75\x40\xfd\xc6\xdf\x81\x2e\x5c\xb8\x7a\x50\x8e\x7e\x3e\x43\x22\xee\x7c\x2e\xc2\x0d\xa9\xda\x27\x2a\x7f\xd5\xd4\x1b\x52\x35\x7a\xce\x5d\xc9\xba\x3a\xe6\x8c\xab\x6d\xf9\xe4\x13\x31\xd8\x69\x5f\xd6\xbe\x4f\xee\x6f\x00\x53\xe9\xdb\x86\x3c\x02\x00\x00"),
+		},
+		"/sql/sqlite3/ShareManager.View.generated.sql": &vfsgen۰FileInfo{
+			name:    "ShareManager.View.generated.sql",
+			modTime: time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			content: []byte("\x2d\x2d\x20\x43\x6f\x64\x65\x20\x67\x65\x6e\x65\x72\x61\x74\x65\x64\x20\x62\x79\x20\x62\x75\x69\x6c\x64\x5f\x73\x71\x6c\x2e\x61\x77\x6b\x3b\x20\x44\x4f\x20\x4e\x4f\x54\x20\x45\x44\x49\x54\x2e\x0a\x75\x70\x64\x61\x74\x65\x20\x73\x68\x61\x72\x65\x73\x0a\x20\x20\x73\x65\x74\x0a\x20\x20\x20\x20\x76\x69\x65\x77\x73\x20\x3d\x20\x76\x69\x65\x77\x73\x20\x2b\x20\x31\x2c\x0a\x20\x20\x20\x20\x6c\x61\x73\x74\x5f\x76\x69\x65\x77\x65\x64\x5f\x61\x74\x20\x3d\x20\x43\x55\x52\x52\x45\x4e\x54\x5f\x54\x49\x4d\x45\x53\x54\x41\x4d\x50\x0a\x77\x68\x65\x72\x65\x20\x69\x64\x20\x3d\x20\x3f\x0a"),
+		},
 		"/sql/sqlite3/TagManager.Create.generated.sql": &vfsgen۰CompressedFileInfo{
 			name:             "TagManager.Create.generated.sql",
-			modTime:          time.Date(2020, 12, 16, 19, 18, 9, 999987786, time.UTC),
-			uncompressedSize: 157,
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 186,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x4c\xcc\xb1\x0e\x82\x30\x10\x87\xf1\x9d\xa7\xf8\x8f\x98\x14\x1e\xa0\x8e\xe2\xe0\x22\x0b\x3b\x39\xec\xa5\x69\xac\xad\x5e\xaf\x1a\xdf\xde\x10\x19\xd8\xbe\xe1\x97\xaf\xeb\x70\xca\x8e\xe1\x39\xb1\x90\xb2\xc3\xf2\xc5\x52\x43\x74\x73\x79\xc5\x9e\x3e\xf7\x23\x86\x11\xd7\x71\xc2\x79\xb8\x4c\x7d\x13\x52\x61\x51\x64\x41\xf0\x29\x0b\x23\x24\xcd\x50\xf2\x05\x6d\x70\x06\x89\x1e\x6c\x70\x13\x5e\x67\x33\xa9\x41\x7d\xba\xad\x0f\xcd\x9b\x62\xe5\x82\xd6\xae\xd4\xfe\xad\xdd\x63\xbb\xd7\xbf\x00\x00\x00\xff\xff\xd2\x6f\x3c\x02\x9d\x00\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x4c\xcc\xb1\x6e\x83\x30\x14\x85\xe1\x9d\xa7\x38\x23\x48\x86\x07\x70\xa7\x0a\x18\x18\x80\x8a\xba\x33\xba\xe0\x2b\x64\xd5\xb1\x13\xdb\x24\xca\xdb\x47\x48\x0c\x6c\x67\xf8\xcf\x57\x96\xa8\xbd\x66\x6c\xec\x38\x50\x62\x8d\xe5\x8d\x65\x37\x56\xcf\xf1\x61\x2b\x7a\xfd\x7f\xa1\x19\x31\x8c\x0a\x6d\xd3\xa9\x2a\x33\x2e\x72\x48\xf0\x01\x66\x73\x3e\x30\x8c\x4b\x1e\x89\xb6\x88\xdc\x68\x01\x47\x37\x16\x58\x03\x1f\xd8\x4c\x49\x60\xbf\xeb\x73\x17\xd9\x93\xec\xce\x11\xb9\x3c\x52\x79\xb6\x9e\x2c\xc7\x95\x73\x79\x7d\xd5\x7f\xd3\xd4\x0e\x6a\x56\x5d\xdf\xfe\xaa\xef\xfe\xa7\x10\x90\x57\xea\x33\x00\xb5\xc5\xff\xab\xba\x00\x00\x00"),
 		},
 		"/sql/sqlite3/TagManager.GetByID.generated.sql": &vfsgen۰FileInfo{
 			name:    "TagManager.GetByID.generated.sql",
-			modTime: time.Date(2020, 12, 16, 19, 18, 9, 999987786, time.UTC),
+			modTime: time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
 			content: []byte("\x2d\x2d\x20\x43\x6f\x64\x65\x20\x67\x65\x6e\x65\x72\x61\x74\x65\x64\x20\x62\x79\x20\x62\x75\x69\x6c\x64\x5f\x73\x71\x6c\x2e\x61\x77\x6b\x3b\x20\x44\x4f\x20\x4e\x4f\x54\x20\x45\x44\x49\x54\x2e\x0a\x73\x65\x6c\x65\x63\x74\x0a\x20\x20\x69\x64\x2c\x0a\x20\x20\x6e\x61\x6d\x65\x2c\x0a\x20\x20\x63\x72\x65\x61\x74\x65\x64\x5f\x61\x74\x2c\x0a\x20\x20\x75\x70\x64\x61\x74\x65\x64\x5f\x61\x74\x0a\x66\x72\x6f\x6d\x20\x74\x61\x67\x73\x0a\x77\x68\x65\x72\x65\x20\x69\x64\x20\x3d\x20\x3f\x0a"),
 		},
 		"/sql/sqlite3/TagManager.GetByName.generated.sql": &vfsgen۰FileInfo{
 			name:    "TagManager.GetByName.generated.sql",
-			modTime: time.Date(2020, 12, 16, 19, 18, 9, 999987786, time.UTC),
+			modTime: time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
 			content: []byte("\x2d\x2d\x20\x43\x6f\x64\x65\x20\x67\x65\x6e\x65\x72\x61\x74\x65\x64\x20\x62\x79\x20\x62\x75\x69\x6c\x64\x5f\x73\x71\x6c\x2e\x61\x77\x6b\x3b\x20\x44\x4f\x20\x4e\x4f\x54\x20\x45\x44\x49\x54\x2e\x0a\x73\x65\x6c\x65\x63\x74\x0a\x20\x20\x69\x64\x2c\x0a\x20\x20\x6e\x61\x6d\x65\x2c\x0a\x20\x20\x63\x72\x65\x61\x74\x65\x64\x5f\x61\x74\x2c\x0a\x20\x20\x75\x70\x64\x61\x74\x65\x64\x5f\x61\x74\x0a\x66\x72\x6f\x6d\x20\x74\x61\x67\x73\x0a\x77\x68\x65\x72\x65\x20\x6e\x61\x6d\x65\x20\x3d\x20\x3f\x0a"),
 		},
+		"/sql/sqlite3/URLCheckManager.Due.generated.sql": &vfsgen۰CompressedFileInfo{
+			name:             "URLCheckManager.Due.generated.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 268,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x64\x8f\xb1\x6e\xc2\x50\x0c\x45\x77\x7f\xc5\x1d\x41\x82\x48\x9d\x5b\xc4\x50\x3a\x74\x29\x0b\x7b\xf4\x88\xdd\x62\x61\xf2\x54\xfb\x59\xb4\x7f\x5f\x25\x99\x50\x37\x5f\x5b\x3a\xc7\x77\xbb\xc5\x6b\x65\xc1\x97\x8c\xe2\xa5\x09\xe3\xfc\x8b\x73\xaa\x71\x1f\xdf\xd6\x95\xfb\xf5\x19\x87\x23\x3e\x8e\x27\xbc\x1d\xde\x4f\x1d\x85\x98\x0c\x8d\x80\xec\x94\x51\x02\xca\x9b\x39\xa5\xdb\x14\xd3\x8d\x3e\xbd\xde\xa6\x21\x90\x74\xbf\x88\x0b\x56\xd9\x0d\x17\x19\xae\xc2\x7d\x69\xd0\xc0\x98\x66\xa8\x8e\x87\xfd\xcb\x0e\xfb\x35\x01\x65\x64\xc8\x8f\x46\x0b\xac\x16\x1f\x9e\xb0\x40\x43\xbc\x5f\xc8\x89\x05\x9d\xb3\xba\x57\xc6\x6e\xfe\x69\x4d\xd5\x59\x7c\xea\xf1\x4f\x5a\xdb\x2c\xde\x3c\x5c\xc8\xf4\xa6\x0d\x7b\xfa\x1b\x00\x75\x94\xa1\xb0\x0c\x01\x00\x00"),
+		},
+		"/sql/sqlite3/URLCheckManager.GetByURLID.generated.sql": &vfsgen۰CompressedFileInfo{
+			name:             "URLCheckManager.GetByURLID.generated.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 361,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x54\x90\xbd\x4e\x03\x31\x10\x84\x7b\x3f\xc5\x74\x01\x29\xc9\x0b\x20\x94\x82\x50\xd0\x90\x26\xbd\xe5\xd8\x13\x62\x9d\xef\x0c\xbb\x3e\x45\xbc\x3d\xda\x1c\xf9\xb9\x6e\xbf\x6f\x56\x1e\xad\x57\x2b\xbc\xd5\x44\x7c\x71\xa0\x84\xc6\x84\xc3\x2f\x0e\x63\x2e\xc9\xeb\x4f\x59\x87\x73\xf7\x82\xed\x0e\x9f\xbb\x3d\xde\xb7\x1f\xfb\xb5\x53\x16\xc6\xe6\x80\x9c\x10\x14\x39\x2d\x1d\x30\x4a\xf1\x13\x4f\x93\xb9\xda\x19\xd7\xce\x66\x6d\xa1\x8d\xea\xa3\x35\x05\x7d\x44\x4b\x63\x0d\x85\x1a\xf9\x74\xcc\x43\x28\x7e\x94\xb2\xc4\x62\xf1\x6c\x9b\x77\xe3\x00\x61\xca\xc2\xd8\xd4\x92\x1b\x4c\x89\x7e\xd7\x41\xe9\x5b\xee\xe9\xfb\xff\x85\xb9\x9b\x35\x51\xa4\xca\xad\x65\x22\xcb\x4f\x8c\x1d\x93\x0f\xcd\xf4\x9d\xdc\x51\x6a\x7f\xb9\xed\xe2\xd4\x9d\x4f\x14\x5e\xcf\x7e\xc5\xc6\x55\x49\x14\xfb\xbb\x87\x27\x12\x35\xba\x92\xfb\xdc\xb0\x71\x7f\x03\x00\x9b\x88\xff\x7a\x69\x01\x00\x00"),
+		},
+		"/sql/sqlite3/URLCheckManager.create.generated.sql": &vfsgen۰CompressedFileInfo{
+			name:             "URLCheckManager.create.generated.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 270,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x64\xcd\xb1\x4e\xc4\x30\x0c\xc6\xf1\xbd\x4f\xf1\x8d\x20\xe5\xee\x01\xcc\xc8\x31\xb0\x70\x4b\xf7\x28\x4d\x0c\x44\x4d\x13\xb0\x1d\x10\x6f\x8f\x52\x81\x54\xc4\x64\xeb\x1b\xfe\xbf\xd3\x09\xf7\x2d\x31\x5e\xb8\xb2\x04\xe3\x84\xe5\x0b\x4b\xcf\x25\x79\x7d\x2f\xe7\xf0\xb9\xde\xe1\x72\xc5\xd3\x75\xc6\xc3\xe5\x71\x3e\x4f\xb9\x2a\x8b\x21\x57\x6b\xe8\x52\x7c\x7c\xe5\xb8\xea\x04\xdc\xe4\xe4\xf6\x65\xdc\xb6\x3a\xa8\x05\xeb\xea\x63\x4b\xec\xf0\x9c\x6b\x28\xbe\x4b\x71\x10\x4e\x59\x38\x9a\x8e\x57\xdf\x5a\x55\xf6\x96\x37\xf6\x9b\x3a\xb0\x48\x13\x87\xbd\xca\xc9\x07\xbb\x9d\x3e\x42\xe9\xbc\x0b\x34\xd2\xf4\x6b\xd0\x40\xe8\x8f\x42\x07\x86\x0e\x0e\xfd\x87\xe8\x47\xa2\x23\xf5\x3d\x00\x24\x56\x01\x59\x0e\x01\x00\x00"),
+		},
+		"/sql/sqlite3/URLCheckManager.prune.generated.sql": &vfsgen۰CompressedFileInfo{
+			name:             "URLCheckManager.prune.generated.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 219,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x74\x8e\xb1\x4e\xc3\x30\x18\x84\x77\x3f\xc5\x37\xc2\xd0\x3e\x40\x11\x13\x65\x60\xa1\x4b\xf7\xc8\xf5\x7f\x10\x2b\x8e\x0d\xb6\xa3\x88\xb7\x47\x09\x1b\x52\xb7\xd3\x77\xa7\x4f\x77\x38\xf0\x52\x4c\x7c\x2a\xab\xfa\x2e\xe3\xf6\xc3\x6d\x89\xc9\x86\xf6\x9d\x8e\x7e\x9d\x9e\x38\x5f\x78\xbf\x5c\x79\x3d\xbf\x5d\x8f\xce\x94\xd4\xc5\x47\x2d\x33\x4b\x4d\x43\x18\x15\xa6\xe6\xd6\x51\x55\x3b\x88\xc6\x33\xa7\xbf\xe4\xc0\x67\x23\x1a\xb9\x74\x62\xe6\xc1\x01\x34\x25\x85\xbe\xe1\xff\x1a\x80\xbb\x2a\x28\xd5\x54\xb7\x87\xfb\x5c\x36\xf8\x8e\xa9\x85\xbd\x4c\x71\x8e\x9d\xd3\x24\x7d\x39\x78\x74\xbf\x03\x00\x1b\x2d\xcf\x26\xdb\x00\x00\x00"),
+		},
+		"/sql/sqlite3/URLCheckManager.updateURL.generated.sql": &vfsgen۰CompressedFileInfo{
+			name:             "URLCheckManager.updateURL.generated.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 280,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x6c\xce\x31\x4f\xc4\x30\x0c\x05\xe0\x3d\xbf\xe2\xed\x70\x27\x58\x8b\xca\xc2\x31\xb0\x70\xcb\xed\x91\xaf\x7e\xa5\x51\xa3\x16\x62\x47\x15\xff\x1e\x05\x86\x22\xc1\x96\xa7\xf8\x7b\xf6\xe1\x80\xa7\x55\x89\x37\x2e\x2c\xe2\x54\x5c\x3f\x71\xad\x29\x6b\xb4\x8f\x7c\x94\x6d\x7e\xc0\xe9\x8c\xd7\xf3\x05\xcf\xa7\x97\xcb\x31\xd4\x77\x15\x27\x6a\xc9\x16\x00\xa3\x07\x00\x30\x17\xaf\x16\x87\x56\xd5\xa3\xfb\x15\x6f\xbf\xff\x47\x49\xb9\x16\x1a\x7a\x0c\x62\xc4\x36\x71\x41\xb7\xce\xf0\xf6\xb8\x03\xb3\x71\x1f\xba\xc1\x3d\xb8\xe8\x0f\x55\x8a\xfe\xcf\x46\x69\xea\x2f\x7d\xec\xd1\x35\x15\x65\x74\x96\xbd\x69\x98\x38\xcc\xd4\x28\xde\x6e\xdc\x53\xd8\x26\x16\x22\xb5\x35\x5d\x2d\x39\x26\x0d\x5f\x03\x00\x05\xf0\x47\xa2\x18\x01\x00\x00"),
+		},
 		"/sql/sqlite3/URLManager.Create.generated.sql": &vfsgen۰CompressedFileInfo{
 			name:             "URLManager.Create.generated.sql",
-			modTime:          time.Date(2020, 12, 16, 19, 18, 9, 999987786, time.UTC),
-			uncompressedSize: 174,
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 203,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x4c\xcc\xbd\x8a\x84\x30\x18\x46\xe1\xde\xab\x78\x4b\x85\xe8\x05\x64\xab\x45\x2d\x2c\xd4\xc5\xcd\xd6\x12\xcd\x87\x84\x0d\xc9\x6e\x7e\x66\x98\xbb\x1f\x22\x33\x60\x77\x9a\xe7\xd4\x35\x5a\xa7\x08\x07\x59\xf2\x32\x92\xc2\xf6\xc0\x96\xb4\x51\x6b\xf8\x37\x8d\xbc\xff\x7e\xa0\x9b\x31\xcd\x02\x7d\x37\x88\xa6\xd0\x36\x90\x8f\x70\x1e\xfa\xb0\xce\x13\xb4\x8d\x0e\xc9\x9b\x50\x00\xa5\x56\x2c\x37\x43\xd4\xd1\x10\xc3\xee\x29\x4f\x57\x19\x19\xd2\x9f\x7a\x75\x55\xdc\xa4\x49\x74\x0a\x9e\x09\x3f\x0d\x7f\x23\x27\x0d\x85\x9d\x4a\x7e\xe5\xed\xcf\xb2\xf4\x93\x58\xc5\x30\xf6\xdf\xe2\x73\xfc\xaa\xb2\xbb\x3c\x9f\x03\x00\x1d\xae\x58\xa6\xcb\x00\x00\x00"),
+		},
+		"/sql/sqlite3/URLManager.GetByID.generated.sql": &vfsgen۰CompressedFileInfo{
+			name:             "URLManager.GetByID.generated.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 672,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x4c\xcc\x31\x8e\xc2\x30\x10\x46\xe1\xde\xa7\xf8\xcb\x5d\xc9\xc9\x01\x4c\x49\x28\x68\x48\x93\x3e\x72\xf0\x28\x1a\x61\xd9\x30\x1e\x83\xb8\x3d\x72\x94\x22\xdd\x6b\xbe\xd7\x75\x38\xe7\x40\x58\x29\x91\x78\xa5\x80\xe5\x8b\xa5\x72\x0c\x73\x79\xc5\xde\x7f\x1e\x27\x0c\x23\x6e\xe3\x84\xcb\x70\x9d\x7a\xc3\xa9\x90\x28\xb2\x80\xd7\x94\x85\xc0\x49\x33\xaa\xc4\x62\x80\x3f\x0e\xb6\xb5\x85\xb2\x46\xb2\xb8\x0b\xb5\xe9\xec\xd5\xa2\x3e\xc3\xde\xff\xe6\xed\x63\xa5\x4d\xb8\x46\xdc\x66\xdc\x8e\xdc\x51\xb9\x23\xfb\x05\x00\x00\xff\xff\x41\x74\x22\xa6\xae\x00\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x54\x92\xc1\x72\x2a\x21\x10\x45\xf7\x7c\x45\xef\x7c\xaf\x4a\xfd\x81\x54\x2a\x8b\x98\x45\x36\x71\xe3\x7e\xaa\x85\x76\xa6\x4b\x84\x09\x34\x5a\xfe\x7d\xaa\x35\x01\x66\xc7\xb9\xf7\xa0\xcd\xc0\x66\x03\xef\xd1\x11\x8c\x14\x28\xa1\x90\x83\xe3\x1d\x8e\x85\xbd\x1b\xf2\xb7\xdf\xe2\xed\xfc\x02\xbb\x3d\x7c\xed\x0f\xf0\xb1\xfb\x3c\x6c\x4d\x26\x4f\x56\x0c\x00\x3b\xc0\x0c\xec\xd6\x06\xa0\x24\xaf\x50\x92\x57\x12\x16\x4f\xca\x8f\x85\x26\x36\xa2\xa7\x6c\xe9\x9f\x8d\x41\x28\xc8\x20\xf7\x99\xd6\xb0\x5a\xfd\x57\x6d\x11\xf6\xb6\xa3\x6c\x13\xcf\xc2\x31\x54\xb9\xcf\x7a\x97\x2f\x38\xd2\xa0\x13\xfc\x99\x2d\xe9\xbd\xcc\x42\x43\xc0\x4b\xfb\xfb\x96\xf4\x1e\x16\x99\x62\xaa\xd2\x2f\x1a\x80\xb9\x1c\x3d\xe7\x89\xdc\x80\xa2\x4d\xcf\xcb\xb3\x62\x88\x81\x2d\xfa\xc5\x54\xcb\xb4\xf7\x3d\x86\xb1\xe0\xd8\x06\xab\x41\x6f\x9d\xf0\xca\x36\x86\xc5\x6f\xf6\x99\x01\xc8\x82\x52\xf2\x60\xf5\x5e\x31\xf7\xa8\xed\x09\xd9\x97\x44\xf9\xb9\xf1\xb9\xd6\xdc\x11\xba\xe7\x07\xc6\xc7\x9d\xda\x89\xec\xb9\x9e\xb2\x91\x76\x98\xec\xc4\xd7\x5a\x76\xf8\xd8\x99\x08\xa5\x96\x8d\xb4\x2b\xb3\xeb\xba\x46\xe6\x94\xe2\x45\x1f\x50\x36\xb7\x89\x12\xe9\xf3\x7a\x85\x37\xf3\x33\x00\x6b\x2c\x91\x3b\xa0\x02\x00\x00"),
 		},
 		"/sql/sqlite3/URLManager.GetByURL.generated.sql": &vfsgen۰CompressedFileInfo{
 			name:             "URLManager.GetByURL.generated.sql",
-			modTime:          time.Date(2020, 12, 16, 19, 18, 9, 999987786, time.UTC),
-			uncompressedSize: 180,
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 674,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x4c\xcd\x31\xce\xc2\x30\x0c\x05\xe0\xdd\xa7\x78\x07\xf8\xdb\x0b\xfc\x42\x0c\x94\x81\x85\x2e\xdd\xab\xb4\x36\x10\x61\x28\x38\x89\x2a\x6e\x8f\x9c\xa5\x6c\xfe\x9e\xe5\xe7\xa6\xc1\x61\x61\xc1\x55\x9e\x62\x21\x0b\x63\xfa\x60\x2a\x51\x79\x4c\x6f\x6d\xc3\x7a\xff\x47\xd7\xe3\xdc\x0f\x38\x76\xa7\xa1\xa5\x24\x2a\x73\x06\x01\x91\x11\x12\x22\xff\x11\x50\x4c\x1d\xc5\xd4\x95\x63\x56\x71\xd7\xc1\x93\xd9\xc4\xcb\xc7\x90\x3d\xde\x54\x6f\x5f\xfc\xb3\xdb\x44\x17\x5b\x1e\x5e\x99\x68\xbd\x89\x49\x7d\xb2\xc3\x9e\xbe\x01\x00\x00\xff\xff\xc4\xcb\xe7\x40\xb4\x00\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x54\x92\xb1\x72\xea\x30\x10\x45\x7b\x7d\xc5\x76\xbc\x37\x03\xfc\x40\x26\x93\x22\xa4\x48\x13\x1a\x7a\xcf\x22\x2d\xf6\x0e\x42\x72\xa4\x15\x0c\x7f\x9f\x59\x48\x24\xb9\xd3\xb9\xf7\xc8\x5e\x59\xde\x6c\xe0\x3d\x3a\x82\x91\x02\x25\x14\x72\x70\xbc\xc3\xb1\xb0\x77\x43\xfe\xf6\x5b\xbc\x9d\x5f\x60\xb7\x87\xaf\xfd\x01\x3e\x76\x9f\x87\xad\xc9\xe4\xc9\x0a\x18\x00\x76\x80\x19\xd8\xad\x0d\x40\x49\x5e\xa1\x24\xaf\x24\x2c\x9e\x94\x1f\x0b\x4d\x6c\x44\x4f\xd9\xd2\x3f\x1b\x83\x50\x90\x41\xee\x33\xad\x61\xb5\xfa\xaf\xda\x22\xec\x6d\x47\xd9\x26\x9e\x85\x63\xa8\x72\x9f\xf5\x2e\x5f\x70\xa4\x41\x27\xf8\x33\x5b\xd2\x7b\x99\x85\x86\x80\x97\xf6\xfa\x96\xf4\x1e\x16\x99\x62\xaa\xd2\x2f\x1a\x80\xb9\x1c\x3d\xe7\x89\xdc\x80\xa2\x4d\xcf\xcb\xb3\x62\x88\x81\x2d\xfa\xc5\x54\xcb\xb4\xf7\x3d\x86\xb1\xe0\xd8\x06\xab\x41\x6f\x9d\xf0\xca\x36\x86\xc5\x33\xfb\xcc\x00\x64\x41\x29\x79\xb0\x7a\xb1\x98\x7b\xd4\xf6\x84\xec\x4b\xa2\xfc\xdc\xf8\x5c\x6b\xee\x08\xdd\xf3\x03\xe3\xe3\x4e\xed\x44\xf6\x5c\x4f\xd9\x48\x3b\x4c\x76\xe2\x6b\x2d\x3b\x7c\xec\x4c\x84\x52\xcb\x46\xda\x95\xd9\x75\x5d\x23\x73\x4a\xf1\xa2\x3f\x50\x36\xb7\x89\x12\xe9\x12\x5e\xe1\xcd\xfc\x0c\x00\xb8\xb4\x59\x5b\xa2\x02\x00\x00"),
+		},
+		"/sql/sqlite3/URLManager.Update.generated.sql": &vfsgen۰CompressedFileInfo{
+			name:             "URLManager.Update.generated.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 441,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x54\x90\xc1\x6e\xc2\x30\x0c\x86\xef\x7d\x0a\x3f\xc0\xe0\x01\x36\xed\x30\x41\x0f\x1c\x80\x89\x75\xe7\xc8\x6d\xbc\xd6\x5a\x48\xba\xc4\x01\xf1\xf6\x53\x93\xb6\x0a\x37\x7f\xfe\x9c\xfc\x96\x37\x1b\xd8\x39\x4d\xd0\x93\x25\x8f\x42\x1a\xda\x07\xb4\x91\x8d\x56\xe1\xcf\x6c\xf1\xfe\xfb\x06\xfb\x33\x9c\xce\x0d\xd4\xfb\x43\xb3\xad\xe2\xa8\x51\x08\xa2\x37\xa1\x02\x08\x24\x15\x00\x80\xb0\x18\x82\x77\x78\x4d\xc5\x4b\xea\x75\xce\x0a\x59\x51\xf2\x18\x93\x2a\x39\x4f\x68\x0a\x9d\xe7\x51\xd8\xd9\x69\xa0\xc0\xec\xf9\x8a\x3d\xa9\xe8\xcd\x64\x57\xc8\x2e\xb0\x90\xb2\x78\x4d\x5f\xaf\x90\x1d\x46\x19\x9c\x9f\x44\xae\x72\x77\x8c\xad\xe1\x30\x90\x56\x28\x93\x2b\x79\xde\x18\xad\xb3\xdc\xa1\x59\x32\x9f\x1a\x79\xc6\xa0\xed\x23\xf6\x29\x76\xa9\xb3\xf9\xc1\x1b\x77\xce\x2e\x6f\x0b\x9c\xb7\xf2\xdd\xc0\xb7\x35\xbe\xc0\xec\xf3\x65\x67\xbd\xfb\xbe\x5c\xea\x53\xa3\x9a\xc3\xb1\xfe\x6a\x3e\x8e\x9f\xd5\x7d\x20\x4f\xc0\x3a\xdd\x42\x57\xff\x03\x00\xce\xea\xc0\x25\xb9\x01\x00\x00"),
+		},
+		"/sql/sqlite3/UserManager.AddIdentity.generated.sql": &vfsgen۰FileInfo{
+			name:    "UserManager.AddIdentity.generated.sql",
+			modTime: time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			content: []byte("\x2d\x2d\x20\x43\x6f\x64\x65\x20\x67\x65\x6e\x65\x72\x61\x74\x65\x64\x20\x62\x79\x20\x62\x75\x69\x6c\x64\x5f\x73\x71\x6c\x2e\x61\x77\x6b\x3b\x20\x44\x4f\x20\x4e\x4f\x54\x20\x45\x44\x49\x54\x2e\x0a\x69\x6e\x73\x65\x72\x74\x20\x69\x6e\x74\x6f\x20\x75\x73\x65\x72\x5f\x69\x64\x65\x6e\x74\x69\x74\x69\x65\x73\x20\x28\x69\x73\x73\x75\x65\x72\x2c\x20\x73\x75\x62\x6a\x65\x63\x74\x2c\x20\x75\x73\x65\x72\x5f\x69\x64\x29\x20\x76\x61\x6c\x75\x65\x73\x20\x28\x3f\x2c\x20\x3f\x2c\x20\x3f\x29\x0a"),
+		},
+		"/sql/sqlite3/UserManager.AddWebAuthnCredential.generated.sql": &vfsgen۰CompressedFileInfo{
+			name:             "UserManager.AddWebAuthnCredential.generated.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 245,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x54\xcd\x3b\x6e\xc3\x30\x10\x04\xd0\x9e\xa7\xd8\xd2\x02\x68\x1f\x80\xa9\x02\x5b\x85\x0b\x5b\x81\xc2\xd4\xc4\x92\x5c\x28\x84\x98\x65\xc2\x4f\x04\xdd\x3e\x50\x04\xe4\xd3\x4d\x31\xf3\xe6\x78\x84\x73\xf2\x04\x13\x31\x65\xac\xe4\xc1\xae\x60\x5b\x88\xde\x94\x8f\x78\xc2\x65\x7e\x80\xcb\x00\xf7\x41\x43\x7f\xb9\xea\x93\x08\x5c\x28\x57\x08\x5c\x13\xb4\x42\xd9\x2c\x64\xb1\xd5\x57\x36\x2e\x93\x27\xae\x01\x63\x11\x00\x87\xe0\xe5\x5e\xd8\x02\xe3\x1b\x49\x78\x6f\x36\x06\x67\x66\x5a\x25\x94\x30\xb1\x71\xa9\x71\x95\xe0\x32\x6d\xd7\x06\x6b\x27\x3e\x31\x36\xfa\x06\xd4\x36\x54\x3f\x84\xda\x0d\xf5\x17\x51\xff\x94\x84\x91\x8a\xa3\x83\xfa\xf5\x24\x9c\x5f\xc6\xb1\xbf\x6b\xa3\xaf\xb7\xfe\x59\x3f\xde\x9e\xba\x4e\x7c\x0d\x00\x66\xb2\x81\xfb\xf5\x00\x00\x00"),
 		},
 		"/sql/sqlite3/UserManager.Create.generated.sql": &vfsgen۰CompressedFileInfo{
 			name:             "UserManager.Create.generated.sql",
-			modTime:          time.Date(2020, 12, 16, 19, 18, 9, 999987786, time.UTC),
-			uncompressedSize: 185,
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 214,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x5c\xcc\xb1\x6e\x83\x30\x14\x46\xe1\x9d\xa7\xf8\xc7\x44\x72\xf2\x00\xee\x54\x25\x0c\x19\x80\x8a\xba\xb3\x75\xc1\x57\xc5\xaa\x8b\xa9\xaf\x5d\xd4\xb7\xaf\xa8\x18\x50\xb7\xb3\x7c\xe7\x72\xc1\x2d\x3a\xc6\x3b\xcf\x9c\x28\xb3\xc3\xf0\x83\xa1\xf8\xe0\xac\x7c\x85\x2b\xad\x1f\x4f\xb8\x77\x68\x3b\x83\xfa\xfe\x30\xd7\xca\xcf\xc2\x29\xc3\xcf\x39\xa2\x08\x27\xa9\x80\x93\x77\x0a\xfc\x49\x3e\x28\x2c\x24\xb2\xc6\xe4\xec\x44\x32\x29\x8c\x89\xb7\xab\xa5\xac\x50\x16\xb7\xf7\xb9\xfa\xa6\x50\xf8\xcf\xea\x0d\xeb\x5d\xeb\xff\x3c\x52\x60\x19\xf9\xa4\x8f\xa3\xdb\x5b\xdf\xd7\xad\xb1\xe6\xd1\xd4\xaf\xe6\xb9\x79\x39\x2b\xe8\xe3\xfd\x77\x00\x3c\xea\x11\xe0\xd6\x00\x00\x00"),
+		},
+		"/sql/sqlite3/UserManager.Delete.generated.sql": &vfsgen۰FileInfo{
+			name:    "UserManager.Delete.generated.sql",
+			modTime: time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			content: []byte("\x2d\x2d\x20\x43\x6f\x64\x65\x20\x67\x65\x6e\x65\x72\x61\x74\x65\x64\x20\x62\x79\x20\x62\x75\x69\x6c\x64\x5f\x73\x71\x6c\x2e\x61\x77\x6b\x3b\x20\x44\x4f\x20\x4e\x4f\x54\x20\x45\x44\x49\x54\x2e\x0a\x64\x65\x6c\x65\x74\x65\x20\x66\x72\x6f\x6d\x20\x75\x73\x65\x72\x73\x20\x77\x68\x65\x72\x65\x20\x69\x64\x20\x3d\x20\x3f\x0a"),
+		},
+		"/sql/sqlite3/UserManager.DeleteWebAuthnCredential.generated.sql": &vfsgen۰FileInfo{
+			name:    "UserManager.DeleteWebAuthnCredential.generated.sql",
+			modTime: time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			content: []byte("\x2d\x2d\x20\x43\x6f\x64\x65\x20\x67\x65\x6e\x65\x72\x61\x74\x65\x64\x20\x62\x79\x20\x62\x75\x69\x6c\x64\x5f\x73\x71\x6c\x2e\x61\x77\x6b\x3b\x20\x44\x4f\x20\x4e\x4f\x54\x20\x45\x44\x49\x54\x2e\x0a\x64\x65\x6c\x65\x74\x65\x20\x66\x72\x6f\x6d\x20\x75\x73\x65\x72\x5f\x77\x65\x62\x61\x75\x74\x68\x6e\x5f\x63\x72\x65\x64\x65\x6e\x74\x69\x61\x6c\x73\x20\x77\x68\x65\x72\x65\x20\x75\x73\x65\x72\x5f\x69\x64\x20\x3d\x20\x3f\x20\x61\x6e\x64\x20\x69\x64\x20\x3d\x20\x3f\x0a"),
+		},
+		"/sql/sqlite3/UserManager.GetAll.generated.sql": &vfsgen۰CompressedFileInfo{
+			name:             "UserManager.GetAll.generated.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 419,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x5c\xcc\xb1\xae\x82\x30\x14\x87\xf1\x9d\xa7\xf8\x8f\xf7\x26\x85\x07\xa8\xa3\x38\xb8\xc8\xc2\x4e\x0e\x9e\x13\x69\xac\x2d\xf6\xb4\x12\xdf\xde\x60\x18\x88\xdb\xb7\xfc\xbe\xba\xc6\x31\xb2\xe0\x26\x41\x12\x65\x61\x8c\x6f\x8c\xc5\x79\x1e\xf4\xe9\x1b\x5a\xee\x07\xb4\x1d\x2e\x5d\x8f\x53\x7b\xee\x9b\xca\x05\x95\x94\xe1\x42\x8e\x28\x2a\x49\x2b\xe0\xcf\xb1\x81\x3c\xc8\x79\x83\x99\x54\x97\x98\x78\x98\x48\x27\x83\x6b\x92\xf5\x3a\x50\x36\x28\x33\x6f\xfd\x5f\xbd\xc8\x17\xf9\x5a\xbb\x62\xbb\x69\xfb\xc3\xed\xde\xdb\xfd\xe0\x13\x00\x00\xff\xff\x84\x9d\x5e\x3a\xb9\x00\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x5c\x90\x31\x4f\xc3\x30\x10\x85\x77\xff\x8a\x63\xea\xd2\xe6\x0f\x54\x4c\x94\x81\x85\x2e\xdd\x2d\x27\xf7\x28\x16\x4e\x6c\xce\x0e\x15\xff\x1e\xd9\xa1\xbd\x28\xdb\x7b\xdf\xfb\x64\x59\x77\x38\xd0\x4b\x64\xd0\x15\x13\xc4\x15\x30\xf5\xbf\xd4\xcf\x3e\xb0\xcd\xdf\xa1\x73\xb7\xaf\x23\x9d\xce\xf4\x7e\xbe\xd0\xeb\xe9\xed\xd2\x99\x8c\x80\xa1\x18\xa2\x39\x43\x72\xe7\x99\x5c\x26\xcf\xfb\x07\xc1\xe8\x7c\xa8\xb0\x85\x35\xef\xc1\x36\x7d\xc6\x12\xf3\x32\x6b\xdf\x5a\x3f\x9e\xb1\xb6\x96\xae\x96\xe3\xd1\x4f\x75\x6e\x41\x39\xfb\xec\xfa\x80\xf6\xa7\x7b\xd6\x35\x41\x6c\x72\x57\xd4\xf5\x9e\x75\x2d\xb1\x24\x9b\x31\x08\x0a\x3d\x3d\xd3\x6e\x57\xb5\x06\x31\x6d\x1e\x1a\x04\xf5\x54\xd6\x95\xea\x68\x53\x63\x4e\xbc\x32\xb4\x99\x0f\x89\xe3\xe2\x98\x28\x0c\xa9\xe7\xde\x3e\xba\xff\x27\x12\x6f\x9e\x8f\xe6\x6f\x00\x82\xc0\xae\xce\xa3\x01\x00\x00"),
+		},
+		"/sql/sqlite3/UserManager.GetByAPIToken.generated.sql": &vfsgen۰CompressedFileInfo{
+			name:             "UserManager.GetByAPIToken.generated.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 538,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x64\x91\xb1\x6e\xf3\x30\x0c\x84\x77\x3d\xc5\xfd\x93\x13\x20\xc9\x0b\x04\xc1\x3f\x34\x1d\xba\x34\x4b\x76\x81\x36\x99\x58\x88\x62\xb9\xa2\xdc\xa0\x6f\x5f\xc8\x6e\x2b\xd7\x5d\x8c\xbb\xe3\x67\x8a\x94\xb6\x5b\x3c\x05\x16\x5c\xa5\x93\x48\x49\x18\xf5\x07\xea\xc1\x79\xb6\xfa\xe6\x77\xf4\xb8\xed\x71\x3c\xe1\xf5\x74\xc6\xf3\xf1\xe5\xbc\x33\x2a\x5e\x9a\x64\x80\x41\x25\xea\xce\x31\x48\xe1\x78\xf3\x93\xc8\x9d\x9c\xcf\xe1\x28\x4a\xde\x93\xea\x23\x44\xb6\x2d\x69\x9b\xeb\xbf\x82\xcc\x35\x81\xbc\x68\x23\x2b\x03\x00\xdd\xe0\xbd\xbb\xac\xa6\x9f\xa9\x77\x36\x85\x9b\x74\x1b\x54\xd5\x3a\x7f\x0c\xb0\xce\x5d\x4a\x65\x36\x41\x2d\x6c\xfb\x36\xa4\xa0\xd3\x20\xc5\x2f\xa9\x77\xc7\x32\xa7\x26\x5f\x28\xe2\xbb\xeb\xc6\x73\xb2\x28\x39\x3b\xa5\xda\xcb\xb8\xfd\xb7\x9e\xed\x2a\xd1\xf6\x74\x95\x71\xcd\x2f\x5d\xaa\x29\xa4\xde\xaa\x34\x51\x12\xfe\x1d\x50\x55\x19\x1b\x43\xe9\x16\x8d\x9a\x28\xf9\x51\x2c\xa5\xcc\x14\x57\x88\xa1\xe7\x19\x51\x9c\xb9\xc4\x70\x9f\x18\xf3\x68\x25\x0a\x16\x37\x89\x03\xfe\x83\x3a\xfe\x93\x8f\x23\xed\xcd\xe7\x00\x17\xcc\x53\x44\x1a\x02\x00\x00"),
 		},
 		"/sql/sqlite3/UserManager.GetByEmail.generated.sql": &vfsgen۰CompressedFileInfo{
 			name:             "UserManager.GetByEmail.generated.sql",
-			modTime:          time.Date(2020, 12, 16, 19, 18, 9, 999987786, time.UTC),
-			uncompressedSize: 345,
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 508,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x54\x8f\xb1\x6e\xc2\x30\x10\x86\x77\x3f\xc5\xbf\x01\x12\xe4\x05\x50\xd5\xa1\x74\xe8\x52\x16\x76\xeb\xe2\x3b\x1a\x0b\x63\xa7\x3e\x47\x51\xdf\xbe\x32\x91\x70\x58\x2c\xdf\x77\x9f\xad\xff\x3f\x1c\xf0\x91\x58\xf0\x23\x51\x32\x15\x61\xf4\x7f\xe8\x27\x1f\xd8\xea\x6f\xe8\x68\xbe\x1d\x71\x3a\xe3\xfb\x7c\xc1\xe7\xe9\xeb\xd2\x19\x95\x20\xae\x18\x60\x52\xc9\xda\x79\x06\x29\x3c\xef\x9f\x44\xee\xe4\x43\x85\x8f\x4b\xe3\x23\xa9\xce\x29\xb3\x1d\x48\x87\xba\x7f\x01\xd5\x73\x89\x82\xa8\x93\xad\x01\x80\x38\x85\xe0\xaf\xdb\xe5\x31\x8d\xde\x96\x74\x93\xb8\xc7\x66\xb3\xab\x87\x01\x76\xf5\x97\xb6\x59\x25\xe8\x85\xad\x4b\xb1\x48\x2c\x4b\x92\x15\x68\x9e\xcb\x52\x1b\x5b\x7a\x48\x6d\x6a\xc6\x34\xf2\xca\x68\x93\xb9\xe6\x74\x5f\x1c\x33\x0f\x92\xe5\xa5\xfb\x1b\xde\x8f\xe6\x3f\x00\x00\xff\xff\x50\x39\x47\xdb\x59\x01\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x5c\x91\xb1\x6e\xf3\x30\x0c\x84\x77\x3d\x05\xff\xc9\x09\x90\xf8\x05\x82\xe0\x1f\x9a\x0e\x5d\x9a\x25\xbb\x40\x5b\x97\x58\x88\x6c\xa9\xa2\x5c\xa3\x6f\x5f\xc8\x6e\x2b\x27\x8b\x71\x77\xfc\x4c\x91\xe0\x7e\x4f\x2f\xde\x80\x6e\x18\x10\x39\xc1\x50\xf3\x45\xcd\x68\x9d\xd1\xf2\xe1\x6a\x9e\xee\x07\x3a\x9d\xe9\xfd\x7c\xa1\xd7\xd3\xdb\xa5\x56\x02\x87\x36\x29\xa2\x51\x10\xa5\xb6\x86\x58\xc8\x9a\xdd\x5f\x82\x9e\xad\xcb\xe1\x2c\x4a\x1e\x58\x64\xf2\xd1\xe8\x8e\xa5\xcb\xf5\x87\x20\x73\xad\x67\x07\x69\xb1\x51\x44\x44\xc3\xe8\x9c\xbd\x6e\x96\x9f\x39\x58\x9d\xfc\x1d\xc3\x8e\xaa\x6a\x9b\x3f\x8a\x68\x9b\xbb\x94\xca\x6a\x82\x06\x46\x87\xce\x27\x2f\xcb\x20\xc5\x3f\x53\x9f\xd6\x60\x4d\x2d\xbe\x50\x6c\x7a\x3b\xcc\xef\x64\x51\x72\x63\x85\x1b\x87\x79\xfb\x5f\xbd\xda\x15\x51\x07\xbe\x61\x5e\xf3\x47\x97\x6a\xf2\x29\x68\x41\x1b\x91\xe8\xdf\x91\xaa\x2a\x63\x73\x88\xe1\xa9\x51\x1b\x91\x8f\xa2\x39\x65\xa6\xb8\x42\x8c\xc1\xac\x88\xe2\xd4\x35\xfa\x7e\x61\xd4\xd4\x21\xe2\xe1\x3c\x47\xfa\x7f\x50\xdf\x03\x00\x1e\x12\xbc\x9c\xfc\x01\x00\x00"),
 		},
 		"/sql/sqlite3/UserManager.GetByID.generated.sql": &vfsgen۰CompressedFileInfo{
 			name:             "UserManager.GetByID.generated.sql",
-			modTime:          time.Date(2020, 12, 16, 19, 18, 9, 999987786, time.UTC),
-			uncompressedSize: 236,
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 399,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x5c\x90\x31\x4f\xc3\x30\x10\x85\x77\xff\x8a\x63\xea\x42\xf3\x07\xaa\x8a\x81\x32\xb0\xd0\xa5\xbb\xe5\xe4\x1e\xad\x85\x13\x1b\x9f\x43\xc5\xbf\x47\x76\x54\x2e\xca\xf6\xde\xfb\x3e\x59\xd6\xed\xf7\xf4\x1a\x19\x74\xc5\x84\xec\x0a\x98\xfa\x5f\xea\x67\x1f\xd8\xca\x77\xe8\xdc\xfd\xeb\x40\xa7\x33\x7d\x9c\x2f\xf4\x76\x7a\xbf\x74\x46\x10\x30\x14\x43\x34\x0b\xb2\x74\x9e\xc9\x09\x79\x7e\xfe\x5f\x30\x3a\x1f\xea\xd8\xc2\x7a\xef\xc1\x36\xdd\x62\x89\xb2\x60\xed\x5b\xeb\xc7\x33\xd6\xd6\xd2\xd5\x72\x3c\xfa\xa9\xe2\x16\x74\x67\x2f\xae\x0f\x68\x7f\x7a\x64\xa5\x09\xd9\x26\x77\x45\xa5\x8f\xac\xb4\xc4\x92\xac\x60\xc8\x28\xf4\x74\xa4\xdd\xae\x6a\x6d\xc4\xb4\x79\x68\xc8\xa8\xa7\xb2\xae\x54\x47\x9b\x1a\x73\xe2\x95\xa1\xcd\x7c\xe6\x38\x2e\x8e\xb9\xdf\x90\xa1\x67\x3c\xd2\xcb\xc1\xfc\x0d\x00\xe5\x09\x32\x74\x8f\x01\x00\x00"),
+		},
+		"/sql/sqlite3/UserManager.GetByIdentity.generated.sql": &vfsgen۰CompressedFileInfo{
+			name:             "UserManager.GetByIdentity.generated.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 574,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x4c\x8e\x3b\x0e\x82\x40\x14\x45\xfb\x59\xc5\x5d\x80\xb0\x01\x62\x2c\xc4\xc2\x46\x1a\x7a\x32\xf0\xae\x3a\x71\x00\x9d\x4f\x88\xbb\x37\x40\xe2\x4c\xf7\xee\x79\xa7\x38\x45\x81\xf3\x2c\xc4\x83\x13\x9d\x0e\x14\xf4\x5f\xf4\xd1\x58\xe9\xfc\xc7\x96\x7a\x79\x55\xa8\x1b\xdc\x9a\x16\x97\xfa\xda\x96\xca\xd3\x72\x08\x0a\x88\x9e\xce\x97\x46\xa0\x3d\x8c\x1c\xfe\x84\xa3\x36\x76\x85\xdb\x91\xf3\x9e\xd2\x0d\xf3\x14\x38\x85\xfd\x9f\x81\xe4\x0d\x8e\x6b\x47\xa7\x37\x29\xad\x64\xc4\xb7\x64\x46\x5a\xea\xee\xe6\x71\x77\xd4\xf2\xa4\x63\x6a\x3c\xe2\x54\xa9\x5f\x00\x00\x00\xff\xff\x2f\xcb\xee\x41\xec\x00\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x5c\x91\xbf\x8e\xe2\x30\x10\xc6\x7b\x3f\xc5\x77\x55\x40\x82\xbc\x00\x42\x57\x1c\x57\x5c\x73\x34\xf4\x96\x13\x0f\x64\xc0\xd8\x39\x8f\x7d\x68\xdf\x7e\xe5\x84\xc5\x59\x9a\xe8\xfb\xf3\x8b\xed\xd1\x6c\xb7\xf8\x15\x2c\xe1\x42\x9e\xa2\x49\x64\xd1\x7d\xa0\xcb\xec\xac\x96\x7f\xae\x35\x8f\xdb\x0e\x87\x23\xfe\x1e\x4f\xf8\x7d\xf8\x73\x6a\x95\x90\xa3\x3e\x29\x20\x0b\x45\x69\xd9\xc2\x08\xd8\x6e\x5e\x09\xdd\x0d\xbb\x12\x4e\xa2\xe6\xa3\x11\x79\x84\x68\xf5\x60\x64\x28\xfd\xb7\xa0\x70\x7d\x30\x8e\xa4\xa7\x95\x02\x00\x9f\x9d\xe3\xf3\x6a\xfe\xd9\x8c\xac\x53\xb8\x91\xdf\xa0\x69\xd6\xe5\xa3\x80\x75\x39\xa5\x36\x8b\x17\x74\x64\xf5\x38\x84\x14\x64\x7e\x48\xf5\xef\xd4\x7f\xb6\xb4\xa4\x66\x5f\x29\x63\xef\xec\xa7\x7b\x8a\xa8\xb9\x65\x31\x9d\xa3\x69\xfa\x2f\xbd\x98\x95\xa2\x1e\xcd\x85\xa6\x31\x9f\xba\xb6\x29\xa4\x51\x0b\xf5\x91\x12\x7e\xec\xd1\x34\x05\x9b\x42\xf2\x6f\x07\xf5\x91\xca\x52\xb4\x49\x85\xa9\xae\x12\x79\xb4\x0b\xa2\x3a\x75\x8e\xe1\x3e\x33\xea\x1a\xd8\x4f\x52\xb3\x25\x9f\x38\x31\x09\x32\x23\x78\x64\x6e\x9f\x05\xf6\xaf\x95\xaa\xc7\x40\x91\x4a\xc7\x22\x99\x22\xf6\xf8\x09\xe3\x6d\x49\x24\x77\x57\xea\x53\x89\x76\xea\x73\x00\x38\x1f\xc6\x49\x3e\x02\x00\x00"),
+		},
+		"/sql/sqlite3/UserManager.GetTOTP.generated.sql": &vfsgen۰CompressedFileInfo{
+			name:             "UserManager.GetTOTP.generated.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 303,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x6c\x8f\xc1\x4e\x85\x30\x10\x45\xf7\xfd\x8a\xeb\xea\x3d\x8c\xf4\x07\x08\x71\x21\x2e\xdc\xc8\x86\x7d\x53\xda\x51\x89\x95\x62\x67\x90\xf0\xf7\xa6\x60\x30\x1a\x76\xed\xdc\x33\xe7\x66\xca\x12\x0f\xd1\x13\x5e\x69\xa4\x64\x85\x3c\xfa\x15\xfd\x3c\x04\x6f\xf8\x33\x68\xbb\xbc\x57\x68\x5a\x3c\xb7\x1d\x1e\x9b\xa7\x4e\x2b\xa6\x40\x4e\x14\x30\x33\x25\xd6\x12\x65\x32\x4c\x2e\x91\xc0\x32\xf6\xd7\xdd\xdf\x38\x58\x16\xc3\x42\x53\x26\x8e\x4f\x86\xae\x0a\x00\x76\x25\x5c\x9c\x47\xb9\xde\x16\x78\x49\xf1\x63\xdb\x37\x89\x5c\xfc\xa2\xb4\x1a\x17\x3d\xf1\x06\x2f\x6f\x94\xe8\x2c\xd5\xdb\x6c\xf0\xa8\x7f\xba\x07\xaf\x80\x22\x77\xfe\xd3\x1c\x7e\x56\xbf\xb6\xcc\xa3\xc6\x3d\xec\xe8\x4f\x6e\xbb\xa9\x71\xb9\x54\xea\x7b\x00\x6c\x26\xe7\xcd\x2f\x01\x00\x00"),
+		},
+		"/sql/sqlite3/UserManager.GetWebAuthnCredential.generated.sql": &vfsgen۰CompressedFileInfo{
+			name:             "UserManager.GetWebAuthnCredential.generated.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 265,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x4c\x8f\xb1\x0e\x82\x30\x10\x86\xf7\x3e\xc5\x3d\x80\xf0\x02\xc6\x38\x88\x83\x8b\x2c\xec\xcd\xb5\x3d\xa1\xa1\x14\xed\xb5\x21\xbc\xbd\x39\x31\xe2\xd4\x7e\xdf\xb7\xfc\x57\x55\x70\x99\x1d\x41\x4f\x91\x12\x66\x72\x60\x56\x30\xc5\x07\xa7\xf9\x15\x6a\x5c\xc6\x23\x34\x2d\xdc\xdb\x0e\xae\xcd\xad\xab\x15\x53\x20\x9b\x15\x80\x77\x80\x0c\xde\x1d\x14\x40\x61\x4a\x7a\x13\xdf\xaf\xd8\x88\x13\x89\x92\x57\xf8\x59\x4c\xf0\x56\x8f\xb4\x8a\xdd\x49\x1a\xfb\x3e\x6a\x3b\x97\x98\xa5\xed\x24\xcd\x26\x92\x65\x1a\x3f\x6d\x27\x69\x01\x39\xeb\xc2\xbf\xfa\xcf\xea\x91\xe6\x69\x1b\xb4\x90\xc1\x92\x87\xa8\x6d\x22\x47\x31\x7b\x0c\xac\x96\x81\x12\xc9\x1d\x27\x38\xab\xf7\x00\x90\x16\x2a\x70\x09\x01\x00\x00"),
+		},
+		"/sql/sqlite3/UserManager.HasIdentity.generated.sql": &vfsgen۰FileInfo{
+			name:    "UserManager.HasIdentity.generated.sql",
+			modTime: time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			content: []byte("\x2d\x2d\x20\x43\x6f\x64\x65\x20\x67\x65\x6e\x65\x72\x61\x74\x65\x64\x20\x62\x79\x20\x62\x75\x69\x6c\x64\x5f\x73\x71\x6c\x2e\x61\x77\x6b\x3b\x20\x44\x4f\x20\x4e\x4f\x54\x20\x45\x44\x49\x54\x2e\x0a\x73\x65\x6c\x65\x63\x74\x20\x65\x78\x69\x73\x74\x73\x20\x28\x73\x65\x6c\x65\x63\x74\x20\x31\x20\x66\x72\x6f\x6d\x20\x75\x73\x65\x72\x5f\x69\x64\x65\x6e\x74\x69\x74\x69\x65\x73\x20\x77\x68\x65\x72\x65\x20\x75\x73\x65\x72\x5f\x69\x64\x20\x3d\x20\x3f\x29\x0a"),
+		},
+		"/sql/sqlite3/UserManager.Update.generated.sql": &vfsgen۰CompressedFileInfo{
+			name:             "UserManager.Update.generated.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 232,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x4c\x8e\x41\xae\x82\x30\x14\x45\xe7\x5d\xc5\x5d\xc0\x87\x05\xfc\x1f\x06\x3f\xc0\x80\x01\x60\xb0\x8e\x9b\x92\xf7\x02\x8d\x28\xb5\x2d\x12\x77\x6f\xb0\x1a\x99\xdd\xfb\xce\x79\xc9\x4d\x12\xe4\x33\x31\x06\xbe\xb2\xd3\x81\x09\xfd\x03\xfd\x62\x26\x52\xfe\x36\xa5\x7a\x3d\xff\xa1\x68\xd1\xb4\x12\x65\x51\xc9\x54\x2c\x96\x74\x60\x2c\x9e\x9d\x17\x80\xe7\x20\x00\x80\x2f\xda\x4c\xc8\xf0\xfb\x0a\x3f\xef\x5b\xcf\xa4\xec\x38\x87\xd9\x47\xf4\xed\x7b\xe3\x6e\x88\xf7\x46\xec\xd1\xb0\xec\x94\xd5\x03\x6f\xf4\x93\x23\x89\x43\x48\xe9\x80\x0c\xf9\xa9\xeb\xca\x46\x2a\x59\xd5\xe5\x51\xfe\xd7\x07\xb1\x8e\xec\x18\x86\xb6\x47\x43\xe2\x39\x00\xfe\x8c\xe1\xa0\xe8\x00\x00\x00"),
+		},
+		"/sql/sqlite3/UserManager.UpdateAPIToken.generated.sql": &vfsgen۰FileInfo{
+			name:    "UserManager.UpdateAPIToken.generated.sql",
+			modTime: time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			content: []byte("\x2d\x2d\x20\x43\x6f\x64\x65\x20\x67\x65\x6e\x65\x72\x61\x74\x65\x64\x20\x62\x79\x20\x62\x75\x69\x6c\x64\x5f\x73\x71\x6c\x2e\x61\x77\x6b\x3b\x20\x44\x4f\x20\x4e\x4f\x54\x20\x45\x44\x49\x54\x2e\x0a\x75\x70\x64\x61\x74\x65\x20\x75\x73\x65\x72\x73\x0a\x20\x20\x73\x65\x74\x0a\x20\x20\x20\x20\x61\x70\x69\x5f\x74\x6f\x6b\x65\x6e\x20\x3d\x20\x3f\x2c\x0a\x20\x20\x20\x20\x75\x70\x64\x61\x74\x65\x64\x5f\x61\x74\x20\x3d\x20\x43\x55\x52\x52\x45\x4e\x54\x5f\x54\x49\x4d\x45\x53\x54\x41\x4d\x50\x0a\x77\x68\x65\x72\x65\x20\x69\x64\x20\x3d\x20\x3f\x0a"),
+		},
+		"/sql/sqlite3/UserManager.UpdateAccess.generated.sql": &vfsgen۰CompressedFileInfo{
+			name:             "UserManager.UpdateAccess.generated.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 149,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x44\xcb\x4d\x0a\xc2\x30\x10\x47\xf1\x7d\x4e\xf1\x3f\x80\xed\x05\xa4\x88\xb4\x59\x74\xd1\x56\x6a\x5c\x87\x09\x33\x68\x30\x56\xcd\x07\xc5\xdb\x0b\x75\xe1\xf2\x3d\xf8\x55\x15\xda\x27\x0b\xae\xb2\x48\xa4\x2c\x0c\xf7\x81\x2b\x3e\xb0\x4d\xef\x50\xd3\x7a\xdf\xa3\x9b\x30\x4e\x06\xba\xeb\x4d\xad\xca\x8b\x29\x0b\x4a\x92\x98\x14\x90\x24\x2b\x00\x20\x7e\xf8\x05\x0d\x0e\xbb\x2d\xd9\x27\x72\x41\xf8\x7f\x7e\x8e\x2d\x65\x34\x68\x2f\xf3\xac\x47\x63\x4d\x3f\xe8\xb3\x39\x0e\x27\xb5\xde\x24\x0a\xfc\x06\xd4\x77\x00\x16\x28\x3d\xa2\x95\x00\x00\x00"),
+		},
+		"/sql/sqlite3/UserManager.UpdatePassword.generated.sql": &vfsgen۰FileInfo{
+			name:    "UserManager.UpdatePassword.generated.sql",
+			modTime: time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			content: []byte("\x2d\x2d\x20\x43\x6f\x64\x65\x20\x67\x65\x6e\x65\x72\x61\x74\x65\x64\x20\x62\x79\x20\x62\x75\x69\x6c\x64\x5f\x73\x71\x6c\x2e\x61\x77\x6b\x3b\x20\x44\x4f\x20\x4e\x4f\x54\x20\x45\x44\x49\x54\x2e\x0a\x75\x70\x64\x61\x74\x65\x20\x75\x73\x65\x72\x73\x0a\x20\x20\x73\x65\x74\x0a\x20\x20\x20\x20\x70\x61\x73\x73\x77\x6f\x72\x64\x5f\x68\x61\x73\x68\x20\x3d\x20\x3f\x2c\x0a\x20\x20\x20\x20\x75\x70\x64\x61\x74\x65\x64\x5f\x61\x74\x20\x3d\x20\x43\x55\x52\x52\x45\x4e\x54\x5f\x54\x49\x4d\x45\x53\x54\x41\x4d\x50\x0a\x77\x68\x65\x72\x65\x20\x69\x64\x20\x3d\x20\x3f\x0a"),
 		},
 		"/sql/sqlite3/UserManager.UpdatePinnedCategories.generated.sql": &vfsgen۰CompressedFileInfo{
 			name:             "UserManager.UpdatePinnedCategories.generated.sql",
-			modTime:          time.Date(2020, 12, 16, 19, 18, 9, 999987786, time.UTC),
-			uncompressedSize: 496,
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 491,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x6c\x91\xc1\x6e\xf2\x30\x10\x84\xef\x79\x8a\x39\xfc\x92\x63\x29\xe4\x05\x7e\x21\x0e\xa5\x87\x5e\xca\x85\x7b\xb4\xb1\xb7\xc1\x60\x62\x6a\x3b\xa5\xbc\x7d\xe5\x0d\xaa\xa2\xa8\xa7\x8d\x66\x77\x66\x3e\xc5\x9b\x0d\x5e\x82\x65\x0c\x3c\x72\xa4\xcc\x16\xfd\x03\xfd\xe4\xbc\xed\xd2\xa7\x6f\xe9\x7e\xf9\x8f\xfd\x01\xef\x87\x23\x5e\xf7\x6f\xc7\xb6\x9a\x6e\x96\x32\x63\x4a\x1c\x53\x05\x24\xce\xb8\xb9\x71\x64\xdb\x19\xca\x3c\x84\xe8\x38\x61\x8b\x5a\x76\x9e\x4d\xae\x00\xe0\x9c\xc2\xd8\x0d\x31\x4c\xb7\x8e\x62\xa4\x47\x2d\xea\x53\x0f\xfd\x99\x4d\xae\x95\xa7\x9e\xbd\x6a\x20\xb3\x81\xca\x34\x24\xd5\xa0\x0c\xad\x2b\xe0\x23\x86\x2b\x66\xe7\x22\xfa\x19\xc2\xdf\x39\x92\xc9\xb5\xa1\x9c\xda\x2f\xf2\x13\x37\x50\xff\xda\x39\x53\x83\xd2\x33\x76\xe9\xf9\x03\x68\x85\xe4\xac\x6a\x7e\x37\xab\x26\x97\xf9\xba\xac\x72\x56\x69\x2d\x4d\x85\x58\x5c\x42\x3c\x9b\xc8\x9c\xea\xf2\x55\xef\xb4\x46\x81\x9c\xff\x4b\x70\xe3\xe2\x60\x05\x5f\x72\x5a\xa9\x51\x1a\x32\xc5\x24\xd8\xe5\x9d\xe4\xfc\xc2\x0f\x51\x43\xb4\x1c\x97\xaa\xd6\xd5\xfd\xc4\x91\xe1\x2c\xb6\xd8\x55\x3f\x03\x00\x8f\xb0\x23\x09\xeb\x01\x00\x00"),
+		},
+		"/sql/sqlite3/UserManager.UseRecoveryCode.generated.sql": &vfsgen۰FileInfo{
+			name:    "UserManager.UseRecoveryCode.generated.sql",
+			modTime: time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			content: []byte("\x2d\x2d\x20\x43\x6f\x64\x65\x20\x67\x65\x6e\x65\x72\x61\x74\x65\x64\x20\x62\x79\x20\x62\x75\x69\x6c\x64\x5f\x73\x71\x6c\x2e\x61\x77\x6b\x3b\x20\x44\x4f\x20\x4e\x4f\x54\x20\x45\x44\x49\x54\x2e\x0a\x64\x65\x6c\x65\x74\x65\x20\x66\x72\x6f\x6d\x20\x75\x73\x65\x72\x5f\x72\x65\x63\x6f\x76\x65\x72\x79\x5f\x63\x6f\x64\x65\x73\x20\x77\x68\x65\x72\x65\x20\x75\x73\x65\x72\x5f\x69\x64\x20\x3d\x20\x3f\x20\x61\x6e\x64\x20\x63\x6f\x64\x65\x5f\x68\x61\x73\x68\x20\x3d\x20\x3f\x0a"),
+		},
+		"/sql/sqlite3/UserManager.UseTOTPStep.generated.sql": &vfsgen۰CompressedFileInfo{
+			name:             "UserManager.UseTOTPStep.generated.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 145,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x44\x8b\xb1\x0e\x82\x30\x14\x45\xf7\x7e\xc5\x75\x62\x82\x1f\x50\xc2\x20\x0e\x2e\xb2\xb0\x37\xc5\x77\xa3\xc4\x06\x6a\xdf\x6b\x88\x7f\x6f\x8c\x03\xe3\x39\x39\xa7\xae\x71\x5e\x85\x78\x70\x61\x0e\x46\xc1\xf4\xc1\x54\xe6\x28\x5e\xdf\xb1\x09\xdb\xeb\x88\x7e\xc0\x6d\x18\x71\xe9\xaf\x63\xe3\x4a\x92\x60\x44\x51\x66\x75\x80\xd2\x60\xab\x25\x1f\x83\x9a\x57\x63\x42\x8b\xce\x6d\x4f\x66\x62\x96\x1f\x20\x2c\xf2\x6f\x94\xf7\x4c\xc3\xa1\x45\x55\xed\x76\x3f\x4f\xe8\xdc\x77\x00\x5e\x77\xd1\xdb\x91\x00\x00\x00"),
+		},
+		"/sql/sqlite3/UserManager.UseWebAuthnCredential.generated.sql": &vfsgen۰CompressedFileInfo{
+			name:             "UserManager.UseWebAuthnCredential.generated.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 158,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8c\x91\xb1\x6e\xf3\x20\x14\x85\x77\x9e\xe2\x0c\xbf\x04\x48\x8e\x5f\xe0\x57\x94\xa1\xe9\xd0\xa5\x59\xb2\x5b\xd7\x70\xeb\x90\x12\x93\x02\x6e\x9a\xb7\xaf\xc0\x51\x65\x59\x1d\x3a\x1d\xeb\x5e\x9f\x73\x3e\x60\xb3\xc1\x53\xb0\x8c\x81\x47\x8e\x94\xd9\xa2\xbf\xa3\x9f\x9c\xb7\x5d\xfa\xf0\x2d\xdd\xde\xff\x63\x7f\xc0\xeb\xe1\x88\xe7\xfd\xcb\xb1\x15\xd3\xd5\x52\x66\x4c\x89\x63\x12\x40\xe2\x8c\xab\x1b\x47\xb6\x9d\xa1\xcc\x43\x88\x8e\x13\xb6\x50\x75\xe7\xd9\x64\x01\x00\xe7\x14\xc6\x6e\x88\x61\xba\x76\x14\x23\xdd\x55\x9d\x3e\xe6\xa1\x3f\xb3\xc9\x4a\x7a\xea\xd9\xcb\x06\x55\x1b\xc8\x4c\x43\x92\x0d\x8a\x68\x2d\x80\xb7\x18\x2e\x98\x9d\x8b\xe8\x47\x08\x7f\xe5\x48\x26\x2b\x43\x39\xb5\x9f\xe4\x27\x6e\x20\xff\xb5\x73\xa6\x06\xa5\x47\xec\xd2\xf3\x0b\xd0\x0a\xc9\x59\xd9\xfc\x6c\x56\x4d\x2e\xf3\x65\x59\xe5\xac\xd4\xba\x36\x15\xe2\xea\xaa\xc4\xb3\x89\xcc\x49\x95\x2f\xb5\xd3\x1a\x05\x72\xbe\x97\xe0\xc6\xc5\x0f\x2b\xf8\x92\xd3\xd6\x1a\xa9\x51\xb5\x9a\x2a\x76\x79\xa7\x3f\x1c\x5c\x6b\x71\x3b\x71\x64\x38\x8b\x2d\x76\xe2\x3b\x00\x00\xff\xff\x6d\x6e\x8f\x38\xf0\x01\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x1c\xcb\x41\x0e\x82\x30\x10\x05\xd0\x7d\x4f\xf1\x0f\x20\x5c\xc0\x18\x63\x80\x05\x0b\xc0\x60\x5d\x37\x85\x4e\xa0\xb1\x29\xda\x99\x86\x78\x7b\x83\xdb\x97\xbc\xa2\x40\xb5\x39\xc2\x42\x91\x92\x15\x72\x98\xbe\x98\xb2\x0f\xce\xf0\x27\x94\x76\x7f\x9d\x51\x0f\xe8\x07\x8d\xa6\x6e\x75\xa9\xf2\xdb\x59\x21\x64\xa6\x64\x76\x9a\x6c\x96\x35\x9a\x39\x91\xa3\x28\xde\x06\x56\x00\x93\x28\x00\x60\xbf\x44\x33\x6f\x39\x0a\x2e\xb8\x9e\xfe\x16\x2c\x8b\xc9\x4c\xce\xd8\x43\xab\xe7\x38\x36\xbd\x36\xba\xed\x9a\x87\xbe\x75\x77\xb5\xaf\x94\x08\xde\x1d\x45\xfd\x06\x00\x7a\xc4\x2f\x0a\x9e\x00\x00\x00"),
+		},
+		"/sql/sqlite3/UserManager.addRecoveryCode.generated.sql": &vfsgen۰FileInfo{
+			name:    "UserManager.addRecoveryCode.generated.sql",
+			modTime: time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			content: []byte("\x2d\x2d\x20\x43\x6f\x64\x65\x20\x67\x65\x6e\x65\x72\x61\x74\x65\x64\x20\x62\x79\x20\x62\x75\x69\x6c\x64\x5f\x73\x71\x6c\x2e\x61\x77\x6b\x3b\x20\x44\x4f\x20\x4e\x4f\x54\x20\x45\x44\x49\x54\x2e\x0a\x69\x6e\x73\x65\x72\x74\x20\x69\x6e\x74\x6f\x20\x75\x73\x65\x72\x5f\x72\x65\x63\x6f\x76\x65\x72\x79\x5f\x63\x6f\x64\x65\x73\x20\x28\x75\x73\x65\x72\x5f\x69\x64\x2c\x20\x63\x6f\x64\x65\x5f\x68\x61\x73\x68\x29\x20\x76\x61\x6c\x75\x65\x73\x20\x28\x3f\x2c\x20\x3f\x29\x0a"),
+		},
+		"/sql/sqlite3/UserManager.clearRecoveryCodes.generated.sql": &vfsgen۰FileInfo{
+			name:    "UserManager.clearRecoveryCodes.generated.sql",
+			modTime: time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			content: []byte("\x2d\x2d\x20\x43\x6f\x64\x65\x20\x67\x65\x6e\x65\x72\x61\x74\x65\x64\x20\x62\x79\x20\x62\x75\x69\x6c\x64\x5f\x73\x71\x6c\x2e\x61\x77\x6b\x3b\x20\x44\x4f\x20\x4e\x4f\x54\x20\x45\x44\x49\x54\x2e\x0a\x64\x65\x6c\x65\x74\x65\x20\x66\x72\x6f\x6d\x20\x75\x73\x65\x72\x5f\x72\x65\x63\x6f\x76\x65\x72\x79\x5f\x63\x6f\x64\x65\x73\x20\x77\x68\x65\x72\x65\x20\x75\x73\x65\x72\x5f\x69\x64\x20\x3d\x20\x3f\x0a"),
 		},
 		"/sql/sqlite3/UserManager.getPinnedCategories.generated.sql": &vfsgen۰CompressedFileInfo{
 			name:             "UserManager.getPinnedCategories.generated.sql",
-			modTime:          time.Date(2020, 12, 16, 19, 18, 9, 999987786, time.UTC),
-			uncompressedSize: 437,
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 431,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x50\x31\x4e\x03\x31\x10\xec\xfd\x8a\x2d\x90\x7c\x96\x2e\xfe\x00\x42\x14\x84\x82\x86\x34\xe9\x4f\x7b\xf6\x72\xf1\xc5\xb1\xc3\x7a\x8f\x90\xdf\x23\x3b\x41\x22\x29\x68\xac\xdd\xf1\x8c\x66\x76\x56\x2b\x78\xc9\x9e\x60\xa2\x44\x8c\x42\x1e\xc6\x33\x8c\x4b\x88\x7e\x28\x9f\xd1\xe2\x69\xff\x08\xeb\x0d\xbc\x6f\xb6\xf0\xba\x7e\xdb\x5a\x55\x28\x92\x13\x05\x30\x97\x9c\x06\xfa\x16\x46\x27\x9d\x43\x29\xf6\x0b\xe3\x42\x3d\xe8\x07\x1b\x71\xa4\xa8\x0d\x60\x81\x36\xf6\xbf\xfc\x3c\xce\xe4\xa4\xd3\x41\xe8\x50\x74\x85\xaf\x1f\x13\xe7\xe5\x38\x20\x33\x9e\xbb\x86\xde\x0b\xbc\xee\x41\x6c\xf0\x3d\xe8\x84\x07\x6a\x5b\x1d\x4c\x63\xd7\xb7\xd9\x09\x4e\x45\x7d\x70\x3e\xc0\x52\x88\x8b\x9a\x73\x48\xd7\xa8\xe8\x76\x5d\x03\xed\x31\xa4\x44\x7e\x70\x28\x34\x65\x0e\x54\x0c\xd4\x03\xee\xc9\xff\x5d\x58\x7d\xb4\x31\x30\xcb\x45\x55\x77\x10\xc8\xa9\x85\x84\xa7\xdb\x7a\x66\xf9\x23\x0d\x5e\x1b\x75\xda\x11\xd3\x25\xe3\x85\xff\xac\x5a\x05\xb5\xfe\x66\xb5\xa7\xb3\xca\xec\x89\x6f\x90\x9f\x01\x00\x8c\x92\x19\x1f\xaf\x01\x00\x00"),
+		},
+		"/sql/sqlite3/UserManager.getWebAuthnCredentials.generated.sql": &vfsgen۰CompressedFileInfo{
+			name:             "UserManager.getWebAuthnCredentials.generated.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 297,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x4c\x90\x31\x4e\x43\x31\x10\x05\x7b\x9f\x62\x0f\x40\x72\x01\x84\x28\x08\x05\x0d\x69\xd2\x5b\xfb\xbd\x8f\xc4\x8a\x63\xc3\xae\x2d\x2b\xb7\x47\x4b\x50\x7e\x2a\x7b\x66\x0a\x3f\x79\xb3\xa1\xb7\x26\xa0\x23\x2a\x94\x3b\x84\x96\x2b\x2d\x23\x17\x89\xf6\x53\xb6\x3c\xcf\xcf\xb4\xdb\xd3\xe7\xfe\x40\xef\xbb\x8f\xc3\x36\x18\x0a\x52\x0f\x44\x59\x88\x8d\xb2\x3c\x05\xa2\x61\xd0\x78\x13\xff\x57\xb7\x95\x2f\x70\xe5\xa7\xf3\xf7\x58\x4a\x4e\xf1\x8c\xab\xdb\x95\xbc\x59\x3e\xd6\x98\xda\xa8\xdd\xdb\x4a\xde\x92\xc2\x97\x45\xfe\x6b\x2b\x79\x2b\x6c\x3d\x0e\xbb\xd7\x47\x0e\x5f\xda\x2e\xb7\x41\x13\x0b\x8f\x7e\xaa\x31\x29\x04\xb5\x67\x2e\x16\xe6\x09\x8a\xfb\xf6\x17\x7a\x0d\x4d\x05\xea\x3f\xf0\xf0\x08\x69\x9b\x59\xc2\xef\x00\x2b\x97\x32\xfa\x29\x01\x00\x00"),
+		},
+		"/sql/sqlite3/UserManager.updateTOTP.generated.sql": &vfsgen۰CompressedFileInfo{
+			name:             "UserManager.updateTOTP.generated.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 161,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x90\x41\x4f\x02\x31\x10\x85\xef\xfd\x15\xcf\xc4\xa4\xdb\x04\xfa\x07\x0c\xf1\x20\x1e\xbc\xc8\x85\x9b\x31\x64\xb6\x1d\xa0\x6b\x69\xb5\x1d\x44\xfe\xbd\xd9\x82\x09\x24\xea\xa5\x99\xbe\x79\xd3\xf9\x5e\xa7\x53\x3c\x64\xcf\xd8\x70\xe2\x42\xc2\x1e\xfd\x11\xfd\x3e\x44\xbf\xaa\x1f\xd1\xd2\xe1\xed\x0e\xf3\x05\x9e\x17\x4b\x3c\xce\x9f\x96\x56\x55\x8e\xec\x44\x01\x43\xcd\x69\xc5\x5f\x52\xc8\x49\xe7\x48\xaa\xfd\xa4\xb8\xe7\x09\xf4\xad\x8d\xd4\x73\xd4\x06\x54\xd1\xca\xc9\x8f\x3f\xf7\x03\x3b\xe9\x74\x10\xde\x55\x3d\xca\xe7\x06\x95\x42\xc7\xae\xdd\xcf\x4a\x65\xe9\x06\xb9\x78\x34\xd1\x8e\xf5\x04\xd2\x0a\xd3\xac\xe3\xd9\xb6\x08\x6d\xaa\x5a\x97\xbc\xc3\xbe\x72\xa9\x2a\xf2\x5a\x30\xe4\x90\xce\x98\xe4\xb6\x5d\xeb\xd8\xf7\x90\x12\xfb\x95\x23\xe1\x4d\x2e\x81\xab\xc1\x08\xff\xeb\xc4\x7f\x11\xc7\x8d\xda\x18\x0c\x72\x31\x3a\x8a\x10\xe4\x04\xb1\xc1\x63\x76\xfd\x49\x57\x69\x82\xd7\x46\x1d\xb6\x5c\xf8\x84\x7c\xf2\xdf\x83\x92\xc7\x1f\xa4\xb8\x99\x41\xbf\xbc\x6a\xf5\x1d\x00\x00\xff\xff\x3d\xab\xdf\xd2\xb5\x01\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x44\xcc\xc1\xaa\x82\x40\x18\x86\xe1\xfd\x5c\xc5\x77\x01\x47\x39\xfb\x90\x08\x75\xe1\x42\x0d\x9b\xd6\xc3\xd8\x7c\x94\x24\x69\xf3\xff\x22\xdd\x7d\x88\x8b\xb6\x2f\x2f\x4f\x92\x20\x9f\x02\x71\xe7\x8b\xd1\x2b\x03\xfa\x0f\xfa\x65\x18\x83\x93\xf7\x98\xfa\xf5\x79\x40\xd1\xa2\x69\x2d\xca\xa2\xb2\xa9\x59\xe6\xe0\x95\x58\x84\x51\x0c\x20\x54\x03\x00\x3a\xe9\xec\x84\xb7\x48\x45\x86\xe3\xdf\x2f\x8e\x5e\xd4\x89\x72\x46\x86\xff\xbd\xef\x46\x70\x7e\x7b\xf3\x6b\xd7\x95\x8d\x75\xb6\xaa\xcb\x8b\x3d\xd5\x67\xb3\x3e\x18\x89\x21\x6c\x90\xf9\x0e\x00\x16\xbd\x7a\xce\xa1\x00\x00\x00"),
 		},
 		"/sql/sqlite3/UserURLManager.Create.generated.sql": &vfsgen۰CompressedFileInfo{
 			name:             "UserURLManager.Create.generated.sql",
-			modTime:          time.Date(2020, 12, 16, 19, 18, 9, 999987786, time.UTC),
-			uncompressedSize: 215,
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 284,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x4c\xcd\xbd\xae\xc2\x30\x0c\x86\xe1\xbd\x57\xf1\x8d\xe7\x48\x69\x2f\x20\x8c\x94\x81\x85\x2e\xdd\xab\x94\x18\x64\x61\xb5\xe0\x38\x45\xdc\x3d\xea\x8f\x44\x27\xbf\xb2\xe4\xc7\x65\x89\xe3\x18\x09\x77\x1a\x48\x83\x51\x44\xff\x41\x9f\x59\x62\x97\x5e\x52\x85\xf7\xe3\x80\xba\xc1\xa5\x69\x71\xaa\xcf\x6d\x55\xf0\x90\x48\x0d\x3c\xd8\x88\x9c\x48\xbb\xac\x92\x0a\xe0\x8f\xa3\x5b\x17\x4b\xa8\x2c\xd3\xd8\x84\x1c\x6e\x61\x1a\x95\x8d\x1c\xae\x4a\xf3\x97\x2e\x98\x43\x7e\xc6\xad\xff\x8b\x29\x48\xa6\xc5\xf1\xf3\x9d\x9f\xa5\x6a\x2d\x95\x35\x36\xcb\xff\x30\xbf\xd7\xfc\x9e\xfb\x06\x00\x00\xff\xff\xd1\xfa\x72\x92\xd7\x00\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x54\x8e\xb1\x4e\xc3\x30\x10\x86\xf7\x3c\xc5\x3f\xb6\x92\x9b\x07\x30\x13\x6a\x33\x74\x68\x8b\x8a\x99\x23\xa7\x3e\xd0\x89\x53\x0c\xf6\x39\xa8\x6f\x8f\x9c\x44\xa2\x4c\xf7\xe9\x86\xef\xfb\x77\x3b\xec\x63\x20\x7c\xd0\x48\xc9\x2b\x05\x0c\x77\x0c\x85\x25\xf4\xf9\x5b\x5a\xff\xf3\xf9\x84\xc3\x05\xe7\x8b\x43\x77\x38\xba\xb6\xe1\x31\x53\x52\xf0\xa8\x11\x25\x53\xea\x4b\x92\xdc\x00\x1b\x0e\x66\x79\xcc\x90\x64\xbe\xca\x2a\x64\x30\x46\xa5\x6c\xf0\xee\xa7\x98\x58\xc9\x60\xe2\xcc\x03\x0b\xeb\xdd\xe0\x96\xa8\x86\x7b\xaf\x06\xe5\x2b\xac\xbc\x6d\x26\x2f\x85\x66\xb5\xad\x2a\x5b\xe5\xed\x42\x49\x16\x58\xf5\x76\xf5\xdb\xbf\x80\xfd\x57\x88\x5e\x28\xdf\x68\x63\x1f\x5b\xfb\xb7\xeb\xb5\x3b\xbb\xde\x1d\x4f\xdd\xab\x7b\x3e\xbd\x6c\xab\xfa\x61\xc0\xef\x00\x56\x58\xbf\xcb\x1c\x01\x00\x00"),
+		},
+		"/sql/sqlite3/UserURLManager.Delete.generated.sql": &vfsgen۰FileInfo{
+			name:    "UserURLManager.Delete.generated.sql",
+			modTime: time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			content: []byte("\x2d\x2d\x20\x43\x6f\x64\x65\x20\x67\x65\x6e\x65\x72\x61\x74\x65\x64\x20\x62\x79\x20\x62\x75\x69\x6c\x64\x5f\x73\x71\x6c\x2e\x61\x77\x6b\x3b\x20\x44\x4f\x20\x4e\x4f\x54\x20\x45\x44\x49\x54\x2e\x0a\x64\x65\x6c\x65\x74\x65\x20\x66\x72\x6f\x6d\x20\x75\x73\x65\x72\x5f\x75\x72\x6c\x73\x20\x77\x68\x65\x72\x65\x20\x75\x73\x65\x72\x5f\x69\x64\x20\x3d\x20\x3f\x20\x61\x6e\x64\x20\x69\x64\x20\x3d\x20\x3f\x0a"),
 		},
 		"/sql/sqlite3/UserURLManager.GetAll.generated.sql": &vfsgen۰CompressedFileInfo{
 			name:             "UserURLManager.GetAll.generated.sql",
-			modTime:          time.Date(2020, 12, 16, 19, 18, 9, 999987786, time.UTC),
-			uncompressedSize: 597,
-
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x5c\x52\xbf\x6e\xf3\x20\x10\xdf\xfd\x14\xb7\x11\x4b\x0e\x2f\xf0\x29\xfa\x86\xa6\x43\x97\x66\xc9\x8e\x88\xb9\xb8\xa4\xc4\xa4\xc7\x5d\xa2\xbc\x7d\x05\x06\x2b\xea\xc6\xef\x2f\xe6\xce\xdb\x2d\xbc\x45\x87\x30\xe1\x8c\x64\x19\x1d\x9c\x9e\x70\x12\x1f\x9c\x49\x3f\x41\xdb\xc7\xf7\x3f\xd8\x1f\xe0\xf3\x70\x84\xf7\xfd\xc7\x51\x77\x09\x03\x8e\xdc\x01\x88\x68\xef\xc0\x26\xf0\x6e\xc8\xb0\x22\x25\x14\xb4\x77\x6a\xe1\x84\xc2\x4a\x0a\x85\xca\xb2\xe7\x80\x2b\x5f\xd0\xa2\x88\x96\x84\x64\x5a\x53\x42\x5a\xab\x5e\x52\xe5\x90\xc9\x31\xda\x80\x69\xc4\x4d\x07\x00\x30\x4b\x08\xfe\xbc\x69\xce\x01\x94\xea\x87\xa2\x54\xa6\x03\xe8\x73\xde\x21\xf9\x3b\x3a\xb3\xf6\x5c\x52\x9c\x4d\x3c\x5d\x70\xe4\x8d\xf2\x8c\xd7\xa4\x96\x60\x11\x26\x8a\x72\x33\x96\xc8\x3e\x97\x8b\xfe\x06\x9c\x1a\x80\xb5\x77\x03\xa8\xd9\x5e\xb1\xa0\x7c\xe8\x8b\xbb\x6f\xd7\xb2\x9d\x52\x7d\xca\xd9\xde\x23\x79\x2e\xaf\x69\xe7\x2a\x8d\x84\x79\x0d\xc6\x72\x9b\xc8\xcd\x55\xa2\x3b\x53\xbc\x66\x32\xcf\x48\x28\x24\x10\xe9\x2e\xd1\xcf\xb0\x00\x88\xf3\xb2\x86\x5d\xc9\x51\x30\xde\x55\xbd\x26\x4c\xfe\x06\x10\x2e\x4e\xd6\x2b\xdd\x32\xcd\x5f\x6c\xc5\xc5\xb5\x8f\x35\xdb\x29\xf7\x3d\xbe\x90\xf0\x75\x53\x3b\xf8\xdf\x95\x11\xe5\x3f\x67\xe9\xf8\x0d\x00\x00\xff\xff\xac\x69\xc8\x83\x55\x02\x00\x00"),
-		},
-		"/sql/sqlite3/UserURLManager.GetAllAfter.generated.sql": &vfsgen۰CompressedFileInfo{
-			name:             "UserURLManager.GetAllAfter.generated.sql",
-			modTime:          time.Date(2020, 12, 16, 19, 18, 9, 999987786, time.UTC),
-			uncompressedSize: 794,
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 1934,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x5c\x52\xcb\x92\xdb\x20\x10\xbc\xf3\x15\x7d\x93\x9d\xd2\xf2\x03\xc9\x66\x0f\xd9\x1c\x72\xc9\x5e\xf6\x4e\x61\x31\x76\x70\xb0\xd8\x0c\x8c\x55\xfe\xfb\x14\x08\x3d\x6a\x4f\x9a\xe9\x6e\xba\x99\x41\x4f\x4f\xf8\x11\x1d\xe1\x42\x23\xb1\xcd\xe4\x70\x7a\xe0\x24\x3e\x38\x93\xfe\x05\x6d\xa7\xbf\x5f\xf1\xfa\x86\xdf\x6f\xef\xf8\xf9\xfa\xeb\x5d\xab\x44\x81\x86\x0c\x05\x00\x22\xfa\x4b\x5f\xab\x6b\x8a\xa3\x89\xa7\x2b\x0d\xf9\xd0\xf9\x4c\xb7\xd4\xcd\x44\xa3\x2e\x1c\xe5\xc3\x58\x66\xfb\x38\x34\xfc\xf3\x21\xd7\xf5\xc8\xda\xbb\x1e\xdd\x68\x6f\x54\xbb\x52\x1c\x9b\x7e\xfe\x1e\x61\x13\xb2\xbd\x24\x75\xe6\x78\x43\x31\x9b\x6f\x54\x59\x8e\x93\x19\xe5\x76\x22\x3e\x1c\x11\xef\xc4\x58\xd2\x22\x3b\xe2\x32\x9b\x88\xf6\xae\xba\x70\x9c\xfa\x65\x0c\xef\x0a\xe2\x5d\x03\x5a\xdf\x09\x07\x5d\x2e\xd6\x50\xe1\xb0\xc2\xc2\x61\xc5\xb3\xcf\x81\x56\xa6\x76\xdd\x6a\x2d\x89\xd8\x2c\x7e\x89\x78\x67\xb8\x3b\x59\x8b\x19\x1e\xa2\x0d\x94\x06\x5a\xae\x3e\x4a\x08\xfe\x7c\x58\xd4\x3d\xba\xee\xb8\x2c\xb7\x61\xdb\x6a\x1c\xb1\xbf\x93\x33\x3b\x3f\x11\x7d\xb6\xf7\xc8\x3e\xd7\xa4\xa5\x5e\xc9\x81\xa9\x3c\xbc\xb1\xb9\xd0\x5b\xb7\x4d\xf0\xe1\x76\x82\xad\x53\x40\x79\x84\x59\x56\xa6\x14\x0e\x09\x22\x0a\xb8\x46\x3f\x62\x6e\x11\xc7\x79\xa1\xcf\xd5\x8b\x83\xf1\x4e\x01\xd3\x1f\x62\xda\xef\xe7\x19\x2f\xaa\x8e\x20\xa2\x02\x9d\x73\xf3\x68\xbe\xa6\xbc\x39\x24\x57\xb7\xac\x57\x78\xf1\xf5\x6e\x77\xa8\x6a\xab\x34\xb7\xe0\xac\xb3\xbd\x94\xe0\x39\x96\xe3\x84\xef\x78\x81\x1d\x5d\xad\xbf\x95\xf0\xfa\x8f\xae\xbf\x88\xfa\x1f\x00\x00\xff\xff\x9f\x15\x51\x40\x1a\x03\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8c\x55\x3d\x93\xdb\x38\x0c\xed\xf5\x2b\xd0\xc9\x9e\xf1\x6a\xee\x5a\xdf\x39\x4d\x36\x45\x9a\x6c\xb3\xbd\x86\x26\x61\x1b\x6b\x9a\x74\x48\xc0\x3b\xfe\xf7\x19\x7e\x48\x96\xe4\xc9\x24\x2e\x76\x89\x87\x07\x02\x78\x80\xa4\x97\x17\xf8\xea\x0d\xc2\x11\x1d\x06\xc5\x68\x60\x7f\x87\xbd\x90\x35\x7d\xfc\x69\x3b\xf5\x79\xfe\x0f\x5e\xdf\xe0\xc7\xdb\x3b\x7c\x7b\xfd\xfe\xde\x35\x11\x2d\x6a\x6e\x00\x44\x3a\x32\xa0\x22\x90\xd9\x24\xb3\x5a\xad\x04\xdb\x91\x69\x0b\x26\xc1\x8e\xa0\x04\x5b\x51\x26\xb6\x38\xe2\xd9\xca\x1e\xed\x95\xc5\xa8\x71\x25\x9d\xf6\x8e\xd1\x71\xcf\xf7\x2b\x6e\xa0\x6d\xd7\x23\x7d\xea\x59\x46\x19\x8c\x3a\xd0\x95\xc9\xbb\x79\xd0\xc4\xb1\x8c\xa1\x8b\x3a\x62\x2f\xc1\xce\x23\x46\x78\xc9\x8f\xc4\xd8\x3b\x75\x59\x94\x35\xc2\x4b\xbe\x12\x3e\xf9\x30\x27\x17\xac\xaa\x71\x95\xbd\xa5\x78\x42\xd3\x2b\x1e\x19\x53\xf0\x49\x1b\xe5\xbc\x23\xad\xec\x73\xd5\x33\xd7\x32\xce\x2a\x77\x14\x75\x5c\x14\x3e\xa0\x4b\xf6\x41\xdd\x48\x7b\xf7\x9c\x63\xe2\xa8\x1d\x44\x56\x2c\xb1\xd7\x69\x91\x46\x3d\x1e\x58\x65\x1d\x14\x59\x09\x18\x27\x17\x15\xa0\xfa\x0d\x2a\x33\x19\x98\x1a\x76\x48\x9f\x50\x9f\xe7\xea\x3c\xa0\xca\x51\x41\x9f\xe8\x36\x27\x4d\xb0\xc2\x92\x4e\x22\x86\x7e\xd8\xd3\x88\x61\x5c\xd4\xc9\x4e\xe6\xc3\x4c\x8b\x06\x00\xc0\x89\xb5\x74\x58\x0d\xcc\x2c\xc9\x26\x7b\x2a\xd2\x00\x64\x8d\x0c\x86\x9c\xf5\xf9\x1e\x91\xce\x79\xc6\x38\xca\x59\xac\x06\xa0\xa4\x28\x8f\x16\x7c\x44\xef\x7a\xbf\xff\x40\xcd\xab\x96\x18\x2f\x45\xa0\xf4\xcb\xae\x63\xf0\x72\xed\x55\x08\xea\xbe\xaa\x38\x2c\x82\x4c\xbb\x01\xee\xc8\x6c\xa0\x2d\x2b\x09\xdc\xa5\xc3\xba\xf2\xd7\xcd\xe3\xef\x21\xf8\x0b\x64\x61\x24\xd8\x9e\xd5\x31\x82\x70\xf6\x7c\x78\x72\x90\x01\x06\xef\xf2\x85\xb0\x03\xe1\x8e\xd5\xb1\x27\x93\x39\x9f\x27\x0c\x98\xb0\xf1\x86\x42\x4a\xaf\x83\x41\x91\x74\x45\x55\xf9\xa0\x6e\x3e\x10\x67\xa1\x87\x73\x75\xdd\x28\xd2\x9e\x2c\xf1\x3d\x39\x1f\xd6\xa6\xa9\xdd\x95\x8e\x45\x3a\x1d\x30\xbd\xa9\x7a\xc5\x9b\x92\x28\x67\xd1\x12\xa2\x0f\xf5\xb2\x09\xa5\x00\x72\x35\x15\x68\x52\xc3\x09\xac\x05\x47\x10\x69\x72\xab\xc5\x48\xad\x4a\x37\x74\x51\x3a\x6a\x6a\x9b\x8f\x0d\xda\xc1\xb6\x1e\x1b\x00\xe5\x4c\x1d\xe1\x36\x49\xa3\xbd\x38\x86\x1d\xfc\x93\x21\x1f\x60\x18\x53\x1d\x70\xf6\xaf\x0c\x45\x26\xa7\x79\x31\x9a\xdf\x8f\xe3\xef\x06\xf2\xc7\x91\x94\x5f\x2a\xb9\x24\x06\x72\xb0\xaa\x95\xdd\x94\x15\x2c\x25\x64\xc9\x51\xe9\xd3\x2a\xf5\x14\xd7\x75\x65\xe0\xcb\x0e\xb4\x8a\x98\xb2\x38\xd8\x2a\x77\x2f\x35\x72\x32\xff\x05\xb4\x11\xa7\x22\xa0\xcb\x5b\x30\x68\xe4\x3c\xc3\x76\x1f\xfc\x19\x5d\xd2\xa5\x3c\xf3\x73\x6f\x7e\xf1\xe9\xec\x9d\xed\xc4\x0e\xda\xe2\x6a\xe7\xfc\x71\xa3\x4a\xc4\x60\xae\xe7\x63\x51\x07\xc6\xd0\x9f\xf1\x0e\x14\xf3\xa3\x3c\x8c\x66\xb6\x2b\xf0\xff\x84\x39\x0e\x6f\x4e\xd9\x4d\x2f\x4b\x19\xca\x77\x70\x8c\xa4\xdc\xcf\xba\xf1\xc1\x60\x48\x1f\xd3\x79\x78\xfa\x10\xd5\xad\xcd\xe7\xc6\xd2\x85\x18\xb6\xf9\x5f\xf3\x6b\x00\xdb\x27\x5f\x35\x8e\x07\x00\x00"),
 		},
-		"/sql/sqlite3/UserURLManager.GetAllByTags.generated.sql": &vfsgen۰CompressedFileInfo{
-			name:             "UserURLManager.GetAllByTags.generated.sql",
-			modTime:          time.Date(2020, 12, 16, 19, 18, 9, 999987786, time.UTC),
-			uncompressedSize: 568,
+		"/sql/sqlite3/UserURLManager.GetByID.generated.sql": &vfsgen۰CompressedFileInfo{
+			name:             "UserURLManager.GetByID.generated.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 1308,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x5c\x92\x3d\x8e\xe3\x30\x0c\x85\x7b\x9f\x82\x9d\x6d\xc0\xd1\x05\x16\x41\x8a\xcd\x16\xdb\x6c\x9a\xf4\x82\x62\x31\x5e\x65\x14\x29\x43\x91\x13\xe4\xf6\x03\xfd\xa5\x98\xc6\x10\x3f\xbe\xf7\x28\x11\xde\xed\xe0\x77\xb4\x08\x1b\x06\x24\xc3\x68\xe1\xf2\x82\x8b\x38\x6f\x75\xfa\xf4\xca\x3c\x3f\x7e\xc1\xf1\x04\xff\x4e\x67\xf8\x73\xfc\x7b\x56\x43\x42\x8f\x2b\x0f\x00\x22\xca\x59\x30\x09\x9c\x5d\x72\xd9\xaa\x51\xc8\x2b\x67\xc7\xca\x84\xfc\x1b\x0a\xf9\x46\xd9\xb1\xc7\x37\x2f\x55\xed\x88\x92\x84\xa4\x7b\x52\x42\xea\x51\x6b\x34\x1e\xd3\x8a\x53\x10\xef\xdd\x75\x92\x96\xb2\xc0\x38\xce\x4b\xcf\x9c\xb3\xaf\xf2\x1a\x77\x35\x5f\x91\x1c\x97\x61\xfd\x9c\x5b\xb7\x14\x83\x8e\x97\x1b\xae\x3c\x8d\x8e\xf1\x9e\xca\x94\xd6\xd8\x28\xca\x43\x1b\x22\xf3\x9a\x0a\xfd\x69\xb0\xe3\x02\xac\x9c\x5d\x60\x0c\xe6\x8e\xa5\xca\x87\xb9\xa8\xf3\xb7\xde\xc4\x6c\xa9\x5d\x64\x25\xcc\xdb\xd5\x86\xfb\x43\x1f\xb6\x81\xe1\x4a\xf1\x9e\x61\x7e\xba\x90\x4f\x20\x32\xdc\xa2\x0b\x50\x0b\x88\xa1\x6e\x77\x5f\x7c\xe4\xb5\xb3\xad\xdf\x1c\x3a\x0f\x02\xe1\xa2\x64\xf5\xc6\xdd\xd3\xf5\x45\x56\x54\xdc\xf2\x58\xb1\xd9\x72\xde\xf3\x3f\x12\x42\xdf\xfe\x1e\x0e\x60\x82\xad\x32\x17\xa6\xc3\x3c\x94\x9d\xe4\x9f\xa3\xe6\x7d\x07\x00\x00\xff\xff\x2e\xb5\xd2\x23\x38\x02\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x64\x54\xcb\x92\xe2\x30\x0c\xbc\xe7\x2b\x74\x0b\x53\xc5\xe4\x07\xb6\xa6\xf6\xb0\xb3\x87\xbd\xec\x5c\xe6\xee\x12\xb6\x08\x66\x8c\xcd\xda\x12\x53\xfc\xfd\x96\x1f\x09\x49\xe0\x40\x59\xdd\x2d\xb9\xd3\x88\xbc\xbe\xc2\xaf\x60\x08\x46\xf2\x14\x91\xc9\xc0\xe1\x0e\x07\xb1\xce\xa8\xf4\xcf\x0d\xf8\xfd\xf5\x03\xde\x3f\xe0\xef\xc7\x27\xfc\x7e\xff\xf3\x39\x74\x89\x1c\x69\xee\x00\x44\x06\x6b\x00\x13\x58\xb3\xcf\x65\xab\x7a\x89\x6e\xb0\xa6\xaf\x98\x44\x37\x83\x12\x5d\x43\xd9\xb2\xa3\x19\x2f\x55\x61\x74\x40\x47\x49\xd3\x4e\x06\x1d\x3c\x93\x67\xc5\xf7\x2b\xed\xa1\xef\x5f\x66\xf9\x92\xd9\x76\x19\x4a\x3a\xda\x2b\xdb\xe0\xd7\x4d\x0b\x62\xdb\x63\x2f\x38\x92\x92\xe8\xd6\x1d\x33\xbc\xd5\x27\xcb\xa4\x3c\x5e\x36\xb6\x66\x78\xab\x47\xe1\x53\x88\x6b\x71\xc5\x5a\x1a\x57\x39\x38\x9b\x4e\x64\x14\xf2\xac\x58\x82\x4f\xd9\xa0\x0f\xde\x6a\x74\xcf\xae\x57\xd4\xb6\xcf\xa1\x1f\x05\xc7\x8d\xf1\x09\xdd\xaa\x8f\x78\xb3\x3a\xf8\xe7\x3b\x16\x44\x7b\x82\xc4\xc8\x92\x94\xce\x8b\x34\xe7\xf1\xc0\x9a\xea\x88\xd6\x49\xa4\xb4\x18\x54\x81\xc6\x1b\x42\xb3\xf8\xc1\x70\xda\x21\x7d\x22\xfd\xb5\x4e\xe7\x01\x35\x0d\x46\x7d\xb2\xb7\xb5\x68\x81\x55\x95\x0c\x92\x28\xaa\x69\x4f\x13\xc5\x79\x51\x17\x3b\x59\x0e\xab\x2c\x3a\x00\x00\x2f\xce\xd9\xe3\x6e\x52\x96\x48\xf6\x85\x69\x48\x07\x50\x32\x32\x14\xcb\xad\xcf\x73\x44\x06\x1f\x98\xd2\x1c\x67\xad\x3a\x80\x7a\x45\xfd\x6b\xc1\x39\x05\xaf\xc2\xe1\x4c\x9a\x77\xbd\x65\xba\xd4\x80\xf2\xa7\x50\x63\x0c\x72\x55\x18\x23\xde\x77\x0d\x87\x4d\x93\xe9\xf7\xc0\x83\x35\x7b\xe8\xeb\x4a\x02\x0f\xf9\xf0\xd2\xf4\x2f\xdd\xe3\xfb\x18\xc3\x05\x4a\x30\x12\x9d\x62\x1c\x13\x08\x17\xe6\x1c\xac\x87\x02\x30\x04\x5f\x06\xc2\x1b\x08\x0f\x8c\xa3\xb2\xa6\x68\xbe\x4f\x14\x29\x63\xf3\x84\x2a\xca\xaf\x83\x29\x91\x3c\xa2\xa5\x7c\xc4\x5b\x88\x96\x4b\xd0\xd3\xb9\x51\x37\x9b\xec\xc1\x3a\xcb\xf7\x4c\x3e\xaa\x46\xeb\x48\xf9\xf5\xa4\x90\x1b\x20\x57\xd3\x80\x2e\x3f\x42\x06\x9b\x85\x04\x22\x5d\x31\x5f\x8b\x6c\x5e\x86\xc9\x57\xf5\xd8\x35\xe3\x8f\x9d\x78\x83\x9f\x80\xde\x54\xeb\xb9\xea\xfe\x0f\x00\xcd\xcc\xd0\xa4\x1c\x05\x00\x00"),
 		},
 		"/sql/sqlite3/UserURLManager.GetByURLID.generated.sql": &vfsgen۰CompressedFileInfo{
 			name:             "UserURLManager.GetByURLID.generated.sql",
-			modTime:          time.Date(2020, 12, 16, 19, 18, 9, 999987786, time.UTC),
-			uncompressedSize: 653,
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 1312,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x64\x94\xb1\x92\xe2\x30\x0c\x86\xfb\x3c\x85\xba\xb0\x33\x6c\x5e\xe0\x66\xe7\x8a\xdb\x2b\xae\xb9\x6d\xb6\xcf\x08\x5b\x04\xb1\xc6\xe6\x6c\x89\x1d\xde\xfe\xc6\x8e\x13\x92\x40\xc1\x44\xdf\xff\xcb\x51\x7e\x44\x5e\x5f\xe1\x57\xb0\x04\x03\x79\x8a\x28\x64\xe1\x70\x87\x83\xb2\xb3\x7d\xfa\xe7\x3a\xfc\xfe\xfa\x01\xef\x1f\xf0\xf7\xe3\x13\x7e\xbf\xff\xf9\xec\x9a\x44\x8e\x8c\x34\x00\xaa\x1d\x5b\xc0\x04\x6c\xf7\xb9\xac\x55\xab\xd1\x75\x6c\xdb\x91\x69\x74\x33\xd4\xe8\x2a\x15\x16\x47\x33\x2f\x55\x51\x4c\x40\x47\xc9\xd0\x4e\x3b\x13\xbc\x90\x97\x5e\xee\x57\xda\x43\xdb\xbe\xcc\xf6\xa5\xb2\xed\xb2\x94\x4c\xe4\xab\x70\xf0\xeb\xa6\x85\xb0\xed\xe1\x0b\x0e\xd4\x6b\x74\xeb\x8e\x19\x6f\xfd\x89\x85\x7a\x8f\x97\xcd\x58\x33\xde\xfa\x51\xe5\x14\xe2\xda\x3c\xb2\x9a\xc6\x55\x0f\x8e\xd3\x89\x6c\x8f\x32\x3b\x96\xf0\x29\x1b\xf4\xc1\xb3\x41\xf7\x3c\xf5\x4a\xda\xf6\x39\xf4\x83\xe2\xb0\x19\x7c\xa2\x5b\xf7\x11\x6f\x6c\x82\x7f\xbe\xc7\x42\xa8\x4f\x90\x04\x45\x53\x6f\xf2\x22\xcd\x79\x3c\x58\x75\x1d\x91\x9d\x46\x4a\x8b\x83\x46\x50\x75\x4b\x68\x17\x3f\x18\x4e\x3b\x64\x4e\x64\xbe\xd6\xe9\x3c\x50\xf5\x60\x34\x27\xbe\xad\x4d\x0b\x36\xba\xb4\xd3\x44\xb1\x9f\xf6\x34\x51\x9c\x17\x75\xb1\x93\xe5\x62\x95\x45\x03\x00\xe0\xd5\x39\x3e\xee\x26\x67\x89\x64\x5f\x94\x4a\x1a\x80\x92\x91\xa5\x58\xee\xfa\x7c\x8e\x6a\xe7\x83\x50\x9a\xe3\x1c\xab\x06\x60\xbc\xc5\xf8\xd7\x82\x73\x0a\xbe\x0f\x87\x33\x19\xd9\xb5\x2c\x74\x19\x03\xca\x9f\x22\x0d\x31\xe8\xb5\xc7\x18\xf1\xbe\xab\x1c\x36\x4d\xb6\xdd\x83\x74\x6c\xf7\xd0\x8e\x2b\x09\xd2\xe5\x8b\x97\xea\x7f\x69\x1e\xdf\xc7\x18\x2e\x50\x82\xd1\xe8\x7a\xc1\x21\x81\x4a\x51\xce\x81\x3d\x14\x20\x10\x7c\x39\x10\xde\x40\xa5\x13\x1c\x7a\xb6\xc5\xf3\x7d\xa2\x48\x99\xcd\x27\x8c\xa6\xfc\x3a\x98\x12\xc9\x47\xd4\x94\x8f\x78\x0b\x91\xa5\x04\x3d\x5d\x57\xe9\xc6\x89\x0f\xec\x58\xee\x59\x7c\x54\x55\x36\x91\xf2\xeb\xa9\x47\xa9\x40\xaf\xb6\x82\x26\x3f\x42\x86\x75\x84\x04\xaa\x4d\x19\x7e\x2c\xf2\xf0\xda\x4d\x73\x8d\x33\x36\x75\xf0\xc7\x4e\xbc\xc1\x4f\x40\x6f\x1f\x96\x4c\x9a\xff\x03\x00\xfe\x8e\xcd\xf7\x20\x05\x00\x00"),
+		},
+		"/sql/sqlite3/UserURLManager.GetLastUpdated.generated.sql": &vfsgen۰FileInfo{
+			name:    "UserURLManager.GetLastUpdated.generated.sql",
+			modTime: time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			content: []byte("\x2d\x2d\x20\x43\x6f\x64\x65\x20\x67\x65\x6e\x65\x72\x61\x74\x65\x64\x20\x62\x79\x20\x62\x75\x69\x6c\x64\x5f\x73\x71\x6c\x2e\x61\x77\x6b\x3b\x20\x44\x4f\x20\x4e\x4f\x54\x20\x45\x44\x49\x54\x2e\x0a\x73\x65\x6c\x65\x63\x74\x20\x6d\x61\x78\x28\x63\x6f\x61\x6c\x65\x73\x63\x65\x28\x75\x70\x64\x61\x74\x65\x64\x5f\x61\x74\x2c\x20\x63\x72\x65\x61\x74\x65\x64\x5f\x61\x74\x29\x29\x0a\x66\x72\x6f\x6d\x20\x75\x73\x65\x72\x5f\x75\x72\x6c\x73\x0a\x77\x68\x65\x72\x65\x20\x75\x73\x65\x72\x5f\x69\x64\x20\x3d\x20\x3f\x0a"),
+		},
+		"/sql/sqlite3/UserURLManager.GetTags.generated.sql": &vfsgen۰CompressedFileInfo{
+			name:             "UserURLManager.GetTags.generated.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 321,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x5c\x52\x3d\xb3\xe2\x30\x0c\xec\xf3\x2b\xd4\x39\x61\x82\xf9\x01\x37\xcc\x15\xc7\x15\xd7\x1c\x0d\xbd\xc7\xc4\x22\x67\xce\xd8\x3c\x59\x86\xe1\xdf\xbf\xf1\x47\xf2\x98\xd7\x69\x57\xbb\xab\x58\xca\x76\x0b\xbf\x82\x41\x98\xd1\x23\x69\x46\x03\xe7\x17\x9c\x93\x75\x46\xc5\x0f\x27\xf5\xf3\xff\x0f\x38\x1c\xe1\xef\xf1\x04\xbf\x0f\x7f\x4e\xb2\x8b\xe8\x70\xe2\x0e\x20\x25\x69\x0d\xe8\x08\xd6\x8c\x19\x36\x24\x12\x39\x69\x8d\xa8\x5c\x22\xb7\x92\x89\x5c\x63\xd9\xb2\xc3\x95\x2f\xa8\x76\x92\x4c\x11\x49\x2d\x49\x11\x69\x8d\x7a\x73\x95\x22\x93\x53\xd0\x0e\xe3\x84\x7d\x07\x00\xe0\x93\x73\xf6\xd2\x2f\xca\x11\x84\x18\xc6\xd2\x69\x4c\x07\x30\x64\xbf\x41\xb2\x0f\x34\x6a\xcd\xb9\xc6\xe0\x55\x38\x5f\x71\xe2\x5e\x58\xc6\x5b\x14\xd5\x58\x1a\x33\x85\x74\x57\x9a\x48\xbf\xea\xa0\xef\x06\x23\x46\x60\x69\xcd\x08\xc2\xeb\x1b\x16\x94\x8b\xa1\xa8\x87\x65\x2c\xeb\x39\xe6\xd8\xdd\x06\x6a\xe4\x14\xfc\xa4\xb9\x6f\xe2\x55\x02\x9b\x5d\x7d\xf0\x45\x3f\x02\x59\x2e\x6f\x5e\xea\xb6\x8b\x89\x30\x1f\x4b\x69\x5e\xf6\x76\x37\x8d\xe8\x2e\x14\x6e\x99\xcc\x9b\x4c\xe4\x22\xa4\xd4\x5d\x83\xf5\x50\x01\x04\x5f\x8f\xb5\x2f\x3e\x72\xca\x9a\xd6\x6f\x0e\x95\x3f\x03\x12\x17\x25\xcb\x95\x5e\x3c\x8b\xbe\xc8\x8a\x8a\x5b\x1e\x4b\xd6\x73\xce\x7b\xfe\x43\xc2\xf7\x7b\xee\xe1\x27\x68\x6f\xbe\x46\x66\xa6\x2b\x7b\xc8\x7f\x5c\x4d\xfd\x0c\x00\x00\xff\xff\xa7\x30\x37\xe6\x8d\x02\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x54\x90\xb1\x4e\x43\x31\x0c\x45\xf7\x7c\xc5\x1d\x41\xa2\xf9\x01\x54\x31\x50\x06\x16\xba\x74\x8f\xd2\x67\xf3\x08\xbc\x26\x25\xb1\x55\xf1\xf7\xc8\x7e\x52\xa1\x53\x74\xee\x3d\x72\x9c\x6c\x36\x78\x6e\xc4\x98\xb9\x72\xcf\xc2\x84\xe3\x0f\x8e\x5a\x16\x4a\xe3\x7b\x89\xf9\xf2\xf5\x88\xdd\x1e\x6f\xfb\x03\x5e\x76\xaf\x87\x18\x06\x2f\x3c\x49\x00\x24\x16\x42\x1e\x28\xf4\xe0\x54\xf3\x89\x8d\xed\xb4\x64\x6a\x5a\xe5\x4e\x35\x16\xba\xb7\xdc\x79\x55\xa7\xce\x76\x55\xca\xe2\xc5\x95\xd6\x56\xcf\xf4\xaf\xfd\xa3\xf0\xde\xdb\x09\x92\xe7\x01\x09\x9f\xad\x54\xe8\xe0\x9e\xb4\x2f\xc9\x43\x15\xb4\x0a\x95\x28\x79\x4e\x85\xb0\xf5\x15\x6f\xcd\x01\x55\xb7\x6c\x2d\x6c\xcd\xbe\x0e\x29\x14\x2e\x1f\xdc\xd9\x4a\x0f\xdd\x78\x0a\x73\x6f\x7a\xb6\x6f\xf1\x71\xad\x13\xf7\x95\xec\xa5\xe1\x77\x00\x96\xe6\xef\x0f\x41\x01\x00\x00"),
+		},
+		"/sql/sqlite3/UserURLManager.Search.generated.sql": &vfsgen۰CompressedFileInfo{
+			name:             "UserURLManager.Search.generated.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 2313,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x94\x56\xbd\x8e\xe3\x36\x10\xee\xf5\x14\xd3\x04\x92\x01\xad\xb0\x3e\xe0\x12\xc0\x39\xa7\xc9\xa5\x48\x93\x6b\xae\x17\xc6\xe4\xd8\xe6\x9a\x26\x15\x72\xe8\x83\xbb\x3c\x4d\x1e\x2c\x4f\x12\xf0\x47\xb2\x24\xe3\x90\xac\x0b\x2d\xf9\x7d\xf3\x43\x7e\x33\xa3\xd5\xcb\x0b\xfc\x6a\x25\xc1\x89\x0c\x39\x64\x92\x70\xb8\xc3\x21\x28\x2d\x7b\xff\xa7\xee\xf0\xdb\xe5\x67\xf8\xfc\x05\xfe\xf8\xf2\x15\x7e\xfb\xfc\xfb\xd7\xae\xf2\xa4\x49\x70\x05\x10\x42\xa7\x24\xa0\x07\x25\xdb\xb8\x2d\xbb\x3a\x38\xdd\x29\x59\x67\x2c\x38\x3d\x81\xc1\xe9\x82\xb2\x62\x4d\x13\x9e\x76\x89\x11\x16\x35\x79\x41\x4d\xe8\x84\x35\x4c\x86\x7b\xbe\x0f\xd4\x42\x5d\x6f\x26\xf3\x39\xb3\xf6\x92\xe4\x85\x53\x03\x2b\x6b\x96\x4e\x33\x62\xed\xa3\xae\x78\xa2\x3e\x38\xbd\xf4\x98\xe0\xb5\xbd\x57\x4c\xbd\xc1\xeb\xea\x58\x13\xbc\xb6\xc7\xc0\x67\xeb\x96\xc6\x19\x2b\x6a\x0c\xe1\xa0\x95\x3f\x93\xec\x91\x27\x8b\x39\xf8\xa4\x0d\x1a\x6b\x94\x40\xfd\x7c\xea\x05\xb5\xf6\xd3\x68\x4e\x01\x4f\xab\x83\x8f\xe8\xda\xfa\x88\x37\x25\xac\x79\xce\x31\x23\xca\x0d\x3c\x23\x07\xdf\x8b\xd8\x48\x93\x1e\x0f\xac\x58\x1d\x51\xe9\xe0\xc8\xcf\x02\x65\xa0\xf0\x92\x50\xce\x0a\x86\x63\x0f\x89\x33\x89\xcb\x52\x9d\x07\x54\x6c\xd0\x89\xb3\xba\x2d\x8d\x66\x58\xb6\x0a\x5d\xf0\xe4\xfa\xb1\x4f\x3d\xb9\xa9\x51\x67\x3d\x99\x16\x0b\x2d\x2a\x00\x00\x13\xb4\x56\xc7\x66\xb4\x4c\x92\xb4\x89\x29\x48\x05\x90\x34\x92\xe4\x52\xd6\xe7\x38\x21\x74\xc6\x32\xf9\x49\xce\xbc\xab\x00\x72\x8a\x3c\x5a\xf0\xe6\xad\xe9\xed\xe1\x8d\x04\x37\xb5\x62\xba\x66\x81\xe2\x2f\x51\x27\x67\xc3\xd0\xa3\x73\x78\x6f\x0a\x0e\x2b\x27\x59\xb7\xc0\x9d\x92\x2d\xd4\xb9\x25\x81\xbb\xb8\xd8\x14\xfb\x4d\xf5\x78\x1e\x9d\xbd\x42\x12\x26\x38\xdd\x33\x9e\x3c\x04\x4e\xcc\x9b\x55\x06\x12\xc0\x60\x4d\x0a\x08\x7b\x08\xdc\x31\x9e\x7a\x25\x93\xcd\xb7\x33\x39\x8a\xd8\x14\x21\x1b\xc5\xd7\xc1\xa8\x48\x0c\x51\x54\x3e\xe2\xcd\x3a\xc5\x49\xe8\x71\x5d\xa8\x9b\xf2\xea\xa0\xb4\xe2\x7b\x24\x1f\xbb\x48\x7b\xa3\x86\x81\xb8\x99\x92\x78\x8a\xd5\x6d\xe1\x65\xdb\xc2\xee\x8a\x2c\xce\xbd\x67\x74\x3c\xed\xc8\xc4\xcb\xff\xf3\xd7\xdf\x75\x0b\xdb\x1f\xd3\x31\x4a\x90\x18\xef\xe5\x70\xfd\xf0\xf1\x39\xda\xb6\x7b\x6d\x61\xfb\xfa\x78\x7e\x88\x8f\x8f\xdd\x6b\xf2\x77\x68\x2e\x6d\x55\xa4\xce\xf2\x0f\x4e\x19\x3e\x36\xf5\x0f\xdd\xf6\xa7\x53\xdd\xbe\x3f\xee\xa6\xcd\x52\xa5\x04\x22\x38\x6f\x5d\x91\x43\x38\x8a\xaf\xe3\x1e\xb9\x00\x61\x90\x05\xa8\x96\x25\xcb\x59\xaa\x54\xad\x11\xf4\x10\x42\xac\x59\x0a\x0e\xfb\x09\x2f\xc6\xf3\x6a\x15\xc7\xe4\x93\x5c\xba\xb1\x82\x85\x2f\x25\x5e\x46\x80\x24\x33\xec\x4a\x72\x00\x34\x72\x3e\x61\x7b\xd8\x95\x65\xe1\x72\xab\xee\x62\xeb\x08\x1b\x0c\xc3\x1e\x5e\x13\x64\x1d\x8c\x6d\x5c\x06\x20\xf1\x8d\x54\x9e\x95\x11\xbc\x6a\xdd\xef\xb7\xeb\xff\x6b\xd8\xff\x6c\xd9\xfc\x8b\x47\xce\x89\x41\x19\x68\xca\xc9\x6e\xa8\x03\xe5\x23\xa4\x2e\x20\x14\xe7\x26\xde\xc9\x6f\xca\x48\xc1\x2f\x7b\x10\xe8\x29\x66\x31\xb0\x43\x73\xcf\x67\xe4\xb8\xdd\x02\x69\x4f\x73\x11\xc8\xa4\x29\x19\x35\x32\x96\x61\x77\x70\xf6\x42\x26\xea\x92\xdf\x89\x4b\x36\xfd\x63\x10\x89\x5d\xcc\xcc\x1e\xea\x4c\xd5\x4b\xfb\x69\xe2\xb2\xc7\xb8\xdd\x2c\xcb\x82\x47\x26\xd7\x5f\xe8\x0e\xca\xa7\x57\xdd\x58\x9a\xf7\x4f\xca\xa7\x78\x7f\x6e\x66\x21\xd1\x83\x23\xd4\x9b\xa9\xdc\xef\x0f\xba\xff\x6e\xd0\xb1\xf1\x94\x84\x4f\xe3\x3d\x54\xd2\x6c\x53\x59\x27\xc9\xc5\x0f\x9a\x38\xba\x10\xbf\x01\xca\xb8\xa5\x75\xa5\xd5\x55\x31\xec\xd2\x9f\xea\xdf\x01\x00\xfc\x9c\x55\x7f\x09\x09\x00\x00"),
 		},
 		"/sql/sqlite3/UserURLManager.Update.generated.sql": &vfsgen۰CompressedFileInfo{
 			name:             "UserURLManager.Update.generated.sql",
-			modTime:          time.Date(2020, 12, 16, 19, 18, 9, 999987786, time.UTC),
-			uncompressedSize: 192,
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 242,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x34\x8d\xc1\xae\x82\x30\x14\x44\xf7\xfd\x8a\xf9\x80\x07\x1f\xf0\x5e\x58\xbc\x00\x0b\x16\x80\xc1\xba\x6e\x4a\xee\x55\x1b\x1b\xd0\xf6\x56\xe2\xdf\x1b\x40\x77\x27\x67\x32\x33\x59\x86\x72\x26\xc6\x85\x27\x0e\x56\x98\x30\xbe\x30\x26\xe7\xc9\xc4\x87\xcf\xed\x72\xfb\x43\xd5\xa3\xeb\x35\xea\xaa\xd1\xb9\x4a\x77\xb2\xc2\x48\x91\x83\x49\xc1\x47\x05\x44\x16\x28\x00\x10\x27\x9e\x51\xe0\x77\x83\x9f\xcd\x9d\xed\x73\x0e\x4e\x36\xfd\xe5\x3d\xd9\x97\xc8\x58\x41\x81\xf2\x34\x0c\x75\xa7\x8d\x6e\xda\xfa\xa8\xff\xdb\x83\x5a\xae\x1c\x3e\x3f\x8e\xd6\xf6\x8a\xb9\x23\xd8\x89\xb0\x1b\x47\xea\x1d\x00\x00\xff\xff\x52\x30\xdc\x4f\xc0\x00\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x44\x8e\x41\x4e\xc3\x30\x10\x45\xf7\x3e\xc5\x3f\x00\xcd\x01\x40\x5d\xa0\x36\x8b\x2e\xda\xa0\x60\xd6\x96\xa3\x19\x60\x84\x95\x80\x3d\x4e\x94\xdb\x23\xdb\xa0\xee\x9e\xdf\xf3\x68\xe6\x70\xc0\x69\x21\xc6\x07\xcf\x1c\xbd\x32\x61\xda\x31\x65\x09\xe4\xd2\x4f\xe8\xfc\xf6\xf5\x84\xf3\x80\xdb\x60\xd1\x9f\x2f\xb6\x33\xf9\x9b\xbc\x32\x72\xe2\xe8\x72\x0c\xc9\x00\x89\x15\x06\x00\x54\x34\x30\x8e\x78\xac\xf0\x50\xdd\xbc\x28\xa7\xe2\x2a\x34\xf7\xee\xd7\x25\x8a\xd6\xaf\xff\xdc\xca\x2a\x49\x26\x09\xa2\x7b\x69\xf7\x57\xab\x6d\x37\x39\xaf\x38\xe2\xf4\x36\x8e\xfd\xcd\x3a\x7b\xb9\xf6\xaf\xf6\xf9\xfa\x62\xb6\x4f\x8e\x7f\x97\x09\x95\xf9\x82\x9d\x10\xfc\x4c\x68\x46\xc8\xfc\x0e\x00\xa7\xea\x2c\xed\xf2\x00\x00\x00"),
 		},
 		"/sql/sqlite3/UserURLManager.clearTags.generated.sql": &vfsgen۰FileInfo{
 			name:    "UserURLManager.clearTags.generated.sql",
-			modTime: time.Date(2020, 12, 16, 19, 18, 9, 999987786, time.UTC),
+			modTime: time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
 			content: []byte("\x2d\x2d\x20\x43\x6f\x64\x65\x20\x67\x65\x6e\x65\x72\x61\x74\x65\x64\x20\x62\x79\x20\x62\x75\x69\x6c\x64\x5f\x73\x71\x6c\x2e\x61\x77\x6b\x3b\x20\x44\x4f\x20\x4e\x4f\x54\x20\x45\x44\x49\x54\x2e\x0a\x64\x65\x6c\x65\x74\x65\x20\x66\x72\x6f\x6d\x20\x75\x73\x65\x72\x5f\x75\x72\x6c\x5f\x74\x61\x67\x73\x20\x77\x68\x65\x72\x65\x20\x75\x73\x65\x72\x5f\x75\x72\x6c\x5f\x69\x64\x20\x3d\x20\x3f\x0a"),
 		},
+		"/sql/sqlite3/UserURLManager.replaceTagAdd.generated.sql": &vfsgen۰CompressedFileInfo{
+			name:             "UserURLManager.replaceTagAdd.generated.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 239,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x5c\xcd\xb1\x4e\xc5\x30\x0c\x85\xe1\x3d\x4f\x71\x46\x90\xb8\x79\x01\x74\xd5\x81\x32\xb0\xd0\xa5\x7b\x94\x12\x13\x0c\x21\x11\x8e\xad\x8a\xb7\x47\xa5\x52\xa5\xde\xf5\xf3\xd1\xef\xcb\x05\x4f\x2d\x11\x32\x55\x92\xa8\x94\xb0\xfc\x62\x31\x2e\x29\xf4\x9f\xe2\xe3\xfa\xf5\x88\x71\xc2\xeb\x34\xe3\x79\x7c\x99\xbd\xe3\xda\x49\x14\x4d\xc0\xb9\x36\x21\x70\xd5\x06\xeb\x24\xc1\xa4\x04\x8d\xb9\x3b\xe0\xee\x00\x4e\x0f\xd0\x98\x03\xa7\x7b\xd7\xa9\xd0\x9b\xc2\xd4\x9f\xce\x83\x7b\x97\xf6\x7d\x6e\xc0\xd4\x7d\x36\xae\x87\x76\x98\xa1\x55\x98\x79\x4e\xb8\xde\x44\xdc\xfa\x41\x42\x1b\xee\xbf\x70\xc5\x80\x58\xd3\x36\xff\x9f\xed\xe4\xfe\x06\x00\x36\xf3\x71\x62\xef\x00\x00\x00"),
+		},
+		"/sql/sqlite3/UserURLManager.replaceTagRemove.generated.sql": &vfsgen۰CompressedFileInfo{
+			name:             "UserURLManager.replaceTagRemove.generated.sql",
+			modTime:          time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
+			uncompressedSize: 158,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x54\xcc\xb1\x0a\xc2\x30\x14\x85\xe1\x3d\x4f\x71\x46\x1d\xda\x17\x10\x71\xb0\x0e\x2e\x76\xe9\x1e\x52\xef\xb1\x06\x63\x8a\xb9\x37\x14\xdf\x5e\xb0\x83\x38\x1e\x38\xff\xd7\x34\x38\xce\x42\x4c\xcc\x2c\xc1\x28\x18\xdf\x18\x6b\x4c\xe2\xf5\x95\xda\xb0\x3c\x76\xe8\x7a\x5c\xfa\x01\xa7\xee\x3c\xb4\x4e\x98\x68\xc4\xad\xcc\x4f\x54\x65\xf1\xb5\x24\x6f\x61\x52\xb7\xdc\x59\x08\x0b\x93\x8f\x82\x3d\x0e\x0e\x08\x59\x7e\xa7\x28\x88\x19\x1b\x65\xe2\xd5\x10\xe5\xdf\x50\xac\xfd\x77\xaf\xc0\xd6\x7d\x06\x00\x51\xab\xaa\x9d\x9e\x00\x00\x00"),
+		},
 		"/sql/sqlite3/UserURLManager.updateTags.generated.sql": &vfsgen۰FileInfo{
 			name:    "UserURLManager.updateTags.generated.sql",
-			modTime: time.Date(2020, 12, 16, 19, 18, 9, 999987786, time.UTC),
+			modTime: time.Date(2026, 10, 17, 7, 43, 46, 875636893, time.UTC),
 			content: []byte("\x2d\x2d\x20\x43\x6f\x64\x65\x20\x67\x65\x6e\x65\x72\x61\x74\x65\x64\x20\x62\x79\x20\x62\x75\x69\x6c\x64\x5f\x73\x71\x6c\x2e\x61\x77\x6b\x3b\x20\x44\x4f\x20\x4e\x4f\x54\x20\x45\x44\x49\x54\x2e\x0a\x69\x6e\x73\x65\x72\x74\x20\x69\x6e\x74\x6f\x20\x75\x73\x65\x72\x5f\x75\x72\x6c\x5f\x74\x61\x67\x73\x0a\x20\x20\x28\x75\x73\x65\x72\x5f\x75\x72\x6c\x5f\x69\x64\x2c\x20\x74\x61\x67\x5f\x69\x64\x29\x0a\x76\x61\x6c\x75\x65\x73\x0a\x20\x20\x28\x3f\x2c\x20\x3f\x29\x0a"),
 		},
 	}
@@ -186,27 +558,85 @@ var assets = func() http.FileSystem {
 	}
 	fs["/sql/migrations"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
 		fs["/sql/migrations/001-init.sql"].(os.FileInfo),
+		fs["/sql/migrations/003-user-url-search.sql"].(os.FileInfo),
+		fs["/sql/migrations/004-user-url-private.sql"].(os.FileInfo),
+		fs["/sql/migrations/005-fetch-jobs.sql"].(os.FileInfo),
+		fs["/sql/migrations/006-url-metadata.sql"].(os.FileInfo),
+		fs["/sql/migrations/007-url-checks.sql"].(os.FileInfo),
+		fs["/sql/migrations/008-url-archive.sql"].(os.FileInfo),
+		fs["/sql/migrations/009-user-accounts.sql"].(os.FileInfo),
+		fs["/sql/migrations/010-user-totp.sql"].(os.FileInfo),
+		fs["/sql/migrations/011-user-webauthn.sql"].(os.FileInfo),
+		fs["/sql/migrations/012-user-url-visibility.sql"].(os.FileInfo),
+		fs["/sql/migrations/013-shares.sql"].(os.FileInfo),
+		fs["/sql/migrations/014-embeds.sql"].(os.FileInfo),
+		fs["/sql/migrations/015-user-identities.sql"].(os.FileInfo),
 		fs["/sql/migrations/migrations-table.sql"].(os.FileInfo),
 	}
 	fs["/sql/sqlite3"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
 		fs["/sql/sqlite3/.keep"].(os.FileInfo),
+		fs["/sql/sqlite3/EmbedManager.Get.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/EmbedManager.Put.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/FetchJobManager.Complete.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/FetchJobManager.Enqueue.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/FetchJobManager.Retry.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/FetchJobManager.claim.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/FetchJobManager.next.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/ShareManager.Create.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/ShareManager.Delete.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/ShareManager.GetAll.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/ShareManager.GetByID.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/ShareManager.View.generated.sql"].(os.FileInfo),
 		fs["/sql/sqlite3/TagManager.Create.generated.sql"].(os.FileInfo),
 		fs["/sql/sqlite3/TagManager.GetByID.generated.sql"].(os.FileInfo),
 		fs["/sql/sqlite3/TagManager.GetByName.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/URLCheckManager.Due.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/URLCheckManager.GetByURLID.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/URLCheckManager.create.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/URLCheckManager.prune.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/URLCheckManager.updateURL.generated.sql"].(os.FileInfo),
 		fs["/sql/sqlite3/URLManager.Create.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/URLManager.GetByID.generated.sql"].(os.FileInfo),
 		fs["/sql/sqlite3/URLManager.GetByURL.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/URLManager.Update.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/UserManager.AddIdentity.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/UserManager.AddWebAuthnCredential.generated.sql"].(os.FileInfo),
 		fs["/sql/sqlite3/UserManager.Create.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/UserManager.Delete.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/UserManager.DeleteWebAuthnCredential.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/UserManager.GetAll.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/UserManager.GetByAPIToken.generated.sql"].(os.FileInfo),
 		fs["/sql/sqlite3/UserManager.GetByEmail.generated.sql"].(os.FileInfo),
 		fs["/sql/sqlite3/UserManager.GetByID.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/UserManager.GetByIdentity.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/UserManager.GetTOTP.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/UserManager.GetWebAuthnCredential.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/UserManager.HasIdentity.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/UserManager.Update.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/UserManager.UpdateAPIToken.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/UserManager.UpdateAccess.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/UserManager.UpdatePassword.generated.sql"].(os.FileInfo),
 		fs["/sql/sqlite3/UserManager.UpdatePinnedCategories.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/UserManager.UseRecoveryCode.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/UserManager.UseTOTPStep.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/UserManager.UseWebAuthnCredential.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/UserManager.addRecoveryCode.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/UserManager.clearRecoveryCodes.generated.sql"].(os.FileInfo),
 		fs["/sql/sqlite3/UserManager.getPinnedCategories.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/UserManager.getWebAuthnCredentials.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/UserManager.updateTOTP.generated.sql"].(os.FileInfo),
 		fs["/sql/sqlite3/UserURLManager.Create.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/UserURLManager.Delete.generated.sql"].(os.FileInfo),
 		fs["/sql/sqlite3/UserURLManager.GetAll.generated.sql"].(os.FileInfo),
-		fs["/sql/sqlite3/UserURLManager.GetAllAfter.generated.sql"].(os.FileInfo),
-		fs["/sql/sqlite3/UserURLManager.GetAllByTags.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/UserURLManager.GetByID.generated.sql"].(os.FileInfo),
 		fs["/sql/sqlite3/UserURLManager.GetByURLID.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/UserURLManager.GetLastUpdated.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/UserURLManager.GetTags.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/UserURLManager.Search.generated.sql"].(os.FileInfo),
 		fs["/sql/sqlite3/UserURLManager.Update.generated.sql"].(os.FileInfo),
 		fs["/sql/sqlite3/UserURLManager.clearTags.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/UserURLManager.replaceTagAdd.generated.sql"].(os.FileInfo),
+		fs["/sql/sqlite3/UserURLManager.replaceTagRemove.generated.sql"].(os.FileInfo),
 		fs["/sql/sqlite3/UserURLManager.updateTags.generated.sql"].(os.FileInfo),
 	}
 
@@ -0,0 +1,74 @@
+package sqlitestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/kyleterry/sufr/pkg/embed"
+	"github.com/kyleterry/sufr/pkg/store"
+)
+
+type embedManager struct {
+	statementLoader
+	store *Store
+}
+
+func (m *embedManager) Get(ctx context.Context, urlID string) (*embed.Embed, error) {
+	st, err := m.getStatement("Get")
+	if err != nil {
+		return nil, err
+	}
+
+	e := embed.Embed{}
+
+	if err := m.store.queryer().GetContext(ctx, &e, st, urlID); err != nil {
+		err = mapError(err)
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, embed.ErrNotCached
+		}
+
+		return nil, fmt.Errorf("failed to get embed: %w", err)
+	}
+
+	return &e, nil
+}
+
+func (m *embedManager) Put(ctx context.Context, urlID string, e *embed.Embed) error {
+	return m.store.withTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		st, err := m.getStatement("Put")
+		if err != nil {
+			return err
+		}
+
+		args := map[string]interface{}{
+			"url_id":        urlID,
+			"url":           e.URL,
+			"type":          string(e.Type),
+			"title":         e.Title,
+			"provider_name": e.ProviderName,
+			"html":          e.HTML,
+			"width":         e.Width,
+			"height":        e.Height,
+			"thumbnail_url": e.ThumbnailURL,
+			"fetched_at":    e.FetchedAt,
+		}
+
+		if _, err := tx.NamedExecContext(ctx, st, args); err != nil {
+			return fmt.Errorf("failed to cache embed: %w", mapError(err))
+		}
+
+		return nil
+	})
+}
+
+func newEmbedManager(store *Store) *embedManager {
+	return &embedManager{
+		statementLoader: statementLoader{
+			dialect:     store.db.DriverName(),
+			managerName: "EmbedManager",
+		},
+		store: store,
+	}
+}
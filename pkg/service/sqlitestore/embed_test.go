@@ -0,0 +1,62 @@
+package sqlitestore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyleterry/sufr/pkg/embed"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeds(t *testing.T) {
+	WithTempDatabase(t, func(db *Store) {
+		ctx := context.Background()
+		em := db.Embeds()
+
+		u := MustCreateRandomURL(t, db)
+
+		t.Run("has nothing for new urls", func(t *testing.T) {
+			_, err := em.Get(ctx, u.Id)
+			require.True(t, errors.Is(err, embed.ErrNotCached))
+		})
+
+		e := &embed.Embed{
+			URL:          u.Url,
+			Type:         embed.TypeRich,
+			Title:        "A track",
+			ProviderName: "SoundCloud",
+			HTML:         `<iframe src="https://w.soundcloud.com/player/"></iframe>`,
+			Height:       166,
+			FetchedAt:    time.Now().UTC().Truncate(time.Second),
+		}
+
+		t.Run("puts and gets", func(t *testing.T) {
+			require.NoError(t, em.Put(ctx, u.Id, e))
+
+			cached, err := em.Get(ctx, u.Id)
+			require.NoError(t, err)
+			require.Equal(t, e.URL, cached.URL)
+			require.Equal(t, embed.TypeRich, cached.Type)
+			require.Equal(t, e.Title, cached.Title)
+			require.Equal(t, e.ProviderName, cached.ProviderName)
+			require.Equal(t, e.HTML, cached.HTML)
+			require.Equal(t, 166, cached.Height)
+			require.True(t, e.FetchedAt.Equal(cached.FetchedAt))
+		})
+
+		t.Run("replaces what was cached", func(t *testing.T) {
+			e.Title = "A new title"
+			require.NoError(t, em.Put(ctx, u.Id, e))
+
+			cached, err := em.Get(ctx, u.Id)
+			require.NoError(t, err)
+			require.Equal(t, "A new title", cached.Title)
+		})
+
+		t.Run("needs the url to exist", func(t *testing.T) {
+			require.Error(t, em.Put(ctx, "missing", e))
+		})
+	})
+}
@@ -0,0 +1,135 @@
+package sqlitestore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/kyleterry/sufr/pkg/api"
+	"github.com/kyleterry/sufr/pkg/fetchqueue"
+	"github.com/kyleterry/sufr/pkg/store"
+	"github.com/rs/xid"
+)
+
+type fetchJobManager struct {
+	statementLoader
+	store *Store
+}
+
+func (m *fetchJobManager) Enqueue(ctx context.Context, u *api.URL) error {
+	return m.store.withTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		st, err := m.getStatement("Enqueue")
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+
+		job := &fetchqueue.Job{
+			ID:        xid.New().String(),
+			URLID:     u.Id,
+			URL:       u.Url,
+			RunAt:     now,
+			CreatedAt: now,
+		}
+
+		if _, err := tx.NamedExecContext(ctx, st, job); err != nil {
+			return fmt.Errorf("failed to enqueue fetch job: %w", mapError(err))
+		}
+
+		return nil
+	})
+}
+
+func (m *fetchJobManager) Claim(ctx context.Context, now time.Time, lease time.Duration) (*fetchqueue.Job, error) {
+	next, err := m.getStatement("next")
+	if err != nil {
+		return nil, err
+	}
+
+	claim, err := m.getStatement("claim")
+	if err != nil {
+		return nil, err
+	}
+
+	// another worker can claim the job between the select and the update,
+	// in which case the update doesn't match and we look again
+	for {
+		job := fetchqueue.Job{}
+
+		err := m.store.queryer().GetContext(ctx, &job, next, now)
+		if err != nil {
+			err = mapError(err)
+
+			if err == store.ErrNotFound {
+				return nil, fetchqueue.ErrNoJobs
+			}
+
+			return nil, fmt.Errorf("failed to get fetch job: %w", err)
+		}
+
+		job.RunAt = now.Add(lease)
+
+		var claimed bool
+
+		err = m.store.withTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+			res, err := tx.ExecContext(ctx, claim, job.RunAt, job.ID, now)
+			if err != nil {
+				return err
+			}
+
+			n, err := res.RowsAffected()
+			claimed = n == 1
+
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to claim fetch job: %w", mapError(err))
+		}
+
+		if claimed {
+			return &job, nil
+		}
+	}
+}
+
+func (m *fetchJobManager) Complete(ctx context.Context, job *fetchqueue.Job) error {
+	return m.store.withTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		st, err := m.getStatement("Complete")
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, st, job.ID); err != nil {
+			return fmt.Errorf("failed to complete fetch job: %w", mapError(err))
+		}
+
+		return nil
+	})
+}
+
+func (m *fetchJobManager) Retry(ctx context.Context, job *fetchqueue.Job) error {
+	return m.store.withTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		st, err := m.getStatement("Retry")
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.NamedExecContext(ctx, st, job); err != nil {
+			return fmt.Errorf("failed to retry fetch job: %w", mapError(err))
+		}
+
+		return nil
+	})
+}
+
+func newFetchJobManager(store *Store) *fetchJobManager {
+	return &fetchJobManager{
+		statementLoader: statementLoader{
+			dialect:     store.db.DriverName(),
+			managerName: "FetchJobManager",
+		},
+		store: store,
+	}
+}
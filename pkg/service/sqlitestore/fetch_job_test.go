@@ -0,0 +1,77 @@
+package sqlitestore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyleterry/sufr/pkg/fetchqueue"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchJobs(t *testing.T) {
+	WithTempDatabase(t, func(db *Store) {
+		ctx := context.Background()
+		fjm := db.FetchJobs()
+
+		url := MustCreateRandomURL(t, db)
+		url2 := MustCreateRandomURL(t, db)
+
+		require.NoError(t, fjm.Enqueue(ctx, url))
+		// a url only has one job queued
+		require.NoError(t, fjm.Enqueue(ctx, url))
+
+		t.Run("claims due jobs once until the lease is up", func(t *testing.T) {
+			now := time.Now().UTC()
+
+			job, err := fjm.Claim(ctx, now, time.Minute)
+			require.NoError(t, err)
+			require.Equal(t, url.Id, job.URLID)
+			require.Equal(t, url.Url, job.URL)
+			require.Equal(t, 0, job.Attempts)
+
+			_, err = fjm.Claim(ctx, now, time.Minute)
+			require.True(t, errors.Is(err, fetchqueue.ErrNoJobs))
+
+			again, err := fjm.Claim(ctx, now.Add(2*time.Minute), time.Minute)
+			require.NoError(t, err)
+			require.Equal(t, job.ID, again.ID)
+		})
+
+		t.Run("retries and completes jobs", func(t *testing.T) {
+			now := time.Now().UTC().Add(time.Hour)
+
+			job, err := fjm.Claim(ctx, now, time.Minute)
+			require.NoError(t, err)
+
+			job.Attempts = 2
+			job.LastError = "connection refused"
+			job.RunAt = now.Add(time.Hour)
+			require.NoError(t, fjm.Retry(ctx, job))
+
+			_, err = fjm.Claim(ctx, now.Add(30*time.Minute), time.Minute)
+			require.True(t, errors.Is(err, fetchqueue.ErrNoJobs))
+
+			retried, err := fjm.Claim(ctx, now.Add(2*time.Hour), time.Minute)
+			require.NoError(t, err)
+			require.Equal(t, job.ID, retried.ID)
+			require.Equal(t, 2, retried.Attempts)
+			require.Equal(t, "connection refused", retried.LastError)
+
+			require.NoError(t, fjm.Complete(ctx, retried))
+
+			_, err = fjm.Claim(ctx, now.Add(24*time.Hour), time.Minute)
+			require.True(t, errors.Is(err, fetchqueue.ErrNoJobs))
+		})
+
+		t.Run("claims the job due the longest first", func(t *testing.T) {
+			require.NoError(t, fjm.Enqueue(ctx, url2))
+			require.NoError(t, fjm.Enqueue(ctx, url))
+
+			job, err := fjm.Claim(ctx, time.Now().UTC(), time.Minute)
+			require.NoError(t, err)
+			require.Equal(t, url2.Id, job.URLID)
+		})
+	})
+}
@@ -15,6 +15,19 @@ import (
 var migrations = []Migration{
 	InitializeDatabase{},
 	AddInitialAdminUser{},
+	AddUserURLSearchIndex{},
+	AddUserURLPrivate{},
+	AddFetchJobs{},
+	AddURLMetadata{},
+	AddURLChecks{},
+	AddURLArchive{},
+	AddUserAccounts{},
+	AddUserTOTP{},
+	AddUserWebAuthn{},
+	AddUserURLVisibility{},
+	AddShares{},
+	AddEmbeds{},
+	AddUserIdentities{},
 }
 
 type Migration interface {
@@ -155,3 +168,320 @@ func (m AddInitialAdminUser) Run(ctx context.Context, tx *sqlx.Tx) error {
 
 	return nil
 }
+
+// AddUserURLSearchIndex adds notes to user urls and creates the full text
+// search index over them. The index is kept in sync by triggers.
+type AddUserURLSearchIndex struct{}
+
+func (m AddUserURLSearchIndex) Description() string {
+	return "adding user url full text search index"
+}
+
+func (m AddUserURLSearchIndex) Version() string {
+	return "003"
+}
+
+func (m AddUserURLSearchIndex) Run(ctx context.Context, tx *sqlx.Tx) error {
+	st, err := getSQL(filepath.Join("migrations", "003-user-url-search"))
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, st); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AddUserURLPrivate adds the private flag to user urls.
+type AddUserURLPrivate struct{}
+
+func (m AddUserURLPrivate) Description() string {
+	return "adding private flag to user urls"
+}
+
+func (m AddUserURLPrivate) Version() string {
+	return "004"
+}
+
+func (m AddUserURLPrivate) Run(ctx context.Context, tx *sqlx.Tx) error {
+	st, err := getSQL(filepath.Join("migrations", "004-user-url-private"))
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, st); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AddFetchJobs adds the metadata fetch queue and the content type of urls.
+type AddFetchJobs struct{}
+
+func (m AddFetchJobs) Description() string {
+	return "adding url metadata fetch queue"
+}
+
+func (m AddFetchJobs) Version() string {
+	return "005"
+}
+
+func (m AddFetchJobs) Run(ctx context.Context, tx *sqlx.Tx) error {
+	st, err := getSQL(filepath.Join("migrations", "005-fetch-jobs"))
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, st); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AddURLMetadata adds the page metadata found when fetching urls.
+type AddURLMetadata struct{}
+
+func (m AddURLMetadata) Description() string {
+	return "adding page metadata to urls"
+}
+
+func (m AddURLMetadata) Version() string {
+	return "006"
+}
+
+func (m AddURLMetadata) Run(ctx context.Context, tx *sqlx.Tx) error {
+	st, err := getSQL(filepath.Join("migrations", "006-url-metadata"))
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, st); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AddURLChecks adds the link check history and the health of urls.
+type AddURLChecks struct{}
+
+func (m AddURLChecks) Description() string {
+	return "adding url link check history"
+}
+
+func (m AddURLChecks) Version() string {
+	return "007"
+}
+
+func (m AddURLChecks) Run(ctx context.Context, tx *sqlx.Tx) error {
+	st, err := getSQL(filepath.Join("migrations", "007-url-checks"))
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, st); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AddURLArchive adds when the page of urls was last archived.
+type AddURLArchive struct{}
+
+func (m AddURLArchive) Description() string {
+	return "adding url page archive time"
+}
+
+func (m AddURLArchive) Version() string {
+	return "008"
+}
+
+func (m AddURLArchive) Run(ctx context.Context, tx *sqlx.Tx) error {
+	st, err := getSQL(filepath.Join("migrations", "008-url-archive"))
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, st); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AddUserAccounts adds what's needed to run an instance with many accounts:
+// admins, disabled accounts and per-user settings.
+type AddUserAccounts struct{}
+
+func (m AddUserAccounts) Description() string {
+	return "adding user account management"
+}
+
+func (m AddUserAccounts) Version() string {
+	return "009"
+}
+
+func (m AddUserAccounts) Run(ctx context.Context, tx *sqlx.Tx) error {
+	st, err := getSQL(filepath.Join("migrations", "009-user-accounts"))
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, st); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AddUserTOTP adds two-factor authentication secrets and recovery codes.
+type AddUserTOTP struct{}
+
+func (m AddUserTOTP) Description() string {
+	return "adding two-factor authentication"
+}
+
+func (m AddUserTOTP) Version() string {
+	return "010"
+}
+
+func (m AddUserTOTP) Run(ctx context.Context, tx *sqlx.Tx) error {
+	st, err := getSQL(filepath.Join("migrations", "010-user-totp"))
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, st); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AddUserWebAuthn adds the passkeys and security keys users log in with.
+type AddUserWebAuthn struct{}
+
+func (m AddUserWebAuthn) Description() string {
+	return "adding webauthn credentials"
+}
+
+func (m AddUserWebAuthn) Version() string {
+	return "011"
+}
+
+func (m AddUserWebAuthn) Run(ctx context.Context, tx *sqlx.Tx) error {
+	st, err := getSQL(filepath.Join("migrations", "011-user-webauthn"))
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, st); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AddUserURLVisibility replaces the private flag of user urls with their
+// visibility.
+type AddUserURLVisibility struct{}
+
+func (m AddUserURLVisibility) Description() string {
+	return "adding user url visibility"
+}
+
+func (m AddUserURLVisibility) Version() string {
+	return "012"
+}
+
+func (m AddUserURLVisibility) Run(ctx context.Context, tx *sqlx.Tx) error {
+	st, err := getSQL(filepath.Join("migrations", "012-user-url-visibility"))
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, st); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AddShares adds the secret links users share urls and tags with.
+type AddShares struct{}
+
+func (m AddShares) Description() string {
+	return "adding share links"
+}
+
+func (m AddShares) Version() string {
+	return "013"
+}
+
+func (m AddShares) Run(ctx context.Context, tx *sqlx.Tx) error {
+	st, err := getSQL(filepath.Join("migrations", "013-shares"))
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, st); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AddEmbeds splits embedding content into photos and videos and adds the
+// oEmbed cache.
+type AddEmbeds struct{}
+
+func (m AddEmbeds) Description() string {
+	return "adding embeds"
+}
+
+func (m AddEmbeds) Version() string {
+	return "014"
+}
+
+func (m AddEmbeds) Run(ctx context.Context, tx *sqlx.Tx) error {
+	st, err := getSQL(filepath.Join("migrations", "014-embeds"))
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, st); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AddUserIdentities ties accounts to the single sign-on accounts that log in
+// as them.
+type AddUserIdentities struct{}
+
+func (m AddUserIdentities) Description() string {
+	return "adding user single sign-on identities"
+}
+
+func (m AddUserIdentities) Version() string {
+	return "015"
+}
+
+func (m AddUserIdentities) Run(ctx context.Context, tx *sqlx.Tx) error {
+	st, err := getSQL(filepath.Join("migrations", "015-user-identities"))
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, st); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package sqlitestore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/kyleterry/sufr/pkg/api"
+)
+
+type shareManager struct {
+	statementLoader
+	store *Store
+}
+
+func (m *shareManager) Create(ctx context.Context, user *api.User, share *api.Share) error {
+	return m.store.withTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		st, err := m.getStatement("Create")
+		if err != nil {
+			return err
+		}
+
+		share.UserId = user.Id
+
+		if _, err := tx.NamedExecContext(ctx, st, share); err != nil {
+			return fmt.Errorf("failed to create share: %w", mapError(err))
+		}
+
+		return nil
+	})
+}
+
+func (m *shareManager) GetByID(ctx context.Context, id string) (*api.Share, error) {
+	st, err := m.getStatement("GetByID")
+	if err != nil {
+		return nil, err
+	}
+
+	share := api.Share{}
+
+	if err := m.store.queryer().GetContext(ctx, &share, st, id); err != nil {
+		return nil, fmt.Errorf("failed to get share: %w", mapError(err))
+	}
+
+	return &share, nil
+}
+
+func (m *shareManager) GetAll(ctx context.Context, user *api.User) ([]*api.Share, error) {
+	st, err := m.getStatement("GetAll")
+	if err != nil {
+		return nil, err
+	}
+
+	shares := []*api.Share{}
+
+	if err := m.store.queryer().SelectContext(ctx, &shares, st, user.Id); err != nil {
+		return nil, fmt.Errorf("failed to get shares: %w", mapError(err))
+	}
+
+	return shares, nil
+}
+
+func (m *shareManager) View(ctx context.Context, id string) error {
+	return m.store.withTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		st, err := m.getStatement("View")
+		if err != nil {
+			return err
+		}
+
+		res, err := tx.ExecContext(ctx, st, id)
+		if err != nil {
+			return fmt.Errorf("failed to view share: %w", mapError(err))
+		}
+
+		return expectAffected(res, "failed to view share")
+	})
+}
+
+func (m *shareManager) Delete(ctx context.Context, user *api.User, id string) error {
+	return m.store.withTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		st, err := m.getStatement("Delete")
+		if err != nil {
+			return err
+		}
+
+		res, err := tx.ExecContext(ctx, st, user.Id, id)
+		if err != nil {
+			return fmt.Errorf("failed to delete share: %w", mapError(err))
+		}
+
+		return expectAffected(res, "failed to delete share")
+	})
+}
+
+func newShareManager(store *Store) *shareManager {
+	return &shareManager{
+		statementLoader: statementLoader{
+			dialect:     store.db.DriverName(),
+			managerName: "ShareManager",
+		},
+		store: store,
+	}
+}
@@ -0,0 +1,107 @@
+package sqlitestore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyleterry/sufr/pkg/api"
+	"github.com/kyleterry/sufr/pkg/store"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShares(t *testing.T) {
+	WithTempDatabase(t, func(db *Store) {
+		ctx := context.Background()
+		sm := db.Shares()
+
+		user := MustCreateBasicTestUser(t, db)
+		tag := MustCreateRandomTag(t, db)
+
+		uu := &api.UserURL{
+			Url:   MustCreateRandomURL(t, db),
+			User:  user,
+			Title: "secret plans",
+			Tags:  &api.TagList{},
+		}
+		require.NoError(t, db.UserURLs(user).Create(ctx, uu))
+
+		urlShare := &api.Share{Id: "url-share", UserUrlId: uu.Id}
+		require.NoError(t, sm.Create(ctx, user, urlShare))
+
+		expires := &api.Timestamp{}
+		expires.SetFromGoTime(time.Now().Add(-time.Hour))
+
+		tagShare := &api.Share{Id: "tag-share", TagId: tag.Id, ExpiresAt: expires}
+		require.NoError(t, sm.Create(ctx, user, tagShare))
+
+		t.Run("shares one thing", func(t *testing.T) {
+			err := sm.Create(ctx, user, &api.Share{Id: "nothing"})
+			require.Error(t, err)
+
+			err = sm.Create(ctx, user, &api.Share{Id: "both", UserUrlId: uu.Id, TagId: tag.Id})
+			require.Error(t, err)
+		})
+
+		t.Run("gets by id", func(t *testing.T) {
+			share, err := sm.GetByID(ctx, urlShare.Id)
+			require.NoError(t, err)
+			require.Equal(t, user.Id, share.UserId)
+			require.Equal(t, uu.Id, share.UserUrlId)
+			require.Empty(t, share.TagId)
+			require.Equal(t, "secret plans", share.Title)
+			require.Nil(t, share.ExpiresAt)
+			require.False(t, share.Expired())
+
+			share, err = sm.GetByID(ctx, tagShare.Id)
+			require.NoError(t, err)
+			require.Equal(t, tag.Name, share.Title)
+			require.True(t, share.Expired())
+
+			_, err = sm.GetByID(ctx, "missing")
+			require.True(t, errors.Is(err, store.ErrNotFound))
+		})
+
+		t.Run("counts views", func(t *testing.T) {
+			require.NoError(t, sm.View(ctx, urlShare.Id))
+			require.NoError(t, sm.View(ctx, urlShare.Id))
+
+			share, err := sm.GetByID(ctx, urlShare.Id)
+			require.NoError(t, err)
+			require.Equal(t, int64(2), share.Views)
+			require.NotNil(t, share.LastViewedAt)
+
+			require.True(t, errors.Is(sm.View(ctx, "missing"), store.ErrNotFound))
+		})
+
+		t.Run("lists the user's shares", func(t *testing.T) {
+			shares, err := sm.GetAll(ctx, user)
+			require.NoError(t, err)
+			require.Len(t, shares, 2)
+
+			other := &api.User{Id: "someone-else"}
+
+			shares, err = sm.GetAll(ctx, other)
+			require.NoError(t, err)
+			require.Empty(t, shares)
+
+			err = sm.Delete(ctx, other, urlShare.Id)
+			require.True(t, errors.Is(err, store.ErrNotFound))
+		})
+
+		t.Run("revokes", func(t *testing.T) {
+			require.NoError(t, sm.Delete(ctx, user, tagShare.Id))
+
+			_, err := sm.GetByID(ctx, tagShare.Id)
+			require.True(t, errors.Is(err, store.ErrNotFound))
+		})
+
+		t.Run("goes away with the url", func(t *testing.T) {
+			require.NoError(t, db.UserURLs(user).Delete(ctx, uu.Id))
+
+			_, err := sm.GetByID(ctx, urlShare.Id)
+			require.True(t, errors.Is(err, store.ErrNotFound))
+		})
+	})
+}
@@ -0,0 +1,110 @@
+alter table user_urls add column notes text;
+
+-- user_url_search is a full text index of everything a user can search for on
+-- one of their urls. user_url_id is the id of the user_urls row each row
+-- indexes, which is how results are joined back to user_urls. user_urls has
+-- no integer primary key, so its rowids can change on vacuum and can't be
+-- used for this.
+create virtual table if not exists user_url_search using fts5(
+  url,
+  title,
+  derived_title,
+  notes,
+  tags,
+  user_url_id unindexed,
+  tokenize = 'porter unicode61'
+);
+
+create view if not exists user_url_search_source as
+select
+  uu.id as user_url_id,
+  u.url as url,
+  uu.title as title,
+  coalesce(
+    nullif(uu.title, ''),
+    u.title
+  ) as derived_title,
+  uu.notes as notes,
+  coalesce((
+    select group_concat(t.name, ' ')
+    from user_url_tags ut
+    join tags t on t.id = ut.tag_id
+    where ut.user_url_id = uu.id
+  ), '') as tags
+from user_urls uu
+join urls u on u.id = uu.url_id;
+
+insert into user_url_search (url, title, derived_title, notes, tags, user_url_id)
+select url, title, derived_title, notes, tags, user_url_id
+from user_url_search_source;
+
+create trigger if not exists user_urls_search_insert
+after insert on user_urls
+begin
+  insert into user_url_search (url, title, derived_title, notes, tags, user_url_id)
+  select url, title, derived_title, notes, tags, user_url_id
+  from user_url_search_source where user_url_id = new.id;
+end;
+
+create trigger if not exists user_urls_search_update
+after update on user_urls
+begin
+  delete from user_url_search where user_url_id = old.id;
+  insert into user_url_search (url, title, derived_title, notes, tags, user_url_id)
+  select url, title, derived_title, notes, tags, user_url_id
+  from user_url_search_source where user_url_id = new.id;
+end;
+
+create trigger if not exists user_urls_search_delete
+after delete on user_urls
+begin
+  delete from user_url_search where user_url_id = old.id;
+end;
+
+create trigger if not exists urls_search_update
+after update of url, title on urls
+begin
+  delete from user_url_search
+  where user_url_id in (select id from user_urls where url_id = new.id);
+  insert into user_url_search (url, title, derived_title, notes, tags, user_url_id)
+  select url, title, derived_title, notes, tags, user_url_id
+  from user_url_search_source
+  where user_url_id in (select id from user_urls where url_id = new.id);
+end;
+
+create trigger if not exists user_url_tags_search_insert
+after insert on user_url_tags
+begin
+  delete from user_url_search
+  where user_url_id = new.user_url_id;
+  insert into user_url_search (url, title, derived_title, notes, tags, user_url_id)
+  select url, title, derived_title, notes, tags, user_url_id
+  from user_url_search_source
+  where user_url_id = new.user_url_id;
+end;
+
+create trigger if not exists user_url_tags_search_delete
+after delete on user_url_tags
+begin
+  delete from user_url_search
+  where user_url_id = old.user_url_id;
+  insert into user_url_search (url, title, derived_title, notes, tags, user_url_id)
+  select url, title, derived_title, notes, tags, user_url_id
+  from user_url_search_source
+  where user_url_id = old.user_url_id;
+end;
+
+create trigger if not exists tags_search_update
+after update of name on tags
+begin
+  delete from user_url_search
+  where user_url_id in (
+    select user_url_id from user_url_tags
+    where tag_id = new.id);
+  insert into user_url_search (url, title, derived_title, notes, tags, user_url_id)
+  select url, title, derived_title, notes, tags, user_url_id
+  from user_url_search_source
+  where user_url_id in (
+    select user_url_id from user_url_tags
+    where tag_id = new.id);
+end;
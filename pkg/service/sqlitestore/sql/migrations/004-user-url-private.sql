@@ -0,0 +1 @@
+alter table user_urls add column private boolean not null default false;
@@ -0,0 +1,22 @@
+alter table urls add column content_type text;
+
+-- fetch_jobs is the queue of urls waiting for their metadata to be fetched.
+-- run_at is pushed forward while a worker has a job claimed and after a
+-- failed attempt.
+create table if not exists fetch_jobs (
+  id text not null primary key,
+  url_id text not null unique,
+  attempts integer not null default 0,
+  run_at timestamp not null,
+  last_error text,
+  created_at timestamp default CURRENT_TIMESTAMP,
+  foreign key(url_id) references urls(id) on delete cascade
+);
+
+create index if not exists fetch_jobs_run_at on fetch_jobs (run_at);
+
+-- urls saved before the queue existed never had their metadata fetched
+insert into fetch_jobs (id, url_id, run_at)
+select lower(hex(randomblob(12))), id, CURRENT_TIMESTAMP
+from urls
+where coalesce(title, '') = '';
@@ -0,0 +1,8 @@
+alter table urls add column description text;
+alter table urls add column image_url text;
+alter table urls add column site_name text;
+alter table urls add column author text;
+alter table urls add column published_at timestamp;
+alter table urls add column canonical_url text;
+alter table urls add column language text;
+alter table urls add column favicon_url text;
@@ -0,0 +1,25 @@
+-- the outcome of the latest link check. failures counts the checks that
+-- failed in a row and dead is set once it reaches the checker's limit.
+alter table urls add column status_code integer not null default 0;
+alter table urls add column failures integer not null default 0;
+alter table urls add column dead boolean not null default false;
+alter table urls add column checked_at timestamp;
+
+create index if not exists urls_checked_at on urls (checked_at);
+
+-- url_checks is the history of link checks. final_url is where redirects
+-- ended up and error is set when no response came back at all.
+create table if not exists url_checks (
+  id text not null primary key,
+  url_id text not null,
+  ok boolean not null default false,
+  status_code integer not null default 0,
+  final_url text,
+  redirects integer not null default 0,
+  response_time_ms integer not null default 0,
+  error text,
+  checked_at timestamp not null,
+  foreign key(url_id) references urls(id) on delete cascade
+);
+
+create index if not exists url_checks_url_id_checked_at on url_checks (url_id, checked_at);
@@ -0,0 +1 @@
+alter table urls add column archived_at timestamp;
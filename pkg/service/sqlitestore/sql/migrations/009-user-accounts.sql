@@ -0,0 +1,7 @@
+alter table users add column admin boolean not null default false;
+alter table users add column disabled boolean not null default false;
+alter table users add column per_page integer not null default 0;
+
+-- the oldest account is the one the instance was set up with
+update users set admin = true
+where id = (select id from users order by created_at, rowid limit 1);
@@ -0,0 +1,15 @@
+-- totp_secret is the base32 secret of a user's authenticator app, empty
+-- when two-factor authentication is off. totp_last_step is the time step of
+-- the last code used so a code can't be used twice.
+alter table users add column totp_secret text not null default '';
+alter table users add column totp_last_step integer not null default 0;
+
+-- user_recovery_codes are the hashes of the codes that log a user in when
+-- they've lost their authenticator. Each one is deleted once used.
+create table if not exists user_recovery_codes (
+  user_id text not null,
+  code_hash blob not null,
+  created_at timestamp not null default current_timestamp,
+  primary key(user_id, code_hash),
+  foreign key(user_id) references users(id) on delete cascade
+);
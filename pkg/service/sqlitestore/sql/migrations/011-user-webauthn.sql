@@ -0,0 +1,17 @@
+-- user_webauthn_credentials are the passkeys and security keys users log in
+-- with. id is the credential id the authenticator made and public_key is its
+-- COSE_Key. sign_count is the last signature counter it sent, which only goes
+-- up unless the authenticator doesn't keep one.
+create table if not exists user_webauthn_credentials (
+  id blob primary key,
+  user_id text not null,
+  name text not null,
+  public_key blob not null,
+  sign_count integer not null default 0,
+  created_at timestamp not null default current_timestamp,
+  last_used_at timestamp,
+  foreign key(user_id) references users(id) on delete cascade
+);
+
+create index if not exists user_webauthn_credentials_user_id
+  on user_webauthn_credentials(user_id);
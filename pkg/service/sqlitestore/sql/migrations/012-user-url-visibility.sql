@@ -0,0 +1,10 @@
+-- visibility replaces the private flag. urls flagged private stay private
+-- and the rest were public under the flag, so they start out public. The
+-- private column is left behind unused because sqlite can't drop columns
+-- before 3.35.
+alter table user_urls add column visibility text not null default 'private'
+  check (visibility in ('private', 'unlisted', 'public'));
+
+update user_urls set visibility = case when private then 'private' else 'public' end;
+
+create index user_urls_user_id_visibility on user_urls (user_id, visibility);
@@ -0,0 +1,19 @@
+-- shares are secret links to one of a user's urls or to everything they
+-- tagged with a tag, whatever the urls' visibility. id is the secret in the
+-- link. Exactly one of user_url_id and tag_id is set.
+create table if not exists shares (
+  id text primary key,
+  user_id text not null,
+  user_url_id text,
+  tag_id text,
+  views integer not null default 0,
+  expires_at timestamp,
+  created_at timestamp not null default current_timestamp,
+  last_viewed_at timestamp,
+  foreign key(user_id) references users(id) on delete cascade,
+  foreign key(user_url_id) references user_urls(id) on delete cascade,
+  foreign key(tag_id) references tags(id) on delete cascade,
+  check ((user_url_id is null) != (tag_id is null))
+);
+
+create index if not exists shares_user_id on shares(user_id);
@@ -0,0 +1,23 @@
+-- embed_photos and embed_videos replace embed_content, which turned both on
+-- at once. embed_content is left behind unused because sqlite can't drop
+-- columns before 3.35.
+alter table users add column embed_photos boolean not null default false;
+alter table users add column embed_videos boolean not null default false;
+
+update users set embed_photos = embed_content, embed_videos = embed_content;
+
+-- embeds caches what oEmbed endpoints answered for urls, with the html
+-- already sanitized.
+create table if not exists embeds (
+  url_id text primary key,
+  url text not null,
+  type text not null,
+  title text not null default '',
+  provider_name text not null default '',
+  html text not null,
+  width integer not null default 0,
+  height integer not null default 0,
+  thumbnail_url text not null default '',
+  fetched_at timestamp not null,
+  foreign key(url_id) references urls(id) on delete cascade
+);
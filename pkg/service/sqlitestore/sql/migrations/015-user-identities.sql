@@ -0,0 +1,12 @@
+-- user_identities ties accounts to the single sign-on accounts that log in
+-- as them, by the provider's issuer and the subject it knows them by. Emails
+-- at a provider can change hands, subjects can't.
+create table if not exists user_identities (
+  issuer text not null,
+  subject text not null,
+  user_id text not null,
+  created_at timestamp not null default current_timestamp,
+  primary key (issuer, subject),
+  unique (user_id, issuer),
+  foreign key(user_id) references users(id) on delete cascade
+);
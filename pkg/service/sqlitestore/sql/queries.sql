@@ -2,7 +2,7 @@
 
 -- sufr:map_query TagManager.Create
 insert or ignore into tags (id, name, created_at, updated_at)
-values (:id, :name, :created_at, :updated_at)
+values (:id, :name, coalesce(:created_at, CURRENT_TIMESTAMP), :updated_at)
 
 -- sufr:map_query TagManager.GetByID
 select
@@ -26,23 +26,164 @@ where name = ?
 insert or ignore into urls
   (id, url, title, created_at, updated_at)
 values
-  (:id, :url, :title, :created_at, :updated_at)
+  (:id, :url, :title, coalesce(:created_at, CURRENT_TIMESTAMP), :updated_at)
 
 -- sufr:map_query URLManager.GetByURL
 select 
   id as id,
   url as url,
   title as title,
+  coalesce(content_type, '') as content_type,
+  coalesce(description, '') as description,
+  coalesce(image_url, '') as image_url,
+  coalesce(site_name, '') as site_name,
+  coalesce(author, '') as author,
+  published_at as published_at,
+  coalesce(canonical_url, '') as canonical_url,
+  coalesce(language, '') as language,
+  coalesce(favicon_url, '') as favicon_url,
+  status_code as status_code,
+  failures as failures,
+  dead as dead,
+  checked_at as checked_at,
+  archived_at as archived_at,
   created_at as created_at,
   updated_at as updated_at
 from urls
 where url = ?
 
+-- sufr:map_query URLManager.GetByID
+select
+  id as id,
+  url as url,
+  title as title,
+  coalesce(content_type, '') as content_type,
+  coalesce(description, '') as description,
+  coalesce(image_url, '') as image_url,
+  coalesce(site_name, '') as site_name,
+  coalesce(author, '') as author,
+  published_at as published_at,
+  coalesce(canonical_url, '') as canonical_url,
+  coalesce(language, '') as language,
+  coalesce(favicon_url, '') as favicon_url,
+  status_code as status_code,
+  failures as failures,
+  dead as dead,
+  checked_at as checked_at,
+  archived_at as archived_at,
+  created_at as created_at,
+  updated_at as updated_at
+from urls
+where id = ?
+
+-- sufr:map_query URLManager.Update
+update urls
+  set
+    title = :title,
+    content_type = :content_type,
+    description = :description,
+    image_url = :image_url,
+    site_name = :site_name,
+    author = :author,
+    published_at = :published_at,
+    canonical_url = :canonical_url,
+    language = :language,
+    favicon_url = :favicon_url,
+    archived_at = :archived_at,
+    updated_at = CURRENT_TIMESTAMP
+where id = :id
+
+-- sufr:map_query FetchJobManager.Enqueue
+insert or ignore into fetch_jobs
+  (id, url_id, run_at)
+values
+  (:id, :url_id, :run_at)
+
+-- sufr:map_query FetchJobManager.next
+select
+  j.id as id,
+  j.url_id as url_id,
+  u.url as url,
+  j.attempts as attempts,
+  j.run_at as run_at,
+  coalesce(j.last_error, '') as last_error,
+  j.created_at as created_at
+from fetch_jobs j
+join urls u on u.id = j.url_id
+where j.run_at <= ?
+order by j.run_at
+limit 1
+
+-- sufr:map_query FetchJobManager.claim
+update fetch_jobs set run_at = ? where id = ? and run_at <= ?
+
+-- sufr:map_query FetchJobManager.Complete
+delete from fetch_jobs where id = ?
+
+-- sufr:map_query FetchJobManager.Retry
+update fetch_jobs
+  set
+    attempts = :attempts,
+    run_at = :run_at,
+    last_error = :last_error
+where id = :id
+
+-- sufr:map_query URLCheckManager.Due
+select
+  u.id as id,
+  u.url as url
+from urls u
+where (u.checked_at is null or u.checked_at <= ?)
+  and exists (select 1 from user_urls uu where uu.url_id = u.id)
+order by u.checked_at is not null, u.checked_at
+limit ?
+
+-- sufr:map_query URLCheckManager.create
+insert into url_checks
+  (id, url_id, ok, status_code, final_url, redirects, response_time_ms, error, checked_at)
+values
+  (:id, :url_id, :ok, :status_code, :final_url, :redirects, :response_time_ms, :error, :checked_at)
+
+-- sufr:map_query URLCheckManager.updateURL
+update urls
+  set
+    status_code = :status_code,
+    failures = case when :ok then 0 else failures + 1 end,
+    dead = case when :ok then false else failures + 1 >= :dead_after end,
+    checked_at = :checked_at
+where id = :url_id
+
+-- sufr:map_query URLCheckManager.prune
+delete from url_checks
+where url_id = :url_id
+  and id not in (
+    select id from url_checks
+    where url_id = :url_id
+    order by checked_at desc
+    limit :keep
+  )
+
+-- sufr:map_query URLCheckManager.GetByURLID
+select
+  id as id,
+  url_id as url_id,
+  ok as ok,
+  status_code as status_code,
+  coalesce(final_url, '') as final_url,
+  redirects as redirects,
+  response_time_ms as response_time_ms,
+  coalesce(error, '') as error,
+  checked_at as checked_at
+from url_checks
+where url_id = ?
+order by checked_at desc
+limit ?
+
 -- sufr:map_query UserManager.Create
 insert into users
   (id, email, password_hash, created_at, updated_at)
 values
-  (:id, :email, :password_hash, :created_at, :updated_at)
+  (:id, :email, :password_hash, coalesce(:created_at, CURRENT_TIMESTAMP), :updated_at)
 
 -- sufr:map_query UserManager.UpdatePinnedCategories
 update users
@@ -58,7 +199,15 @@ update users
           json_extract(items.value, '$.id'))) as tags
     from json_each(json(?)) cats
     join json_each(cats.value, '$.tags.items') items
-    group by json_extract(cats.value, '$.label')))
+    group by cats.key
+    order by cats.key))
+where id = ?
+
+-- sufr:map_query UserManager.UpdateAPIToken
+update users
+  set
+    api_token = ?,
+    updated_at = CURRENT_TIMESTAMP
 where id = ?
 
 -- sufr:map_query UserManager.GetByEmail
@@ -69,47 +218,223 @@ select
   coalesce(
     nullif(users.api_token, ''), ''
   ) as api_token,
-  users.embed_content as embed_content,
+  users.embed_photos as embed_photos,
+  users.embed_videos as embed_videos,
+  users.admin as admin,
+  users.disabled as disabled,
+  users.per_page as per_page,
+  users.totp_secret != '' as totp_enabled,
   users.created_at as created_at,
   users.updated_at as updated_at
 from users
 where users.email = ?;
 
+-- sufr:map_query UserManager.GetByIdentity
+select
+  users.id as id,
+  users.email as email,
+  users.password_hash as password_hash,
+  coalesce(
+    nullif(users.api_token, ''), ''
+  ) as api_token,
+  users.embed_photos as embed_photos,
+  users.embed_videos as embed_videos,
+  users.admin as admin,
+  users.disabled as disabled,
+  users.per_page as per_page,
+  users.totp_secret != '' as totp_enabled,
+  users.created_at as created_at,
+  users.updated_at as updated_at
+from users
+join user_identities ui on ui.user_id = users.id
+where ui.issuer = ? and ui.subject = ?;
+
+-- sufr:map_query UserManager.GetByAPIToken
+select
+  users.id as id,
+  users.email as email,
+  users.password_hash as password_hash,
+  coalesce(
+    nullif(users.api_token, ''), ''
+  ) as api_token,
+  users.embed_photos as embed_photos,
+  users.embed_videos as embed_videos,
+  users.admin as admin,
+  users.disabled as disabled,
+  users.per_page as per_page,
+  users.totp_secret != '' as totp_enabled,
+  users.created_at as created_at,
+  users.updated_at as updated_at
+from users
+where users.api_token = ? and users.api_token != '';
+
 -- sufr:map_query UserManager.GetByID
 select
   users.id as id,
   users.email as email,
-  users.embed_content as embed_content,
+  users.embed_photos as embed_photos,
+  users.embed_videos as embed_videos,
+  users.admin as admin,
+  users.disabled as disabled,
+  users.per_page as per_page,
+  users.totp_secret != '' as totp_enabled,
   users.created_at as created_at,
   users.updated_at as updated_at
 from users
 where users.id = ?;
 
+-- sufr:map_query UserManager.GetAll
+select
+  users.id as id,
+  users.email as email,
+  users.embed_photos as embed_photos,
+  users.embed_videos as embed_videos,
+  users.admin as admin,
+  users.disabled as disabled,
+  users.per_page as per_page,
+  users.totp_secret != '' as totp_enabled,
+  users.created_at as created_at,
+  users.updated_at as updated_at
+from users
+order by users.created_at, users.rowid;
+
+-- sufr:map_query UserManager.Update
+update users
+  set
+    email = :email,
+    embed_photos = :embed_photos,
+    embed_videos = :embed_videos,
+    per_page = :per_page,
+    updated_at = CURRENT_TIMESTAMP
+where id = :id
+
+-- sufr:map_query UserManager.UpdatePassword
+update users
+  set
+    password_hash = ?,
+    updated_at = CURRENT_TIMESTAMP
+where id = ?
+
+-- sufr:map_query UserManager.UpdateAccess
+update users
+  set
+    admin = ?,
+    disabled = ?,
+    updated_at = CURRENT_TIMESTAMP
+where id = ?
+
+-- sufr:map_query UserManager.Delete
+delete from users where id = ?
+
+-- sufr:map_query UserManager.GetTOTP
+select
+  users.totp_secret as secret,
+  users.totp_last_step as last_step,
+  (
+    select count(*) from user_recovery_codes
+    where user_recovery_codes.user_id = users.id
+  ) as recovery_codes
+from users
+where users.id = ? and users.totp_secret != '';
+
+-- sufr:map_query UserManager.updateTOTP
+update users
+  set
+    totp_secret = ?,
+    totp_last_step = 0,
+    updated_at = CURRENT_TIMESTAMP
+where id = ?
+
+-- sufr:map_query UserManager.UseTOTPStep
+update users
+  set totp_last_step = ?
+where id = ? and totp_secret != '' and totp_last_step < ?
+
+-- sufr:map_query UserManager.clearRecoveryCodes
+delete from user_recovery_codes where user_id = ?
+
+-- sufr:map_query UserManager.addRecoveryCode
+insert into user_recovery_codes (user_id, code_hash) values (?, ?)
+
+-- sufr:map_query UserManager.UseRecoveryCode
+delete from user_recovery_codes where user_id = ? and code_hash = ?
+
+-- sufr:map_query UserManager.AddWebAuthnCredential
+insert into user_webauthn_credentials
+  (id, user_id, name, public_key, sign_count, created_at)
+values
+  (:id, :user_id, :name, :public_key, :sign_count, coalesce(:created_at, CURRENT_TIMESTAMP))
+
+-- sufr:map_query UserManager.GetWebAuthnCredential
+select
+  id as id,
+  user_id as user_id,
+  name as name,
+  public_key as public_key,
+  sign_count as sign_count,
+  created_at as created_at,
+  last_used_at as last_used_at
+from user_webauthn_credentials
+where id = ?
+
+-- sufr:map_query UserManager.UseWebAuthnCredential
+update user_webauthn_credentials
+  set
+    sign_count = ?,
+    last_used_at = CURRENT_TIMESTAMP
+where id = ?
+
+-- sufr:map_query UserManager.DeleteWebAuthnCredential
+delete from user_webauthn_credentials where user_id = ? and id = ?
+
+-- sufr:map_query UserManager.getWebAuthnCredentials
+select
+  id as id,
+  user_id as user_id,
+  name as name,
+  public_key as public_key,
+  sign_count as sign_count,
+  created_at as created_at,
+  last_used_at as last_used_at
+from user_webauthn_credentials
+where user_id = ?
+order by created_at, rowid
+
+-- sufr:map_query UserManager.AddIdentity
+insert into user_identities (issuer, subject, user_id) values (?, ?, ?)
+
+-- sufr:map_query UserManager.HasIdentity
+select exists (select 1 from user_identities where user_id = ?)
+
 -- sufr:map_query UserManager.getPinnedCategories
 select
   json_extract(cats.value, '$.label') as label,
   json_object('items',
-    json_array(
-      json_set(jt.value, '$.name', t.name)
+    json_group_array(
+      json_object('id', t.id, 'name', t.name)
     )
   ) as tags
 from users
-left join json_each(users.pinned_categories) cats
-left join json_each(json_extract(cats.value, '$.tags')) jt
-left join tags t on t.id = json_extract(jt.value, '$.id')
-where users.id = ? and users.pinned_categories != '[]'
+join json_each(users.pinned_categories) cats
+join json_each(json_extract(cats.value, '$.tags')) jt
+join tags t on t.id = json_extract(jt.value, '$.id')
+where users.id = ?
+group by cats.key
+order by cats.key
 
 -- sufr:map_query UserURLManager.Create
 insert into user_urls
-  (id, user_id, url_id, title, favorite, created_at, updated_at)
+  (id, user_id, url_id, title, notes, favorite, visibility, created_at, updated_at)
 values
-  (:id, :user.id, :url.id, :title, :favorite, :created_at, :updated_at)
+  (:id, :user.id, :url.id, :title, :notes, :favorite, :visibility, coalesce(:created_at, CURRENT_TIMESTAMP), :updated_at)
 
 -- sufr:map_query UserURLManager.Update
 update user_urls
   set 
     title = :title,
+    notes = :notes,
     favorite = :favorite,
+    visibility = :visibility,
     updated_at = CURRENT_TIMESTAMP
 where user_id = :user.id and id = :id
 
@@ -128,62 +453,66 @@ select
   u.id as 'url.id',
   u.url as 'url.url',
   u.title as 'url.title',
+  coalesce(u.content_type, '') as 'url.content_type',
+  coalesce(u.description, '') as 'url.description',
+  coalesce(u.image_url, '') as 'url.image_url',
+  coalesce(u.site_name, '') as 'url.site_name',
+  coalesce(u.author, '') as 'url.author',
+  u.published_at as 'url.published_at',
+  coalesce(u.canonical_url, '') as 'url.canonical_url',
+  coalesce(u.language, '') as 'url.language',
+  coalesce(u.favicon_url, '') as 'url.favicon_url',
+  u.status_code as 'url.status_code',
+  u.failures as 'url.failures',
+  u.dead as 'url.dead',
+  u.checked_at as 'url.checked_at',
+  u.archived_at as 'url.archived_at',
   uu.user_id as 'user.id',
   uu.title as title,
   coalesce(
     nullif(uu.title, ''),
     u.title
   ) as derived_title,
-  json_object('items',
-    json_group_array(
-      json_object('id', t.id, 'name', t.name)
+  coalesce(uu.notes, '') as notes,
+  (
+    select json_object('items',
+      json_group_array(
+        json_object('id', t.id, 'name', t.name)
+      )
     )
+    from user_url_tags ut
+    join tags t on t.id = ut.tag_id
+    where ut.user_url_id = uu.id
   ) as tags,
   uu.favorite as favorite,
+  uu.visibility as visibility,
+  json_array(uu.created_at, uu.id) as cursor,
   uu.created_at,
   uu.updated_at
 from
   user_urls uu
 join urls u on u.id = uu.url_id
-join user_url_tags ut on ut.user_url_id = uu.id
-join tags t on t.id = ut.tag_id
-where uu.user_id = ?
-group by uu.id
-
--- sufr:map_query UserURLManager.GetAllAfter
-select 
-    uu.*,
-    json_object('items',
-      json_group_array(
-        json_object('id', t.id, 'name', t.name)
-      )
-    ) as tags
-from (
-  select
-    row_number() over (
-      order by uu.id) as row,
-    uu.id as id,
-    u.id as 'url.id',
-    u.url as 'url.url',
-    u.title as 'url.title',
-    uu.user_id as 'user.id',
-    uu.title as title,
-    coalesce(
-      nullif(uu.title, ''),
-      u.title
-    ) as derived_title,
-    uu.favorite as favorite,
-    uu.created_at as created_at,
-    uu.updated_at as updated_at
-  from
-    user_urls uu
-  join urls u on u.id = uu.url_id
-  where uu.user_id = ?
-) as uu
-left join user_url_tags ut on ut.user_url_id = uu.id
-left join tags t on t.id = ut.tag_id
-where row > ? and row <= ?
-group by uu.id
+where uu.user_id = :user_id
+  and (
+    :tag_count = 0
+    or (
+      select count(distinct t.name)
+      from user_url_tags ut
+      join tags t on t.id = ut.tag_id
+      where ut.user_url_id = uu.id
+        and t.name in (select value from json_each(:tags))
+    ) >= case when :any_tags then 1 else :tag_count end
+  )
+  and (not :broken or u.dead)
+  and (not :public or uu.visibility = 'public')
+  and (not :favorite or uu.favorite)
+  and (
+    :after_key is null
+    or uu.created_at < :after_key
+    or (uu.created_at = :after_key and uu.id < :after_id)
+  )
+order by uu.created_at desc, uu.id desc
+limit :limit
 
 -- sufr:map_query UserURLManager.GetByURLID
 select
@@ -191,49 +520,287 @@ select
   u.id as 'url.id',
   u.url as 'url.url',
   u.title as 'url.title',
+  coalesce(u.content_type, '') as 'url.content_type',
+  coalesce(u.description, '') as 'url.description',
+  coalesce(u.image_url, '') as 'url.image_url',
+  coalesce(u.site_name, '') as 'url.site_name',
+  coalesce(u.author, '') as 'url.author',
+  u.published_at as 'url.published_at',
+  coalesce(u.canonical_url, '') as 'url.canonical_url',
+  coalesce(u.language, '') as 'url.language',
+  coalesce(u.favicon_url, '') as 'url.favicon_url',
+  u.status_code as 'url.status_code',
+  u.failures as 'url.failures',
+  u.dead as 'url.dead',
+  u.checked_at as 'url.checked_at',
+  u.archived_at as 'url.archived_at',
   uu.user_id as 'user.id',
   uu.title as title,
   coalesce(
     nullif(uu.title, ''),
     u.title
   ) as derived_title,
-  json_object('items',
-    json_group_array(
-      json_object('id', t.id, 'name', t.name)
+  coalesce(uu.notes, '') as notes,
+  (
+    select json_object('items',
+      json_group_array(
+        json_object('id', t.id, 'name', t.name)
+      )
     )
+    from user_url_tags ut
+    join tags t on t.id = ut.tag_id
+    where ut.user_url_id = uu.id
   ) as tags,
-  /* group_concat(t.name) as tags, */
   uu.favorite as favorite,
+  uu.visibility as visibility,
   uu.created_at,
   uu.updated_at
 from
   user_urls uu
 join urls u on u.id = uu.url_id
-join user_url_tags ut on ut.user_url_id = uu.id
-join tags t on t.id = ut.tag_id
 where uu.user_id = ? and uu.url_id = ?
-group by uu.id
 
--- sufr:map_query UserURLManager.GetAllByTags
+-- sufr:map_query UserURLManager.GetByID
 select
   uu.id as id,
   u.id as 'url.id',
   u.url as 'url.url',
   u.title as 'url.title',
+  coalesce(u.content_type, '') as 'url.content_type',
+  coalesce(u.description, '') as 'url.description',
+  coalesce(u.image_url, '') as 'url.image_url',
+  coalesce(u.site_name, '') as 'url.site_name',
+  coalesce(u.author, '') as 'url.author',
+  u.published_at as 'url.published_at',
+  coalesce(u.canonical_url, '') as 'url.canonical_url',
+  coalesce(u.language, '') as 'url.language',
+  coalesce(u.favicon_url, '') as 'url.favicon_url',
+  u.status_code as 'url.status_code',
+  u.failures as 'url.failures',
+  u.dead as 'url.dead',
+  u.checked_at as 'url.checked_at',
+  u.archived_at as 'url.archived_at',
   uu.user_id as 'user.id',
-  coalesce(nullif(uu.title, ''), u.title) as title,
-  uu.favorite as favorite,
-  json_object('items',
-    json_group_array(
-      json_object('id', t.id, 'name', t.name)
+  uu.title as title,
+  coalesce(
+    nullif(uu.title, ''),
+    u.title
+  ) as derived_title,
+  coalesce(uu.notes, '') as notes,
+  (
+    select json_object('items',
+      json_group_array(
+        json_object('id', t.id, 'name', t.name)
+      )
     )
+    from user_url_tags ut
+    join tags t on t.id = ut.tag_id
+    where ut.user_url_id = uu.id
   ) as tags,
+  uu.favorite as favorite,
+  uu.visibility as visibility,
   uu.created_at,
   uu.updated_at
 from
   user_urls uu
 join urls u on u.id = uu.url_id
-join user_url_tags ut on ut.user_url_id = uu.id
-join tags t on t.id = ut.tag_id
-where user_id = ? and t.id in(?)
-group by uu.id
+where uu.user_id = ? and uu.id = ?
+
+-- sufr:map_query UserURLManager.Delete
+delete from user_urls where user_id = ? and id = ?
+
+-- sufr:map_query UserURLManager.GetTags
+select
+  t.id as id,
+  t.name as name,
+  count(uu.id) as count,
+  t.created_at as created_at,
+  t.updated_at as updated_at
+from tags t
+join user_url_tags ut on ut.tag_id = t.id
+join user_urls uu on uu.id = ut.user_url_id
+where uu.user_id = ?
+group by t.id
+order by t.name
+
+-- sufr:map_query UserURLManager.GetLastUpdated
+select max(coalesce(updated_at, created_at))
+from user_urls
+where user_id = ?
+
+-- sufr:map_query UserURLManager.replaceTagAdd
+insert or ignore into user_url_tags
+  (user_url_id, tag_id)
+select ut.user_url_id, ?
+from user_url_tags ut
+join user_urls uu on uu.id = ut.user_url_id
+where ut.tag_id = ? and uu.user_id = ?
+
+-- sufr:map_query UserURLManager.replaceTagRemove
+delete from user_url_tags
+where tag_id = ?
+  and user_url_id in (select id from user_urls where user_id = ?)
+
+-- sufr:map_query UserURLManager.Search
+select
+  uu.id as id,
+  u.id as 'url.id',
+  u.url as 'url.url',
+  u.title as 'url.title',
+  coalesce(u.content_type, '') as 'url.content_type',
+  coalesce(u.description, '') as 'url.description',
+  coalesce(u.image_url, '') as 'url.image_url',
+  coalesce(u.site_name, '') as 'url.site_name',
+  coalesce(u.author, '') as 'url.author',
+  u.published_at as 'url.published_at',
+  coalesce(u.canonical_url, '') as 'url.canonical_url',
+  coalesce(u.language, '') as 'url.language',
+  coalesce(u.favicon_url, '') as 'url.favicon_url',
+  u.status_code as 'url.status_code',
+  u.failures as 'url.failures',
+  u.dead as 'url.dead',
+  u.checked_at as 'url.checked_at',
+  u.archived_at as 'url.archived_at',
+  uu.user_id as 'user.id',
+  uu.title as title,
+  coalesce(
+    nullif(uu.title, ''),
+    u.title
+  ) as derived_title,
+  coalesce(uu.notes, '') as notes,
+  (
+    select json_object('items',
+      json_group_array(
+        json_object('id', t.id, 'name', t.name)
+      )
+    )
+    from user_url_tags ut
+    join tags t on t.id = ut.tag_id
+    where ut.user_url_id = uu.id
+  ) as tags,
+  uu.favorite as favorite,
+  uu.visibility as visibility,
+  snippet(user_url_search, -1, :match_start, :match_end, '…', 16) as snippet,
+  -bm25(user_url_search, 1.0, 10.0, 10.0, 2.0, 5.0) as rank,
+  json_array(printf('%.17g', -bm25(user_url_search, 1.0, 10.0, 10.0, 2.0, 5.0)), uu.id) as cursor,
+  uu.created_at,
+  uu.updated_at
+from user_url_search
+join user_urls uu on uu.id = user_url_search.user_url_id
+join urls u on u.id = uu.url_id
+where user_url_search match :search
+  and uu.user_id = :user_id
+  and (
+    :tag_count = 0
+    or (
+      select count(distinct t.name)
+      from user_url_tags ut
+      join tags t on t.id = ut.tag_id
+      where ut.user_url_id = uu.id
+        and t.name in (select value from json_each(:tags))
+    ) >= case when :any_tags then 1 else :tag_count end
+  )
+  and (not :broken or u.dead)
+  and (not :public or uu.visibility = 'public')
+  and (not :favorite or uu.favorite)
+  and (
+    :after_key is null
+    or -bm25(user_url_search, 1.0, 10.0, 10.0, 2.0, 5.0) < cast(:after_key as real)
+    or (-bm25(user_url_search, 1.0, 10.0, 10.0, 2.0, 5.0) = cast(:after_key as real) and uu.id < :after_id)
+  )
+order by rank desc, uu.id desc
+limit :limit
+
+-- sufr:map_query ShareManager.Create
+insert into shares
+  (id, user_id, user_url_id, tag_id, expires_at, created_at)
+values
+  (:id, :user_id, nullif(:user_url_id, ''), nullif(:tag_id, ''), :expires_at, coalesce(:created_at, CURRENT_TIMESTAMP))
+
+-- sufr:map_query ShareManager.GetByID
+select
+  s.id as id,
+  s.user_id as user_id,
+  coalesce(s.user_url_id, '') as user_url_id,
+  coalesce(s.tag_id, '') as tag_id,
+  coalesce(
+    (
+      select coalesce(nullif(uu.title, ''), u.title)
+      from user_urls uu
+      join urls u on u.id = uu.url_id
+      where uu.id = s.user_url_id
+    ),
+    (select t.name from tags t where t.id = s.tag_id),
+    ''
+  ) as title,
+  s.views as views,
+  s.expires_at as expires_at,
+  s.created_at as created_at,
+  s.last_viewed_at as last_viewed_at
+from shares s
+where s.id = ?
+
+-- sufr:map_query ShareManager.GetAll
+select
+  s.id as id,
+  s.user_id as user_id,
+  coalesce(s.user_url_id, '') as user_url_id,
+  coalesce(s.tag_id, '') as tag_id,
+  coalesce(
+    (
+      select coalesce(nullif(uu.title, ''), u.title)
+      from user_urls uu
+      join urls u on u.id = uu.url_id
+      where uu.id = s.user_url_id
+    ),
+    (select t.name from tags t where t.id = s.tag_id),
+    ''
+  ) as title,
+  s.views as views,
+  s.expires_at as expires_at,
+  s.created_at as created_at,
+  s.last_viewed_at as last_viewed_at
+from shares s
+where s.user_id = ?
+order by s.created_at desc, s.rowid desc
+
+-- sufr:map_query ShareManager.View
+update shares
+  set
+    views = views + 1,
+    last_viewed_at = CURRENT_TIMESTAMP
+where id = ?
+
+-- sufr:map_query ShareManager.Delete
+delete from shares where user_id = ? and id = ?
+
+-- sufr:map_query EmbedManager.Get
+select
+  url as url,
+  type as type,
+  title as title,
+  provider_name as provider_name,
+  html as html,
+  width as width,
+  height as height,
+  thumbnail_url as thumbnail_url,
+  fetched_at as fetched_at
+from embeds
+where url_id = ?
+
+-- sufr:map_query EmbedManager.Put
+insert into embeds
+  (url_id, url, type, title, provider_name, html, width, height, thumbnail_url, fetched_at)
+values
+  (:url_id, :url, :type, :title, :provider_name, :html, :width, :height, :thumbnail_url, :fetched_at)
+on conflict(url_id) do update
+  set
+    url = excluded.url,
+    type = excluded.type,
+    title = excluded.title,
+    provider_name = excluded.provider_name,
+    html = excluded.html,
+    width = excluded.width,
+    height = excluded.height,
+    thumbnail_url = excluded.thumbnail_url,
+    fetched_at = excluded.fetched_at
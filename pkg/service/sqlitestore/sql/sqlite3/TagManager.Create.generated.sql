@@ -1,3 +1,3 @@
 -- Code generated by build_sql.awk; DO NOT EDIT.
 insert or ignore into tags (id, name, created_at, updated_at)
-values (:id, :name, :created_at, :updated_at)
+values (:id, :name, coalesce(:created_at, CURRENT_TIMESTAMP), :updated_at)
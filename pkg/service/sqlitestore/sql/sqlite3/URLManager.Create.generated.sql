@@ -2,4 +2,4 @@
 insert or ignore into urls
   (id, url, title, created_at, updated_at)
 values
-  (:id, :url, :title, :created_at, :updated_at)
+  (:id, :url, :title, coalesce(:created_at, CURRENT_TIMESTAMP), :updated_at)
@@ -3,6 +3,20 @@ select
   id as id,
   url as url,
   title as title,
+  coalesce(content_type, '') as content_type,
+  coalesce(description, '') as description,
+  coalesce(image_url, '') as image_url,
+  coalesce(site_name, '') as site_name,
+  coalesce(author, '') as author,
+  published_at as published_at,
+  coalesce(canonical_url, '') as canonical_url,
+  coalesce(language, '') as language,
+  coalesce(favicon_url, '') as favicon_url,
+  status_code as status_code,
+  failures as failures,
+  dead as dead,
+  checked_at as checked_at,
+  archived_at as archived_at,
   created_at as created_at,
   updated_at as updated_at
 from urls
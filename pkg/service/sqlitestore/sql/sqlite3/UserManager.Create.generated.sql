@@ -2,4 +2,4 @@
 insert into users
   (id, email, password_hash, created_at, updated_at)
 values
-  (:id, :email, :password_hash, :created_at, :updated_at)
+  (:id, :email, :password_hash, coalesce(:created_at, CURRENT_TIMESTAMP), :updated_at)
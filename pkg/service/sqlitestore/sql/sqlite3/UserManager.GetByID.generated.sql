@@ -2,7 +2,12 @@
 select
   users.id as id,
   users.email as email,
-  users.embed_content as embed_content,
+  users.embed_photos as embed_photos,
+  users.embed_videos as embed_videos,
+  users.admin as admin,
+  users.disabled as disabled,
+  users.per_page as per_page,
+  users.totp_secret != '' as totp_enabled,
   users.created_at as created_at,
   users.updated_at as updated_at
 from users
@@ -12,5 +12,6 @@ update users
           json_extract(items.value, '$.id'))) as tags
     from json_each(json(?)) cats
     join json_each(cats.value, '$.tags.items') items
-    group by json_extract(cats.value, '$.label')))
+    group by cats.key
+    order by cats.key))
 where id = ?
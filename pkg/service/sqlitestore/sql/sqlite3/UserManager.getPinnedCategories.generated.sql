@@ -2,12 +2,14 @@
 select
   json_extract(cats.value, '$.label') as label,
   json_object('items',
-    json_array(
-      json_set(jt.value, '$.name', t.name)
+    json_group_array(
+      json_object('id', t.id, 'name', t.name)
     )
   ) as tags
 from users
-left join json_each(users.pinned_categories) cats
-left join json_each(json_extract(cats.value, '$.tags')) jt
-left join tags t on t.id = json_extract(jt.value, '$.id')
-where users.id = ? and users.pinned_categories != '[]'
+join json_each(users.pinned_categories) cats
+join json_each(json_extract(cats.value, '$.tags')) jt
+join tags t on t.id = json_extract(jt.value, '$.id')
+where users.id = ?
+group by cats.key
+order by cats.key
@@ -1,5 +1,5 @@
 -- Code generated by build_sql.awk; DO NOT EDIT.
 insert into user_urls
-  (id, user_id, url_id, title, favorite, created_at, updated_at)
+  (id, user_id, url_id, title, notes, favorite, visibility, created_at, updated_at)
 values
-  (:id, :user.id, :url.id, :title, :favorite, :created_at, :updated_at)
+  (:id, :user.id, :url.id, :title, :notes, :favorite, :visibility, coalesce(:created_at, CURRENT_TIMESTAMP), :updated_at)
@@ -4,24 +4,63 @@ select
   u.id as 'url.id',
   u.url as 'url.url',
   u.title as 'url.title',
+  coalesce(u.content_type, '') as 'url.content_type',
+  coalesce(u.description, '') as 'url.description',
+  coalesce(u.image_url, '') as 'url.image_url',
+  coalesce(u.site_name, '') as 'url.site_name',
+  coalesce(u.author, '') as 'url.author',
+  u.published_at as 'url.published_at',
+  coalesce(u.canonical_url, '') as 'url.canonical_url',
+  coalesce(u.language, '') as 'url.language',
+  coalesce(u.favicon_url, '') as 'url.favicon_url',
+  u.status_code as 'url.status_code',
+  u.failures as 'url.failures',
+  u.dead as 'url.dead',
+  u.checked_at as 'url.checked_at',
+  u.archived_at as 'url.archived_at',
   uu.user_id as 'user.id',
   uu.title as title,
   coalesce(
     nullif(uu.title, ''),
     u.title
   ) as derived_title,
-  json_object('items',
-    json_group_array(
-      json_object('id', t.id, 'name', t.name)
+  coalesce(uu.notes, '') as notes,
+  (
+    select json_object('items',
+      json_group_array(
+        json_object('id', t.id, 'name', t.name)
+      )
     )
+    from user_url_tags ut
+    join tags t on t.id = ut.tag_id
+    where ut.user_url_id = uu.id
   ) as tags,
   uu.favorite as favorite,
+  uu.visibility as visibility,
+  json_array(uu.created_at, uu.id) as cursor,
   uu.created_at,
   uu.updated_at
 from
   user_urls uu
 join urls u on u.id = uu.url_id
-join user_url_tags ut on ut.user_url_id = uu.id
-join tags t on t.id = ut.tag_id
-where uu.user_id = ?
-group by uu.id
+where uu.user_id = :user_id
+  and (
+    :tag_count = 0
+    or (
+      select count(distinct t.name)
+      from user_url_tags ut
+      join tags t on t.id = ut.tag_id
+      where ut.user_url_id = uu.id
+        and t.name in (select value from json_each(:tags))
+    ) >= case when :any_tags then 1 else :tag_count end
+  )
+  and (not :broken or u.dead)
+  and (not :public or uu.visibility = 'public')
+  and (not :favorite or uu.favorite)
+  and (
+    :after_key is null
+    or uu.created_at < :after_key
+    or (uu.created_at = :after_key and uu.id < :after_id)
+  )
+order by uu.created_at desc, uu.id desc
+limit :limit
@@ -2,6 +2,8 @@
 update user_urls
   set 
     title = :title,
+    notes = :notes,
     favorite = :favorite,
+    visibility = :visibility,
     updated_at = CURRENT_TIMESTAMP
 where user_id = :user.id and id = :id
@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/jmoiron/sqlx/reflectx"
@@ -43,6 +44,16 @@ func WithPath(path string) StoreOption {
 
 type Store struct {
 	db *sqlx.DB
+	// tx is set on the Store passed to Transaction callbacks. Every manager
+	// created from it runs its queries in tx.
+	tx *sqlx.Tx
+}
+
+// queryer is the part of sqlx.DB and sqlx.Tx managers use for reads.
+type queryer interface {
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	Rebind(query string) string
 }
 
 func (s *Store) URLs() store.URLManager {
@@ -61,11 +72,70 @@ func (s *Store) Users() store.UserManager {
 	return newUserManager(s)
 }
 
+func (s *Store) FetchJobs() store.FetchJobManager {
+	return newFetchJobManager(s)
+}
+
+func (s *Store) URLChecks() store.URLCheckManager {
+	return newURLCheckManager(s)
+}
+
+func (s *Store) Shares() store.ShareManager {
+	return newShareManager(s)
+}
+
+func (s *Store) Embeds() store.EmbedManager {
+	return newEmbedManager(s)
+}
+
+// Transaction runs fn with a Manager whose reads and writes all happen in a
+// single transaction. The transaction is rolled back if fn returns an error.
+func (s *Store) Transaction(ctx context.Context, fn func(ctx context.Context, tx store.Manager) error) error {
+	return s.withTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		return fn(ctx, &Store{db: s.db, tx: tx})
+	})
+}
+
+func (s *Store) queryer() queryer {
+	if s.tx != nil {
+		return s.tx
+	}
+
+	return s.db
+}
+
 func (s *Store) Migrate(ctx context.Context) error {
+	if err := s.checkFTS5(ctx); err != nil {
+		return err
+	}
+
 	return runAllMigrations(ctx, s)
 }
 
+// checkFTS5 makes sure SQLite was built with the full text search module
+// user urls are indexed with. go-sqlite3 leaves it out unless it's built with
+// the sqlite_fts5 tag, which the Makefile sets.
+func (s *Store) checkFTS5(ctx context.Context) error {
+	var enabled bool
+
+	if err := s.db.GetContext(ctx, &enabled, `select sqlite_compileoption_used('ENABLE_FTS5')`); err != nil {
+		return err
+	}
+
+	if !enabled {
+		return errors.New(`sqlite was built without fts5, build sufr with -tags "sqlite_json1 sqlite_fts5"`)
+	}
+
+	return nil
+}
+
 func (s *Store) withTx(ctx context.Context, fn txFunc) (err error) {
+	// already in a transaction started by Transaction, which takes care of
+	// committing or rolling back.
+	if s.tx != nil {
+		return fn(ctx, s.tx)
+	}
+
 	var tx *sqlx.Tx
 
 	tx, err = s.db.BeginTxx(ctx, nil)
@@ -105,6 +175,9 @@ func New(opts ...StoreOption) (*Store, error) {
 
 	dbOptions := url.Values{}
 	dbOptions.Set("_foreign_keys", "true")
+	// background workers write alongside requests, so wait for locks
+	// instead of failing straight away
+	dbOptions.Set("_busy_timeout", "5000")
 
 	dbURL := url.URL{
 		Scheme:   "file",
@@ -155,11 +228,27 @@ func getSQL(name string) (string, error) {
 	return b.String(), nil
 }
 
+// parseTimestamp parses a timestamp the way the sqlite driver does for
+// timestamp columns. It's needed for computed columns, which the driver
+// returns as plain strings.
+func parseTimestamp(s string) (time.Time, error) {
+	s = strings.TrimSuffix(s, "Z")
+
+	for _, format := range sqlite3.SQLiteTimestampFormats {
+		if t, err := time.ParseInLocation(format, s, time.UTC); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unknown timestamp format: %q", s)
+}
+
 func mapError(err error) error {
 	if slErr, ok := err.(sqlite3.Error); ok {
-		if slErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+		switch slErr.ExtendedCode {
+		case sqlite3.ErrConstraintUnique, sqlite3.ErrConstraintPrimaryKey:
 			err = store.ErrAlreadyExists
-		} else if slErr.ExtendedCode == sqlite3.ErrConstraintForeignKey {
+		case sqlite3.ErrConstraintForeignKey:
 			err = store.ErrInvalidDependency
 		}
 	} else {
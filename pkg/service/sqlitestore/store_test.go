@@ -2,11 +2,15 @@ package sqlitestore
 
 import (
 	"context"
+	"errors"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/jmoiron/sqlx"
+	"github.com/kyleterry/sufr/pkg/api"
+	"github.com/kyleterry/sufr/pkg/store"
 	"github.com/stretchr/testify/require"
 )
 
@@ -25,3 +29,92 @@ func WithTempDatabase(t *testing.T, fn func(store *Store)) {
 
 	require.NoError(t, os.RemoveAll(tempdir))
 }
+
+func TestTransaction(t *testing.T) {
+	WithTempDatabase(t, func(db *Store) {
+		ctx := context.Background()
+
+		user := MustCreateBasicTestUser(t, db)
+		url := MustCreateRandomURL(t, db)
+
+		t.Run("rolls back everything when fn fails", func(t *testing.T) {
+			errAbort := errors.New("abort")
+
+			err := db.Transaction(ctx, func(ctx context.Context, tx store.Manager) error {
+				require.NoError(t, tx.Tags().Create(ctx, &api.Tag{Name: "rolled-back"}))
+
+				_, err := tx.Tags().GetByName(ctx, "rolled-back")
+				require.NoError(t, err)
+
+				return errAbort
+			})
+			require.True(t, errors.Is(err, errAbort))
+
+			_, err = db.Tags().GetByName(ctx, "rolled-back")
+			require.True(t, errors.Is(err, store.ErrNotFound))
+		})
+
+		t.Run("commits when fn succeeds and keeps going after duplicates", func(t *testing.T) {
+			err := db.Transaction(ctx, func(ctx context.Context, tx store.Manager) error {
+				uum := tx.UserURLs(user)
+
+				require.NoError(t, uum.Create(ctx, &api.UserURL{Url: url, User: user, Visibility: api.Visibility_VISIBILITY_UNLISTED, Tags: &api.TagList{}}))
+
+				err := uum.Create(ctx, &api.UserURL{Url: url, User: user, Tags: &api.TagList{}})
+				require.True(t, errors.Is(err, store.ErrAlreadyExists))
+
+				return nil
+			})
+			require.NoError(t, err)
+
+			uu, err := db.UserURLs(user).GetByURLID(ctx, url.Id)
+			require.NoError(t, err)
+			require.Equal(t, api.Visibility_VISIBILITY_UNLISTED, uu.Visibility)
+		})
+	})
+}
+
+func TestMigrateUserURLVisibility(t *testing.T) {
+	db, err := New(WithPath(filepath.Join(t.TempDir(), "sufr.db")))
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	ctx := context.Background()
+
+	// migrate up to visibility and save urls with the private flag it
+	// replaces
+	err = db.withTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		require.NoError(t, createMigrationsTable(ctx, tx))
+
+		for _, m := range migrations {
+			if _, ok := m.(AddUserURLVisibility); ok {
+				break
+			}
+
+			require.NoError(t, m.Run(ctx, tx))
+			require.NoError(t, recordMigration(ctx, tx, m))
+		}
+
+		for _, st := range []string{
+			`insert into users (id, email, password_hash) values ('user', 'kyle@example.com', '')`,
+			`insert into urls (id, url) values ('one', 'https://example.com/1'), ('two', 'https://example.com/2')`,
+			`insert into user_urls (id, user_id, url_id, private) values ('private', 'user', 'one', true), ('public', 'user', 'two', false)`,
+		} {
+			_, err := tx.ExecContext(ctx, st)
+			require.NoError(t, err)
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, db.Migrate(ctx))
+
+	for _, id := range []string{"private", "public"} {
+		var visibility string
+
+		require.NoError(t, db.db.GetContext(ctx, &visibility, `select visibility from user_urls where id = ?`, id))
+		require.Equal(t, id, visibility)
+	}
+}
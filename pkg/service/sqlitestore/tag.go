@@ -41,9 +41,9 @@ func (m *tagManager) GetByID(ctx context.Context, id string) (*api.Tag, error) {
 
 	tag := api.Tag{}
 
-	err = m.store.db.GetContext(ctx, &tag, st, id)
+	err = m.store.queryer().GetContext(ctx, &tag, st, id)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to get Tag: %w", mapError(err))
 	}
 
 	return &tag, nil
@@ -57,9 +57,9 @@ func (m *tagManager) GetByName(ctx context.Context, name string) (*api.Tag, erro
 
 	tag := api.Tag{}
 
-	err = m.store.db.GetContext(ctx, &tag, st, name)
+	err = m.store.queryer().GetContext(ctx, &tag, st, name)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to get Tag: %w", mapError(err))
 	}
 
 	return &tag, nil
@@ -40,14 +40,47 @@ func (m *urlManager) GetByURL(ctx context.Context, us string) (*api.URL, error)
 
 	u := api.URL{}
 
-	err = m.store.db.GetContext(ctx, &u, statement, us)
+	err = m.store.queryer().GetContext(ctx, &u, statement, us)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get URL: %w", mapError(err))
+	}
+
+	return &u, nil
+}
+
+func (m *urlManager) GetByID(ctx context.Context, id string) (*api.URL, error) {
+	statement, err := m.getStatement("GetByID")
 	if err != nil {
 		return nil, err
 	}
 
+	u := api.URL{}
+
+	err = m.store.queryer().GetContext(ctx, &u, statement, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get URL: %w", mapError(err))
+	}
+
 	return &u, nil
 }
 
+// Update saves the title and content type of u.
+func (m *urlManager) Update(ctx context.Context, u *api.URL) error {
+	return m.store.withTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		statement, err := m.getStatement("Update")
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.NamedExecContext(ctx, statement, u)
+		if err != nil {
+			return fmt.Errorf("failed to update URL: %w", mapError(err))
+		}
+
+		return nil
+	})
+}
+
 func newURLManager(store *Store) *urlManager {
 	return &urlManager{
 		statementLoader: statementLoader{
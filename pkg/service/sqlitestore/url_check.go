@@ -0,0 +1,104 @@
+package sqlitestore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/kyleterry/sufr/pkg/api"
+	"github.com/kyleterry/sufr/pkg/linkcheck"
+	"github.com/rs/xid"
+)
+
+// urlCheckHistory is how many checks are kept for each url.
+const urlCheckHistory = 30
+
+type urlCheckManager struct {
+	statementLoader
+	store *Store
+}
+
+func (m *urlCheckManager) Due(ctx context.Context, before time.Time, limit int) ([]*linkcheck.Link, error) {
+	st, err := m.getStatement("Due")
+	if err != nil {
+		return nil, err
+	}
+
+	links := []*linkcheck.Link{}
+
+	if err := m.store.queryer().SelectContext(ctx, &links, st, before, limit); err != nil {
+		return nil, fmt.Errorf("failed to get due links: %w", mapError(err))
+	}
+
+	return links, nil
+}
+
+func (m *urlCheckManager) Record(ctx context.Context, result *linkcheck.Result, deadAfter int) error {
+	return m.store.withTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		create, err := m.getStatement("create")
+		if err != nil {
+			return err
+		}
+
+		update, err := m.getStatement("updateURL")
+		if err != nil {
+			return err
+		}
+
+		prune, err := m.getStatement("prune")
+		if err != nil {
+			return err
+		}
+
+		result.ID = xid.New().String()
+
+		if _, err := tx.NamedExecContext(ctx, create, result); err != nil {
+			return fmt.Errorf("failed to record url check: %w", mapError(err))
+		}
+
+		args := map[string]interface{}{
+			"url_id":      result.URLID,
+			"ok":          result.OK,
+			"status_code": result.StatusCode,
+			"checked_at":  result.CheckedAt,
+			"dead_after":  deadAfter,
+			"keep":        urlCheckHistory,
+		}
+
+		if _, err := tx.NamedExecContext(ctx, update, args); err != nil {
+			return fmt.Errorf("failed to update url health: %w", mapError(err))
+		}
+
+		if _, err := tx.NamedExecContext(ctx, prune, args); err != nil {
+			return fmt.Errorf("failed to prune url checks: %w", mapError(err))
+		}
+
+		return nil
+	})
+}
+
+func (m *urlCheckManager) GetByURLID(ctx context.Context, urlID string) (*api.URLCheckList, error) {
+	st, err := m.getStatement("GetByURLID")
+	if err != nil {
+		return nil, err
+	}
+
+	checks := []*api.URLCheck{}
+
+	if err := m.store.queryer().SelectContext(ctx, &checks, st, urlID, urlCheckHistory); err != nil {
+		return nil, fmt.Errorf("failed to get url checks: %w", mapError(err))
+	}
+
+	return &api.URLCheckList{Items: checks}, nil
+}
+
+func newURLCheckManager(store *Store) *urlCheckManager {
+	return &urlCheckManager{
+		statementLoader: statementLoader{
+			dialect:     store.db.DriverName(),
+			managerName: "URLCheckManager",
+		},
+		store: store,
+	}
+}
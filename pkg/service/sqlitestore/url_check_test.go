@@ -0,0 +1,135 @@
+package sqlitestore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyleterry/sufr/pkg/api"
+	"github.com/kyleterry/sufr/pkg/linkcheck"
+	"github.com/kyleterry/sufr/pkg/store"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURLChecks(t *testing.T) {
+	WithTempDatabase(t, func(db *Store) {
+		ctx := context.Background()
+		ucm := db.URLChecks()
+
+		user := MustCreateBasicTestUser(t, db)
+		uum := db.UserURLs(user)
+
+		url := MustCreateRandomURL(t, db)
+		url2 := MustCreateRandomURL(t, db)
+		// nobody saved it so it isn't worth checking
+		MustCreateRandomURL(t, db)
+
+		for _, u := range []*api.URL{url, url2} {
+			require.NoError(t, uum.Create(ctx, &api.UserURL{Url: u, User: user, Tags: &api.TagList{}}))
+		}
+
+		now := time.Now().UTC()
+
+		record := func(u *api.URL, ok bool, at time.Time) {
+			res := &linkcheck.Result{
+				URLID:          u.Id,
+				OK:             ok,
+				StatusCode:     200,
+				ResponseTimeMS: 42,
+				CheckedAt:      at,
+			}
+
+			if !ok {
+				res.StatusCode = 404
+			}
+
+			require.NoError(t, ucm.Record(ctx, res, 2))
+		}
+
+		t.Run("saved urls that were never checked are due", func(t *testing.T) {
+			links, err := ucm.Due(ctx, now, 10)
+			require.NoError(t, err)
+			require.ElementsMatch(t, []string{url.Id, url2.Id}, linkIDs(links))
+		})
+
+		t.Run("checked urls are due again after the interval", func(t *testing.T) {
+			record(url, true, now.Add(-time.Hour))
+
+			links, err := ucm.Due(ctx, now.Add(-2*time.Hour), 10)
+			require.NoError(t, err)
+			require.Equal(t, []string{url2.Id}, linkIDs(links))
+
+			links, err = ucm.Due(ctx, now, 10)
+			require.NoError(t, err)
+			require.Equal(t, []string{url2.Id, url.Id}, linkIDs(links))
+		})
+
+		t.Run("urls are dead after enough failures in a row", func(t *testing.T) {
+			record(url2, false, now.Add(-3*time.Minute))
+
+			u, err := db.URLs().GetByID(ctx, url2.Id)
+			require.NoError(t, err)
+			require.Equal(t, int32(404), u.StatusCode)
+			require.Equal(t, int32(1), u.Failures)
+			require.False(t, u.Dead)
+			require.NotNil(t, u.CheckedAt)
+
+			record(url2, false, now.Add(-2*time.Minute))
+
+			u, err = db.URLs().GetByID(ctx, url2.Id)
+			require.NoError(t, err)
+			require.Equal(t, int32(2), u.Failures)
+			require.True(t, u.Dead)
+
+			broken, err := uum.GetAll(ctx, store.WithBroken(true))
+			require.NoError(t, err)
+			require.Len(t, broken, 1)
+			require.Equal(t, url2.Id, broken[0].Url.Id)
+			require.True(t, broken[0].Url.Dead)
+
+			all, err := uum.GetAll(ctx)
+			require.NoError(t, err)
+			require.Len(t, all, 2)
+
+			record(url2, true, now.Add(-time.Minute))
+
+			u, err = db.URLs().GetByID(ctx, url2.Id)
+			require.NoError(t, err)
+			require.Zero(t, u.Failures)
+			require.False(t, u.Dead)
+
+			broken, err = uum.GetAll(ctx, store.WithBroken(true))
+			require.NoError(t, err)
+			require.Empty(t, broken)
+		})
+
+		t.Run("keeps a history newest first", func(t *testing.T) {
+			checks, err := ucm.GetByURLID(ctx, url2.Id)
+			require.NoError(t, err)
+			require.Len(t, checks.Items, 3)
+			require.True(t, checks.Items[0].Ok)
+			require.False(t, checks.Items[1].Ok)
+			require.Equal(t, int32(404), checks.Items[1].StatusCode)
+			require.Equal(t, int64(42), checks.Items[1].ResponseTimeMs)
+
+			for i := 0; i < urlCheckHistory; i++ {
+				record(url2, true, now.Add(time.Duration(i)*time.Second))
+			}
+
+			checks, err = ucm.GetByURLID(ctx, url2.Id)
+			require.NoError(t, err)
+			require.Len(t, checks.Items, urlCheckHistory)
+			require.True(t, checks.Items[urlCheckHistory-1].Ok)
+		})
+	})
+}
+
+func linkIDs(links []*linkcheck.Link) []string {
+	ids := make([]string, len(links))
+
+	for i, link := range links {
+		ids[i] = link.ID
+	}
+
+	return ids
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/kyleterry/sufr/pkg/api"
 	"github.com/matryer/is"
@@ -63,3 +64,50 @@ func TestURLCreate(t *testing.T) {
 		is.Equal(newURL.UpdatedAt, nil)
 	})
 }
+
+func TestURLUpdate(t *testing.T) {
+	is := is.New(t)
+
+	WithTempDatabase(t, func(store *Store) {
+		ctx := context.Background()
+		um := store.URLs()
+
+		u := MustCreateRandomURL(t, store)
+		is.Equal(u.PublishedAt, nil)
+
+		published := time.Date(2020, 11, 20, 17, 30, 0, 0, time.UTC)
+
+		u.Title = "fetched title"
+		u.ContentType = "text/html"
+		u.Description = "a description"
+		u.ImageUrl = "https://unit-testing.sufr.io/preview.png"
+		u.SiteName = "SUFR"
+		u.Author = "Jane Doe"
+		u.PublishedAt = &api.Timestamp{}
+		u.PublishedAt.SetFromGoTime(published)
+		u.CanonicalUrl = "https://unit-testing.sufr.io/canonical"
+		u.Language = "en"
+		u.FaviconUrl = "https://unit-testing.sufr.io/favicon.ico"
+		u.ArchivedAt = &api.Timestamp{}
+		u.ArchivedAt.SetFromGoTime(published.Add(time.Hour))
+
+		is.NoErr(um.Update(ctx, u))
+
+		newURL, err := um.GetByID(ctx, u.Id)
+		is.NoErr(err)
+
+		is.Equal(u.Url, newURL.Url)
+		is.Equal("fetched title", newURL.Title)
+		is.Equal("text/html", newURL.ContentType)
+		is.Equal(u.Description, newURL.Description)
+		is.Equal(u.ImageUrl, newURL.ImageUrl)
+		is.Equal(u.SiteName, newURL.SiteName)
+		is.Equal(u.Author, newURL.Author)
+		is.Equal(published, newURL.PublishedAt.AsTime())
+		is.Equal(u.CanonicalUrl, newURL.CanonicalUrl)
+		is.Equal(u.Language, newURL.Language)
+		is.Equal(u.FaviconUrl, newURL.FaviconUrl)
+		is.Equal(published.Add(time.Hour), newURL.ArchivedAt.AsTime())
+		is.True(newURL.UpdatedAt != nil)
+	})
+}
@@ -2,11 +2,13 @@ package sqlitestore
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/kyleterry/sufr/pkg/api"
+	"github.com/kyleterry/sufr/pkg/store"
 	"github.com/rs/xid"
 )
 
@@ -26,19 +28,121 @@ func (m *userManager) Create(ctx context.Context, user *api.User) error {
 
 		_, err = tx.NamedExecContext(ctx, st, user)
 		if err != nil {
-			return err
+			return fmt.Errorf("failed to create User: %w", mapError(err))
+		}
+
+		if user.Admin || user.Disabled {
+			if err := m.updateAccess(ctx, tx, user); err != nil {
+				return err
+			}
 		}
 
 		return m.updatePinnedCategories(ctx, tx, user)
 	})
 }
 
+func (m *userManager) Update(ctx context.Context, user *api.User) error {
+	return m.store.withTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		st, err := m.getStatement("Update")
+		if err != nil {
+			return err
+		}
+
+		res, err := tx.NamedExecContext(ctx, st, user)
+		if err != nil {
+			return fmt.Errorf("failed to update User: %w", mapError(err))
+		}
+
+		return expectAffected(res, "failed to update User")
+	})
+}
+
 func (m *userManager) UpdatePinnedCategories(ctx context.Context, user *api.User) error {
 	return m.store.withTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
 		return m.updatePinnedCategories(ctx, tx, user)
 	})
 }
 
+func (m *userManager) UpdateAPIToken(ctx context.Context, user *api.User) error {
+	return m.store.withTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		st, err := m.getStatement("UpdateAPIToken")
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, st, user.ApiToken, user.Id); err != nil {
+			return fmt.Errorf("failed to update api token: %w", mapError(err))
+		}
+
+		return nil
+	})
+}
+
+func (m *userManager) UpdatePassword(ctx context.Context, user *api.User) error {
+	return m.store.withTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		st, err := m.getStatement("UpdatePassword")
+		if err != nil {
+			return err
+		}
+
+		res, err := tx.ExecContext(ctx, st, user.PasswordHash, user.Id)
+		if err != nil {
+			return fmt.Errorf("failed to update password: %w", mapError(err))
+		}
+
+		return expectAffected(res, "failed to update password")
+	})
+}
+
+func (m *userManager) UpdateAccess(ctx context.Context, user *api.User) error {
+	return m.store.withTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		return m.updateAccess(ctx, tx, user)
+	})
+}
+
+func (m *userManager) Delete(ctx context.Context, id string) error {
+	return m.store.withTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		st, err := m.getStatement("Delete")
+		if err != nil {
+			return err
+		}
+
+		res, err := tx.ExecContext(ctx, st, id)
+		if err != nil {
+			return fmt.Errorf("failed to delete User: %w", mapError(err))
+		}
+
+		return expectAffected(res, "failed to delete User")
+	})
+}
+
+func (m *userManager) GetAll(ctx context.Context) ([]*api.User, error) {
+	st, err := m.getStatement("GetAll")
+	if err != nil {
+		return nil, err
+	}
+
+	users := []*api.User{}
+
+	if err := m.store.queryer().SelectContext(ctx, &users, st); err != nil {
+		return nil, fmt.Errorf("failed to get Users: %w", mapError(err))
+	}
+
+	for _, user := range users {
+		user.PinnedCategories, err = m.getPinnedCategories(ctx, user)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pinned categories: %w", err)
+		}
+
+		user.WebauthnCredentials, err = m.getWebAuthnCredentials(ctx, user)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get webauthn credentials: %w", err)
+		}
+	}
+
+	return users, nil
+}
+
 func (m *userManager) GetByID(ctx context.Context, id string) (*api.User, error) {
 	st, err := m.getStatement("GetByID")
 	if err != nil {
@@ -47,8 +151,8 @@ func (m *userManager) GetByID(ctx context.Context, id string) (*api.User, error)
 
 	user := api.User{}
 
-	if err := m.store.db.GetContext(ctx, &user, st, id); err != nil {
-		return nil, err
+	if err := m.store.queryer().GetContext(ctx, &user, st, id); err != nil {
+		return nil, fmt.Errorf("failed to get User: %w", mapError(err))
 	}
 
 	user.PinnedCategories, err = m.getPinnedCategories(ctx, &user)
@@ -56,6 +160,11 @@ func (m *userManager) GetByID(ctx context.Context, id string) (*api.User, error)
 		return nil, fmt.Errorf("failed to fetch pinned categories: %w", err)
 	}
 
+	user.WebauthnCredentials, err = m.getWebAuthnCredentials(ctx, &user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webauthn credentials: %w", err)
+	}
+
 	return &user, nil
 }
 
@@ -69,29 +178,250 @@ func (m *userManager) GetByEmailAndPassword(ctx context.Context, email, password
 		return nil, err
 	}
 
+	if user.Disabled {
+		return nil, store.ErrDisabled
+	}
+
 	return user, nil
 }
 
 func (m *userManager) GetByEmail(ctx context.Context, email string) (*api.User, error) {
-	st, err := m.getStatement("GetByEmail")
+	return m.getUser(ctx, "GetByEmail", email)
+}
+
+func (m *userManager) GetByAPIToken(ctx context.Context, token string) (*api.User, error) {
+	if token == "" {
+		return nil, fmt.Errorf("failed to get User: %w", store.ErrNotFound)
+	}
+
+	user, err := m.getUser(ctx, "GetByAPIToken", token)
 	if err != nil {
 		return nil, err
 	}
 
-	user := api.User{}
+	if user.Disabled {
+		return nil, store.ErrDisabled
+	}
+
+	return user, nil
+}
 
-	if err := m.store.db.GetContext(ctx, &user, st, email); err != nil {
+func (m *userManager) GetByIdentity(ctx context.Context, issuer, subject string) (*api.User, error) {
+	user, err := m.getUser(ctx, "GetByIdentity", issuer, subject)
+	if err != nil {
 		return nil, err
 	}
 
+	if user.Disabled {
+		return nil, store.ErrDisabled
+	}
+
+	return user, nil
+}
+
+func (m *userManager) AddIdentity(ctx context.Context, user *api.User, issuer, subject string) error {
+	return m.store.withTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		st, err := m.getStatement("AddIdentity")
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, st, issuer, subject, user.Id); err != nil {
+			return fmt.Errorf("failed to add identity: %w", mapError(err))
+		}
+
+		return nil
+	})
+}
+
+func (m *userManager) HasIdentity(ctx context.Context, user *api.User) (bool, error) {
+	st, err := m.getStatement("HasIdentity")
+	if err != nil {
+		return false, err
+	}
+
+	var has bool
+
+	if err := m.store.queryer().GetContext(ctx, &has, st, user.Id); err != nil {
+		return false, fmt.Errorf("failed to check identities: %w", mapError(err))
+	}
+
+	return has, nil
+}
+
+// getUser returns the user, with everything about them, that the statement
+// called name finds with args.
+func (m *userManager) getUser(ctx context.Context, name string, args ...interface{}) (*api.User, error) {
+	st, err := m.getStatement(name)
+	if err != nil {
+		return nil, err
+	}
+
+	user := api.User{}
+
+	if err := m.store.queryer().GetContext(ctx, &user, st, args...); err != nil {
+		return nil, fmt.Errorf("failed to get User: %w", mapError(err))
+	}
+
 	user.PinnedCategories, err = m.getPinnedCategories(ctx, &user)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pinned categories: %w", err)
 	}
 
+	user.WebauthnCredentials, err = m.getWebAuthnCredentials(ctx, &user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webauthn credentials: %w", err)
+	}
+
 	return &user, nil
 }
 
+func (m *userManager) GetTOTP(ctx context.Context, user *api.User) (*store.TOTP, error) {
+	st, err := m.getStatement("GetTOTP")
+	if err != nil {
+		return nil, err
+	}
+
+	t := store.TOTP{}
+
+	if err := m.store.queryer().GetContext(ctx, &t, st, user.Id); err != nil {
+		return nil, fmt.Errorf("failed to get two-factor setup: %w", mapError(err))
+	}
+
+	return &t, nil
+}
+
+func (m *userManager) EnableTOTP(ctx context.Context, user *api.User, secret string, recoveryHashes [][]byte) error {
+	return m.store.withTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		if err := m.updateTOTP(ctx, tx, user, secret); err != nil {
+			return err
+		}
+
+		return m.replaceRecoveryCodes(ctx, tx, user, recoveryHashes)
+	})
+}
+
+func (m *userManager) DisableTOTP(ctx context.Context, user *api.User) error {
+	return m.store.withTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		if err := m.updateTOTP(ctx, tx, user, ""); err != nil {
+			return err
+		}
+
+		return m.replaceRecoveryCodes(ctx, tx, user, nil)
+	})
+}
+
+func (m *userManager) UseTOTPStep(ctx context.Context, user *api.User, step int64) error {
+	return m.store.withTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		st, err := m.getStatement("UseTOTPStep")
+		if err != nil {
+			return err
+		}
+
+		res, err := tx.ExecContext(ctx, st, step, user.Id, step)
+		if err != nil {
+			return fmt.Errorf("failed to use two-factor code: %w", mapError(err))
+		}
+
+		return expectAffected(res, "failed to use two-factor code")
+	})
+}
+
+func (m *userManager) UseRecoveryCode(ctx context.Context, user *api.User, hash []byte) error {
+	return m.store.withTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		st, err := m.getStatement("UseRecoveryCode")
+		if err != nil {
+			return err
+		}
+
+		res, err := tx.ExecContext(ctx, st, user.Id, hash)
+		if err != nil {
+			return fmt.Errorf("failed to use recovery code: %w", mapError(err))
+		}
+
+		return expectAffected(res, "failed to use recovery code")
+	})
+}
+
+func (m *userManager) AddWebAuthnCredential(ctx context.Context, user *api.User, cred *api.WebAuthnCredential) error {
+	return m.store.withTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		st, err := m.getStatement("AddWebAuthnCredential")
+		if err != nil {
+			return err
+		}
+
+		cred.UserId = user.Id
+
+		if _, err := tx.NamedExecContext(ctx, st, cred); err != nil {
+			return fmt.Errorf("failed to add webauthn credential: %w", mapError(err))
+		}
+
+		return nil
+	})
+}
+
+func (m *userManager) GetWebAuthnCredential(ctx context.Context, id []byte) (*api.WebAuthnCredential, error) {
+	st, err := m.getStatement("GetWebAuthnCredential")
+	if err != nil {
+		return nil, err
+	}
+
+	cred := api.WebAuthnCredential{}
+
+	if err := m.store.queryer().GetContext(ctx, &cred, st, id); err != nil {
+		return nil, fmt.Errorf("failed to get webauthn credential: %w", mapError(err))
+	}
+
+	return &cred, nil
+}
+
+func (m *userManager) UseWebAuthnCredential(ctx context.Context, cred *api.WebAuthnCredential) error {
+	return m.store.withTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		st, err := m.getStatement("UseWebAuthnCredential")
+		if err != nil {
+			return err
+		}
+
+		res, err := tx.ExecContext(ctx, st, cred.SignCount, cred.Id)
+		if err != nil {
+			return fmt.Errorf("failed to use webauthn credential: %w", mapError(err))
+		}
+
+		return expectAffected(res, "failed to use webauthn credential")
+	})
+}
+
+func (m *userManager) DeleteWebAuthnCredential(ctx context.Context, user *api.User, id []byte) error {
+	return m.store.withTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		st, err := m.getStatement("DeleteWebAuthnCredential")
+		if err != nil {
+			return err
+		}
+
+		res, err := tx.ExecContext(ctx, st, user.Id, id)
+		if err != nil {
+			return fmt.Errorf("failed to delete webauthn credential: %w", mapError(err))
+		}
+
+		return expectAffected(res, "failed to delete webauthn credential")
+	})
+}
+
+func (m *userManager) getWebAuthnCredentials(ctx context.Context, user *api.User) ([]*api.WebAuthnCredential, error) {
+	st, err := m.getStatement("getWebAuthnCredentials")
+	if err != nil {
+		return nil, err
+	}
+
+	creds := []*api.WebAuthnCredential{}
+
+	if err := m.store.queryer().SelectContext(ctx, &creds, st, user.Id); err != nil {
+		return nil, err
+	}
+
+	return creds, nil
+}
+
 func (m *userManager) getPinnedCategories(ctx context.Context, user *api.User) ([]*api.Category, error) {
 	st, err := m.getStatement("getPinnedCategories")
 	if err != nil {
@@ -100,7 +430,7 @@ func (m *userManager) getPinnedCategories(ctx context.Context, user *api.User) (
 
 	cats := []*api.Category{}
 
-	if err := m.store.db.SelectContext(ctx, &cats, st, user.Id); err != nil {
+	if err := m.store.queryer().SelectContext(ctx, &cats, st, user.Id); err != nil {
 		return nil, err
 	}
 
@@ -123,6 +453,73 @@ func (m *userManager) updatePinnedCategories(ctx context.Context, tx *sqlx.Tx, u
 	return err
 }
 
+func (m *userManager) updateAccess(ctx context.Context, tx *sqlx.Tx, user *api.User) error {
+	st, err := m.getStatement("UpdateAccess")
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, st, user.Admin, user.Disabled, user.Id)
+	if err != nil {
+		return fmt.Errorf("failed to update access: %w", mapError(err))
+	}
+
+	return expectAffected(res, "failed to update access")
+}
+
+func (m *userManager) updateTOTP(ctx context.Context, tx *sqlx.Tx, user *api.User, secret string) error {
+	st, err := m.getStatement("updateTOTP")
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, st, secret, user.Id)
+	if err != nil {
+		return fmt.Errorf("failed to update two-factor setup: %w", mapError(err))
+	}
+
+	return expectAffected(res, "failed to update two-factor setup")
+}
+
+func (m *userManager) replaceRecoveryCodes(ctx context.Context, tx *sqlx.Tx, user *api.User, hashes [][]byte) error {
+	clear, err := m.getStatement("clearRecoveryCodes")
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, clear, user.Id); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", mapError(err))
+	}
+
+	add, err := m.getStatement("addRecoveryCode")
+	if err != nil {
+		return err
+	}
+
+	for _, hash := range hashes {
+		if _, err := tx.ExecContext(ctx, add, user.Id, hash); err != nil {
+			return fmt.Errorf("failed to add recovery code: %w", mapError(err))
+		}
+	}
+
+	return nil
+}
+
+// expectAffected returns ErrNotFound, prefixed with msg, if res didn't change
+// any rows.
+func expectAffected(res sql.Result, msg string) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return fmt.Errorf("%s: %w", msg, store.ErrNotFound)
+	}
+
+	return nil
+}
+
 func newUserManager(store *Store) *userManager {
 	return &userManager{
 		statementLoader: statementLoader{
@@ -2,9 +2,11 @@ package sqlitestore
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/kyleterry/sufr/pkg/api"
+	"github.com/kyleterry/sufr/pkg/store"
 	"github.com/stretchr/testify/require"
 )
 
@@ -53,7 +55,8 @@ func TestCreateUser(t *testing.T) {
 			require.NotEmpty(t, newUser.Id)
 			require.Equal(t, BasicTestUserEmail, newUser.Email)
 			require.NoError(t, api.CompareHashAndPassword(newUser, BasicTestUserPassword))
-			require.False(t, newUser.EmbedContent)
+			require.False(t, newUser.EmbedPhotos)
+			require.False(t, newUser.EmbedVideos)
 			require.NotZero(t, newUser.CreatedAt.AsTime())
 			require.Nil(t, newUser.UpdatedAt)
 		})
@@ -63,7 +66,8 @@ func TestCreateUser(t *testing.T) {
 			require.NoError(t, err)
 			require.NotEmpty(t, newUser.Id)
 			require.Equal(t, BasicTestUserEmail, newUser.Email)
-			require.False(t, newUser.EmbedContent)
+			require.False(t, newUser.EmbedPhotos)
+			require.False(t, newUser.EmbedVideos)
 			require.NotZero(t, newUser.CreatedAt.AsTime())
 			require.Nil(t, newUser.UpdatedAt)
 		})
@@ -75,13 +79,50 @@ func TestCreateUser(t *testing.T) {
 			require.Equal(t, BasicTestUserEmail, newUser.Email)
 			require.Empty(t, newUser.PasswordHash)
 			require.Empty(t, newUser.ApiToken)
-			require.False(t, newUser.EmbedContent)
+			require.False(t, newUser.EmbedPhotos)
+			require.False(t, newUser.EmbedVideos)
 			require.NotZero(t, newUser.CreatedAt.AsTime())
 			require.Nil(t, newUser.UpdatedAt)
 		})
 	})
 }
 
+func TestUserUpdateAPIToken(t *testing.T) {
+	WithTempDatabase(t, func(db *Store) {
+		ctx := context.Background()
+		um := db.Users()
+
+		user := MustCreateBasicTestUser(t, db)
+		user.ApiToken = "secret-token"
+
+		require.NoError(t, um.UpdateAPIToken(ctx, user))
+
+		newUser, err := um.GetByEmail(ctx, user.Email)
+		require.NoError(t, err)
+		require.Equal(t, user.ApiToken, newUser.ApiToken)
+		require.NotNil(t, newUser.UpdatedAt)
+
+		t.Run("finds the user by token", func(t *testing.T) {
+			byToken, err := um.GetByAPIToken(ctx, "secret-token")
+			require.NoError(t, err)
+			require.Equal(t, user.Id, byToken.Id)
+
+			for _, token := range []string{"", "secret", "secret-token2"} {
+				_, err := um.GetByAPIToken(ctx, token)
+				require.True(t, errors.Is(err, store.ErrNotFound), token)
+			}
+		})
+
+		t.Run("turns disabled users away", func(t *testing.T) {
+			user.Disabled = true
+			require.NoError(t, um.UpdateAccess(ctx, user))
+
+			_, err := um.GetByAPIToken(ctx, "secret-token")
+			require.True(t, errors.Is(err, store.ErrDisabled))
+		})
+	})
+}
+
 func TestUserPinnedCategories(t *testing.T) {
 	WithTempDatabase(t, func(store *Store) {
 		ctx := context.Background()
@@ -141,7 +182,303 @@ func TestUserPinnedCategories(t *testing.T) {
 			require.NoError(t, err)
 
 			require.Len(t, user.PinnedCategories, 3)
-			// require.Equal(t, newCat.Label, user.PinnedCategories[0].Label)
+			require.Equal(t, newCat.Label, user.PinnedCategories[0].Label)
+		}
+	})
+}
+
+func TestUserAccounts(t *testing.T) {
+	WithTempDatabase(t, func(db *Store) {
+		ctx := context.Background()
+		um := db.Users()
+
+		ph, err := api.GeneratePasswordHash(BasicTestUserPassword)
+		require.NoError(t, err)
+
+		admin := &api.User{
+			Email:        "admin@unit-testing.sufr.io",
+			PasswordHash: ph,
+			Admin:        true,
+		}
+
+		require.NoError(t, um.Create(ctx, admin))
+
+		user := MustCreateBasicTestUser(t, db)
+
+		require.True(t, errors.Is(um.Create(ctx, &api.User{
+			Email:        admin.Email,
+			PasswordHash: ph,
+		}), store.ErrAlreadyExists))
+
+		t.Run("migration makes the initial user an admin", func(t *testing.T) {
+			initial, err := um.GetByEmail(ctx, "admin@localhost")
+			require.NoError(t, err)
+			require.True(t, initial.Admin)
+		})
+
+		t.Run("creates admins", func(t *testing.T) {
+			newUser, err := um.GetByID(ctx, admin.Id)
+			require.NoError(t, err)
+			require.True(t, newUser.Admin)
+			require.False(t, newUser.Disabled)
+			require.False(t, user.Admin)
+		})
+
+		t.Run("gets everyone oldest first", func(t *testing.T) {
+			users, err := um.GetAll(ctx)
+			require.NoError(t, err)
+			require.Len(t, users, 3)
+			require.Equal(t, "admin@localhost", users[0].Email)
+			require.Equal(t, admin.Id, users[1].Id)
+			require.Equal(t, user.Id, users[2].Id)
+			require.Empty(t, users[2].PasswordHash)
+		})
+
+		t.Run("updates settings", func(t *testing.T) {
+			user.Email = "new-" + BasicTestUserEmail
+			user.EmbedPhotos = true
+			user.PerPage = 40
+
+			require.NoError(t, um.Update(ctx, user))
+
+			newUser, err := um.GetByID(ctx, user.Id)
+			require.NoError(t, err)
+			require.Equal(t, user.Email, newUser.Email)
+			require.True(t, newUser.EmbedPhotos)
+			require.False(t, newUser.EmbedVideos)
+			require.Equal(t, int32(40), newUser.PerPage)
+			require.NotNil(t, newUser.UpdatedAt)
+
+			user.Email = admin.Email
+			require.True(t, errors.Is(um.Update(ctx, user), store.ErrAlreadyExists))
+			user.Email = newUser.Email
+		})
+
+		t.Run("updates passwords", func(t *testing.T) {
+			user.PasswordHash, err = api.GeneratePasswordHash("new password")
+			require.NoError(t, err)
+			require.NoError(t, um.UpdatePassword(ctx, user))
+
+			_, err := um.GetByEmailAndPassword(ctx, user.Email, BasicTestUserPassword)
+			require.Error(t, err)
+
+			_, err = um.GetByEmailAndPassword(ctx, user.Email, "new password")
+			require.NoError(t, err)
+		})
+
+		t.Run("disabled users can't log in", func(t *testing.T) {
+			user.Disabled = true
+			require.NoError(t, um.UpdateAccess(ctx, user))
+
+			_, err := um.GetByEmailAndPassword(ctx, user.Email, "new password")
+			require.True(t, errors.Is(err, store.ErrDisabled))
+
+			newUser, err := um.GetByID(ctx, user.Id)
+			require.NoError(t, err)
+			require.True(t, newUser.Disabled)
+		})
+
+		t.Run("deletes users and what they saved", func(t *testing.T) {
+			uum := db.UserURLs(user)
+			uu := &api.UserURL{
+				Url:  MustCreateRandomURL(t, db),
+				User: user,
+				Tags: &api.TagList{},
+			}
+
+			require.NoError(t, uum.Create(ctx, uu))
+			require.NoError(t, um.Delete(ctx, user.Id))
+
+			_, err := um.GetByID(ctx, user.Id)
+			require.True(t, errors.Is(err, store.ErrNotFound))
+
+			_, err = uum.GetByID(ctx, uu.Id)
+			require.True(t, errors.Is(err, store.ErrNotFound))
+
+			require.True(t, errors.Is(um.Delete(ctx, user.Id), store.ErrNotFound))
+		})
+	})
+}
+
+func TestUserTOTP(t *testing.T) {
+	WithTempDatabase(t, func(db *Store) {
+		ctx := context.Background()
+		um := db.Users()
+		user := MustCreateBasicTestUser(t, db)
+
+		_, err := um.GetTOTP(ctx, user)
+		require.True(t, errors.Is(err, store.ErrNotFound))
+
+		hashes := [][]byte{[]byte("hash-one"), []byte("hash-two")}
+		require.NoError(t, um.EnableTOTP(ctx, user, "ABCDEFGH", hashes))
+
+		t.Run("gets the setup", func(t *testing.T) {
+			totp, err := um.GetTOTP(ctx, user)
+			require.NoError(t, err)
+			require.Equal(t, "ABCDEFGH", totp.Secret)
+			require.Equal(t, int64(0), totp.LastStep)
+			require.Equal(t, 2, totp.RecoveryCodes)
+
+			u, err := um.GetByID(ctx, user.Id)
+			require.NoError(t, err)
+			require.True(t, u.TotpEnabled)
+		})
+
+		t.Run("steps can only be used once", func(t *testing.T) {
+			require.NoError(t, um.UseTOTPStep(ctx, user, 10))
+			require.True(t, errors.Is(um.UseTOTPStep(ctx, user, 10), store.ErrNotFound))
+			require.True(t, errors.Is(um.UseTOTPStep(ctx, user, 9), store.ErrNotFound))
+			require.NoError(t, um.UseTOTPStep(ctx, user, 11))
+
+			totp, err := um.GetTOTP(ctx, user)
+			require.NoError(t, err)
+			require.Equal(t, int64(11), totp.LastStep)
+		})
+
+		t.Run("recovery codes can only be used once", func(t *testing.T) {
+			require.NoError(t, um.UseRecoveryCode(ctx, user, hashes[0]))
+			require.True(t, errors.Is(um.UseRecoveryCode(ctx, user, hashes[0]), store.ErrNotFound))
+			require.True(t, errors.Is(um.UseRecoveryCode(ctx, user, []byte("nope")), store.ErrNotFound))
+
+			totp, err := um.GetTOTP(ctx, user)
+			require.NoError(t, err)
+			require.Equal(t, 1, totp.RecoveryCodes)
+		})
+
+		t.Run("enabling again replaces the codes", func(t *testing.T) {
+			require.NoError(t, um.EnableTOTP(ctx, user, "IJKLMNOP", [][]byte{[]byte("hash-three")}))
+
+			totp, err := um.GetTOTP(ctx, user)
+			require.NoError(t, err)
+			require.Equal(t, "IJKLMNOP", totp.Secret)
+			require.Equal(t, int64(0), totp.LastStep)
+			require.Equal(t, 1, totp.RecoveryCodes)
+			require.True(t, errors.Is(um.UseRecoveryCode(ctx, user, hashes[1]), store.ErrNotFound))
+		})
+
+		t.Run("disables", func(t *testing.T) {
+			require.NoError(t, um.DisableTOTP(ctx, user))
+
+			_, err := um.GetTOTP(ctx, user)
+			require.True(t, errors.Is(err, store.ErrNotFound))
+			require.True(t, errors.Is(um.UseTOTPStep(ctx, user, 100), store.ErrNotFound))
+			require.True(t, errors.Is(um.UseRecoveryCode(ctx, user, []byte("hash-three")), store.ErrNotFound))
+
+			u, err := um.GetByID(ctx, user.Id)
+			require.NoError(t, err)
+			require.False(t, u.TotpEnabled)
+		})
+	})
+}
+
+func TestUserWebAuthnCredentials(t *testing.T) {
+	WithTempDatabase(t, func(db *Store) {
+		ctx := context.Background()
+		um := db.Users()
+		user := MustCreateBasicTestUser(t, db)
+
+		cred := &api.WebAuthnCredential{
+			Id:        []byte{1, 2, 3},
+			Name:      "laptop",
+			PublicKey: []byte("cose key"),
+			SignCount: 4,
 		}
+
+		require.NoError(t, um.AddWebAuthnCredential(ctx, user, cred))
+		require.True(t, errors.Is(um.AddWebAuthnCredential(ctx, user, cred), store.ErrAlreadyExists))
+
+		t.Run("gets the credential", func(t *testing.T) {
+			got, err := um.GetWebAuthnCredential(ctx, cred.Id)
+			require.NoError(t, err)
+			require.Equal(t, user.Id, got.UserId)
+			require.Equal(t, "laptop", got.Name)
+			require.Equal(t, []byte("cose key"), got.PublicKey)
+			require.Equal(t, uint32(4), got.SignCount)
+			require.NotNil(t, got.CreatedAt)
+			require.Nil(t, got.LastUsedAt)
+
+			_, err = um.GetWebAuthnCredential(ctx, []byte{9})
+			require.True(t, errors.Is(err, store.ErrNotFound))
+		})
+
+		t.Run("users have their credentials", func(t *testing.T) {
+			u, err := um.GetByID(ctx, user.Id)
+			require.NoError(t, err)
+			require.Len(t, u.WebauthnCredentials, 1)
+			require.Equal(t, cred.Id, u.WebauthnCredentials[0].Id)
+		})
+
+		t.Run("saves the sign count", func(t *testing.T) {
+			cred.SignCount = 5
+			require.NoError(t, um.UseWebAuthnCredential(ctx, cred))
+
+			got, err := um.GetWebAuthnCredential(ctx, cred.Id)
+			require.NoError(t, err)
+			require.Equal(t, uint32(5), got.SignCount)
+			require.NotNil(t, got.LastUsedAt)
+		})
+
+		t.Run("only the owner deletes it", func(t *testing.T) {
+			other := &api.User{Id: "someone-else"}
+			require.True(t, errors.Is(um.DeleteWebAuthnCredential(ctx, other, cred.Id), store.ErrNotFound))
+			require.NoError(t, um.DeleteWebAuthnCredential(ctx, user, cred.Id))
+
+			_, err := um.GetWebAuthnCredential(ctx, cred.Id)
+			require.True(t, errors.Is(err, store.ErrNotFound))
+		})
+	})
+}
+
+func TestUserIdentities(t *testing.T) {
+	WithTempDatabase(t, func(db *Store) {
+		ctx := context.Background()
+		um := db.Users()
+		user := MustCreateBasicTestUser(t, db)
+
+		other := &api.User{Email: "other@unit-testing.sufr.io", PasswordHash: user.PasswordHash}
+		require.NoError(t, um.Create(ctx, other))
+
+		const issuer = "https://accounts.example.com"
+
+		has, err := um.HasIdentity(ctx, user)
+		require.NoError(t, err)
+		require.False(t, has)
+
+		require.NoError(t, um.AddIdentity(ctx, user, issuer, "1234"))
+
+		t.Run("finds the user by identity", func(t *testing.T) {
+			got, err := um.GetByIdentity(ctx, issuer, "1234")
+			require.NoError(t, err)
+			require.Equal(t, user.Id, got.Id)
+			require.Equal(t, user.Email, got.Email)
+
+			for _, id := range [][2]string{{issuer, "12345"}, {"https://other.example.com", "1234"}} {
+				_, err := um.GetByIdentity(ctx, id[0], id[1])
+				require.True(t, errors.Is(err, store.ErrNotFound))
+			}
+
+			has, err := um.HasIdentity(ctx, user)
+			require.NoError(t, err)
+			require.True(t, has)
+		})
+
+		t.Run("identities log in as one user", func(t *testing.T) {
+			require.True(t, errors.Is(um.AddIdentity(ctx, other, issuer, "1234"), store.ErrAlreadyExists))
+			require.True(t, errors.Is(um.AddIdentity(ctx, user, issuer, "5678"), store.ErrAlreadyExists))
+			require.NoError(t, um.AddIdentity(ctx, user, "https://other.example.com", "1234"))
+		})
+
+		t.Run("turns disabled users away", func(t *testing.T) {
+			user.Disabled = true
+			require.NoError(t, um.UpdateAccess(ctx, user))
+
+			_, err := um.GetByIdentity(ctx, issuer, "1234")
+			require.True(t, errors.Is(err, store.ErrDisabled))
+		})
+
+		t.Run("go with the user", func(t *testing.T) {
+			require.NoError(t, um.Delete(ctx, user.Id))
+			require.NoError(t, um.AddIdentity(ctx, other, issuer, "1234"))
+		})
 	})
 }
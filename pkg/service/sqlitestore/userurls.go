@@ -2,7 +2,11 @@ package sqlitestore
 
 import (
 	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/kyleterry/sufr/pkg/api"
@@ -90,106 +94,126 @@ func (m *userURLManager) updateTagsFunc(id string, tags *api.TagList) txFunc {
 }
 
 func (m *userURLManager) GetAll(ctx context.Context, filters ...store.FilterOption) ([]*api.UserURL, error) {
-	st, err := m.getStatement("GetAll")
+	opts := store.FilterOptions{}
+
+	for _, filter := range filters {
+		filter.Apply(&opts)
+	}
+
+	if opts.Limit <= 0 {
+		opts.Limit = pageSize
+	}
+
+	name := "GetAll"
+	if strings.TrimSpace(opts.Search) != "" {
+		name = "Search"
+	}
+
+	st, err := m.getStatement(name)
 	if err != nil {
 		return nil, err
 	}
 
-	opts := store.FilterOptions{}
+	args, err := m.filterArgs(opts)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, filter := range filters {
-		filter.Apply(&opts)
+	q, params, err := sqlx.Named(st, args)
+	if err != nil {
+		return nil, err
 	}
 
 	uus := []*api.UserURL{}
-	if err := m.store.db.SelectContext(ctx, &uus, st, m.user.Id); err != nil {
+	if err := m.store.queryer().SelectContext(ctx, &uus, m.store.queryer().Rebind(q), params...); err != nil {
 		return nil, fmt.Errorf("failed to get UserURLs: %w", mapError(err))
 	}
 
-	// sub := selectQuery(
-	// 	columns(
-	// 		as(window("row_number()", orderBy("user_url.id")), "row"),
-	// 		column("user_url.id"),
-	// 		column("url.id"),
-	// 		column("url.url"),
-	// 		column("url.title"),
-	// 		as(column("user_url.user_id"), "user.id"),
-	// 		column("user_url.favorite"),
-	// 		column("user_url.created_at"),
-	// 		column("user_url.updated_at"),
-	// 		as(
-	// 			function(
-	// 				"coalesce",
-	// 				function("nullif", column("user_url.title"), sqlstring("''")),
-	// 				column("user_url.title"),
-	// 			),
-	// 			"derived_title",
-	// 		),
-	// 	),
-	// 	from(tableAs(table("user_urls"), "user_url")),
-	// 	join("urls url", on("url.id", "=", "user_url.url_id")),
-	// 	where("user_url.user_id", "=", "?"),
-	// )
-
-	// search := selectQuery(
-	// 	columns(
-	// 		column("uu.*"),
-	// 		as(function("json_object", sqlstring("items"),
-	// 			function("json_group_array",
-	// 				function("json_object",
-	// 					sqlstring("id"),
-	// 					column("t.id"),
-	// 					sqlstring("name"),
-	// 					column("t.name"),
-	// 				),
-	// 			),
-	// 		), "tags"),
-	// 	),
-	// 	from(tableAs(sub, "uu")),
-	// 	join("user_url_tags ut", on("ut.user_url_id", "=", "uu.id")),
-	// 	join("tags tag", on("tag.id", "=", "tag.tag_id")),
-	// 	where("row", ">", "?"),
-	// 	groupBy("uu.id"),
-	// )
-
-	// st := search.build()
+	// the statements return the sort key and id of each result as a json
+	// array, which is made opaque so clients don't come to rely on it
+	for _, uu := range uus {
+		uu.Cursor = base64.RawURLEncoding.EncodeToString([]byte(uu.Cursor))
+	}
 
 	return uus, nil
 }
 
-// func (m *userURLManager) GetAllAfter(ctx context.Context, after int64) ([]*api.UserURL, error) {
-// 	st, err := m.getStatement("GetAllAfter")
-// 	if err != nil {
-// 		return nil, err
-// 	}
+// parseCursor returns the sort key and id in a cursor made by GetAll.
+func parseCursor(cursor string) (string, string, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", "", store.ErrInvalidCursor
+	}
 
-// 	uus := []*api.UserURL{}
-// 	if err := m.store.db.SelectContext(ctx, &uus, st, m.user.Id, after, after+pageSize); err != nil {
-// 		return nil, err
-// 	}
+	var parts []string
 
-// 	return uus, nil
-// }
+	if err := json.Unmarshal(b, &parts); err != nil || len(parts) != 2 {
+		return "", "", store.ErrInvalidCursor
+	}
 
-// func (m *userURLManager) GetAllByTags(ctx context.Context, tags *api.TagList) ([]*api.UserURL, error) {
-// 	st, err := m.getStatement("GetAllByTags")
-// 	if err != nil {
-// 		return nil, err
-// 	}
+	return parts[0], parts[1], nil
+}
 
-// 	uus := []*api.UserURL{}
+// filterArgs builds the named parameters shared by the GetAll and Search
+// statements.
+func (m *userURLManager) filterArgs(opts store.FilterOptions) (map[string]interface{}, error) {
+	seen := make(map[string]bool, len(opts.Tags))
+	tags := []string{}
 
-// 	q, args, err := sqlx.In(st, m.user.Id, tags)
-// 	if err != nil {
-// 		return nil, err
-// 	}
+	for _, tag := range opts.Tags {
+		if tag == "" || seen[tag] {
+			continue
+		}
 
-// 	if err := m.store.db.SelectContext(ctx, &uus, q, args...); err != nil {
-// 		return nil, err
-// 	}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
 
-// 	return uus, nil
-// }
+	tagsJSON, err := json.Marshal(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	var afterKey, afterID interface{}
+
+	if opts.After != "" {
+		key, id, err := parseCursor(opts.After)
+		if err != nil {
+			return nil, err
+		}
+
+		afterKey, afterID = key, id
+	}
+
+	return map[string]interface{}{
+		"user_id":     m.user.Id,
+		"tags":        string(tagsJSON),
+		"tag_count":   len(tags),
+		"any_tags":    opts.AnyTags,
+		"broken":      opts.Broken,
+		"public":      opts.Public,
+		"favorite":    opts.Favorite,
+		"search":      ftsQuery(opts.Search),
+		"match_start": api.SnippetMatchStart,
+		"match_end":   api.SnippetMatchEnd,
+		"after_key":   afterKey,
+		"after_id":    afterID,
+		"limit":       opts.Limit,
+	}, nil
+}
+
+// ftsQuery turns user input into an fts5 match expression. Every word is
+// quoted so fts5 syntax in the input can't cause query errors, and treated
+// as a prefix so partial words still match while typing.
+func ftsQuery(term string) string {
+	words := strings.Fields(term)
+
+	for i, word := range words {
+		words[i] = `"` + strings.ReplaceAll(word, `"`, `""`) + `"*`
+	}
+
+	return strings.Join(words, " ")
+}
 
 func (m *userURLManager) GetByURLID(ctx context.Context, urlID string) (*api.UserURL, error) {
 	st, err := m.getStatement("GetByURLID")
@@ -199,13 +223,141 @@ func (m *userURLManager) GetByURLID(ctx context.Context, urlID string) (*api.Use
 
 	uu := api.UserURL{}
 
-	if err := m.store.db.GetContext(ctx, &uu, st, m.user.Id, urlID); err != nil {
+	if err := m.store.queryer().GetContext(ctx, &uu, st, m.user.Id, urlID); err != nil {
+		return nil, fmt.Errorf("failed to get UserURL: %w", mapError(err))
+	}
+
+	return &uu, nil
+}
+
+func (m *userURLManager) GetByID(ctx context.Context, id string) (*api.UserURL, error) {
+	st, err := m.getStatement("GetByID")
+	if err != nil {
 		return nil, err
 	}
 
+	uu := api.UserURL{}
+
+	if err := m.store.queryer().GetContext(ctx, &uu, st, m.user.Id, id); err != nil {
+		return nil, fmt.Errorf("failed to get UserURL: %w", mapError(err))
+	}
+
 	return &uu, nil
 }
 
+func (m *userURLManager) Delete(ctx context.Context, id string) error {
+	return m.store.withTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		st, err := m.getStatement("Delete")
+		if err != nil {
+			return err
+		}
+
+		res, err := tx.ExecContext(ctx, st, m.user.Id, id)
+		if err != nil {
+			return fmt.Errorf("failed to delete UserURL: %w", mapError(err))
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if n == 0 {
+			return fmt.Errorf("failed to delete UserURL: %w", store.ErrNotFound)
+		}
+
+		return nil
+	})
+}
+
+// GetTags returns every tag used on at least one of the user's urls.
+func (m *userURLManager) GetTags(ctx context.Context) (*api.TagList, error) {
+	st, err := m.getStatement("GetTags")
+	if err != nil {
+		return nil, err
+	}
+
+	tags := []*api.Tag{}
+
+	if err := m.store.queryer().SelectContext(ctx, &tags, st, m.user.Id); err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", mapError(err))
+	}
+
+	return &api.TagList{Items: tags}, nil
+}
+
+// GetLastUpdated returns the time any of the user's urls was last created
+// or updated. It returns nil if the user has no urls.
+func (m *userURLManager) GetLastUpdated(ctx context.Context) (*api.Timestamp, error) {
+	st, err := m.getStatement("GetLastUpdated")
+	if err != nil {
+		return nil, err
+	}
+
+	var last sql.NullString
+
+	if err := m.store.queryer().GetContext(ctx, &last, st, m.user.Id); err != nil {
+		return nil, fmt.Errorf("failed to get last update time: %w", mapError(err))
+	}
+
+	if !last.Valid {
+		return nil, nil
+	}
+
+	t, err := parseTimestamp(last.String)
+	if err != nil {
+		return nil, err
+	}
+
+	ts := &api.Timestamp{}
+	ts.SetFromGoTime(t)
+
+	return ts, nil
+}
+
+// ReplaceTag moves every one of the user's urls tagged with old over to new.
+// Other users' urls are left alone.
+func (m *userURLManager) ReplaceTag(ctx context.Context, old, new *api.Tag) error {
+	return m.store.withTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		st, err := m.getStatement("replaceTagAdd")
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, st, new.Id, old.Id, m.user.Id); err != nil {
+			return fmt.Errorf("failed to replace tag: %w", mapError(err))
+		}
+
+		st, err = m.getStatement("replaceTagRemove")
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, st, old.Id, m.user.Id); err != nil {
+			return fmt.Errorf("failed to replace tag: %w", mapError(err))
+		}
+
+		return nil
+	})
+}
+
+// RemoveTag takes tag off every one of the user's urls. Other users' urls
+// are left alone.
+func (m *userURLManager) RemoveTag(ctx context.Context, tag *api.Tag) error {
+	return m.store.withTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		st, err := m.getStatement("replaceTagRemove")
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, st, tag.Id, m.user.Id); err != nil {
+			return fmt.Errorf("failed to remove tag: %w", mapError(err))
+		}
+
+		return nil
+	})
+}
+
 func newUserURLManager(store *Store, user *api.User) *userURLManager {
 	return &userURLManager{
 		statementLoader: statementLoader{
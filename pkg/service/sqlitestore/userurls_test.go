@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/kyleterry/sufr/pkg/api"
 	"github.com/kyleterry/sufr/pkg/store"
@@ -63,15 +64,6 @@ func TestUserURLCreate(t *testing.T) {
 
 			t.Log(err)
 		})
-		// TODO test these
-		// all, err := uum.GetAll(ctx)
-		// require.NoError(t, err)
-
-		// require.Len(t, all, 1)
-
-		// tagged, err := uum.GetAllByTags(ctx, &tags)
-		// require.NoError(t, err)
-		// require.Len(t, tagged, 1)
 	})
 }
 
@@ -125,3 +117,414 @@ func TestUserURLUpdate(t *testing.T) {
 		}
 	})
 }
+
+func TestUserURLGetAll(t *testing.T) {
+	WithTempDatabase(t, func(db *Store) {
+		ctx := context.Background()
+
+		user := MustCreateBasicTestUser(t, db)
+		red := MustCreateRandomTag(t, db)
+		blue := MustCreateRandomTag(t, db)
+
+		uum := db.UserURLs(user)
+
+		create := func(tags ...*api.Tag) *api.UserURL {
+			uu := &api.UserURL{
+				Url:  MustCreateRandomURL(t, db),
+				User: user,
+				Tags: &api.TagList{Items: tags},
+			}
+
+			require.NoError(t, uum.Create(ctx, uu))
+
+			return uu
+		}
+
+		untagged := create()
+		redOnly := create(red)
+		blueOnly := create(blue)
+		both := create(red, blue)
+
+		ids := func(uus []*api.UserURL) []string {
+			ids := []string{}
+			for _, uu := range uus {
+				ids = append(ids, uu.Id)
+			}
+
+			return ids
+		}
+
+		t.Run("returns everything newest first", func(t *testing.T) {
+			all, err := uum.GetAll(ctx)
+			require.NoError(t, err)
+			require.Equal(t, []string{both.Id, blueOnly.Id, redOnly.Id, untagged.Id}, ids(all))
+			require.NotEmpty(t, all[3].Cursor)
+			require.Empty(t, all[3].Tags.Items)
+			require.Len(t, all[0].Tags.Items, 2)
+		})
+
+		t.Run("filters by all tags", func(t *testing.T) {
+			all, err := uum.GetAll(ctx, store.WithTags([]string{red.Name}))
+			require.NoError(t, err)
+			require.Equal(t, []string{both.Id, redOnly.Id}, ids(all))
+
+			all, err = uum.GetAll(ctx, store.WithTags([]string{red.Name, blue.Name}))
+			require.NoError(t, err)
+			require.Equal(t, []string{both.Id}, ids(all))
+		})
+
+		t.Run("filters by any tags", func(t *testing.T) {
+			all, err := uum.GetAll(ctx, store.WithAnyTags([]string{red.Name, blue.Name}))
+			require.NoError(t, err)
+			require.Equal(t, []string{both.Id, blueOnly.Id, redOnly.Id}, ids(all))
+		})
+
+		t.Run("pages with after", func(t *testing.T) {
+			all, err := uum.GetAll(ctx)
+			require.NoError(t, err)
+
+			page, err := uum.GetAll(ctx, store.WithResultsAfter(all[1].Cursor))
+			require.NoError(t, err)
+			require.Equal(t, []string{redOnly.Id, untagged.Id}, ids(page))
+
+			page, err = uum.GetAll(ctx, store.WithResultsAfter(all[3].Cursor))
+			require.NoError(t, err)
+			require.Empty(t, page)
+		})
+
+		t.Run("limits results", func(t *testing.T) {
+			all, err := uum.GetAll(ctx, store.WithLimit(2))
+			require.NoError(t, err)
+			require.Equal(t, []string{both.Id, blueOnly.Id}, ids(all))
+
+			all, err = uum.GetAll(ctx, store.WithLimit(2), store.WithResultsAfter(all[1].Cursor))
+			require.NoError(t, err)
+			require.Equal(t, []string{redOnly.Id, untagged.Id}, ids(all))
+		})
+
+		t.Run("pages don't shift when urls come and go", func(t *testing.T) {
+			first, err := uum.GetAll(ctx, store.WithLimit(2))
+			require.NoError(t, err)
+
+			newer := create()
+			require.NoError(t, uum.Delete(ctx, both.Id))
+
+			next, err := uum.GetAll(ctx, store.WithLimit(2), store.WithResultsAfter(first[1].Cursor))
+			require.NoError(t, err)
+			require.Equal(t, []string{redOnly.Id, untagged.Id}, ids(next))
+
+			require.NoError(t, uum.Delete(ctx, newer.Id))
+			both = create(red, blue)
+		})
+
+		t.Run("combines tags and after", func(t *testing.T) {
+			tagged, err := uum.GetAll(ctx, store.WithAnyTags([]string{red.Name, blue.Name}))
+			require.NoError(t, err)
+
+			all, err := uum.GetAll(ctx,
+				store.WithAnyTags([]string{red.Name, blue.Name}),
+				store.WithResultsAfter(tagged[0].Cursor),
+			)
+			require.NoError(t, err)
+			require.Equal(t, []string{blueOnly.Id, redOnly.Id}, ids(all))
+		})
+
+		t.Run("refuses cursors it didn't make", func(t *testing.T) {
+			for _, cursor := range []string{"2", "not base64!", "WyJvbmUiXQ"} {
+				_, err := uum.GetAll(ctx, store.WithResultsAfter(cursor))
+				require.True(t, errors.Is(err, store.ErrInvalidCursor), "%s: %v", cursor, err)
+			}
+		})
+
+		t.Run("combines search and tags", func(t *testing.T) {
+			all, err := uum.GetAll(ctx,
+				store.WithSearchTerm("unit-testing"),
+				store.WithTags([]string{blue.Name}),
+			)
+			require.NoError(t, err)
+			require.ElementsMatch(t, []string{both.Id, blueOnly.Id}, ids(all))
+		})
+
+		t.Run("filters favorites", func(t *testing.T) {
+			redOnly.Favorite = true
+			require.NoError(t, uum.Update(ctx, redOnly))
+
+			all, err := uum.GetAll(ctx, store.WithFavorite(true))
+			require.NoError(t, err)
+			require.Equal(t, []string{redOnly.Id}, ids(all))
+
+			all, err = uum.GetAll(ctx, store.WithFavorite(true), store.WithSearchTerm("unit-testing"))
+			require.NoError(t, err)
+			require.Equal(t, []string{redOnly.Id}, ids(all))
+		})
+	})
+}
+
+func TestUserURLGetByIDAndDelete(t *testing.T) {
+	WithTempDatabase(t, func(db *Store) {
+		ctx := context.Background()
+
+		user := MustCreateBasicTestUser(t, db)
+		tag := MustCreateRandomTag(t, db)
+		MustCreateRandomTag(t, db)
+
+		uum := db.UserURLs(user)
+
+		uu := &api.UserURL{
+			Url:  MustCreateRandomURL(t, db),
+			User: user,
+			Tags: &api.TagList{Items: []*api.Tag{tag}},
+		}
+		require.NoError(t, uum.Create(ctx, uu))
+
+		found, err := uum.GetByID(ctx, uu.Id)
+		require.NoError(t, err)
+		require.Equal(t, uu.Url.Id, found.Url.Id)
+
+		tags, err := uum.GetTags(ctx)
+		require.NoError(t, err)
+		require.Len(t, tags.Items, 1)
+		require.Equal(t, tag.Id, tags.Items[0].Id)
+
+		require.NoError(t, uum.Delete(ctx, uu.Id))
+
+		_, err = uum.GetByID(ctx, uu.Id)
+		require.True(t, errors.Is(err, store.ErrNotFound), err)
+
+		err = uum.Delete(ctx, uu.Id)
+		require.True(t, errors.Is(err, store.ErrNotFound), err)
+
+		tags, err = uum.GetTags(ctx)
+		require.NoError(t, err)
+		require.Empty(t, tags.Items)
+	})
+}
+
+func TestUserURLReplaceTag(t *testing.T) {
+	WithTempDatabase(t, func(db *Store) {
+		ctx := context.Background()
+
+		user := MustCreateBasicTestUser(t, db)
+		old := MustCreateRandomTag(t, db)
+		new := MustCreateRandomTag(t, db)
+
+		uum := db.UserURLs(user)
+
+		last, err := uum.GetLastUpdated(ctx)
+		require.NoError(t, err)
+		require.Nil(t, last)
+
+		oldOnly := &api.UserURL{
+			Url:  MustCreateRandomURL(t, db),
+			User: user,
+			Tags: &api.TagList{Items: []*api.Tag{old}},
+		}
+		require.NoError(t, uum.Create(ctx, oldOnly))
+
+		both := &api.UserURL{
+			Url:  MustCreateRandomURL(t, db),
+			User: user,
+			Tags: &api.TagList{Items: []*api.Tag{old, new}},
+		}
+		require.NoError(t, uum.Create(ctx, both))
+
+		last, err = uum.GetLastUpdated(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, last)
+		require.WithinDuration(t, time.Now(), last.AsTime(), time.Minute)
+
+		tags, err := uum.GetTags(ctx)
+		require.NoError(t, err)
+		require.Len(t, tags.Items, 2)
+
+		for _, tag := range tags.Items {
+			switch tag.Id {
+			case old.Id:
+				require.Equal(t, int64(2), tag.Count)
+			case new.Id:
+				require.Equal(t, int64(1), tag.Count)
+			}
+		}
+
+		require.NoError(t, uum.ReplaceTag(ctx, old, new))
+
+		tags, err = uum.GetTags(ctx)
+		require.NoError(t, err)
+		require.Len(t, tags.Items, 1)
+		require.Equal(t, new.Id, tags.Items[0].Id)
+		require.Equal(t, int64(2), tags.Items[0].Count)
+
+		// the search index follows the tag change
+		results, err := uum.GetAll(ctx, store.WithSearchTerm(new.Name))
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+
+		require.NoError(t, uum.RemoveTag(ctx, new))
+
+		tags, err = uum.GetTags(ctx)
+		require.NoError(t, err)
+		require.Empty(t, tags.Items)
+
+		_, err = db.Tags().GetByID(ctx, new.Id)
+		require.NoError(t, err)
+	})
+}
+
+func TestUserURLSearch(t *testing.T) {
+	WithTempDatabase(t, func(db *Store) {
+		ctx := context.Background()
+
+		user := MustCreateBasicTestUser(t, db)
+		tag := MustCreateRandomTag(t, db)
+
+		uum := db.UserURLs(user)
+
+		noted := &api.UserURL{
+			Url:   MustCreateRandomURL(t, db),
+			User:  user,
+			Notes: "talk from the gopher conference",
+			Tags:  &api.TagList{},
+		}
+		require.NoError(t, uum.Create(ctx, noted))
+
+		titled := &api.UserURL{
+			Url:   MustCreateRandomURL(t, db),
+			User:  user,
+			Title: "Gophers all the way down",
+			Tags:  &api.TagList{Items: []*api.Tag{tag}},
+		}
+		require.NoError(t, uum.Create(ctx, titled))
+
+		t.Run("matches notes and titles ranked by relevance", func(t *testing.T) {
+			results, err := uum.GetAll(ctx, store.WithSearchTerm("gopher"))
+			require.NoError(t, err)
+			require.Len(t, results, 2)
+
+			// title matches are weighted above notes
+			require.Equal(t, titled.Id, results[0].Id)
+			require.Equal(t, noted.Id, results[1].Id)
+			require.Greater(t, results[0].Rank, results[1].Rank)
+			require.Contains(t, results[1].Snippet, api.SnippetMatchStart+"gopher"+api.SnippetMatchEnd)
+			require.Equal(t, noted.Notes, results[1].Notes)
+		})
+
+		t.Run("pages best match first", func(t *testing.T) {
+			first, err := uum.GetAll(ctx, store.WithSearchTerm("gopher"), store.WithLimit(1))
+			require.NoError(t, err)
+			require.Len(t, first, 1)
+			require.Equal(t, titled.Id, first[0].Id)
+
+			next, err := uum.GetAll(ctx, store.WithSearchTerm("gopher"), store.WithResultsAfter(first[0].Cursor))
+			require.NoError(t, err)
+			require.Len(t, next, 1)
+			require.Equal(t, noted.Id, next[0].Id)
+
+			next, err = uum.GetAll(ctx, store.WithSearchTerm("gopher"), store.WithResultsAfter(next[0].Cursor))
+			require.NoError(t, err)
+			require.Empty(t, next)
+		})
+
+		t.Run("matches tags", func(t *testing.T) {
+			results, err := uum.GetAll(ctx, store.WithSearchTerm(tag.Name))
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+			require.Equal(t, titled.Id, results[0].Id)
+			require.Len(t, results[0].Tags.Items, 1)
+		})
+
+		t.Run("index follows updates", func(t *testing.T) {
+			noted.Notes = "nothing to see here"
+			require.NoError(t, uum.Update(ctx, noted))
+
+			results, err := uum.GetAll(ctx, store.WithSearchTerm("conference"))
+			require.NoError(t, err)
+			require.Empty(t, results)
+		})
+
+		t.Run("fts syntax in the search term is escaped", func(t *testing.T) {
+			_, err := uum.GetAll(ctx, store.WithSearchTerm(`"gopher AND (NEAR`))
+			require.NoError(t, err)
+		})
+
+		t.Run("index survives vacuum", func(t *testing.T) {
+			require.NoError(t, uum.Delete(ctx, noted.Id))
+
+			// vacuum may renumber the rowids of tables without an integer
+			// primary key, like user_urls
+			_, err := db.db.ExecContext(ctx, `vacuum`)
+			require.NoError(t, err)
+
+			results, err := uum.GetAll(ctx, store.WithSearchTerm("gophers"))
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+			require.Equal(t, titled.Id, results[0].Id)
+		})
+	})
+}
+
+func TestUserURLVisibility(t *testing.T) {
+	WithTempDatabase(t, func(db *Store) {
+		ctx := context.Background()
+
+		user := MustCreateBasicTestUser(t, db)
+
+		uum := db.UserURLs(user)
+
+		create := func(title string) *api.UserURL {
+			uu := &api.UserURL{
+				Url:   MustCreateRandomURL(t, db),
+				User:  user,
+				Title: title,
+				Tags:  &api.TagList{},
+			}
+
+			require.NoError(t, uum.Create(ctx, uu))
+
+			return uu
+		}
+
+		private := create("private gophers")
+		unlisted := create("unlisted gophers")
+		public := create("public gophers")
+
+		t.Run("urls start private", func(t *testing.T) {
+			uu, err := uum.GetByID(ctx, private.Id)
+			require.NoError(t, err)
+			require.Equal(t, api.Visibility_VISIBILITY_PRIVATE, uu.Visibility)
+		})
+
+		unlisted.Visibility = api.Visibility_VISIBILITY_UNLISTED
+		require.NoError(t, uum.Update(ctx, unlisted))
+
+		public.Visibility = api.Visibility_VISIBILITY_PUBLIC
+		require.NoError(t, uum.Update(ctx, public))
+
+		t.Run("updates persist", func(t *testing.T) {
+			uu, err := uum.GetByID(ctx, unlisted.Id)
+			require.NoError(t, err)
+			require.Equal(t, api.Visibility_VISIBILITY_UNLISTED, uu.Visibility)
+
+			uu, err = uum.GetByURLID(ctx, public.Url.Id)
+			require.NoError(t, err)
+			require.Equal(t, api.Visibility_VISIBILITY_PUBLIC, uu.Visibility)
+		})
+
+		t.Run("public only lists public urls", func(t *testing.T) {
+			all, err := uum.GetAll(ctx, store.WithPublic(true))
+			require.NoError(t, err)
+			require.Len(t, all, 1)
+			require.Equal(t, public.Id, all[0].Id)
+
+			all, err = uum.GetAll(ctx)
+			require.NoError(t, err)
+			require.Len(t, all, 3)
+		})
+
+		t.Run("public only searches public urls", func(t *testing.T) {
+			results, err := uum.GetAll(ctx, store.WithPublic(true), store.WithSearchTerm("gophers"))
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+			require.Equal(t, public.Id, results[0].Id)
+		})
+	})
+}
@@ -0,0 +1,310 @@
+// Package sessionkeys loads the keys session cookies are signed and encrypted
+// with. Keys come from the SUFR_SESSION_* settings of config.Config when
+// they're set. Otherwise they're generated on the first run and saved in the
+// data dir so sessions survive restarts and no two instances share keys.
+//
+// Keys can be rotated. The current pair is used for new cookies and the
+// previous pairs are still accepted so nobody is logged out by a rotation.
+package sessionkeys
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kyleterry/sufr/pkg/config"
+	"github.com/pkg/errors"
+)
+
+const (
+	// AuthKeySize is the size of generated keys used to sign cookies.
+	AuthKeySize = 64
+	// EncryptionKeySize is the size of generated keys used to encrypt
+	// cookies. It selects AES-256.
+	EncryptionKeySize = 32
+	// DefaultKeep is how many previous pairs Rotate keeps.
+	DefaultKeep = 2
+)
+
+// ErrFromConfig is returned when rotating keys that are set in the config
+// rather than saved in the data dir.
+var ErrFromConfig = errors.New("session keys are set in the config and have to be rotated there")
+
+// Pair is a key to sign cookies with and a key to encrypt them with.
+type Pair struct {
+	Auth       []byte
+	Encryption []byte
+}
+
+type pairJSON struct {
+	Auth       string `json:"auth"`
+	Encryption string `json:"encryption"`
+}
+
+func (p Pair) MarshalJSON() ([]byte, error) {
+	return json.Marshal(pairJSON{
+		Auth:       hex.EncodeToString(p.Auth),
+		Encryption: hex.EncodeToString(p.Encryption),
+	})
+}
+
+func (p *Pair) UnmarshalJSON(b []byte) error {
+	var pj pairJSON
+	if err := json.Unmarshal(b, &pj); err != nil {
+		return err
+	}
+
+	pair, err := parsePair(pj.Auth, pj.Encryption)
+	if err != nil {
+		return err
+	}
+
+	*p = pair
+
+	return nil
+}
+
+// Keys are the current session key pair and the ones it replaced.
+type Keys struct {
+	Current  Pair   `json:"current"`
+	Previous []Pair `json:"previous,omitempty"`
+}
+
+// KeyPairs returns the keys in the order sessions.NewCookieStore takes them,
+// current pair first.
+func (k *Keys) KeyPairs() [][]byte {
+	pairs := [][]byte{k.Current.Auth, k.Current.Encryption}
+
+	for _, p := range k.Previous {
+		pairs = append(pairs, p.Auth, p.Encryption)
+	}
+
+	return pairs
+}
+
+// Generate returns a new random Pair.
+func Generate() (Pair, error) {
+	p := Pair{
+		Auth:       make([]byte, AuthKeySize),
+		Encryption: make([]byte, EncryptionKeySize),
+	}
+
+	if _, err := rand.Read(p.Auth); err != nil {
+		return Pair{}, err
+	}
+
+	if _, err := rand.Read(p.Encryption); err != nil {
+		return Pair{}, err
+	}
+
+	return p, nil
+}
+
+// Load returns the session keys for cfg. Keys set in cfg are used when
+// there are any, otherwise they're read from the keys file in the data dir,
+// which is created with new keys if it doesn't exist yet.
+func Load(cfg *config.Config) (*Keys, error) {
+	keys, err := fromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if keys != nil {
+		return keys, nil
+	}
+
+	path := cfg.SessionKeysFile()
+
+	keys, err = read(path)
+	if err == nil {
+		return keys, nil
+	}
+
+	if !os.IsNotExist(errors.Cause(err)) {
+		return nil, err
+	}
+
+	current, err := Generate()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate session keys")
+	}
+
+	keys = &Keys{Current: current}
+
+	if err := write(path, keys); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// MustLoad is Load but panics on errors.
+func MustLoad(cfg *config.Config) *Keys {
+	keys, err := Load(cfg)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to load session keys"))
+	}
+
+	return keys
+}
+
+// Rotate generates a new current pair and moves the old one to the front
+// of the previous pairs, keeping at most keep of them. Keys set in cfg can't
+// be rotated here.
+func Rotate(cfg *config.Config, keep int) (*Keys, error) {
+	fromCfg, err := fromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if fromCfg != nil {
+		return nil, ErrFromConfig
+	}
+
+	keys, err := Load(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := Generate()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate session keys")
+	}
+
+	previous := append([]Pair{keys.Current}, keys.Previous...)
+	if keep < 0 {
+		keep = 0
+	}
+
+	if len(previous) > keep {
+		previous = previous[:keep]
+	}
+
+	keys = &Keys{Current: current, Previous: previous}
+
+	if err := write(cfg.SessionKeysFile(), keys); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// fromConfig returns the keys set in cfg, or nil if there aren't any.
+func fromConfig(cfg *config.Config) (*Keys, error) {
+	if cfg.SessionAuthKey == "" && cfg.SessionEncryptionKey == "" {
+		if len(cfg.SessionPreviousKeys) > 0 {
+			return nil, errors.New("previous session keys are set without current ones")
+		}
+
+		return nil, nil
+	}
+
+	current, err := parsePair(cfg.SessionAuthKey, cfg.SessionEncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := &Keys{Current: current}
+
+	for _, s := range cfg.SessionPreviousKeys {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+
+		i := strings.IndexByte(s, ':')
+		if i < 0 {
+			return nil, errors.New("previous session keys must be auth:encryption pairs")
+		}
+
+		p, err := parsePair(s[:i], s[i+1:])
+		if err != nil {
+			return nil, err
+		}
+
+		keys.Previous = append(keys.Previous, p)
+	}
+
+	return keys, nil
+}
+
+func parsePair(auth, enc string) (Pair, error) {
+	a, err := hex.DecodeString(auth)
+	if err != nil {
+		return Pair{}, errors.Wrap(err, "session auth key must be hex encoded")
+	}
+
+	e, err := hex.DecodeString(enc)
+	if err != nil {
+		return Pair{}, errors.Wrap(err, "session encryption key must be hex encoded")
+	}
+
+	if len(a) < 32 {
+		return Pair{}, errors.New("session auth key must be at least 32 bytes")
+	}
+
+	switch len(e) {
+	case 16, 24, 32:
+	default:
+		return Pair{}, fmt.Errorf("session encryption key must be 16, 24 or 32 bytes, not %d", len(e))
+	}
+
+	return Pair{Auth: a, Encryption: e}, nil
+}
+
+func read(path string) (*Keys, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read session keys")
+	}
+
+	keys := &Keys{}
+
+	if err := json.Unmarshal(b, keys); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse session keys in %s", path)
+	}
+
+	return keys, nil
+}
+
+// write saves keys to path. Only the owner can read the file.
+func write(path string, keys *Keys) error {
+	b, err := json.MarshalIndent(keys, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return errors.Wrap(err, "failed to create data dir")
+	}
+
+	// written to a temporary file first so a crash can't leave the keys
+	// half written
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".session-keys-")
+	if err != nil {
+		return errors.Wrap(err, "failed to save session keys")
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+
+		return errors.Wrap(err, "failed to save session keys")
+	}
+
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "failed to save session keys")
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return errors.Wrap(err, "failed to save session keys")
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package sessionkeys
+
+import (
+	"encoding/hex"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/kyleterry/sufr/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadGeneratesAndPersists(t *testing.T) {
+	cfg := &config.Config{DataDir: t.TempDir()}
+
+	keys, err := Load(cfg)
+	require.NoError(t, err)
+	assert.Len(t, keys.Current.Auth, AuthKeySize)
+	assert.Len(t, keys.Current.Encryption, EncryptionKeySize)
+	assert.Empty(t, keys.Previous)
+
+	fi, err := os.Stat(cfg.SessionKeysFile())
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+
+	again, err := Load(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, keys, again)
+
+	other, err := Load(&config.Config{DataDir: t.TempDir()})
+	require.NoError(t, err)
+	assert.NotEqual(t, keys.Current, other.Current)
+}
+
+func TestLoadFromConfig(t *testing.T) {
+	auth := strings.Repeat("a1", 32)
+	enc := strings.Repeat("b2", 16)
+	oldAuth := strings.Repeat("c3", 64)
+	oldEnc := strings.Repeat("d4", 32)
+
+	cfg := &config.Config{
+		DataDir:              t.TempDir(),
+		SessionAuthKey:       auth,
+		SessionEncryptionKey: enc,
+		SessionPreviousKeys:  []string{oldAuth + ":" + oldEnc},
+	}
+
+	keys, err := Load(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, auth, hex.EncodeToString(keys.Current.Auth))
+	assert.Equal(t, enc, hex.EncodeToString(keys.Current.Encryption))
+	require.Len(t, keys.Previous, 1)
+	assert.Equal(t, oldAuth, hex.EncodeToString(keys.Previous[0].Auth))
+
+	_, err = os.Stat(cfg.SessionKeysFile())
+	assert.True(t, os.IsNotExist(err), "keys from the config aren't saved")
+
+	_, err = Rotate(cfg, DefaultKeep)
+	assert.Equal(t, ErrFromConfig, err)
+
+	for name, bad := range map[string]*config.Config{
+		"not hex":            {SessionAuthKey: "zz", SessionEncryptionKey: enc},
+		"short auth key":     {SessionAuthKey: "a1a1", SessionEncryptionKey: enc},
+		"bad encryption key": {SessionAuthKey: auth, SessionEncryptionKey: "b2b2"},
+		"missing encryption": {SessionAuthKey: auth},
+		"previous only":      {SessionPreviousKeys: []string{oldAuth + ":" + oldEnc}},
+		"previous not pair":  {SessionAuthKey: auth, SessionEncryptionKey: enc, SessionPreviousKeys: []string{oldAuth}},
+	} {
+		_, err := Load(bad)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestRotate(t *testing.T) {
+	cfg := &config.Config{DataDir: t.TempDir()}
+
+	first, err := Load(cfg)
+	require.NoError(t, err)
+
+	second, err := Rotate(cfg, 2)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.Current, second.Current)
+	assert.Equal(t, []Pair{first.Current}, second.Previous)
+
+	third, err := Rotate(cfg, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []Pair{second.Current, first.Current}, third.Previous)
+
+	fourth, err := Rotate(cfg, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []Pair{third.Current, second.Current}, fourth.Previous)
+
+	loaded, err := Load(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, fourth, loaded)
+
+	pairs := loaded.KeyPairs()
+	require.Len(t, pairs, 6)
+	assert.Equal(t, fourth.Current.Auth, pairs[0])
+	assert.Equal(t, fourth.Current.Encryption, pairs[1])
+	assert.Equal(t, third.Current.Auth, pairs[2])
+}
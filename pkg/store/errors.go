@@ -11,4 +11,7 @@ const (
 	ErrNotFound          = Error("not found")
 	ErrInvalidDependency = Error("record dependency is invalid")
 	ErrUnknown           = Error("unknown error")
+	ErrDisabled          = Error("account is disabled")
+	ErrInvalidBackup     = Error("invalid backup")
+	ErrInvalidCursor     = Error("invalid cursor")
 )
@@ -2,8 +2,13 @@ package store
 
 import (
 	"context"
+	"io"
 
 	"github.com/kyleterry/sufr/pkg/api"
+	"github.com/kyleterry/sufr/pkg/archive"
+	"github.com/kyleterry/sufr/pkg/embed"
+	"github.com/kyleterry/sufr/pkg/fetchqueue"
+	"github.com/kyleterry/sufr/pkg/linkcheck"
 )
 
 type Manager interface {
@@ -11,13 +16,53 @@ type Manager interface {
 	Tags() TagManager
 	UserURLs(*api.User) UserURLManager
 	Users() UserManager
+	FetchJobs() FetchJobManager
+	URLChecks() URLCheckManager
+	Shares() ShareManager
+	Embeds() EmbedManager
+	// Transaction runs fn with a Manager that does all of its work in one
+	// transaction, which is rolled back if fn returns an error.
+	Transaction(ctx context.Context, fn func(ctx context.Context, tx Manager) error) error
 }
 
 type URLManager interface {
 	Create(ctx context.Context, url *api.URL) error
+	Update(ctx context.Context, url *api.URL) error
+	GetByID(ctx context.Context, id string) (*api.URL, error)
 	GetByURL(ctx context.Context, url string) (*api.URL, error)
 }
 
+// FetchJobManager is the persistent queue of url metadata fetches.
+type FetchJobManager interface {
+	fetchqueue.Store
+	// Enqueue adds a job to fetch the metadata of url. A url has at most one
+	// job queued at a time.
+	Enqueue(ctx context.Context, url *api.URL) error
+}
+
+// URLCheckManager keeps the history of link checks.
+type URLCheckManager interface {
+	linkcheck.Store
+	// GetByURLID returns the latest checks of a url, newest first.
+	GetByURLID(ctx context.Context, urlID string) (*api.URLCheckList, error)
+}
+
+// ShareManager keeps the secret links users share urls and tags with.
+type ShareManager interface {
+	// Create saves share for user. Its id has to be set to the secret in
+	// the link, and one of its user url id or tag id to what it shares.
+	Create(ctx context.Context, user *api.User, share *api.Share) error
+	// GetByID returns the share with id, whoever it belongs to. Expired
+	// shares are returned too.
+	GetByID(ctx context.Context, id string) (*api.Share, error)
+	// GetAll returns user's shares, newest first.
+	GetAll(ctx context.Context, user *api.User) ([]*api.Share, error)
+	// View counts a visit to the share with id.
+	View(ctx context.Context, id string) error
+	// Delete revokes one of user's shares.
+	Delete(ctx context.Context, user *api.User, id string) error
+}
+
 type TagManager interface {
 	Create(ctx context.Context, tag *api.Tag) error
 	GetByID(ctx context.Context, id string) (*api.Tag, error)
@@ -27,22 +72,103 @@ type TagManager interface {
 type UserURLManager interface {
 	Create(ctx context.Context, userURL *api.UserURL) error
 	Update(ctx context.Context, userURL *api.UserURL) error
+	Delete(ctx context.Context, id string) error
 	GetAll(ctx context.Context, filters ...FilterOption) ([]*api.UserURL, error)
+	GetByID(ctx context.Context, id string) (*api.UserURL, error)
 	GetByURLID(ctx context.Context, urlID string) (*api.UserURL, error)
+	GetTags(ctx context.Context) (*api.TagList, error)
+	GetLastUpdated(ctx context.Context) (*api.Timestamp, error)
+	ReplaceTag(ctx context.Context, old, new *api.Tag) error
+	// RemoveTag takes tag off every one of the user's urls.
+	RemoveTag(ctx context.Context, tag *api.Tag) error
 }
 
 type UserManager interface {
 	Create(ctx context.Context, user *api.User) error
+	// Update saves the settings users can change themselves: their email,
+	// embed content and per page settings.
+	Update(ctx context.Context, user *api.User) error
 	UpdatePinnedCategories(ctx context.Context, user *api.User) error
+	UpdateAPIToken(ctx context.Context, user *api.User) error
+	UpdatePassword(ctx context.Context, user *api.User) error
+	// UpdateAccess saves whether user is an admin and whether they're
+	// disabled.
+	UpdateAccess(ctx context.Context, user *api.User) error
+	// Delete deletes a user along with everything they saved.
+	Delete(ctx context.Context, id string) error
+	GetAll(ctx context.Context) ([]*api.User, error)
 	GetByID(ctx context.Context, id string) (*api.User, error)
 	GetByEmail(ctx context.Context, email string) (*api.User, error)
+	// GetByAPIToken returns the user whose API token is token. It returns
+	// ErrDisabled for disabled users.
+	GetByAPIToken(ctx context.Context, token string) (*api.User, error)
+	// GetByEmailAndPassword returns ErrDisabled for disabled users.
 	GetByEmailAndPassword(ctx context.Context, email string, password string) (*api.User, error)
+	// GetTOTP returns the two-factor setup of user, or ErrNotFound if it's
+	// turned off.
+	GetTOTP(ctx context.Context, user *api.User) (*TOTP, error)
+	// EnableTOTP turns on two-factor authentication with secret and
+	// replaces the recovery codes with recoveryHashes.
+	EnableTOTP(ctx context.Context, user *api.User, secret string, recoveryHashes [][]byte) error
+	// DisableTOTP turns two-factor authentication off and deletes the
+	// recovery codes.
+	DisableTOTP(ctx context.Context, user *api.User) error
+	// UseTOTPStep records that the code for step was used. It returns
+	// ErrNotFound if that step or a later one was already used.
+	UseTOTPStep(ctx context.Context, user *api.User, step int64) error
+	// UseRecoveryCode deletes the recovery code with hash. It returns
+	// ErrNotFound if there's no such code.
+	UseRecoveryCode(ctx context.Context, user *api.User, hash []byte) error
+	// AddWebAuthnCredential saves a new passkey or security key for user. It
+	// returns ErrAlreadyExists if the credential is already registered.
+	AddWebAuthnCredential(ctx context.Context, user *api.User, cred *api.WebAuthnCredential) error
+	// GetWebAuthnCredential returns the credential with id, whoever it
+	// belongs to.
+	GetWebAuthnCredential(ctx context.Context, id []byte) (*api.WebAuthnCredential, error)
+	// UseWebAuthnCredential saves the sign count of cred after logging in
+	// with it.
+	UseWebAuthnCredential(ctx context.Context, cred *api.WebAuthnCredential) error
+	// DeleteWebAuthnCredential deletes one of user's credentials.
+	DeleteWebAuthnCredential(ctx context.Context, user *api.User, id []byte) error
+	// GetByIdentity returns the user the single sign-on account subject at
+	// issuer logs in as. It returns ErrDisabled for disabled users.
+	GetByIdentity(ctx context.Context, issuer, subject string) (*api.User, error)
+	// AddIdentity ties the single sign-on account subject at issuer to user.
+	// It returns ErrAlreadyExists if that account already logs in as someone,
+	// or user already has an account at issuer.
+	AddIdentity(ctx context.Context, user *api.User, issuer, subject string) error
+	// HasIdentity returns whether any single sign-on account logs in as
+	// user.
+	HasIdentity(ctx context.Context, user *api.User) (bool, error)
+}
+
+// TOTP is the two-factor authentication setup of a user.
+type TOTP struct {
+	Secret string `json:"secret"`
+	// LastStep is the time step of the last code used.
+	LastStep int64 `json:"last_step"`
+	// RecoveryCodes is how many unused recovery codes are left.
+	RecoveryCodes int `json:"recovery_codes"`
 }
 
 type FilterOptions struct {
 	Search string
 	Tags   []string
-	After  int64
+	// AnyTags matches results tagged with any of Tags instead of all of them.
+	AnyTags bool
+	// Broken limits results to urls the link checker flagged dead.
+	Broken bool
+	// Public limits results to urls their owner made public.
+	Public bool
+	// Favorite limits results to urls their owner favorited.
+	Favorite bool
+	// After is the Cursor of the last result already seen. Results start
+	// with the one following it, so urls saved or deleted in the meantime
+	// don't shift them.
+	After string
+	// Limit is the most results returned at once. 0 uses the store's
+	// default.
+	Limit int
 }
 
 type FilterOption interface {
@@ -57,10 +183,11 @@ func (s *FilterOptionFunc) Apply(opts *FilterOptions) {
 	s.f(opts)
 }
 
-func WithResultsAfter(i int64) FilterOption {
+// WithResultsAfter starts results after the one with cursor.
+func WithResultsAfter(cursor string) FilterOption {
 	return &FilterOptionFunc{
 		f: func(opts *FilterOptions) {
-			opts.After = i
+			opts.After = cursor
 		},
 	}
 }
@@ -73,10 +200,75 @@ func WithSearchTerm(term string) FilterOption {
 	}
 }
 
+// WithTags filters results to those tagged with every tag in tags.
 func WithTags(tags []string) FilterOption {
 	return &FilterOptionFunc{
 		f: func(opts *FilterOptions) {
 			opts.Tags = tags
+			opts.AnyTags = false
+		},
+	}
+}
+
+// WithAnyTags filters results to those tagged with at least one tag in tags.
+func WithAnyTags(tags []string) FilterOption {
+	return &FilterOptionFunc{
+		f: func(opts *FilterOptions) {
+			opts.Tags = tags
+			opts.AnyTags = true
 		},
 	}
 }
+
+// WithBroken filters results to urls the link checker flagged dead.
+func WithBroken(broken bool) FilterOption {
+	return &FilterOptionFunc{
+		f: func(opts *FilterOptions) {
+			opts.Broken = broken
+		},
+	}
+}
+
+// WithPublic filters results to urls their owner made public.
+func WithPublic(public bool) FilterOption {
+	return &FilterOptionFunc{
+		f: func(opts *FilterOptions) {
+			opts.Public = public
+		},
+	}
+}
+
+// WithFavorite filters results to urls their owner favorited.
+func WithFavorite(favorite bool) FilterOption {
+	return &FilterOptionFunc{
+		f: func(opts *FilterOptions) {
+			opts.Favorite = favorite
+		},
+	}
+}
+
+// WithLimit sets the most results returned at once.
+func WithLimit(n int) FilterOption {
+	return &FilterOptionFunc{
+		f: func(opts *FilterOptions) {
+			opts.Limit = n
+		},
+	}
+}
+
+// EmbedManager caches what oEmbed endpoints answered for urls.
+type EmbedManager interface {
+	embed.Cache
+}
+
+// Backuper is a Manager that can back up and restore everything it keeps
+// while it's in use.
+type Backuper interface {
+	// WriteBackup writes a gzipped tarball of the database to w, along with
+	// the page snapshots kept by archiver if it isn't nil.
+	WriteBackup(ctx context.Context, w io.Writer, archiver *archive.Archiver) error
+	// RestoreBackup replaces everything with the database in r, either a
+	// tarball made by WriteBackup or a database file. It returns
+	// ErrInvalidBackup if r isn't a backup that can be restored.
+	RestoreBackup(ctx context.Context, r io.Reader) error
+}
@@ -0,0 +1,196 @@
+// Package totp implements the time-based one-time passwords of RFC 6238 that
+// authenticator apps generate, and the recovery codes that stand in for them
+// when the app is lost.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"rsc.io/qr"
+)
+
+const (
+	// Digits is how long codes are.
+	Digits = 6
+	// Period is how long a code is valid for.
+	Period = 30 * time.Second
+	// Skew is how many periods either side of now are accepted to make up
+	// for clocks that are off.
+	Skew = 1
+	// RecoveryCodes is how many recovery codes are made at once.
+	RecoveryCodes = 10
+
+	secretBytes   = 20
+	recoveryBytes = 10
+)
+
+var (
+	// ErrInvalidCode is returned for codes that are wrong, expired or
+	// already used.
+	ErrInvalidCode = errors.New("invalid two-factor code")
+	// ErrInvalidSecret is returned for secrets that aren't base32.
+	ErrInvalidSecret = errors.New("invalid two-factor secret")
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret returns a new random secret, base32 encoded the way
+// authenticator apps expect it.
+func GenerateSecret() (string, error) {
+	b := make([]byte, secretBytes)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return encoding.EncodeToString(b), nil
+}
+
+// Step returns the time step t falls in.
+func Step(t time.Time) int64 {
+	return t.Unix() / int64(Period/time.Second)
+}
+
+// Code returns the code for secret at time step.
+func Code(secret string, step int64) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	// dynamic truncation from RFC 4226
+	offset := sum[len(sum)-1] & 0x0f
+	n := binary.BigEndian.Uint32(sum[offset:]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", Digits, n%1000000), nil
+}
+
+// Verify checks code against secret at time t and returns the time step it
+// was for. Steps up to and including after are turned down so a code can't
+// be used twice; pass 0 when nothing has been used yet.
+func Verify(secret, code string, t time.Time, after int64) (int64, error) {
+	code = normalize(code)
+	if len(code) != Digits {
+		return 0, ErrInvalidCode
+	}
+
+	now := Step(t)
+
+	for step := now - Skew; step <= now+Skew; step++ {
+		if step <= after {
+			continue
+		}
+
+		want, err := Code(secret, step)
+		if err != nil {
+			return 0, err
+		}
+
+		if subtle.ConstantTimeCompare([]byte(want), []byte(code)) == 1 {
+			return step, nil
+		}
+	}
+
+	return 0, ErrInvalidCode
+}
+
+// URI returns the otpauth URI authenticator apps read from QR codes.
+func URI(issuer, account, secret string) string {
+	v := url.Values{}
+	v.Set("secret", secret)
+	v.Set("issuer", issuer)
+	v.Set("algorithm", "SHA1")
+	v.Set("digits", fmt.Sprint(Digits))
+	v.Set("period", fmt.Sprint(int(Period/time.Second)))
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: v.Encode(),
+	}
+
+	return u.String()
+}
+
+// QRCode returns a PNG of a QR code holding uri.
+func QRCode(uri string) ([]byte, error) {
+	c, err := qr.Encode(uri, qr.M)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Scale = 6
+
+	return c.PNG(), nil
+}
+
+// GenerateRecoveryCodes returns RecoveryCodes new codes to show the user
+// once, like "abcdefgh-ijklmnop".
+func GenerateRecoveryCodes() ([]string, error) {
+	codes := make([]string, RecoveryCodes)
+
+	for i := range codes {
+		b := make([]byte, recoveryBytes)
+
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+
+		s := strings.ToLower(encoding.EncodeToString(b))
+		codes[i] = s[:8] + "-" + s[8:16]
+	}
+
+	return codes, nil
+}
+
+// HashRecoveryCode returns the hash of code that is stored in place of it.
+// Dashes, spaces and case don't matter.
+func HashRecoveryCode(code string) []byte {
+	sum := sha256.Sum256([]byte(strings.ToLower(normalize(code))))
+
+	return sum[:]
+}
+
+// IsRecoveryCode returns true if code looks like a recovery code rather than
+// a TOTP code.
+func IsRecoveryCode(code string) bool {
+	return len(normalize(code)) > Digits
+}
+
+func normalize(code string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+
+		return r
+	}, code)
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil || len(key) == 0 {
+		return nil, ErrInvalidSecret
+	}
+
+	return key, nil
+}
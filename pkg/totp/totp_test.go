@@ -0,0 +1,118 @@
+package totp
+
+import (
+	"bytes"
+	"encoding/base32"
+	"image/png"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the SHA1 key from the test vectors in RFC 6238.
+var rfcSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestCode(t *testing.T) {
+	// the last six digits of the RFC 6238 SHA1 test vectors
+	for unix, want := range map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	} {
+		got, err := Code(rfcSecret, Step(time.Unix(unix, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, want, got, "at %d", unix)
+	}
+
+	_, err := Code("not base32!", 1)
+	assert.Equal(t, ErrInvalidSecret, err)
+}
+
+func TestVerify(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+
+	now := time.Unix(1600000000, 0)
+	step := Step(now)
+
+	code, err := Code(secret, step)
+	require.NoError(t, err)
+
+	got, err := Verify(secret, code[:3]+" "+code[3:], now, 0)
+	require.NoError(t, err)
+	assert.Equal(t, step, got)
+
+	t.Run("allows some clock skew", func(t *testing.T) {
+		_, err := Verify(secret, code, now.Add(Period), 0)
+		assert.NoError(t, err)
+
+		_, err = Verify(secret, code, now.Add(-Period), 0)
+		assert.NoError(t, err)
+
+		_, err = Verify(secret, code, now.Add(2*Period), 0)
+		assert.Equal(t, ErrInvalidCode, err)
+	})
+
+	t.Run("rejects used steps", func(t *testing.T) {
+		_, err := Verify(secret, code, now, step)
+		assert.Equal(t, ErrInvalidCode, err)
+
+		next, err := Code(secret, step+1)
+		require.NoError(t, err)
+
+		got, err := Verify(secret, next, now, step)
+		require.NoError(t, err)
+		assert.Equal(t, step+1, got)
+	})
+
+	t.Run("rejects wrong codes", func(t *testing.T) {
+		for _, bad := range []string{"", "12345", "1234567", "abcdef"} {
+			_, err := Verify(secret, bad, now, 0)
+			assert.Equal(t, ErrInvalidCode, err, bad)
+		}
+	})
+}
+
+func TestURI(t *testing.T) {
+	u, err := url.Parse(URI("SUFR", "kyle@example.com", "ABCDEF"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, "/SUFR:kyle@example.com", u.Path)
+	assert.Equal(t, "ABCDEF", u.Query().Get("secret"))
+	assert.Equal(t, "SUFR", u.Query().Get("issuer"))
+}
+
+func TestQRCode(t *testing.T) {
+	b, err := QRCode(URI("SUFR", "kyle@example.com", "ABCDEF"))
+	require.NoError(t, err)
+
+	_, err = png.Decode(bytes.NewReader(b))
+	assert.NoError(t, err)
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes()
+	require.NoError(t, err)
+	require.Len(t, codes, RecoveryCodes)
+
+	seen := map[string]bool{}
+
+	for _, code := range codes {
+		assert.Len(t, code, 17)
+		assert.True(t, IsRecoveryCode(code))
+		assert.False(t, seen[code])
+		seen[code] = true
+	}
+
+	assert.Equal(t, HashRecoveryCode(codes[0]), HashRecoveryCode(" "+codes[0][:8]+codes[0][9:]+" "))
+	assert.NotEqual(t, HashRecoveryCode(codes[0]), HashRecoveryCode(codes[1]))
+	assert.False(t, IsRecoveryCode("123 456"))
+}
@@ -1,6 +1,11 @@
 $(document).ready(function() {
+  // requests that change something need the page's csrf token
+  $.ajaxSetup({
+    headers: {'X-CSRF-Token': $('meta[name="csrf-token"]').attr('content')}
+  });
+
   $('#confirm-delete').on('show.bs.modal', function(e) {
-    $(this).find('.btn-ok').attr('href', $(e.relatedTarget).data('href'));
+    $(this).find('form').attr('action', $(e.relatedTarget).data('href'));
   });
 
   $('.fav-btn').click(function() {
@@ -22,6 +27,56 @@ $(document).ready(function() {
     return false;
   });
 
+  // passkeys only work in browsers that know about them
+  if (window.PublicKeyCredential) {
+    $('.passkey').removeClass('d-none');
+  }
+
+  $('#add-passkey').submit(function() {
+    var form = $(this);
+    postJSON(form.data('options')).then(function(opts) {
+      opts.challenge = fromBase64URL(opts.challenge);
+      opts.user.id = fromBase64URL(opts.user.id);
+      opts.excludeCredentials.forEach(function(c) {
+        c.id = fromBase64URL(c.id);
+      });
+      return navigator.credentials.create({publicKey: opts});
+    }).then(function(cred) {
+      return postJSON(form.attr('action'), {
+        name: form.find('[name="name"]').val(),
+        credential: {
+          id: toBase64URL(cred.rawId),
+          clientDataJSON: toBase64URL(cred.response.clientDataJSON),
+          attestationObject: toBase64URL(cred.response.attestationObject)
+        }
+      });
+    }).then(function(result) {
+      window.location = result.redirect;
+    }, showPasskeyError);
+    return false;
+  });
+
+  $('#passkey-login').click(function() {
+    var btn = $(this);
+    postJSON(btn.data('options')).then(function(opts) {
+      opts.challenge = fromBase64URL(opts.challenge);
+      opts.allowCredentials.forEach(function(c) {
+        c.id = fromBase64URL(c.id);
+      });
+      return navigator.credentials.get({publicKey: opts});
+    }).then(function(cred) {
+      return postJSON(btn.data('action'), {
+        id: toBase64URL(cred.rawId),
+        clientDataJSON: toBase64URL(cred.response.clientDataJSON),
+        authenticatorData: toBase64URL(cred.response.authenticatorData),
+        signature: toBase64URL(cred.response.signature),
+        userHandle: cred.response.userHandle ? toBase64URL(cred.response.userHandle) : ''
+      });
+    }).then(function(result) {
+      window.location = result.redirect;
+    }, showPasskeyError);
+  });
+
   var vidDefer = document.getElementsByTagName('iframe');
   for (var i=0; i<vidDefer.length; i++) {
     if(vidDefer[i].getAttribute('data-src')) {
@@ -29,3 +84,43 @@ $(document).ready(function() {
     }
   }
 });
+
+function postJSON(url, data) {
+  return $.ajax({
+    url: url,
+    type: 'post',
+    contentType: 'application/json',
+    data: JSON.stringify(data || {}),
+    dataType: 'json'
+  });
+}
+
+// passkeys are made of binary data, which goes back and forth as base64url
+function toBase64URL(buf) {
+  var s = '';
+  var bytes = new Uint8Array(buf);
+  for (var i = 0; i < bytes.length; i++) {
+    s += String.fromCharCode(bytes[i]);
+  }
+  return btoa(s).replace(/\+/g, '-').replace(/\//g, '_').replace(/=+$/, '');
+}
+
+function fromBase64URL(s) {
+  s = s.replace(/-/g, '+').replace(/_/g, '/');
+  while (s.length % 4) {
+    s += '=';
+  }
+  return Uint8Array.from(atob(s), function(c) {
+    return c.charCodeAt(0);
+  });
+}
+
+function showPasskeyError(err) {
+  var message = 'That passkey didn\'t work.';
+  if (err && err.responseJSON && err.responseJSON.error) {
+    message = err.responseJSON.error.message;
+  } else if (err && err.name === 'NotAllowedError') {
+    message = 'No passkey was picked.';
+  }
+  $('#passkey-error').text(message).removeClass('d-none');
+}
@@ -0,0 +1,295 @@
+package warc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/kyleterry/sufr/pkg/config"
+	"github.com/kyleterry/sufr/pkg/data"
+)
+
+const (
+	DefaultTimeout      = time.Minute
+	DefaultMaxBodyBytes = 50 << 20
+	DefaultMaxRedirects = 10
+)
+
+type capturerOptions struct {
+	timeout      time.Duration
+	maxBodyBytes int64
+	maxRedirects int
+	transport    http.RoundTripper
+}
+
+type capturerOptionFunc struct {
+	f func(*capturerOptions)
+}
+
+func (c *capturerOptionFunc) apply(opts *capturerOptions) {
+	c.f(opts)
+}
+
+type CapturerOption interface {
+	apply(*capturerOptions)
+}
+
+// WithTimeout sets how long capturing a url may take, redirects included.
+func WithTimeout(d time.Duration) CapturerOption {
+	return &capturerOptionFunc{
+		f: func(opts *capturerOptions) {
+			opts.timeout = d
+		},
+	}
+}
+
+// WithMaxBodyBytes sets how much of a response body is kept. Longer bodies
+// are cut off and their record is marked truncated.
+func WithMaxBodyBytes(n int64) CapturerOption {
+	return &capturerOptionFunc{
+		f: func(opts *capturerOptions) {
+			opts.maxBodyBytes = n
+		},
+	}
+}
+
+// WithMaxRedirects sets how many redirects are followed for each url.
+func WithMaxRedirects(n int) CapturerOption {
+	return &capturerOptionFunc{
+		f: func(opts *capturerOptions) {
+			opts.maxRedirects = n
+		},
+	}
+}
+
+// WithTransport sets the http.RoundTripper used to make requests. It should
+// not ask for compressed responses on its own, or the request records won't
+// match what was sent.
+func WithTransport(rt http.RoundTripper) CapturerOption {
+	return &capturerOptionFunc{
+		f: func(opts *capturerOptions) {
+			opts.transport = rt
+		},
+	}
+}
+
+// Summary counts what an export captured.
+type Summary struct {
+	Captured int
+	Failed   int
+}
+
+// Capturer fetches urls and records the exchanges as WARC records.
+type Capturer struct {
+	client *http.Client
+	opts   capturerOptions
+}
+
+// Export writes a warcinfo record followed by a capture of each of urls to w.
+// A url that can't be fetched is counted as failed and skipped, so a dead
+// link doesn't spoil the rest of the export.
+func (c *Capturer) Export(ctx context.Context, w io.Writer, urls []string) (*Summary, error) {
+	ww := NewWriter(w)
+
+	if _, err := ww.WriteRecord(newWarcinfo()); err != nil {
+		return nil, fmt.Errorf("failed to write warcinfo: %w", err)
+	}
+
+	summary := &Summary{}
+	seen := map[string]bool{}
+
+	for _, u := range urls {
+		if seen[u] {
+			continue
+		}
+
+		seen[u] = true
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if err := c.Capture(ctx, ww, u); err != nil {
+			var we *writeError
+			if errors.As(err, &we) {
+				return nil, err
+			}
+
+			summary.Failed++
+
+			continue
+		}
+
+		summary.Captured++
+	}
+
+	return summary, nil
+}
+
+// Capture fetches rawurl and writes a response and a request record for it
+// and for each redirect followed on the way to the page.
+func (c *Capturer) Capture(ctx context.Context, w *Writer, rawurl string) error {
+	ctx, cancel := context.WithTimeout(ctx, c.opts.timeout)
+	defer cancel()
+
+	target := rawurl
+
+	for redirects := 0; ; redirects++ {
+		req, err := data.NewPageRequest(ctx, target)
+		if err != nil {
+			return err
+		}
+
+		res, err := c.client.Do(req)
+		if err != nil {
+			return err
+		}
+
+		err = c.record(w, req, res)
+		res.Body.Close()
+
+		if err != nil {
+			return err
+		}
+
+		loc, err := res.Location()
+		if err != nil || res.StatusCode < 300 || res.StatusCode > 399 || redirects >= c.opts.maxRedirects {
+			return nil
+		}
+
+		target = loc.String()
+	}
+}
+
+// record writes the response record of an exchange followed by its request
+// record.
+func (c *Capturer) record(w *Writer, req *http.Request, res *http.Response) error {
+	date := time.Now()
+
+	var reqBlock bytes.Buffer
+	if err := req.Write(&reqBlock); err != nil {
+		return err
+	}
+
+	body, truncated, err := readBody(res.Body, c.opts.maxBodyBytes)
+	if err != nil {
+		return err
+	}
+
+	var resBlock bytes.Buffer
+
+	fmt.Fprintf(&resBlock, "HTTP/%d.%d %s\r\n", res.ProtoMajor, res.ProtoMinor, res.Status)
+	res.Header.Write(&resBlock)
+	resBlock.WriteString("\r\n")
+	resBlock.Write(body)
+
+	fields := []Field{{"WARC-Payload-Digest", Digest(body)}}
+	if truncated {
+		fields = append(fields, Field{"WARC-Truncated", "length"})
+	}
+
+	id, err := w.WriteRecord(&Record{
+		Type:        TypeResponse,
+		Date:        date,
+		TargetURI:   req.URL.String(),
+		ContentType: "application/http;msgtype=response",
+		Fields:      fields,
+		Block:       resBlock.Bytes(),
+	})
+	if err != nil {
+		return &writeError{err}
+	}
+
+	_, err = w.WriteRecord(&Record{
+		Type:        TypeRequest,
+		Date:        date,
+		TargetURI:   req.URL.String(),
+		ContentType: "application/http;msgtype=request",
+		Fields:      []Field{{"WARC-Concurrent-To", id}},
+		Block:       reqBlock.Bytes(),
+	})
+	if err != nil {
+		return &writeError{err}
+	}
+
+	return nil
+}
+
+// writeError is a failure to write to the WARC file, as opposed to a failure
+// to fetch a url. It stops an export.
+type writeError struct {
+	err error
+}
+
+func (e *writeError) Error() string {
+	return fmt.Sprintf("failed to write record: %s", e.err)
+}
+
+func (e *writeError) Unwrap() error {
+	return e.err
+}
+
+func newWarcinfo() *Record {
+	var block bytes.Buffer
+
+	fmt.Fprintf(&block, "software: SUFR\r\n")
+	fmt.Fprintf(&block, "format: WARC File Format 1.1\r\n")
+	fmt.Fprintf(&block, "conformsTo: http://iipc.github.io/warc-specifications/specifications/warc-format/warc-1.1/\r\n")
+	fmt.Fprintf(&block, "http-header-user-agent: %s\r\n", config.SUFRUserAgent)
+
+	return &Record{
+		Type:        TypeWarcinfo,
+		ContentType: "application/warc-fields",
+		Block:       block.Bytes(),
+	}
+}
+
+// readBody reads at most max bytes of r. truncated is true if there was more.
+func readBody(r io.Reader, max int64) (body []byte, truncated bool, err error) {
+	var buf bytes.Buffer
+
+	n, err := io.Copy(&buf, io.LimitReader(r, max+1))
+	if err != nil {
+		return nil, false, err
+	}
+
+	if n > max {
+		return buf.Bytes()[:max], true, nil
+	}
+
+	return buf.Bytes(), false, nil
+}
+
+// New returns a Capturer.
+func New(opts ...CapturerOption) *Capturer {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// the request records have to be what was sent, so nothing can be
+	// added to requests on the way out
+	transport.DisableCompression = true
+
+	co := capturerOptions{
+		timeout:      DefaultTimeout,
+		maxBodyBytes: DefaultMaxBodyBytes,
+		maxRedirects: DefaultMaxRedirects,
+		transport:    transport,
+	}
+
+	for _, opt := range opts {
+		opt.apply(&co)
+	}
+
+	return &Capturer{
+		client: &http.Client{
+			Transport: co.transport,
+			// redirects are followed by Capture so each hop is recorded
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		opts: co,
+	}
+}
@@ -0,0 +1,138 @@
+// Package warc writes saved pages to WARC 1.1 files, the format web archives
+// like the Wayback Machine use, so they can be handed to standard archiving
+// tools. Every record is compressed as its own gzip member, the way .warc.gz
+// files are expected to be, so readers can seek straight to any record.
+package warc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha1"
+	"encoding/base32"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Version is the version of the WARC format written.
+	Version = "WARC/1.1"
+	// FileExt is the file extension of gzipped WARC files.
+	FileExt = ".warc.gz"
+)
+
+// RecordType is the WARC-Type of a record.
+type RecordType string
+
+const (
+	TypeWarcinfo RecordType = "warcinfo"
+	TypeRequest  RecordType = "request"
+	TypeResponse RecordType = "response"
+	TypeMetadata RecordType = "metadata"
+)
+
+// Field is a named field of a record header.
+type Field struct {
+	Name  string
+	Value string
+}
+
+// Record is a WARC record. WARC-Type, WARC-Record-ID, WARC-Date,
+// WARC-Target-URI, Content-Type, WARC-Block-Digest and Content-Length are
+// written from the record itself; anything else goes in Fields.
+type Record struct {
+	Type RecordType
+	// ID is the WARC-Record-ID of the record. One is made up when it's empty.
+	ID          string
+	Date        time.Time
+	TargetURI   string
+	ContentType string
+	Fields      []Field
+	Block       []byte
+}
+
+// NewRecordID returns a new WARC-Record-ID.
+func NewRecordID() string {
+	return "<urn:uuid:" + uuid.New().String() + ">"
+}
+
+// Writer writes WARC records.
+type Writer struct {
+	w  io.Writer
+	gw *gzip.Writer
+}
+
+// WriteRecord writes r. The id of the record is returned.
+func (w *Writer) WriteRecord(r *Record) (string, error) {
+	if r.ID == "" {
+		r.ID = NewRecordID()
+	}
+
+	if r.Date.IsZero() {
+		r.Date = time.Now()
+	}
+
+	var buf bytes.Buffer
+
+	header := []Field{
+		{"WARC-Type", string(r.Type)},
+		{"WARC-Record-ID", r.ID},
+		{"WARC-Date", r.Date.UTC().Format(time.RFC3339)},
+	}
+
+	if r.TargetURI != "" {
+		header = append(header, Field{"WARC-Target-URI", r.TargetURI})
+	}
+
+	header = append(header, r.Fields...)
+
+	if r.ContentType != "" {
+		header = append(header, Field{"Content-Type", r.ContentType})
+	}
+
+	header = append(header,
+		Field{"WARC-Block-Digest", Digest(r.Block)},
+		Field{"Content-Length", strconv.Itoa(len(r.Block))},
+	)
+
+	buf.WriteString(Version + "\r\n")
+
+	for _, f := range header {
+		fmt.Fprintf(&buf, "%s: %s\r\n", f.Name, f.Value)
+	}
+
+	buf.WriteString("\r\n")
+	buf.Write(r.Block)
+	buf.WriteString("\r\n\r\n")
+
+	if w.gw == nil {
+		w.gw = gzip.NewWriter(w.w)
+	} else {
+		w.gw.Reset(w.w)
+	}
+
+	if _, err := w.gw.Write(buf.Bytes()); err != nil {
+		return "", err
+	}
+
+	if err := w.gw.Close(); err != nil {
+		return "", err
+	}
+
+	return r.ID, nil
+}
+
+// NewWriter returns a Writer that writes gzipped records to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Digest returns the sha1 digest of b in the form WARC digest fields use.
+func Digest(b []byte) string {
+	sum := sha1.Sum(b)
+
+	return "sha1:" + base32.StdEncoding.EncodeToString(sum[:])
+}
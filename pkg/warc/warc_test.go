@@ -0,0 +1,139 @@
+package warc
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testRecord struct {
+	header textproto.MIMEHeader
+	block  []byte
+}
+
+// readRecords reads every record of a gzipped WARC file. Each record has to
+// be a gzip member of its own.
+func readRecords(t *testing.T, b []byte) []testRecord {
+	records := []testRecord{}
+	br := bytes.NewReader(b)
+
+	gr, err := gzip.NewReader(br)
+	require.NoError(t, err)
+
+	for {
+		gr.Multistream(false)
+
+		member, err := ioutil.ReadAll(gr)
+		require.NoError(t, err)
+
+		r := bufio.NewReader(bytes.NewReader(member))
+
+		version, err := r.ReadString('\n')
+		require.NoError(t, err)
+		require.Equal(t, Version+"\r\n", version)
+
+		header, err := textproto.NewReader(r).ReadMIMEHeader()
+		require.NoError(t, err)
+
+		n, err := strconv.Atoi(header.Get("Content-Length"))
+		require.NoError(t, err)
+
+		block := make([]byte, n)
+		_, err = io.ReadFull(r, block)
+		require.NoError(t, err)
+		require.Equal(t, header.Get("WARC-Block-Digest"), Digest(block))
+
+		rest, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, "\r\n\r\n", string(rest))
+
+		records = append(records, testRecord{header, block})
+
+		if err := gr.Reset(br); err == io.EOF {
+			return records
+		}
+
+		require.NoError(t, err)
+	}
+}
+
+func TestExport(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		io.WriteString(w, "<html><body>hello</body></html>")
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/page", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/big", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, strings.Repeat("a", 64))
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	dead := httptest.NewServer(http.NotFoundHandler())
+	dead.Close()
+
+	var buf bytes.Buffer
+
+	c := New(WithMaxBodyBytes(32))
+
+	summary, err := c.Export(context.Background(), &buf, []string{
+		srv.URL + "/moved",
+		dead.URL + "/gone",
+		srv.URL + "/big",
+		srv.URL + "/moved",
+	})
+	require.NoError(t, err)
+	require.Equal(t, &Summary{Captured: 2, Failed: 1}, summary)
+
+	records := readRecords(t, buf.Bytes())
+	require.Len(t, records, 7)
+
+	types := []string{}
+	for _, r := range records {
+		types = append(types, r.header.Get("WARC-Type"))
+	}
+
+	require.Equal(t, []string{"warcinfo", "response", "request", "response", "request", "response", "request"}, types)
+
+	info := records[0]
+	require.Equal(t, "application/warc-fields", info.header.Get("Content-Type"))
+	require.Contains(t, string(info.block), "format: WARC File Format 1.1\r\n")
+
+	t.Run("redirects are recorded hop by hop", func(t *testing.T) {
+		res, req := records[1], records[2]
+		require.Equal(t, srv.URL+"/moved", res.header.Get("WARC-Target-URI"))
+		require.Equal(t, "application/http;msgtype=response", res.header.Get("Content-Type"))
+		require.True(t, strings.HasPrefix(string(res.block), "HTTP/1.1 301 Moved Permanently\r\n"))
+		require.Equal(t, res.header.Get("WARC-Record-ID"), req.header.Get("WARC-Concurrent-To"))
+		require.True(t, strings.HasPrefix(string(req.block), "GET /moved HTTP/1.1\r\n"))
+		require.Contains(t, string(req.block), "User-Agent: Linux:SUFR:")
+
+		res = records[3]
+		require.Equal(t, srv.URL+"/page", res.header.Get("WARC-Target-URI"))
+		require.True(t, strings.HasSuffix(string(res.block), "\r\n\r\n<html><body>hello</body></html>"))
+		require.Equal(t, Digest([]byte("<html><body>hello</body></html>")), res.header.Get("WARC-Payload-Digest"))
+		require.Empty(t, res.header.Get("WARC-Truncated"))
+	})
+
+	t.Run("long bodies are truncated", func(t *testing.T) {
+		res := records[5]
+		require.Equal(t, srv.URL+"/big", res.header.Get("WARC-Target-URI"))
+		require.Equal(t, "length", res.header.Get("WARC-Truncated"))
+		require.True(t, strings.HasSuffix(string(res.block), "\r\n\r\n"+strings.Repeat("a", 32)))
+	})
+}
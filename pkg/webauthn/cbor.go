@@ -0,0 +1,144 @@
+package webauthn
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// maxCBORDepth is how deeply arrays and maps can nest. Nothing WebAuthn sends
+// comes close.
+const maxCBORDepth = 16
+
+var errCBOR = errors.New("invalid cbor")
+
+// decodeCBOR decodes the CBOR item at the start of b and returns it along
+// with the bytes after it. It only handles what authenticators send: integers
+// become int64, byte strings []byte, text strings string, arrays
+// []interface{} and maps map[interface{}]interface{} with int64 or string
+// keys. Indefinite lengths and floats aren't allowed in WebAuthn and aren't
+// supported.
+func decodeCBOR(b []byte) (interface{}, []byte, error) {
+	return decodeCBORItem(b, 0)
+}
+
+func decodeCBORItem(b []byte, depth int) (interface{}, []byte, error) {
+	if depth > maxCBORDepth || len(b) == 0 {
+		return nil, nil, errCBOR
+	}
+
+	major, info := b[0]>>5, b[0]&0x1f
+	b = b[1:]
+
+	if major == 7 {
+		switch info {
+		case 20:
+			return false, b, nil
+		case 21:
+			return true, b, nil
+		case 22, 23:
+			return nil, b, nil
+		default:
+			return nil, nil, errCBOR
+		}
+	}
+
+	n, b, err := decodeCBORArgument(info, b)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch major {
+	case 0:
+		if n > math.MaxInt64 {
+			return nil, nil, errCBOR
+		}
+
+		return int64(n), b, nil
+	case 1:
+		if n > math.MaxInt64 {
+			return nil, nil, errCBOR
+		}
+
+		return -1 - int64(n), b, nil
+	case 2, 3:
+		if n > uint64(len(b)) {
+			return nil, nil, errCBOR
+		}
+
+		if major == 2 {
+			return append([]byte(nil), b[:n]...), b[n:], nil
+		}
+
+		return string(b[:n]), b[n:], nil
+	case 4:
+		// every item takes at least a byte
+		if n > uint64(len(b)) {
+			return nil, nil, errCBOR
+		}
+
+		items := make([]interface{}, n)
+
+		for i := range items {
+			if items[i], b, err = decodeCBORItem(b, depth+1); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		return items, b, nil
+	case 5:
+		if n > uint64(len(b))/2 {
+			return nil, nil, errCBOR
+		}
+
+		m := make(map[interface{}]interface{}, n)
+
+		for i := uint64(0); i < n; i++ {
+			var k, v interface{}
+
+			if k, b, err = decodeCBORItem(b, depth+1); err != nil {
+				return nil, nil, err
+			}
+
+			switch k.(type) {
+			case int64, string:
+			default:
+				return nil, nil, errCBOR
+			}
+
+			if _, ok := m[k]; ok {
+				return nil, nil, errCBOR
+			}
+
+			if v, b, err = decodeCBORItem(b, depth+1); err != nil {
+				return nil, nil, err
+			}
+
+			m[k] = v
+		}
+
+		return m, b, nil
+	default:
+		// tags only add meaning to the item that follows
+		return decodeCBORItem(b, depth+1)
+	}
+}
+
+// decodeCBORArgument returns the number that follows an initial byte with
+// additional info.
+func decodeCBORArgument(info byte, b []byte) (uint64, []byte, error) {
+	switch {
+	case info < 24:
+		return uint64(info), b, nil
+	case info == 24 && len(b) >= 1:
+		return uint64(b[0]), b[1:], nil
+	case info == 25 && len(b) >= 2:
+		return uint64(binary.BigEndian.Uint16(b)), b[2:], nil
+	case info == 26 && len(b) >= 4:
+		return uint64(binary.BigEndian.Uint32(b)), b[4:], nil
+	case info == 27 && len(b) >= 8:
+		return binary.BigEndian.Uint64(b), b[8:], nil
+	default:
+		return 0, nil, errCBOR
+	}
+}
@@ -0,0 +1,126 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"math/big"
+)
+
+// COSE algorithms credentials can be made with, from the IANA registry.
+const (
+	AlgES256 = -7
+	AlgEdDSA = -8
+	AlgRS256 = -257
+)
+
+// COSE key parameters.
+const (
+	coseKty = 1
+	coseAlg = 3
+
+	coseKtyOKP = 1
+	coseKtyEC2 = 2
+	coseKtyRSA = 3
+
+	coseCrvP256    = 1
+	coseCrvEd25519 = 6
+)
+
+// supportedAlgs are the algorithms asked for when creating credentials, most
+// preferred first.
+var supportedAlgs = []int64{AlgES256, AlgEdDSA, AlgRS256}
+
+// publicKey is a credential public key decoded from a COSE_Key.
+type publicKey struct {
+	alg int64
+	key crypto.PublicKey
+}
+
+// parsePublicKey decodes a COSE_Key.
+func parsePublicKey(b []byte) (*publicKey, error) {
+	v, rest, err := decodeCBOR(b)
+	if err != nil || len(rest) != 0 {
+		return nil, ErrUnsupportedKey
+	}
+
+	m, ok := v.(map[interface{}]interface{})
+	if !ok {
+		return nil, ErrUnsupportedKey
+	}
+
+	kty, _ := m[int64(coseKty)].(int64)
+	alg, _ := m[int64(coseAlg)].(int64)
+	crv, _ := m[int64(-1)].(int64)
+
+	switch {
+	case kty == coseKtyEC2 && alg == AlgES256 && crv == coseCrvP256:
+		x, _ := m[int64(-2)].([]byte)
+		y, _ := m[int64(-3)].([]byte)
+
+		if len(x) != 32 || len(y) != 32 {
+			return nil, ErrUnsupportedKey
+		}
+
+		key := &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}
+
+		if !key.Curve.IsOnCurve(key.X, key.Y) {
+			return nil, ErrUnsupportedKey
+		}
+
+		return &publicKey{alg: alg, key: key}, nil
+	case kty == coseKtyOKP && alg == AlgEdDSA && crv == coseCrvEd25519:
+		x, _ := m[int64(-2)].([]byte)
+
+		if len(x) != ed25519.PublicKeySize {
+			return nil, ErrUnsupportedKey
+		}
+
+		return &publicKey{alg: alg, key: ed25519.PublicKey(x)}, nil
+	case kty == coseKtyRSA && alg == AlgRS256:
+		n, _ := m[int64(-1)].([]byte)
+		e, _ := m[int64(-2)].([]byte)
+
+		if len(n) < 256 || len(e) == 0 || len(e) > 4 {
+			return nil, ErrUnsupportedKey
+		}
+
+		key := &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+
+		return &publicKey{alg: alg, key: key}, nil
+	default:
+		return nil, ErrUnsupportedKey
+	}
+}
+
+// verify checks that sig is a signature of data made with the key.
+func (k *publicKey) verify(data, sig []byte) error {
+	ok := false
+
+	switch key := k.key.(type) {
+	case *ecdsa.PublicKey:
+		sum := sha256.Sum256(data)
+		ok = ecdsa.VerifyASN1(key, sum[:], sig)
+	case ed25519.PublicKey:
+		ok = ed25519.Verify(key, data, sig)
+	case *rsa.PublicKey:
+		sum := sha256.Sum256(data)
+		ok = rsa.VerifyPKCS1v15(key, crypto.SHA256, sum[:], sig) == nil
+	}
+
+	if !ok {
+		return ErrBadSignature
+	}
+
+	return nil
+}
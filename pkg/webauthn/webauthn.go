@@ -0,0 +1,469 @@
+// Package webauthn registers passkeys and security keys and checks the
+// signatures they make when logging in, following the relying party steps of
+// the Web Authentication spec.
+//
+// Only what logging in needs is checked. Attestation isn't asked for, so
+// where an authenticator comes from isn't verified; a self-signed "packed"
+// statement is still checked against the new key when one is sent.
+package webauthn
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// Timeout is how long browsers are given to make a credential or an
+	// assertion.
+	Timeout = 5 * time.Minute
+
+	challengeBytes = 32
+
+	flagUserPresent  = 0x01
+	flagUserVerified = 0x04
+	flagAttested     = 0x40
+	flagExtensions   = 0x80
+)
+
+var (
+	// ErrInvalidResponse is returned for responses that are malformed or
+	// weren't made for the challenge, origin or relying party expected.
+	ErrInvalidResponse = errors.New("invalid webauthn response")
+	// ErrUnsupportedKey is returned for credentials with a key type or
+	// algorithm that isn't supported.
+	ErrUnsupportedKey = errors.New("unsupported webauthn public key")
+	// ErrBadSignature is returned when a signature doesn't match.
+	ErrBadSignature = errors.New("webauthn signature doesn't match")
+	// ErrSignCount is returned when a credential's signature counter didn't
+	// go up, which is a sign the authenticator was cloned.
+	ErrSignCount = errors.New("webauthn signature counter went backwards")
+)
+
+// Bytes is binary data that's base64url encoded in JSON, the way browsers'
+// toJSON methods for credentials encode it.
+type Bytes []byte
+
+func (b Bytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(base64.RawURLEncoding.EncodeToString(b))
+}
+
+func (b *Bytes) UnmarshalJSON(data []byte) error {
+	var s string
+
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return err
+	}
+
+	*b = decoded
+
+	return nil
+}
+
+// RelyingParty is the site credentials are made for.
+type RelyingParty struct {
+	// ID is the domain credentials are scoped to, like "sufr.example.com".
+	ID string
+	// Name is shown by browsers while making a credential.
+	Name string
+	// Origin is where the pages using credentials are served from, like
+	// "https://sufr.example.com".
+	Origin string
+}
+
+// Entity names a relying party in CreationOptions.
+type Entity struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+// UserEntity names the user a credential is made for.
+type UserEntity struct {
+	// ID is returned as the user handle when logging in. It shouldn't be
+	// something personal like an email address.
+	ID          Bytes  `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+// CredentialParameters is a kind of credential that can be made.
+type CredentialParameters struct {
+	Type string `json:"type"`
+	Alg  int64  `json:"alg"`
+}
+
+// CredentialDescriptor points at an existing credential.
+type CredentialDescriptor struct {
+	Type string `json:"type"`
+	ID   Bytes  `json:"id"`
+}
+
+// AuthenticatorSelection says what kind of authenticator is wanted.
+type AuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// CreationOptions are the options for navigator.credentials.create.
+type CreationOptions struct {
+	Challenge              Bytes                  `json:"challenge"`
+	RP                     Entity                 `json:"rp"`
+	User                   UserEntity             `json:"user"`
+	PubKeyCredParams       []CredentialParameters `json:"pubKeyCredParams"`
+	Timeout                int64                  `json:"timeout"`
+	ExcludeCredentials     []CredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection AuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                 `json:"attestation"`
+}
+
+// RequestOptions are the options for navigator.credentials.get.
+type RequestOptions struct {
+	Challenge        Bytes                  `json:"challenge"`
+	Timeout          int64                  `json:"timeout"`
+	RPID             string                 `json:"rpId"`
+	AllowCredentials []CredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                 `json:"userVerification"`
+}
+
+// AttestationResponse is the credential navigator.credentials.create made.
+type AttestationResponse struct {
+	ID                Bytes `json:"id"`
+	ClientDataJSON    Bytes `json:"clientDataJSON"`
+	AttestationObject Bytes `json:"attestationObject"`
+}
+
+// AssertionResponse is the credential navigator.credentials.get returned.
+type AssertionResponse struct {
+	ID                Bytes `json:"id"`
+	ClientDataJSON    Bytes `json:"clientDataJSON"`
+	AuthenticatorData Bytes `json:"authenticatorData"`
+	Signature         Bytes `json:"signature"`
+	// UserHandle is the UserEntity.ID the credential was made for. It's
+	// only sent by discoverable credentials.
+	UserHandle Bytes `json:"userHandle"`
+}
+
+// Credential is a registered credential.
+type Credential struct {
+	ID []byte
+	// PublicKey is the COSE_Key the authenticator sent.
+	PublicKey []byte
+	SignCount uint32
+	// UserVerified is true if the authenticator checked a PIN or biometric
+	// while making the credential.
+	UserVerified bool
+}
+
+// Assertion is the result of a verified login.
+type Assertion struct {
+	SignCount uint32
+	// UserVerified is true if the authenticator checked a PIN or biometric,
+	// which makes the credential a second factor on its own.
+	UserVerified bool
+}
+
+// CreationOptions returns options with a new challenge for making a
+// credential for user. Credentials the user already has go in exclude so the
+// same authenticator isn't registered twice.
+func (rp *RelyingParty) CreationOptions(user UserEntity, exclude [][]byte) (*CreationOptions, error) {
+	challenge, err := newChallenge()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &CreationOptions{
+		Challenge:          challenge,
+		RP:                 Entity{ID: rp.ID, Name: rp.Name},
+		User:               user,
+		Timeout:            Timeout.Milliseconds(),
+		ExcludeCredentials: descriptors(exclude),
+		AuthenticatorSelection: AuthenticatorSelection{
+			ResidentKey:      "preferred",
+			UserVerification: "preferred",
+		},
+		Attestation: "none",
+	}
+
+	for _, alg := range supportedAlgs {
+		opts.PubKeyCredParams = append(opts.PubKeyCredParams, CredentialParameters{Type: "public-key", Alg: alg})
+	}
+
+	return opts, nil
+}
+
+// RequestOptions returns options with a new challenge for logging in with one
+// of the allow credentials. With none, the browser offers every discoverable
+// credential it has for the relying party.
+func (rp *RelyingParty) RequestOptions(allow [][]byte) (*RequestOptions, error) {
+	challenge, err := newChallenge()
+	if err != nil {
+		return nil, err
+	}
+
+	return &RequestOptions{
+		Challenge:        challenge,
+		Timeout:          Timeout.Milliseconds(),
+		RPID:             rp.ID,
+		AllowCredentials: descriptors(allow),
+		UserVerification: "preferred",
+	}, nil
+}
+
+// VerifyRegistration checks a credential made with the options that had
+// challenge and returns it for saving.
+func (rp *RelyingParty) VerifyRegistration(challenge []byte, resp *AttestationResponse) (*Credential, error) {
+	if err := rp.verifyClientData(resp.ClientDataJSON, "webauthn.create", challenge); err != nil {
+		return nil, err
+	}
+
+	v, rest, err := decodeCBOR(resp.AttestationObject)
+	if err != nil || len(rest) != 0 {
+		return nil, fmt.Errorf("%w: bad attestation object", ErrInvalidResponse)
+	}
+
+	obj, _ := v.(map[interface{}]interface{})
+	format, _ := obj["fmt"].(string)
+	stmt, _ := obj["attStmt"].(map[interface{}]interface{})
+	raw, _ := obj["authData"].([]byte)
+
+	data, err := rp.parseAuthenticatorData(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.flags&flagAttested == 0 {
+		return nil, fmt.Errorf("%w: no credential", ErrInvalidResponse)
+	}
+
+	if !bytes.Equal(data.credentialID, resp.ID) {
+		return nil, fmt.Errorf("%w: credential id doesn't match", ErrInvalidResponse)
+	}
+
+	key, err := parsePublicKey(data.publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	clientDataHash := sha256.Sum256(resp.ClientDataJSON)
+
+	switch format {
+	case "none":
+		if len(stmt) != 0 {
+			return nil, fmt.Errorf("%w: unexpected attestation statement", ErrInvalidResponse)
+		}
+	case "packed":
+		signed := append(append([]byte(nil), raw...), clientDataHash[:]...)
+
+		if err := verifyPacked(stmt, key, signed); err != nil {
+			return nil, err
+		}
+	case "":
+		return nil, fmt.Errorf("%w: no attestation format", ErrInvalidResponse)
+	default:
+		// attestation wasn't asked for and what's sent isn't used, so
+		// formats that are only there to prove where the authenticator
+		// came from are let through
+	}
+
+	return &Credential{
+		ID:           data.credentialID,
+		PublicKey:    data.publicKey,
+		SignCount:    data.signCount,
+		UserVerified: data.flags&flagUserVerified != 0,
+	}, nil
+}
+
+// VerifyAssertion checks a login with cred made with the options that had
+// challenge. The returned sign count should be saved with the credential.
+func (rp *RelyingParty) VerifyAssertion(challenge []byte, cred *Credential, resp *AssertionResponse) (*Assertion, error) {
+	if !bytes.Equal(cred.ID, resp.ID) {
+		return nil, fmt.Errorf("%w: credential id doesn't match", ErrInvalidResponse)
+	}
+
+	if err := rp.verifyClientData(resp.ClientDataJSON, "webauthn.get", challenge); err != nil {
+		return nil, err
+	}
+
+	data, err := rp.parseAuthenticatorData(resp.AuthenticatorData)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := parsePublicKey(cred.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	clientDataHash := sha256.Sum256(resp.ClientDataJSON)
+	signed := append(append([]byte(nil), resp.AuthenticatorData...), clientDataHash[:]...)
+
+	if err := key.verify(signed, resp.Signature); err != nil {
+		return nil, err
+	}
+
+	// authenticators without a counter always send 0
+	if (data.signCount != 0 || cred.SignCount != 0) && data.signCount <= cred.SignCount {
+		return nil, ErrSignCount
+	}
+
+	return &Assertion{
+		SignCount:    data.signCount,
+		UserVerified: data.flags&flagUserVerified != 0,
+	}, nil
+}
+
+type clientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin"`
+}
+
+func (rp *RelyingParty) verifyClientData(b []byte, typ string, challenge []byte) error {
+	var cd clientData
+
+	if err := json.Unmarshal(b, &cd); err != nil {
+		return fmt.Errorf("%w: bad client data", ErrInvalidResponse)
+	}
+
+	if cd.Type != typ {
+		return fmt.Errorf("%w: wrong type %q", ErrInvalidResponse, cd.Type)
+	}
+
+	got, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(cd.Challenge, "="))
+	if err != nil || len(challenge) == 0 || subtle.ConstantTimeCompare(got, challenge) != 1 {
+		return fmt.Errorf("%w: wrong challenge", ErrInvalidResponse)
+	}
+
+	if cd.Origin != rp.Origin || cd.CrossOrigin {
+		return fmt.Errorf("%w: wrong origin %q", ErrInvalidResponse, cd.Origin)
+	}
+
+	return nil
+}
+
+type authenticatorData struct {
+	flags        byte
+	signCount    uint32
+	credentialID []byte
+	publicKey    []byte
+}
+
+func (rp *RelyingParty) parseAuthenticatorData(b []byte) (*authenticatorData, error) {
+	if len(b) < 37 {
+		return nil, fmt.Errorf("%w: authenticator data is too short", ErrInvalidResponse)
+	}
+
+	rpIDHash := sha256.Sum256([]byte(rp.ID))
+	if !bytes.Equal(b[:32], rpIDHash[:]) {
+		return nil, fmt.Errorf("%w: wrong relying party", ErrInvalidResponse)
+	}
+
+	data := &authenticatorData{
+		flags:     b[32],
+		signCount: binary.BigEndian.Uint32(b[33:37]),
+	}
+
+	if data.flags&flagUserPresent == 0 {
+		return nil, fmt.Errorf("%w: user wasn't present", ErrInvalidResponse)
+	}
+
+	rest := b[37:]
+
+	if data.flags&flagAttested != 0 {
+		// 16 bytes of AAGUID, then the length of the credential id
+		if len(rest) < 18 {
+			return nil, fmt.Errorf("%w: bad attested credential data", ErrInvalidResponse)
+		}
+
+		n := int(binary.BigEndian.Uint16(rest[16:18]))
+		rest = rest[18:]
+
+		if n == 0 || len(rest) < n {
+			return nil, fmt.Errorf("%w: bad credential id", ErrInvalidResponse)
+		}
+
+		data.credentialID, rest = rest[:n], rest[n:]
+
+		_, after, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, fmt.Errorf("%w: bad public key", ErrInvalidResponse)
+		}
+
+		data.publicKey, rest = rest[:len(rest)-len(after)], after
+	}
+
+	if data.flags&flagExtensions != 0 {
+		var err error
+
+		if _, rest, err = decodeCBOR(rest); err != nil {
+			return nil, fmt.Errorf("%w: bad extensions", ErrInvalidResponse)
+		}
+	}
+
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("%w: trailing authenticator data", ErrInvalidResponse)
+	}
+
+	return data, nil
+}
+
+// verifyPacked checks a "packed" attestation statement. Self attestation is
+// signed with the credential key; otherwise the first certificate's key
+// signed it.
+func verifyPacked(stmt map[interface{}]interface{}, key *publicKey, signed []byte) error {
+	alg, _ := stmt["alg"].(int64)
+	sig, _ := stmt["sig"].([]byte)
+
+	x5c, ok := stmt["x5c"].([]interface{})
+	if !ok || len(x5c) == 0 {
+		if alg != key.alg {
+			return fmt.Errorf("%w: attestation algorithm doesn't match the key", ErrInvalidResponse)
+		}
+
+		return key.verify(signed, sig)
+	}
+
+	der, _ := x5c[0].([]byte)
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return fmt.Errorf("%w: bad attestation certificate", ErrInvalidResponse)
+	}
+
+	return (&publicKey{alg: alg, key: cert.PublicKey}).verify(signed, sig)
+}
+
+func newChallenge() (Bytes, error) {
+	b := make([]byte, challengeBytes)
+
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func descriptors(ids [][]byte) []CredentialDescriptor {
+	ds := make([]CredentialDescriptor, len(ids))
+
+	for i, id := range ids {
+		ds[i] = CredentialDescriptor{Type: "public-key", ID: id}
+	}
+
+	return ds
+}
@@ -0,0 +1,415 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cborPair is a key and value of a cborMap.
+type cborPair struct {
+	k, v interface{}
+}
+
+// cborMap keeps the order keys are encoded in.
+type cborMap []cborPair
+
+// encodeCBOR encodes what the software authenticator sends.
+func encodeCBOR(v interface{}) []byte {
+	head := func(major byte, n uint64) []byte {
+		switch {
+		case n < 24:
+			return []byte{major<<5 | byte(n)}
+		case n < 1<<8:
+			return []byte{major<<5 | 24, byte(n)}
+		case n < 1<<16:
+			b := []byte{major<<5 | 25, 0, 0}
+			binary.BigEndian.PutUint16(b[1:], uint16(n))
+
+			return b
+		default:
+			b := []byte{major<<5 | 26, 0, 0, 0, 0}
+			binary.BigEndian.PutUint32(b[1:], uint32(n))
+
+			return b
+		}
+	}
+
+	switch v := v.(type) {
+	case int:
+		if v < 0 {
+			return head(1, uint64(-1-v))
+		}
+
+		return head(0, uint64(v))
+	case []byte:
+		return append(head(2, uint64(len(v))), v...)
+	case string:
+		return append(head(3, uint64(len(v))), v...)
+	case []interface{}:
+		b := head(4, uint64(len(v)))
+		for _, item := range v {
+			b = append(b, encodeCBOR(item)...)
+		}
+
+		return b
+	case cborMap:
+		b := head(5, uint64(len(v)))
+		for _, p := range v {
+			b = append(b, encodeCBOR(p.k)...)
+			b = append(b, encodeCBOR(p.v)...)
+		}
+
+		return b
+	default:
+		panic("can't encode")
+	}
+}
+
+// softAuthenticator is an authenticator made of software, good for one
+// credential.
+type softAuthenticator struct {
+	rpID      string
+	origin    string
+	id        []byte
+	signer    crypto.Signer
+	signCount uint32
+	flags     byte
+}
+
+func newSoftAuthenticator(t *testing.T, rp *RelyingParty, signer crypto.Signer) *softAuthenticator {
+	id := make([]byte, 16)
+	_, err := rand.Read(id)
+	require.NoError(t, err)
+
+	return &softAuthenticator{
+		rpID:   rp.ID,
+		origin: rp.Origin,
+		id:     id,
+		signer: signer,
+		flags:  flagUserPresent | flagUserVerified,
+	}
+}
+
+func (a *softAuthenticator) coseKey() []byte {
+	switch key := a.signer.Public().(type) {
+	case *ecdsa.PublicKey:
+		x, y := make([]byte, 32), make([]byte, 32)
+		key.X.FillBytes(x)
+		key.Y.FillBytes(y)
+
+		return encodeCBOR(cborMap{{1, 2}, {3, AlgES256}, {-1, 1}, {-2, x}, {-3, y}})
+	case ed25519.PublicKey:
+		return encodeCBOR(cborMap{{1, 1}, {3, AlgEdDSA}, {-1, 6}, {-2, []byte(key)}})
+	default:
+		panic("unsupported key")
+	}
+}
+
+func (a *softAuthenticator) alg() int {
+	if _, ok := a.signer.Public().(ed25519.PublicKey); ok {
+		return AlgEdDSA
+	}
+
+	return AlgES256
+}
+
+func (a *softAuthenticator) clientData(typ string, challenge []byte) []byte {
+	b, _ := json.Marshal(map[string]interface{}{
+		"type":      typ,
+		"challenge": base64.RawURLEncoding.EncodeToString(challenge),
+		"origin":    a.origin,
+	})
+
+	return b
+}
+
+func (a *softAuthenticator) authData(flags byte, attested []byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(a.rpID))
+
+	b := append(rpIDHash[:], flags, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(b[33:], a.signCount)
+
+	return append(b, attested...)
+}
+
+func (a *softAuthenticator) sign(t *testing.T, authData, clientData []byte) []byte {
+	clientDataHash := sha256.Sum256(clientData)
+	data := append(append([]byte(nil), authData...), clientDataHash[:]...)
+
+	var (
+		sig []byte
+		err error
+	)
+
+	if _, ok := a.signer.(ed25519.PrivateKey); ok {
+		sig, err = a.signer.Sign(rand.Reader, data, crypto.Hash(0))
+	} else {
+		sum := sha256.Sum256(data)
+		sig, err = a.signer.Sign(rand.Reader, sum[:], crypto.SHA256)
+	}
+
+	require.NoError(t, err)
+
+	return sig
+}
+
+// create makes the credential. With self set it sends a packed self
+// attestation instead of none.
+func (a *softAuthenticator) create(t *testing.T, opts *CreationOptions, self bool) *AttestationResponse {
+	attested := make([]byte, 16, 16+2+len(a.id))
+	attested = append(attested, byte(len(a.id)>>8), byte(len(a.id)))
+	attested = append(attested, a.id...)
+	attested = append(attested, a.coseKey()...)
+
+	clientData := a.clientData("webauthn.create", opts.Challenge)
+	authData := a.authData(a.flags|flagAttested, attested)
+
+	obj := cborMap{{"fmt", "none"}, {"attStmt", cborMap{}}, {"authData", authData}}
+	if self {
+		stmt := cborMap{{"alg", a.alg()}, {"sig", a.sign(t, authData, clientData)}}
+		obj = cborMap{{"fmt", "packed"}, {"attStmt", stmt}, {"authData", authData}}
+	}
+
+	return &AttestationResponse{
+		ID:                a.id,
+		ClientDataJSON:    clientData,
+		AttestationObject: encodeCBOR(obj),
+	}
+}
+
+func (a *softAuthenticator) get(t *testing.T, opts *RequestOptions) *AssertionResponse {
+	a.signCount++
+
+	clientData := a.clientData("webauthn.get", opts.Challenge)
+	authData := a.authData(a.flags, nil)
+
+	return &AssertionResponse{
+		ID:                a.id,
+		ClientDataJSON:    clientData,
+		AuthenticatorData: authData,
+		Signature:         a.sign(t, authData, clientData),
+	}
+}
+
+var testRP = &RelyingParty{ID: "sufr.example.com", Name: "SUFR", Origin: "https://sufr.example.com"}
+
+func register(t *testing.T, a *softAuthenticator) *Credential {
+	opts, err := testRP.CreationOptions(UserEntity{ID: []byte("user-1"), Name: "kyle@example.com"}, nil)
+	require.NoError(t, err)
+
+	cred, err := testRP.VerifyRegistration(opts.Challenge, a.create(t, opts, false))
+	require.NoError(t, err)
+
+	return cred
+}
+
+func TestRegistrationAndLogin(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	for name, signer := range map[string]crypto.Signer{"ES256": ecKey, "EdDSA": edKey} {
+		t.Run(name, func(t *testing.T) {
+			a := newSoftAuthenticator(t, testRP, signer)
+
+			for _, self := range []bool{false, true} {
+				opts, err := testRP.CreationOptions(UserEntity{ID: []byte("user-1"), Name: "kyle@example.com"}, nil)
+				require.NoError(t, err)
+
+				cred, err := testRP.VerifyRegistration(opts.Challenge, a.create(t, opts, self))
+				require.NoError(t, err)
+				assert.Equal(t, a.id, cred.ID)
+				assert.True(t, cred.UserVerified)
+
+				for i := 0; i < 2; i++ {
+					ropts, err := testRP.RequestOptions([][]byte{cred.ID})
+					require.NoError(t, err)
+
+					assertion, err := testRP.VerifyAssertion(ropts.Challenge, cred, a.get(t, ropts))
+					require.NoError(t, err)
+					assert.Equal(t, a.signCount, assertion.SignCount)
+					assert.True(t, assertion.UserVerified)
+
+					cred.SignCount = assertion.SignCount
+				}
+			}
+		})
+	}
+}
+
+func TestVerifyRegistrationRejects(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	user := UserEntity{ID: []byte("user-1"), Name: "kyle@example.com"}
+
+	for name, tc := range map[string]func(a *softAuthenticator, opts *CreationOptions){
+		"another challenge": func(a *softAuthenticator, opts *CreationOptions) {
+			opts.Challenge = []byte("not the challenge")
+		},
+		"another origin": func(a *softAuthenticator, opts *CreationOptions) {
+			a.origin = "https://evil.example.com"
+		},
+		"another relying party": func(a *softAuthenticator, opts *CreationOptions) {
+			a.rpID = "evil.example.com"
+		},
+		"no user presence": func(a *softAuthenticator, opts *CreationOptions) {
+			a.flags = 0
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			a := newSoftAuthenticator(t, testRP, key)
+
+			opts, err := testRP.CreationOptions(user, nil)
+			require.NoError(t, err)
+
+			challenge := opts.Challenge
+			tc(a, opts)
+
+			_, err = testRP.VerifyRegistration(challenge, a.create(t, opts, false))
+			assert.True(t, errors.Is(err, ErrInvalidResponse), "got %v", err)
+		})
+	}
+
+	t.Run("a bad self attestation", func(t *testing.T) {
+		a := newSoftAuthenticator(t, testRP, key)
+
+		opts, err := testRP.CreationOptions(user, nil)
+		require.NoError(t, err)
+
+		resp := a.create(t, opts, true)
+		resp.ClientDataJSON = a.clientData("webauthn.create", opts.Challenge)
+		resp.ClientDataJSON = append(resp.ClientDataJSON[:len(resp.ClientDataJSON)-1], ' ', '}')
+
+		_, err = testRP.VerifyRegistration(opts.Challenge, resp)
+		assert.True(t, errors.Is(err, ErrBadSignature), "got %v", err)
+	})
+
+	t.Run("a truncated attestation object", func(t *testing.T) {
+		a := newSoftAuthenticator(t, testRP, key)
+
+		opts, err := testRP.CreationOptions(user, nil)
+		require.NoError(t, err)
+
+		resp := a.create(t, opts, false)
+		resp.AttestationObject = resp.AttestationObject[:len(resp.AttestationObject)-10]
+
+		_, err = testRP.VerifyRegistration(opts.Challenge, resp)
+		assert.True(t, errors.Is(err, ErrInvalidResponse), "got %v", err)
+	})
+}
+
+func TestVerifyAssertionRejects(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	t.Run("a bad signature", func(t *testing.T) {
+		a := newSoftAuthenticator(t, testRP, key)
+		cred := register(t, a)
+
+		opts, err := testRP.RequestOptions(nil)
+		require.NoError(t, err)
+
+		resp := a.get(t, opts)
+		resp.Signature[len(resp.Signature)-1] ^= 0xff
+
+		_, err = testRP.VerifyAssertion(opts.Challenge, cred, resp)
+		assert.True(t, errors.Is(err, ErrBadSignature), "got %v", err)
+	})
+
+	t.Run("another credential's key", func(t *testing.T) {
+		a := newSoftAuthenticator(t, testRP, key)
+		cred := register(t, a)
+
+		other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		a.signer = other
+
+		opts, err := testRP.RequestOptions(nil)
+		require.NoError(t, err)
+
+		_, err = testRP.VerifyAssertion(opts.Challenge, cred, a.get(t, opts))
+		assert.True(t, errors.Is(err, ErrBadSignature), "got %v", err)
+	})
+
+	t.Run("a replayed assertion", func(t *testing.T) {
+		a := newSoftAuthenticator(t, testRP, key)
+		cred := register(t, a)
+
+		opts, err := testRP.RequestOptions(nil)
+		require.NoError(t, err)
+
+		resp := a.get(t, opts)
+
+		assertion, err := testRP.VerifyAssertion(opts.Challenge, cred, resp)
+		require.NoError(t, err)
+
+		cred.SignCount = assertion.SignCount
+
+		_, err = testRP.VerifyAssertion(opts.Challenge, cred, resp)
+		assert.True(t, errors.Is(err, ErrSignCount), "got %v", err)
+	})
+
+	t.Run("a creation response", func(t *testing.T) {
+		a := newSoftAuthenticator(t, testRP, key)
+		cred := register(t, a)
+
+		opts, err := testRP.RequestOptions(nil)
+		require.NoError(t, err)
+
+		resp := a.get(t, opts)
+		resp.ClientDataJSON = a.clientData("webauthn.create", opts.Challenge)
+		resp.Signature = a.sign(t, resp.AuthenticatorData, resp.ClientDataJSON)
+
+		_, err = testRP.VerifyAssertion(opts.Challenge, cred, resp)
+		assert.True(t, errors.Is(err, ErrInvalidResponse), "got %v", err)
+	})
+}
+
+func TestBytesJSON(t *testing.T) {
+	b, err := json.Marshal(Bytes{0xfb, 0xff})
+	require.NoError(t, err)
+	assert.Equal(t, `"-_8"`, string(b))
+
+	var got Bytes
+
+	require.NoError(t, json.Unmarshal([]byte(`"-_8="`), &got))
+	assert.Equal(t, Bytes{0xfb, 0xff}, got)
+}
+
+func TestDecodeCBOR(t *testing.T) {
+	v, rest, err := decodeCBOR(append(encodeCBOR(cborMap{{1, -7}, {"a", []interface{}{"b", []byte{1}}}}), 0xff))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xff}, rest)
+	assert.Equal(t, map[interface{}]interface{}{
+		int64(1): int64(-7),
+		"a":      []interface{}{"b", []byte{1}},
+	}, v)
+
+	for _, b := range [][]byte{
+		nil,
+		{0x5f},       // indefinite length byte string
+		{0x43, 1, 2}, // byte string longer than what's left
+		{0x9b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, // huge array
+		{0xa2, 0x01, 0x01, 0x01, 0x02},                         // repeated key
+		{0xf9, 0, 0},                                           // float
+	} {
+		_, _, err := decodeCBOR(b)
+		assert.Error(t, err, "%x", b)
+	}
+}